@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"expvar"
 	"flag"
 	"fmt"
 	"log"
@@ -19,9 +20,14 @@ import (
 	"survey-system/internal/config"
 	"survey-system/internal/repository"
 	"survey-system/internal/service"
+	"survey-system/pkg/chat"
 	"survey-system/pkg/database"
+	"survey-system/pkg/email"
+	"survey-system/pkg/optionset"
 	pkgRedis "survey-system/pkg/redis"
+	"survey-system/pkg/storage"
 	"survey-system/pkg/utils"
+	"survey-system/pkg/webhook"
 )
 
 func main() {
@@ -35,6 +41,9 @@ func main() {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
 
+	// Keep reloadable settings up to date without a restart
+	cfgStore := config.NewStore(*configPath, cfg)
+
 	// Set Gin mode
 	gin.SetMode(cfg.Server.Mode)
 
@@ -50,6 +59,7 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to initialize database: %v", err)
 	}
+	expvar.Publish("database", expvar.Func(func() any { return database.Stats() }))
 
 	// Run auto-migration
 	if err := database.AutoMigrate(db); err != nil {
@@ -57,7 +67,7 @@ func main() {
 	}
 
 	// Initialize default admin account
-	if err := database.InitializeDefaultAdmin(db); err != nil {
+	if err := database.InitializeDefaultAdmin(db, &cfg.Seed, cfg.Server.Mode); err != nil {
 		log.Fatalf("Failed to initialize default admin: %v", err)
 	}
 
@@ -69,7 +79,14 @@ func main() {
 	log.Printf("Redis connection established successfully")
 
 	// Create cache instance
-	cacheInstance := cache.NewRedisCache(redisClient.GetClient())
+	cacheKeys := cache.NewKeys(cfg.Redis.KeyPrefix)
+	tieredCache := cache.NewTieredCache(
+		cache.NewRedisCache(redisClient.GetClient(), cacheKeys),
+		cfg.LocalCache.Size,
+		cfg.LocalCache.TTL,
+	)
+	expvar.Publish("cache", expvar.Func(func() any { return tieredCache.Stats() }))
+	var cacheInstance cache.Cache = tieredCache
 
 	// Initialize encryption service
 	encryptionSvc, err := service.NewEncryptionService(cfg.Encryption.Key)
@@ -78,39 +95,276 @@ func main() {
 	}
 
 	// Initialize repositories
-	surveyRepo := repository.NewSurveyRepository(db)
-	questionRepo := repository.NewQuestionRepository(db)
-	oneLinkRepo := repository.NewOneLinkRepository(db)
-	userRepo := repository.NewUserRepository(db)
-	responseRepo := repository.NewResponseRepository(db)
+	timeouts := repository.Timeouts{
+		Read:  cfg.Database.ReadTimeout,
+		Write: cfg.Database.WriteTimeout,
+	}
+	surveyRepo := repository.NewSurveyRepository(db, timeouts)
+	questionRepo := repository.NewQuestionRepository(db, timeouts)
+	oneLinkRepo := repository.NewOneLinkRepository(db, timeouts)
+	userRepo := repository.NewUserRepository(db, timeouts)
+	sessionRepo := repository.NewSessionRepository(db, timeouts)
+	userTokenRepo := repository.NewUserTokenRepository(db, timeouts)
+	loginRecordRepo := repository.NewLoginRecordRepository(db, timeouts)
+	responseRepo := repository.NewResponseRepository(db, timeouts)
+	draftRepo := repository.NewDraftRepository(db, timeouts)
+	commentRepo := repository.NewCommentRepository(db, timeouts)
+	eventRepo := repository.NewEventRepository(db, timeouts)
+	reportRepo := repository.NewReportRepository(db, timeouts)
+	channelRepo := repository.NewChannelRepository(db, timeouts)
+	exportJobRepo := repository.NewExportJobRepository(db, timeouts)
+	archiveRepo := repository.NewResponseArchiveRepository(db, timeouts)
+	auditRepo := repository.NewAuditLogRepository(db, timeouts)
+	linkTemplateRepo := repository.NewLinkTemplateRepository(db, timeouts)
+	delegationRepo := repository.NewDelegationRepository(db, timeouts)
+	hookRepo := repository.NewHookRepository(db, timeouts)
+	questionChangeRepo := repository.NewQuestionChangeRepository(db, timeouts)
+	teamRepo := repository.NewTeamRepository(db, timeouts)
+
+	// Initialize file storage
+	store, err := storage.New(&cfg.Storage)
+	if err != nil {
+		log.Fatalf("Failed to initialize storage: %v", err)
+	}
+
+	// Initialize email sender
+	mailer := email.NewSender(&cfg.Email)
 
 	// Initialize JWT util
 	jwtUtil := utils.NewJWTUtil(cfg.JWT.Secret, cfg.JWT.Expiration)
+	if cfg.JWT.Algorithm != utils.AlgorithmHS256 {
+		if err := jwtUtil.UseKeyPair(cfg.JWT.Algorithm, []byte(cfg.JWT.PrivateKey), cfg.JWT.KeyID); err != nil {
+			log.Fatalf("Failed to load JWT signing key: %v", err)
+		}
+	}
+
+	// Initialize authorization util, which also grants team members access to shared surveys
+	authz := utils.NewAuthorizationUtil(surveyRepo, questionRepo, teamRepo)
+
+	// Load the option sets offered for import into choice questions
+	optionSets, err := optionset.NewLibrary(cfg.OptionSets.Enabled)
+	if err != nil {
+		log.Fatalf("Failed to load option sets: %v", err)
+	}
+
+	// Initialize feature flags
+	featureFlags := make(map[string]service.FeatureFlag, len(cfg.Features.Flags))
+	for name, flag := range cfg.Features.Flags {
+		featureFlags[name] = service.FeatureFlag{
+			Enabled:      flag.Enabled,
+			Environments: flag.Environments,
+			Users:        flag.Users,
+		}
+	}
+	featureService, err := service.NewFeatureService(cacheInstance, service.FeatureSettings{
+		Environment: cfg.Features.Environment,
+		Flags:       featureFlags,
+	})
+	if err != nil {
+		log.Fatalf("Failed to load feature flags: %v", err)
+	}
 
 	// Initialize services
-	surveyService := service.NewSurveyService(surveyRepo, cacheInstance)
-	questionService := service.NewQuestionService(questionRepo, surveyRepo, cacheInstance)
+	surveyService := service.NewSurveyService(surveyRepo, eventRepo, cacheInstance, authz)
+	usageService := service.NewUsageService(cacheInstance, service.UsageLimits{
+		ShareLinks: cfg.Usage.ShareLinks,
+		Exports:    cfg.Usage.Exports,
+	})
 	shareService := service.NewShareService(
 		surveyRepo,
 		questionRepo,
 		oneLinkRepo,
+		eventRepo,
+		linkTemplateRepo,
+		delegationRepo,
 		encryptionSvc,
+		usageService,
 		cacheInstance,
 		cfg.OneLink.BaseURL,
-		cfg.OneLink.DefaultExpiration,
-		cfg.OneLink.MaxExpiration,
+		func() service.LinkExpiry {
+			current := cfgStore.Get()
+			return service.LinkExpiry{
+				Default: current.OneLink.DefaultExpiration,
+				Preview: current.OneLink.PreviewExpiration,
+				Max:     current.OneLink.MaxExpiration,
+			}
+		},
+		cfg.OneLink.RedirectDomains,
+		authz,
+	)
+	numbering := service.RespondentNumbering{
+		Prefix: cfg.Submission.NumberPrefix,
+		Width:  cfg.Submission.NumberWidth,
+	}
+	exportService := service.NewExportService(surveyRepo, questionRepo, responseRepo, numbering, authz)
+	channelService := service.NewChannelService(
+		channelRepo,
+		responseRepo,
+		surveyRepo,
+		chat.NewSender(cfg.Notifier.WebhookTimeout),
+	)
+	webhookSender := webhook.NewSender(&cfg.Notifier)
+	hookService := service.NewHookService(
+		hookRepo,
+		surveyRepo,
+		questionRepo,
+		responseRepo,
+		webhookSender,
+		numbering,
 	)
-	exportService := service.NewExportService(surveyRepo, questionRepo, responseRepo)
 	responseService := service.NewResponseService(
 		responseRepo,
 		surveyRepo,
 		questionRepo,
 		oneLinkRepo,
+		draftRepo,
+		commentRepo,
+		encryptionSvc,
+		cacheInstance,
+		exportService,
+		service.ResponseNotifiers{channelService, hookService},
+		service.SubmissionLimits{
+			MaxTextLength:  cfg.Submission.MaxTextLength,
+			MaxTableRows:   cfg.Submission.MaxTableRows,
+			ResubmitWindow: cfg.Submission.ResubmitWindow,
+			MinAnswerTime:  cfg.Anomaly.MinAnswerTime,
+		},
+		service.StatisticsOptions{
+			CacheThreshold:    cfg.Statistics.CacheThreshold,
+			ReconcileInterval: cfg.Statistics.ReconcileInterval,
+		},
+		service.ImportOptions{
+			MaxRows:   cfg.Import.MaxRows,
+			BatchSize: cfg.Import.BatchSize,
+		},
+		numbering,
+		service.NewBurstDetector(cacheInstance, eventRepo, service.BurstLimits{
+			Submissions: cfg.Anomaly.BurstSubmissions,
+			Window:      cfg.Anomaly.BurstWindow,
+		}),
+		authz,
+	)
+	questionService := service.NewQuestionService(questionRepo, surveyRepo, eventRepo, questionChangeRepo, cacheInstance, featureService, optionSets, cfg.OptionSets.MaxOptions, service.QuestionLimits{
+		MaxQuestions: cfg.Questions.MaxPerSurvey,
+		MaxOptions:   cfg.Questions.MaxOptions,
+		MaxColumns:   cfg.Questions.MaxColumns,
+		MaxRows:      cfg.Submission.MaxTableRows,
+	}, responseService)
+	exportJobService := service.NewExportJobService(
+		exportJobRepo,
+		surveyRepo,
+		exportService,
+		store,
+		cfg.Export.JobTTL,
+		cfg.Storage.PresignExpiry,
+	)
+	commentService := service.NewCommentService(commentRepo, responseRepo, authz)
+	activityService := service.NewActivityService(eventRepo, responseRepo, surveyRepo)
+	teamService := service.NewTeamService(teamRepo, surveyRepo, userRepo, authz)
+	reportService := service.NewReportService(reportRepo, surveyRepo, userRepo)
+	authService := service.NewAuthService(userRepo, sessionRepo, userTokenRepo, loginRecordRepo, jwtUtil, cacheInstance, mailer, service.AccountSettings{
+		Registration:      cfg.Account.Registration,
+		VerificationTTL:   cfg.Account.VerificationTTL,
+		VerificationGrace: cfg.Account.VerificationGrace,
+		ResetTTL:          cfg.Account.ResetTTL,
+		LoginAlerts:       cfg.Account.LoginAlerts,
+		BaseURL:           cfg.OneLink.BaseURL,
+	})
+	adminService := service.NewAdminService(surveyRepo, userRepo, eventRepo, auditRepo, cacheInstance, jwtUtil, cfg.JWT.ImpersonationExpiration)
+	archiveService := service.NewArchiveService(archiveRepo, store)
+	draftService := service.NewDraftService(
+		draftRepo,
+		surveyRepo,
+		oneLinkRepo,
 		encryptionSvc,
 		cacheInstance,
+		mailer,
+		cfg.OneLink.BaseURL,
+	)
+
+	// Start link expiration notifier
+	expiryNotifier := service.NewExpiryNotifier(
+		oneLinkRepo,
+		cacheInstance,
+		mailer,
+		webhookSender,
+		cfg.Notifier.Interval,
+	)
+	notifierCtx, stopNotifier := context.WithCancel(context.Background())
+	go expiryNotifier.Run(notifierCtx)
+
+	// Start reminders of published surveys without responses
+	surveyReminder := service.NewSurveyReminder(
+		surveyRepo,
+		cacheInstance,
+		mailer,
+		webhookSender,
+		cfg.Notifier.Interval,
+	)
+	go surveyReminder.Run(notifierCtx)
+
+	// Start summary report scheduler
+	reportScheduler := service.NewReportScheduler(
+		reportRepo,
+		responseRepo,
+		responseService,
+		cacheInstance,
+		mailer,
+		cfg.Reports.Interval,
+		cfg.Reports.SendHour,
+		cfg.Reports.TopOptions,
+	)
+	go reportScheduler.Run(notifierCtx)
+
+	// Start cleanup of expired exports and old stored files
+	storageCleaner := service.NewStorageCleaner(
+		exportJobRepo,
+		store,
+		cacheInstance,
+		cfg.Storage.CleanupInterval,
+		cfg.Storage.Retention,
+	)
+	go storageCleaner.Run(notifierCtx)
+
+	// Start archiving and purging of responses past their retention period
+	responsePurger := service.NewResponsePurger(
+		responseRepo,
+		hookRepo,
+		surveyRepo,
+		archiveRepo,
 		exportService,
+		store,
+		cacheInstance,
+		cfg.Retention.Responses,
+		cfg.Retention.Interval,
+		cfg.Retention.ArchivePassword,
+		cfg.Retention.BatchSize,
 	)
-	authService := service.NewAuthService(userRepo, jwtUtil)
+	go responsePurger.Run(notifierCtx)
+
+	// Start cleanup of old hook deliveries and their payloads
+	hookDeliveryCleaner := service.NewHookDeliveryCleaner(
+		hookRepo,
+		cacheInstance,
+		cfg.Retention.Interval,
+		cfg.Retention.HookDeliveries,
+		cfg.Retention.BatchSize,
+	)
+	go hookDeliveryCleaner.Run(notifierCtx)
+
+	// Start periodic connection pool reports
+	poolMonitor := database.NewPoolMonitor(db, cfg.Database.PoolReportInterval, cfg.Database.PoolWaitThreshold)
+	go poolMonitor.Run(notifierCtx)
+
+	// Watch for configuration changes
+	cfgStore.OnReload(func(current *config.Config) {
+		if err := database.SetLogSettings(&current.Database); err != nil {
+			log.Printf("Failed to apply database log settings: %v", err)
+		}
+	})
+	if err := cfgStore.Watch(notifierCtx); err != nil {
+		log.Printf("Configuration hot-reload disabled: %v", err)
+	}
 
 	// Initialize handlers
 	surveyHandler := handler.NewSurveyHandler(surveyService)
@@ -118,6 +372,19 @@ func main() {
 	shareHandler := handler.NewShareHandler(shareService)
 	responseHandler := handler.NewResponseHandler(responseService)
 	authHandler := handler.NewAuthHandler(authService)
+	draftHandler := handler.NewDraftHandler(draftService)
+	commentHandler := handler.NewCommentHandler(commentService)
+	activityHandler := handler.NewActivityHandler(activityService)
+	reportHandler := handler.NewReportHandler(reportService)
+	channelHandler := handler.NewChannelHandler(channelService)
+	hookHandler := handler.NewHookHandler(hookService)
+	exportJobHandler := handler.NewExportJobHandler(exportJobService)
+	fileHandler := handler.NewFileHandler(store)
+	adminHandler := handler.NewAdminHandler(adminService)
+	usageHandler := handler.NewUsageHandler(usageService)
+	archiveHandler := handler.NewArchiveHandler(archiveService)
+	featureHandler := handler.NewFeatureHandler(featureService)
+	teamHandler := handler.NewTeamHandler(teamService)
 
 	// Setup router
 	r := router.SetupRouter(
@@ -126,9 +393,29 @@ func main() {
 		shareHandler,
 		responseHandler,
 		authHandler,
+		draftHandler,
+		commentHandler,
+		activityHandler,
+		reportHandler,
+		channelHandler,
+		hookHandler,
+		exportJobHandler,
+		fileHandler,
+		adminHandler,
+		usageHandler,
+		archiveHandler,
+		featureHandler,
+		teamHandler,
 		jwtUtil,
-		cfg,
+		authService.CheckActive,
+		authService.CheckSession,
+		adminService.RecordImpersonatedRequest,
+		questionRepo.FindSensitiveIDs,
+		usageService.ReserveUsage,
+		featureService.RequireFeature,
+		cfgStore,
 		redisClient.GetClient(),
+		cacheKeys,
 	)
 
 	// Create HTTP server
@@ -155,6 +442,9 @@ func main() {
 
 	log.Println("Shutting down server...")
 
+	// Stop background jobs
+	stopNotifier()
+
 	// Create shutdown context with timeout
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"survey-system/internal/service"
+	"survey-system/pkg/errors"
+)
+
+// ActivityHandler handles survey activity feed HTTP requests
+type ActivityHandler struct {
+	activityService service.ActivityService
+}
+
+// NewActivityHandler creates a new activity handler instance
+func NewActivityHandler(activityService service.ActivityService) *ActivityHandler {
+	return &ActivityHandler{
+		activityService: activityService,
+	}
+}
+
+// GetActivity handles GET /api/v1/surveys/:id/activity
+func (h *ActivityHandler) GetActivity(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		handleError(c, errors.ErrInvalidID)
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	days, _ := strconv.Atoi(c.DefaultQuery("days", "7"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+
+	items, err := h.activityService.GetActivity(c.Request.Context(), userID.(uint), uint(id), days, limit)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    items,
+	})
+}
@@ -0,0 +1,195 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"survey-system/internal/dto/request"
+	"survey-system/internal/service"
+	"survey-system/pkg/errors"
+)
+
+// AdminHandler handles administrative HTTP requests
+type AdminHandler struct {
+	adminService service.AdminService
+}
+
+// NewAdminHandler creates a new admin handler instance
+func NewAdminHandler(adminService service.AdminService) *AdminHandler {
+	return &AdminHandler{
+		adminService: adminService,
+	}
+}
+
+// TransferSurvey handles POST /api/v1/admin/surveys/:id/transfer
+func (h *AdminHandler) TransferSurvey(c *gin.Context) {
+	surveyID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		handleError(c, errors.ErrInvalidID)
+		return
+	}
+
+	var req request.TransferSurveyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	survey, err := h.adminService.TransferSurvey(c.Request.Context(), userID.(uint), uint(surveyID), &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    survey,
+	})
+}
+
+// CloneSurvey handles POST /api/v1/admin/surveys/:id/clone
+func (h *AdminHandler) CloneSurvey(c *gin.Context) {
+	surveyID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		handleError(c, errors.ErrInvalidID)
+		return
+	}
+
+	var req request.CloneSurveyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	survey, err := h.adminService.CloneSurvey(c.Request.Context(), userID.(uint), uint(surveyID), &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    survey,
+	})
+}
+
+// DeactivateUser handles POST /api/v1/admin/users/:id/deactivate
+func (h *AdminHandler) DeactivateUser(c *gin.Context) {
+	targetID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		handleError(c, errors.ErrInvalidID)
+		return
+	}
+
+	// The body is optional; without it the user's surveys stay with them
+	var req request.DeactivateUserRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			handleError(c, err)
+			return
+		}
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	result, err := h.adminService.DeactivateUser(c.Request.Context(), userID.(uint), uint(targetID), &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// ReactivateUser handles POST /api/v1/admin/users/:id/reactivate
+func (h *AdminHandler) ReactivateUser(c *gin.Context) {
+	targetID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		handleError(c, errors.ErrInvalidID)
+		return
+	}
+
+	user, err := h.adminService.ReactivateUser(c.Request.Context(), uint(targetID))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    user,
+	})
+}
+
+// ImpersonateUser handles POST /api/v1/admin/impersonate/:userId
+func (h *AdminHandler) ImpersonateUser(c *gin.Context) {
+	targetID, err := strconv.ParseUint(c.Param("userId"), 10, 32)
+	if err != nil {
+		handleError(c, errors.ErrInvalidID)
+		return
+	}
+
+	var req request.ImpersonateUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	session, err := h.adminService.ImpersonateUser(c.Request.Context(), userID.(uint), uint(targetID), &req, c.ClientIP())
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    session,
+	})
+}
+
+// ListAuditLogs handles GET /api/v1/admin/audit-logs
+func (h *AdminHandler) ListAuditLogs(c *gin.Context) {
+	var query request.AuditLogQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	entries, err := h.adminService.ListAuditLogs(c.Request.Context(), &query)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    entries.Data,
+		"meta":    entries.Meta,
+	})
+}
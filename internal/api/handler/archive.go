@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"survey-system/internal/service"
+	"survey-system/pkg/errors"
+)
+
+// ArchiveHandler handles HTTP requests for archives of purged responses
+type ArchiveHandler struct {
+	archiveService service.ArchiveService
+}
+
+// NewArchiveHandler creates a new archive handler instance
+func NewArchiveHandler(archiveService service.ArchiveService) *ArchiveHandler {
+	return &ArchiveHandler{
+		archiveService: archiveService,
+	}
+}
+
+// ListArchives handles GET /api/v1/admin/archives (with optional survey_id query parameter)
+func (h *ArchiveHandler) ListArchives(c *gin.Context) {
+	var surveyID uint64
+	if value := c.Query("survey_id"); value != "" {
+		var err error
+		surveyID, err = strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			handleError(c, errors.ErrInvalidID)
+			return
+		}
+	}
+
+	archives, err := h.archiveService.ListArchives(c.Request.Context(), uint(surveyID))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    archives,
+	})
+}
+
+// DownloadArchive handles GET /api/v1/admin/archives/:id/download
+func (h *ArchiveHandler) DownloadArchive(c *gin.Context) {
+	archiveID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		handleError(c, errors.ErrInvalidID)
+		return
+	}
+
+	file, archive, err := h.archiveService.OpenArchive(c.Request.Context(), uint(archiveID))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	defer file.Close()
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", archive.Filename))
+	c.Header("Content-Type", "application/zip")
+	c.Header("ETag", fmt.Sprintf("\"archive-%d-%d\"", archive.ID, file.Size()))
+	http.ServeContent(c.Writer, c.Request, archive.Filename, file.ModTime(), file)
+}
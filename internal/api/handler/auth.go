@@ -2,9 +2,11 @@ package handler
 
 import (
 	"net/http"
+	"strconv"
 	"survey-system/internal/dto/request"
 	"survey-system/internal/dto/response"
 	"survey-system/internal/service"
+	"survey-system/pkg/errors"
 
 	"github.com/gin-gonic/gin"
 )
@@ -35,40 +37,14 @@ func NewAuthHandler(authService service.AuthService) *AuthHandler {
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req request.LoginRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"error": gin.H{
-				"code":    "VALIDATION_FAILED",
-				"message": "请求参数验证失败",
-				"details": err.Error(),
-			},
-		})
+		handleError(c, err)
 		return
 	}
 
 	// Call auth service to login
-	loginResp, err := h.authService.Login(req.Username, req.Password)
+	loginResp, err := h.authService.Login(c.Request.Context(), req.Username, req.Password, c.Request.UserAgent(), c.ClientIP())
 	if err != nil {
-		// Check if it's an authentication error
-		if err.Error() == "invalid username or password" {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"success": false,
-				"error": gin.H{
-					"code":    "INVALID_CREDENTIALS",
-					"message": "用户名或密码错误",
-				},
-			})
-			return
-		}
-
-		// Internal server error
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"error": gin.H{
-				"code":    "INTERNAL_ERROR",
-				"message": "服务器内部错误",
-			},
-		})
+		handleError(c, err)
 		return
 	}
 
@@ -90,6 +66,151 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	})
 }
 
+// Register handles self-service sign-up requests
+// @Summary Register
+// @Description Create an account, when registration is open, and email it a verification link
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body request.RegisterRequest true "Account data"
+// @Success 201 {object} response.RegisterResponse
+// @Failure 400 {object} errors.AppError
+// @Failure 403 {object} errors.AppError
+// @Failure 409 {object} errors.AppError
+// @Router /api/v1/auth/register [post]
+func (h *AuthHandler) Register(c *gin.Context) {
+	var req request.RegisterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	if err := h.authService.Register(c.Request.Context(), req.Username, req.Password, req.Email); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data": &response.RegisterResponse{
+			Message: "注册成功，请通过邮件中的链接验证邮箱",
+		},
+	})
+}
+
+// VerifyEmail handles email verification requests
+// @Summary Verify email
+// @Description Verify the email address with the token of a verification link
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body request.VerifyEmailRequest true "Verification token"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} errors.AppError
+// @Router /api/v1/auth/verify-email [post]
+func (h *AuthHandler) VerifyEmail(c *gin.Context) {
+	var req request.VerifyEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	if err := h.authService.VerifyEmail(c.Request.Context(), req.Token); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Email verified successfully",
+	})
+}
+
+// ResendVerification handles requests for a new verification email
+// @Summary Resend verification email
+// @Description Email a new verification link to the unverified account with the address; the response does not reveal whether it exists
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body request.AccountEmailRequest true "Email address"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} errors.AppError
+// @Router /api/v1/auth/resend-verification [post]
+func (h *AuthHandler) ResendVerification(c *gin.Context) {
+	var req request.AccountEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	if err := h.authService.ResendVerification(c.Request.Context(), req.Email); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "If the account exists and is unverified, a verification email has been sent",
+	})
+}
+
+// ForgotPassword handles password reset requests
+// @Summary Forgot password
+// @Description Email a password reset link to the account with the address; the response does not reveal whether it exists
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body request.AccountEmailRequest true "Email address"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} errors.AppError
+// @Router /api/v1/auth/forgot-password [post]
+func (h *AuthHandler) ForgotPassword(c *gin.Context) {
+	var req request.AccountEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	if err := h.authService.ForgotPassword(c.Request.Context(), req.Email); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "If the account exists, a password reset email has been sent",
+	})
+}
+
+// ResetPassword handles setting a new password with a reset link
+// @Summary Reset password
+// @Description Set a new password with the token of a reset link; all sessions of the account are logged out
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body request.ResetPasswordRequest true "Reset token and new password"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} errors.AppError
+// @Failure 403 {object} errors.AppError
+// @Router /api/v1/auth/reset-password [post]
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+	var req request.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	if err := h.authService.ResetPassword(c.Request.Context(), req.Token, req.NewPassword); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Password reset successfully",
+	})
+}
+
 // UpdateProfile handles user profile update requests
 // @Summary Update user profile
 // @Description Update username, email, and/or password
@@ -106,101 +227,45 @@ func (h *AuthHandler) UpdateProfile(c *gin.Context) {
 	// Get user ID from context (set by auth middleware)
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"success": false,
-			"error": gin.H{
-				"code":    "UNAUTHORIZED",
-				"message": "用户未认证",
-			},
-		})
+		handleError(c, errors.ErrUnauthorized)
 		return
 	}
 
 	var req request.UpdateProfileRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"error": gin.H{
-				"code":    "VALIDATION_FAILED",
-				"message": "请求参数验证失败",
-				"details": err.Error(),
-			},
-		})
+		handleError(c, err)
 		return
 	}
 
 	// Validate that at least one field is being updated
 	if req.Username == "" && req.Email == "" && req.NewPassword == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"error": gin.H{
-				"code":    "VALIDATION_FAILED",
-				"message": "至少需要提供一个要更新的字段",
-			},
-		})
+		handleError(c, errors.NewLocalizedError("VALIDATION_FAILED", http.StatusBadRequest, "error.NO_PROFILE_FIELDS"))
 		return
 	}
 
 	// If password is being changed, old password is required
 	if req.NewPassword != "" && req.OldPassword == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"error": gin.H{
-				"code":    "VALIDATION_FAILED",
-				"message": "修改密码需要提供旧密码",
-			},
-		})
+		handleError(c, errors.NewLocalizedError("VALIDATION_FAILED", http.StatusBadRequest, "error.OLD_PASSWORD_REQUIRED"))
 		return
 	}
 
+	// The current session stays signed in when the password changes
+	tokenID, _ := c.Get("token_id")
+	currentTokenID, _ := tokenID.(string)
+
 	// Call auth service to update profile
 	updatedUser, err := h.authService.UpdateProfile(
+		c.Request.Context(),
 		userID.(uint),
+		currentTokenID,
 		req.Username,
 		req.Email,
 		req.OldPassword,
 		req.NewPassword,
 	)
 	if err != nil {
-		// Check specific error types
-		switch err.Error() {
-		case "user not found":
-			c.JSON(http.StatusNotFound, gin.H{
-				"success": false,
-				"error": gin.H{
-					"code":    "USER_NOT_FOUND",
-					"message": "用户不存在",
-				},
-			})
-			return
-		case "username already exists":
-			c.JSON(http.StatusConflict, gin.H{
-				"success": false,
-				"error": gin.H{
-					"code":    "USERNAME_EXISTS",
-					"message": "用户名已存在",
-				},
-			})
-			return
-		case "old password is incorrect":
-			c.JSON(http.StatusBadRequest, gin.H{
-				"success": false,
-				"error": gin.H{
-					"code":    "INVALID_PASSWORD",
-					"message": "旧密码不正确",
-				},
-			})
-			return
-		default:
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"success": false,
-				"error": gin.H{
-					"code":    "INTERNAL_ERROR",
-					"message": "服务器内部错误",
-				},
-			})
-			return
-		}
+		handleError(c, err)
+		return
 	}
 
 	// Convert to response DTO
@@ -220,3 +285,118 @@ func (h *AuthHandler) UpdateProfile(c *gin.Context) {
 		"data":    resp,
 	})
 }
+
+// ListSessions handles listing the current user's active login sessions
+// @Summary List login sessions
+// @Description List the active logins of the current user; the session of the request's token is marked as current
+// @Tags auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} response.SessionResponse
+// @Failure 401 {object} errors.AppError
+// @Router /api/v1/auth/sessions [get]
+func (h *AuthHandler) ListSessions(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+	// Impersonation tokens have no session of their own
+	tokenID, _ := c.Get("token_id")
+	currentTokenID, _ := tokenID.(string)
+
+	sessions, err := h.authService.ListSessions(c.Request.Context(), userID.(uint), currentTokenID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    sessions,
+	})
+}
+
+// RevokeSession handles revoking a login session of the current user
+// @Summary Revoke login session
+// @Description Revoke a login session, e.g. of a stolen device; its token is rejected from the next request on
+// @Tags auth
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Session ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} errors.AppError
+// @Failure 404 {object} errors.AppError
+// @Router /api/v1/auth/sessions/{id} [delete]
+func (h *AuthHandler) RevokeSession(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		handleError(c, errors.ErrInvalidID)
+		return
+	}
+
+	if err := h.authService.RevokeSession(c.Request.Context(), userID.(uint), uint(id)); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Session revoked successfully",
+	})
+}
+
+// ListLoginHistory handles listing the login attempts to the current user's account
+// @Summary List login history
+// @Description List successful and failed logins to the current user's account, newest first
+// @Tags auth
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number"
+// @Param page_size query int false "Page size, at most 100"
+// @Success 200 {object} response.PaginatedLoginHistoryResponse
+// @Failure 401 {object} errors.AppError
+// @Router /api/v1/auth/login-history [get]
+func (h *AuthHandler) ListLoginHistory(c *gin.Context) {
+	var query request.LoginHistoryQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	history, err := h.authService.ListLoginHistory(c.Request.Context(), userID.(uint), &query)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    history.Data,
+		"meta":    history.Meta,
+	})
+}
+
+// JWKS serves the public keys that verify issued tokens
+// @Summary JSON Web Key Set
+// @Description Public keys of RS256 or EdDSA signed tokens; empty when tokens are signed with the HMAC secret
+// @Tags auth
+// @Produce json
+// @Success 200 {object} utils.JWKSet
+// @Router /.well-known/jwks.json [get]
+func (h *AuthHandler) JWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=3600")
+	c.JSON(http.StatusOK, h.authService.JWKS())
+}
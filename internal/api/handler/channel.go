@@ -0,0 +1,180 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"survey-system/internal/dto/request"
+	"survey-system/internal/service"
+	"survey-system/pkg/errors"
+)
+
+// ChannelHandler handles notification channel HTTP requests
+type ChannelHandler struct {
+	channelService service.ChannelService
+}
+
+// NewChannelHandler creates a new channel handler instance
+func NewChannelHandler(channelService service.ChannelService) *ChannelHandler {
+	return &ChannelHandler{
+		channelService: channelService,
+	}
+}
+
+// parseChannelPath reads the survey and (when present) channel IDs from the URL
+func parseChannelPath(c *gin.Context) (surveyID, channelID uint, err error) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return 0, 0, errors.ErrInvalidID
+	}
+
+	if raw := c.Param("channelId"); raw != "" {
+		cid, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			return 0, 0, errors.ErrInvalidID
+		}
+		channelID = uint(cid)
+	}
+	return uint(id), channelID, nil
+}
+
+// ListChannels handles GET /api/v1/surveys/:id/channels
+func (h *ChannelHandler) ListChannels(c *gin.Context) {
+	surveyID, _, err := parseChannelPath(c)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	channels, err := h.channelService.ListChannels(c.Request.Context(), userID.(uint), surveyID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    channels,
+	})
+}
+
+// CreateChannel handles POST /api/v1/surveys/:id/channels
+func (h *ChannelHandler) CreateChannel(c *gin.Context) {
+	surveyID, _, err := parseChannelPath(c)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	var req request.ChannelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	channel, err := h.channelService.CreateChannel(c.Request.Context(), userID.(uint), surveyID, &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    channel,
+	})
+}
+
+// UpdateChannel handles PUT /api/v1/surveys/:id/channels/:channelId
+func (h *ChannelHandler) UpdateChannel(c *gin.Context) {
+	surveyID, channelID, err := parseChannelPath(c)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	var req request.ChannelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	channel, err := h.channelService.UpdateChannel(c.Request.Context(), userID.(uint), surveyID, channelID, &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    channel,
+	})
+}
+
+// DeleteChannel handles DELETE /api/v1/surveys/:id/channels/:channelId
+func (h *ChannelHandler) DeleteChannel(c *gin.Context) {
+	surveyID, channelID, err := parseChannelPath(c)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	if err := h.channelService.DeleteChannel(c.Request.Context(), userID.(uint), surveyID, channelID); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Notification channel deleted successfully",
+	})
+}
+
+// SendTestMessage handles POST /api/v1/surveys/:id/channels/:channelId/test
+func (h *ChannelHandler) SendTestMessage(c *gin.Context) {
+	surveyID, channelID, err := parseChannelPath(c)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	if err := h.channelService.SendTestMessage(c.Request.Context(), userID.(uint), surveyID, channelID); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Test message sent successfully",
+	})
+}
@@ -0,0 +1,167 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"survey-system/internal/dto/request"
+	"survey-system/internal/service"
+	"survey-system/pkg/errors"
+)
+
+// CommentHandler handles response comment HTTP requests
+type CommentHandler struct {
+	commentService service.CommentService
+}
+
+// NewCommentHandler creates a new comment handler instance
+func NewCommentHandler(commentService service.CommentService) *CommentHandler {
+	return &CommentHandler{
+		commentService: commentService,
+	}
+}
+
+// commentPath holds the IDs addressed by a comment route
+type commentPath struct {
+	surveyID   uint
+	responseID uint
+	commentID  uint
+}
+
+// parseCommentPath reads the survey, response and (when present) comment IDs from the URL
+func parseCommentPath(c *gin.Context) (*commentPath, error) {
+	surveyID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return nil, errors.ErrInvalidID
+	}
+	responseID, err := strconv.ParseUint(c.Param("responseId"), 10, 32)
+	if err != nil {
+		return nil, errors.ErrInvalidID
+	}
+
+	path := &commentPath{surveyID: uint(surveyID), responseID: uint(responseID)}
+	if raw := c.Param("commentId"); raw != "" {
+		commentID, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			return nil, errors.ErrInvalidID
+		}
+		path.commentID = uint(commentID)
+	}
+	return path, nil
+}
+
+// ListComments handles GET /api/v1/surveys/:id/responses/:responseId/comments
+func (h *CommentHandler) ListComments(c *gin.Context) {
+	path, err := parseCommentPath(c)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	comments, err := h.commentService.ListComments(c.Request.Context(), userID.(uint), path.surveyID, path.responseID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    comments,
+	})
+}
+
+// CreateComment handles POST /api/v1/surveys/:id/responses/:responseId/comments
+func (h *CommentHandler) CreateComment(c *gin.Context) {
+	path, err := parseCommentPath(c)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	var req request.CommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	comment, err := h.commentService.CreateComment(c.Request.Context(), userID.(uint), path.surveyID, path.responseID, &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    comment,
+	})
+}
+
+// UpdateComment handles PUT /api/v1/surveys/:id/responses/:responseId/comments/:commentId
+func (h *CommentHandler) UpdateComment(c *gin.Context) {
+	path, err := parseCommentPath(c)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	var req request.CommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	comment, err := h.commentService.UpdateComment(c.Request.Context(), userID.(uint), path.surveyID, path.responseID, path.commentID, &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    comment,
+	})
+}
+
+// DeleteComment handles DELETE /api/v1/surveys/:id/responses/:responseId/comments/:commentId
+func (h *CommentHandler) DeleteComment(c *gin.Context) {
+	path, err := parseCommentPath(c)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	if err := h.commentService.DeleteComment(c.Request.Context(), userID.(uint), path.surveyID, path.responseID, path.commentID); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Comment deleted successfully",
+	})
+}
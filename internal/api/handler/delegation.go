@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"survey-system/internal/dto/request"
+	"survey-system/pkg/errors"
+)
+
+// ListDelegations handles GET /api/v1/surveys/:id/delegations
+func (h *ShareHandler) ListDelegations(c *gin.Context) {
+	surveyID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		handleError(c, errors.ErrInvalidID)
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	delegations, err := h.shareService.ListDelegations(c.Request.Context(), userID.(uint), uint(surveyID))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    delegations,
+	})
+}
+
+// CreateDelegation handles POST /api/v1/surveys/:id/delegations
+func (h *ShareHandler) CreateDelegation(c *gin.Context) {
+	surveyID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		handleError(c, errors.ErrInvalidID)
+		return
+	}
+
+	var req request.CreateDelegationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	delegation, err := h.shareService.CreateDelegation(c.Request.Context(), userID.(uint), uint(surveyID), &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    delegation,
+	})
+}
+
+// RevokeDelegation handles DELETE /api/v1/surveys/:id/delegations/:delegationId
+func (h *ShareHandler) RevokeDelegation(c *gin.Context) {
+	surveyID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		handleError(c, errors.ErrInvalidID)
+		return
+	}
+
+	delegationID, err := strconv.ParseUint(c.Param("delegationId"), 10, 32)
+	if err != nil {
+		handleError(c, errors.ErrInvalidID)
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	if err := h.shareService.RevokeDelegation(c.Request.Context(), userID.(uint), uint(surveyID), uint(delegationID)); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Delegation revoked successfully",
+	})
+}
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"survey-system/internal/dto/request"
+	"survey-system/internal/service"
+	"survey-system/pkg/errors"
+)
+
+// DraftHandler handles response draft related HTTP requests
+type DraftHandler struct {
+	draftService service.DraftService
+}
+
+// NewDraftHandler creates a new draft handler instance
+func NewDraftHandler(draftService service.DraftService) *DraftHandler {
+	return &DraftHandler{
+		draftService: draftService,
+	}
+}
+
+// SaveDraft handles POST /api/v1/public/drafts
+func (h *DraftHandler) SaveDraft(c *gin.Context) {
+	var req request.SaveDraftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	draft, err := h.draftService.SaveDraft(c.Request.Context(), &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    draft,
+	})
+}
+
+// ResumeDraft handles GET /api/v1/public/drafts/resume (with draft_token query parameter)
+func (h *DraftHandler) ResumeDraft(c *gin.Context) {
+	draftToken := c.Query("draft_token")
+	if draftToken == "" {
+		handleError(c, errors.ErrMissingToken)
+		return
+	}
+
+	draft, err := h.draftService.ResumeDraft(c.Request.Context(), draftToken)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    draft,
+	})
+}
@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"survey-system/internal/dto/request"
+	"survey-system/internal/service"
+	"survey-system/pkg/errors"
+)
+
+// ExportJobHandler handles asynchronous export HTTP requests
+type ExportJobHandler struct {
+	exportJobService service.ExportJobService
+}
+
+// NewExportJobHandler creates a new export job handler instance
+func NewExportJobHandler(exportJobService service.ExportJobService) *ExportJobHandler {
+	return &ExportJobHandler{
+		exportJobService: exportJobService,
+	}
+}
+
+// parseExportJobPath reads the survey and export job IDs from the URL
+func parseExportJobPath(c *gin.Context) (uint, uint, error) {
+	surveyID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return 0, 0, errors.ErrInvalidID
+	}
+	jobID, err := strconv.ParseUint(c.Param("jobId"), 10, 32)
+	if err != nil {
+		return 0, 0, errors.ErrInvalidID
+	}
+	return uint(surveyID), uint(jobID), nil
+}
+
+// CreateExportJob handles POST /api/v1/surveys/:id/exports
+func (h *ExportJobHandler) CreateExportJob(c *gin.Context) {
+	surveyID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		handleError(c, errors.ErrInvalidID)
+		return
+	}
+
+	var req request.CreateExportJobRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	job, err := h.exportJobService.CreateJob(c.Request.Context(), userID.(uint), uint(surveyID), &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"success": true,
+		"data":    job,
+	})
+}
+
+// GetExportJob handles GET /api/v1/surveys/:id/exports/:jobId
+func (h *ExportJobHandler) GetExportJob(c *gin.Context) {
+	surveyID, jobID, err := parseExportJobPath(c)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	job, err := h.exportJobService.GetJob(c.Request.Context(), userID.(uint), surveyID, jobID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    job,
+	})
+}
+
+// DownloadExportJob handles GET /api/v1/surveys/:id/exports/:jobId/download
+func (h *ExportJobHandler) DownloadExportJob(c *gin.Context) {
+	surveyID, jobID, err := parseExportJobPath(c)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	file, job, err := h.exportJobService.DownloadJob(c.Request.Context(), userID.(uint), surveyID, jobID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	defer file.Close()
+
+	// Range requests let interrupted downloads resume; the ETag ties a resumed
+	// download to the same file through If-Range
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", job.Filename))
+	c.Header("Content-Type", job.ContentType)
+	c.Header("ETag", fmt.Sprintf("\"export-%d-%d\"", job.ID, file.Size()))
+	http.ServeContent(c.Writer, c.Request, job.Filename, file.ModTime(), file)
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"survey-system/internal/dto/request"
+	"survey-system/internal/service"
+	"survey-system/pkg/errors"
+)
+
+// FeatureHandler handles feature flag HTTP requests
+type FeatureHandler struct {
+	featureService service.FeatureService
+}
+
+// NewFeatureHandler creates a new feature handler instance
+func NewFeatureHandler(featureService service.FeatureService) *FeatureHandler {
+	return &FeatureHandler{
+		featureService: featureService,
+	}
+}
+
+// GetFeatures handles GET /api/v1/features
+func (h *FeatureHandler) GetFeatures(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    h.featureService.UserFeatures(c.Request.Context(), userID.(uint)),
+	})
+}
+
+// ListFeatures handles GET /api/v1/admin/features
+func (h *FeatureHandler) ListFeatures(c *gin.Context) {
+	features, err := h.featureService.ListFeatures(c.Request.Context())
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    features,
+	})
+}
+
+// SetFeature handles PUT /api/v1/admin/features/:name
+func (h *FeatureHandler) SetFeature(c *gin.Context) {
+	var req request.SetFeatureRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	feature, err := h.featureService.SetFeature(c.Request.Context(), c.Param("name"), &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    feature,
+	})
+}
+
+// ClearFeature handles DELETE /api/v1/admin/features/:name
+func (h *FeatureHandler) ClearFeature(c *gin.Context) {
+	var query request.FeatureOverrideQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	feature, err := h.featureService.ClearFeature(c.Request.Context(), c.Param("name"), query.UserID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    feature,
+	})
+}
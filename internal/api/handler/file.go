@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"survey-system/pkg/errors"
+	"survey-system/pkg/storage"
+)
+
+// FileHandler serves files of the local storage driver through signed links
+type FileHandler struct {
+	local *storage.Local // nil when files are served by an object store
+}
+
+// NewFileHandler creates a new file handler instance
+func NewFileHandler(store storage.Storage) *FileHandler {
+	local, _ := store.(*storage.Local)
+	return &FileHandler{
+		local: local,
+	}
+}
+
+// DownloadFile handles GET /api/v1/files/*key
+func (h *FileHandler) DownloadFile(c *gin.Context) {
+	// Links of other drivers point at the object store directly
+	if h.local == nil {
+		handleError(c, errors.ErrNotFound)
+		return
+	}
+
+	key := strings.TrimPrefix(c.Param("key"), "/")
+	filename := c.Query("filename")
+	expiresAt, err := strconv.ParseInt(c.Query("expires"), 10, 64)
+	if err != nil {
+		handleError(c, errors.ErrForbidden)
+		return
+	}
+
+	switch err := h.local.Verify(key, filename, expiresAt, c.Query("signature")); err {
+	case nil:
+	case storage.ErrLinkExpired:
+		handleError(c, errors.ErrTokenExpired)
+		return
+	default:
+		handleError(c, errors.ErrForbidden)
+		return
+	}
+
+	file, err := h.local.Open(c.Request.Context(), key)
+	if err != nil {
+		if err == storage.ErrNotFound {
+			handleError(c, errors.ErrNotFound)
+			return
+		}
+		handleError(c, errors.WrapError(err, "failed to read file"))
+		return
+	}
+	defer file.Close()
+
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	if filename != "" {
+		c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	}
+	c.Header("Content-Type", contentType)
+
+	// Serves Range requests so interrupted downloads can resume
+	http.ServeContent(c.Writer, c.Request, path.Base(key), file.ModTime(), file)
+}
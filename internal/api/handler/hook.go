@@ -0,0 +1,179 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"survey-system/internal/dto/request"
+	"survey-system/internal/service"
+	"survey-system/pkg/errors"
+)
+
+// HookHandler handles REST Hooks subscription HTTP requests
+type HookHandler struct {
+	hookService service.HookService
+}
+
+// NewHookHandler creates a new hook handler instance
+func NewHookHandler(hookService service.HookService) *HookHandler {
+	return &HookHandler{
+		hookService: hookService,
+	}
+}
+
+// ListHooks handles GET /api/v1/hooks
+func (h *HookHandler) ListHooks(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	hooks, err := h.hookService.ListHooks(c.Request.Context(), userID.(uint))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    hooks,
+	})
+}
+
+// Subscribe handles POST /api/v1/hooks
+func (h *HookHandler) Subscribe(c *gin.Context) {
+	var req request.SubscribeHookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	hook, err := h.hookService.Subscribe(c.Request.Context(), userID.(uint), &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    hook,
+	})
+}
+
+// Unsubscribe handles DELETE /api/v1/hooks/:id
+func (h *HookHandler) Unsubscribe(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		handleError(c, errors.ErrInvalidID)
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	if err := h.hookService.Unsubscribe(c.Request.Context(), userID.(uint), uint(id)); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Hook unsubscribed successfully",
+	})
+}
+
+// ListDeliveries handles GET /api/v1/hooks/:id/deliveries
+func (h *HookHandler) ListDeliveries(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		handleError(c, errors.ErrInvalidID)
+		return
+	}
+
+	var query request.HookDeliveryQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	deliveries, err := h.hookService.ListDeliveries(c.Request.Context(), userID.(uint), uint(id), &query)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    deliveries.Data,
+		"meta":    deliveries.Meta,
+	})
+}
+
+// RetryDelivery handles POST /api/v1/hooks/deliveries/:id/retry
+func (h *HookHandler) RetryDelivery(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		handleError(c, errors.ErrInvalidID)
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	delivery, err := h.hookService.RetryDelivery(c.Request.Context(), userID.(uint), uint(id))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    delivery,
+	})
+}
+
+// SamplePayloads handles GET /api/v1/hooks/sample
+func (h *HookHandler) SamplePayloads(c *gin.Context) {
+	var query request.HookSampleQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	payloads, err := h.hookService.SamplePayloads(c.Request.Context(), userID.(uint), &query)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    payloads,
+	})
+}
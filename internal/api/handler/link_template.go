@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"survey-system/internal/dto/request"
+	"survey-system/pkg/errors"
+)
+
+// parseLinkTemplatePath reads the survey and (when present) template IDs from the URL
+func parseLinkTemplatePath(c *gin.Context) (surveyID, templateID uint, err error) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return 0, 0, errors.ErrInvalidID
+	}
+
+	if raw := c.Param("templateId"); raw != "" {
+		tid, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			return 0, 0, errors.ErrInvalidID
+		}
+		templateID = uint(tid)
+	}
+	return uint(id), templateID, nil
+}
+
+// ListLinkTemplates handles GET /api/v1/surveys/:id/link-templates
+func (h *ShareHandler) ListLinkTemplates(c *gin.Context) {
+	surveyID, _, err := parseLinkTemplatePath(c)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	templates, err := h.shareService.ListLinkTemplates(c.Request.Context(), userID.(uint), surveyID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    templates,
+	})
+}
+
+// CreateLinkTemplate handles POST /api/v1/surveys/:id/link-templates
+func (h *ShareHandler) CreateLinkTemplate(c *gin.Context) {
+	surveyID, _, err := parseLinkTemplatePath(c)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	var req request.LinkTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	template, err := h.shareService.CreateLinkTemplate(c.Request.Context(), userID.(uint), surveyID, &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    template,
+	})
+}
+
+// UpdateLinkTemplate handles PUT /api/v1/surveys/:id/link-templates/:templateId
+func (h *ShareHandler) UpdateLinkTemplate(c *gin.Context) {
+	surveyID, templateID, err := parseLinkTemplatePath(c)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	var req request.LinkTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	template, err := h.shareService.UpdateLinkTemplate(c.Request.Context(), userID.(uint), surveyID, templateID, &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    template,
+	})
+}
+
+// DeleteLinkTemplate handles DELETE /api/v1/surveys/:id/link-templates/:templateId
+func (h *ShareHandler) DeleteLinkTemplate(c *gin.Context) {
+	surveyID, templateID, err := parseLinkTemplatePath(c)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	if err := h.shareService.DeleteLinkTemplate(c.Request.Context(), userID.(uint), surveyID, templateID); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Link template deleted successfully",
+	})
+}
@@ -26,26 +26,14 @@ func NewQuestionHandler(questionService service.QuestionService) *QuestionHandle
 func (h *QuestionHandler) CreateQuestion(c *gin.Context) {
 	var req request.CreateQuestionRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"error": gin.H{
-				"code":    "VALIDATION_ERROR",
-				"message": err.Error(),
-			},
-		})
+		handleError(c, err)
 		return
 	}
 
 	// Get user ID from context (set by auth middleware)
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"success": false,
-			"error": gin.H{
-				"code":    errors.ErrUnauthorized.Code,
-				"message": errors.ErrUnauthorized.Message,
-			},
-		})
+		handleError(c, errors.ErrUnauthorized)
 		return
 	}
 
@@ -65,36 +53,33 @@ func (h *QuestionHandler) CreateQuestion(c *gin.Context) {
 func (h *QuestionHandler) UpdateQuestion(c *gin.Context) {
 	questionID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"error": gin.H{
-				"code":    "INVALID_ID",
-				"message": "Invalid question ID",
-			},
-		})
+		handleError(c, errors.ErrInvalidID)
 		return
 	}
 
 	var req request.UpdateQuestionRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"error": gin.H{
-				"code":    "VALIDATION_ERROR",
-				"message": err.Error(),
-			},
-		})
+		handleError(c, err)
 		return
 	}
 
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"success": false,
-			"error": gin.H{
-				"code":    errors.ErrUnauthorized.Code,
-				"message": errors.ErrUnauthorized.Message,
-			},
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	// A dry run only reports the impact on collected answers and outstanding links
+	if dryRun, _ := strconv.ParseBool(c.Query("dry_run")); dryRun {
+		impact, err := h.questionService.PreviewQuestionUpdate(c.Request.Context(), userID.(uint), uint(questionID), &req)
+		if err != nil {
+			handleError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"success": true,
+			"data":    impact,
 		})
 		return
 	}
@@ -115,25 +100,13 @@ func (h *QuestionHandler) UpdateQuestion(c *gin.Context) {
 func (h *QuestionHandler) DeleteQuestion(c *gin.Context) {
 	questionID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"error": gin.H{
-				"code":    "INVALID_ID",
-				"message": "Invalid question ID",
-			},
-		})
+		handleError(c, errors.ErrInvalidID)
 		return
 	}
 
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"success": false,
-			"error": gin.H{
-				"code":    errors.ErrUnauthorized.Code,
-				"message": errors.ErrUnauthorized.Message,
-			},
-		})
+		handleError(c, errors.ErrUnauthorized)
 		return
 	}
 
@@ -148,41 +121,90 @@ func (h *QuestionHandler) DeleteQuestion(c *gin.Context) {
 	})
 }
 
+// GetQuestionHistory handles GET /api/v1/questions/:id/history
+func (h *QuestionHandler) GetQuestionHistory(c *gin.Context) {
+	questionID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		handleError(c, errors.ErrInvalidID)
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	history, err := h.questionService.GetQuestionHistory(c.Request.Context(), userID.(uint), uint(questionID))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    history,
+	})
+}
+
+// RestoreQuestion handles POST /api/v1/questions/:id/history/:versionId/restore
+func (h *QuestionHandler) RestoreQuestion(c *gin.Context) {
+	questionID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		handleError(c, errors.ErrInvalidID)
+		return
+	}
+
+	versionID, err := strconv.ParseUint(c.Param("versionId"), 10, 32)
+	if err != nil {
+		handleError(c, errors.ErrInvalidID)
+		return
+	}
+
+	// The body is optional; it is only needed when the restore removes options chosen in responses
+	var req request.RestoreQuestionRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			handleError(c, err)
+			return
+		}
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	question, err := h.questionService.RestoreQuestion(c.Request.Context(), userID.(uint), uint(questionID), uint(versionID), &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    question,
+	})
+}
+
 // ReorderQuestions handles PUT /api/v1/surveys/:id/questions/reorder
 func (h *QuestionHandler) ReorderQuestions(c *gin.Context) {
 	surveyID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"error": gin.H{
-				"code":    "INVALID_ID",
-				"message": "Invalid survey ID",
-			},
-		})
+		handleError(c, errors.ErrInvalidID)
 		return
 	}
 
 	var req request.ReorderQuestionsRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"error": gin.H{
-				"code":    "VALIDATION_ERROR",
-				"message": err.Error(),
-			},
-		})
+		handleError(c, err)
 		return
 	}
 
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"success": false,
-			"error": gin.H{
-				"code":    errors.ErrUnauthorized.Code,
-				"message": errors.ErrUnauthorized.Message,
-			},
-		})
+		handleError(c, errors.ErrUnauthorized)
 		return
 	}
 
@@ -196,3 +218,69 @@ func (h *QuestionHandler) ReorderQuestions(c *gin.Context) {
 		"message": "Questions reordered successfully",
 	})
 }
+
+// GetSizeReport handles GET /api/v1/surveys/:id/size-report
+func (h *QuestionHandler) GetSizeReport(c *gin.Context) {
+	surveyID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		handleError(c, errors.ErrInvalidID)
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	report, err := h.questionService.SizeReport(c.Request.Context(), userID.(uint), uint(surveyID))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    report,
+	})
+}
+
+// ImportQuestionOptions handles POST /api/v1/questions/:id/options/import
+func (h *QuestionHandler) ImportQuestionOptions(c *gin.Context) {
+	questionID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		handleError(c, errors.ErrInvalidID)
+		return
+	}
+
+	var req request.ImportQuestionOptionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	result, err := h.questionService.ImportQuestionOptions(c.Request.Context(), userID.(uint), uint(questionID), &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// ListOptionSets handles GET /api/v1/option-sets
+func (h *QuestionHandler) ListOptionSets(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    h.questionService.ListOptionSets(),
+	})
+}
@@ -1,13 +1,17 @@
 package handler
 
 import (
+	stderrors "errors"
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 
 	"survey-system/internal/dto/request"
+	"survey-system/internal/repository"
 	"survey-system/internal/service"
 	"survey-system/pkg/errors"
+	"survey-system/pkg/i18n"
 
 	"github.com/gin-gonic/gin"
 )
@@ -28,13 +32,7 @@ func NewResponseHandler(responseSvc *service.ResponseService) *ResponseHandler {
 func (h *ResponseHandler) SubmitResponse(c *gin.Context) {
 	var req request.SubmitResponseRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"error": gin.H{
-				"code":    "BAD_REQUEST",
-				"message": "请求参数错误: " + err.Error(),
-			},
-		})
+		handleError(c, err)
 		return
 	}
 
@@ -45,26 +43,9 @@ func (h *ResponseHandler) SubmitResponse(c *gin.Context) {
 	userAgent := c.GetHeader("User-Agent")
 
 	// Submit response
-	resp, err := h.responseSvc.SubmitResponse(&req, ipAddress, userAgent)
+	resp, err := h.responseSvc.SubmitResponse(c.Request.Context(), &req, ipAddress, userAgent)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			c.JSON(appErr.Status, gin.H{
-				"success": false,
-				"error": gin.H{
-					"code":    appErr.Code,
-					"message": appErr.Message,
-				},
-			})
-			return
-		}
-
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"error": gin.H{
-				"code":    "INTERNAL_ERROR",
-				"message": "服务器内部错误",
-			},
-		})
+		handleError(c, err)
 		return
 	}
 
@@ -79,26 +60,14 @@ func (h *ResponseHandler) GetResponses(c *gin.Context) {
 	// Get user ID from context (set by auth middleware)
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"success": false,
-			"error": gin.H{
-				"code":    "UNAUTHORIZED",
-				"message": "未授权访问",
-			},
-		})
+		handleError(c, errors.ErrUnauthorized)
 		return
 	}
 
 	// Get survey ID from URL parameter
 	surveyID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"error": gin.H{
-				"code":    "INVALID_ID",
-				"message": "无效的问卷 ID",
-			},
-		})
+		handleError(c, errors.ErrInvalidID)
 		return
 	}
 
@@ -106,27 +75,70 @@ func (h *ResponseHandler) GetResponses(c *gin.Context) {
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
 
+	includeTest, _ := strconv.ParseBool(c.Query("include_test"))
+	flaggedOnly, _ := strconv.ParseBool(c.Query("flagged"))
+	filter := repository.ResponseFilter{Campaign: c.Query("campaign"), IncludeTest: includeTest, FlaggedOnly: flaggedOnly, ReviewStatus: c.Query("review_status")}
+
+	// The presence of the cursor parameter selects keyset pagination
+	if cursor, ok := c.GetQuery("cursor"); ok {
+		responseList, meta, err := h.responseSvc.GetResponsesByCursor(c.Request.Context(), userID.(uint), uint(surveyID), filter, cursor, pageSize)
+		if err != nil {
+			handleError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"success": true,
+			"data":    responseList,
+			"meta":    meta,
+		})
+		return
+	}
+
 	// Get responses
-	responseList, meta, err := h.responseSvc.GetResponses(userID.(uint), uint(surveyID), page, pageSize)
+	responseList, meta, err := h.responseSvc.GetResponses(c.Request.Context(), userID.(uint), uint(surveyID), filter, page, pageSize)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    responseList,
+		"meta":    meta,
+	})
+}
+
+// SampleResponses handles GET /api/v1/surveys/:id/responses/sample
+func (h *ResponseHandler) SampleResponses(c *gin.Context) {
+	// Get user ID from context (set by auth middleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	// Get survey ID from URL parameter
+	surveyID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			c.JSON(appErr.Status, gin.H{
-				"success": false,
-				"error": gin.H{
-					"code":    appErr.Code,
-					"message": appErr.Message,
-				},
-			})
+		handleError(c, errors.ErrInvalidID)
+		return
+	}
+
+	// Sample size is clamped by the service; stratify names an optional choice question
+	size, _ := strconv.Atoi(c.DefaultQuery("n", "50"))
+	var stratifyBy uint64
+	if raw := c.Query("stratify"); raw != "" {
+		stratifyBy, err = strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			handleError(c, errors.ErrInvalidID)
 			return
 		}
+	}
 
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"error": gin.H{
-				"code":    "INTERNAL_ERROR",
-				"message": "服务器内部错误",
-			},
-		})
+	responseList, meta, err := h.responseSvc.SampleResponses(c.Request.Context(), userID.(uint), uint(surveyID), size, uint(stratifyBy))
+	if err != nil {
+		handleError(c, err)
 		return
 	}
 
@@ -142,56 +154,230 @@ func (h *ResponseHandler) GetStatistics(c *gin.Context) {
 	// Get user ID from context (set by auth middleware)
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"success": false,
-			"error": gin.H{
-				"code":    "UNAUTHORIZED",
-				"message": "未授权访问",
-			},
-		})
+		handleError(c, errors.ErrUnauthorized)
 		return
 	}
 
 	// Get survey ID from URL parameter
 	surveyID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"error": gin.H{
-				"code":    "INVALID_ID",
-				"message": "无效的问卷 ID",
-			},
-		})
+		handleError(c, errors.ErrInvalidID)
 		return
 	}
 
+	// Test responses are left out unless asked for
+	includeTest, _ := strconv.ParseBool(c.Query("include_test"))
+
 	// Get statistics
-	resp, err := h.responseSvc.GetStatistics(userID.(uint), uint(surveyID))
+	resp, err := h.responseSvc.GetStatistics(c.Request.Context(), userID.(uint), uint(surveyID), includeTest)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    resp,
+	})
+}
+
+// GetCrosstab handles GET /api/v1/surveys/:id/statistics/crosstab
+func (h *ResponseHandler) GetCrosstab(c *gin.Context) {
+	// Get user ID from context (set by auth middleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	// Get survey ID from URL parameter
+	surveyID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		handleError(c, errors.ErrInvalidID)
+		return
+	}
+
+	// The row and column questions are given by ID
+	rowID, err := strconv.ParseUint(c.Query("row"), 10, 32)
+	if err != nil {
+		handleError(c, errors.ErrInvalidID)
+		return
+	}
+	colID, err := strconv.ParseUint(c.Query("col"), 10, 32)
+	if err != nil {
+		handleError(c, errors.ErrInvalidID)
+		return
+	}
+
+	includeTest, _ := strconv.ParseBool(c.Query("include_test"))
+
+	resp, err := h.responseSvc.GetCrosstab(c.Request.Context(), userID.(uint), uint(surveyID), uint(rowID), uint(colID), includeTest)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    resp,
+	})
+}
+
+// GetAssessmentStatistics handles GET /api/v1/surveys/:id/statistics/assessment
+func (h *ResponseHandler) GetAssessmentStatistics(c *gin.Context) {
+	// Get user ID from context (set by auth middleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	// Get survey ID from URL parameter
+	surveyID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		handleError(c, errors.ErrInvalidID)
+		return
+	}
+
+	includeTest, _ := strconv.ParseBool(c.Query("include_test"))
+
+	resp, err := h.responseSvc.GetAssessmentStatistics(c.Request.Context(), userID.(uint), uint(surveyID), includeTest)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    resp,
+	})
+}
+
+// CompareStatistics handles GET /api/v1/surveys/:id/statistics/compare
+func (h *ResponseHandler) CompareStatistics(c *gin.Context) {
+	// Get user ID from context (set by auth middleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	// Get survey ID from URL parameter
+	surveyID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		handleError(c, errors.ErrInvalidID)
+		return
+	}
+
+	var req request.CompareStatisticsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	resp, err := h.responseSvc.CompareStatistics(c.Request.Context(), userID.(uint), uint(surveyID), &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    resp,
+	})
+}
+
+// ImportResponses handles POST /api/v1/surveys/:id/responses/import
+func (h *ResponseHandler) ImportResponses(c *gin.Context) {
+	// Get user ID from context (set by auth middleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	// Get survey ID from URL parameter
+	surveyID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			c.JSON(appErr.Status, gin.H{
-				"success": false,
-				"error": gin.H{
-					"code":    appErr.Code,
-					"message": appErr.Message,
-				},
-			})
+		handleError(c, errors.ErrInvalidID)
+		return
+	}
+
+	var req request.ImportResponsesRequest
+	if err := c.ShouldBind(&req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if stderrors.As(err, &maxBytesErr) {
+			handleError(c, err)
 			return
 		}
+		handleError(c, errors.NewLocalizedError("INVALID_IMPORT_FILE", 400, "import.file_required"))
+		return
+	}
 
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"error": gin.H{
-				"code":    "INTERNAL_ERROR",
-				"message": "服务器内部错误",
-			},
-		})
+	reader, err := file.Open()
+	if err != nil {
+		handleError(c, errors.WrapError(err, "failed to open uploaded file"))
+		return
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		handleError(c, errors.WrapError(err, "failed to read uploaded file"))
+		return
+	}
+
+	lang := i18n.FromAcceptLanguage(c.GetHeader("Accept-Language"))
+	result, err := h.responseSvc.ImportResponses(c.Request.Context(), userID.(uint), uint(surveyID), file.Filename, data, &req, lang)
+	if err != nil {
+		handleError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"data":    resp,
+		"data":    result,
+	})
+}
+
+// SimulateResponse handles POST /api/v1/surveys/:id/simulate
+func (h *ResponseHandler) SimulateResponse(c *gin.Context) {
+	// Get user ID from context (set by auth middleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	// Get survey ID from URL parameter
+	surveyID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		handleError(c, errors.ErrInvalidID)
+		return
+	}
+
+	var req request.SimulateResponseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	lang := i18n.FromAcceptLanguage(c.GetHeader("Accept-Language"))
+	result, err := h.responseSvc.SimulateResponse(c.Request.Context(), userID.(uint), uint(surveyID), &req, lang)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
 	})
 }
 
@@ -200,63 +386,32 @@ func (h *ResponseHandler) ExportResponses(c *gin.Context) {
 	// Get user ID from context (set by auth middleware)
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"success": false,
-			"error": gin.H{
-				"code":    "UNAUTHORIZED",
-				"message": "未授权访问",
-			},
-		})
+		handleError(c, errors.ErrUnauthorized)
 		return
 	}
 
 	// Get survey ID from URL parameter
 	surveyID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"error": gin.H{
-				"code":    "INVALID_ID",
-				"message": "无效的问卷 ID",
-			},
-		})
+		handleError(c, errors.ErrInvalidID)
 		return
 	}
 
-	// Get format parameter (default to csv)
-	format := c.DefaultQuery("format", "csv")
-	if format != "csv" && format != "excel" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"error": gin.H{
-				"code":    "INVALID_FORMAT",
-				"message": "不支持的导出格式，请使用 csv 或 excel",
-			},
-		})
+	// Parse export options (format defaults to csv)
+	var req request.ExportResponsesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		handleError(c, err)
 		return
 	}
+	if req.Format == "" {
+		req.Format = "csv"
+	}
+	format := req.Format
 
 	// Export responses
-	data, filename, err := h.responseSvc.ExportResponses(userID.(uint), uint(surveyID), format)
+	data, filename, err := h.responseSvc.ExportResponses(c.Request.Context(), userID.(uint), uint(surveyID), &req)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			c.JSON(appErr.Status, gin.H{
-				"success": false,
-				"error": gin.H{
-					"code":    appErr.Code,
-					"message": appErr.Message,
-				},
-			})
-			return
-		}
-
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"error": gin.H{
-				"code":    "INTERNAL_ERROR",
-				"message": "服务器内部错误",
-			},
-		})
+		handleError(c, err)
 		return
 	}
 
@@ -274,3 +429,76 @@ func (h *ResponseHandler) ExportResponses(c *gin.Context) {
 
 	c.Data(http.StatusOK, contentType, data)
 }
+
+// parseResponsePath reads the survey and response IDs from the URL
+func parseResponsePath(c *gin.Context) (uint, uint, error) {
+	surveyID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return 0, 0, errors.ErrInvalidID
+	}
+	responseID, err := strconv.ParseUint(c.Param("responseId"), 10, 32)
+	if err != nil {
+		return 0, 0, errors.ErrInvalidID
+	}
+	return uint(surveyID), uint(responseID), nil
+}
+
+// ListResponseVersions handles GET /api/v1/surveys/:id/responses/:responseId/versions
+func (h *ResponseHandler) ListResponseVersions(c *gin.Context) {
+	// Get user ID from context (set by auth middleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	surveyID, responseID, err := parseResponsePath(c)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	versions, err := h.responseSvc.ListResponseVersions(c.Request.Context(), userID.(uint), surveyID, responseID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    versions,
+	})
+}
+
+// DiffResponseVersions handles GET /api/v1/surveys/:id/responses/:responseId/versions/diff
+func (h *ResponseHandler) DiffResponseVersions(c *gin.Context) {
+	// Get user ID from context (set by auth middleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	surveyID, responseID, err := parseResponsePath(c)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	var req request.ResponseDiffRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	diff, err := h.responseSvc.DiffResponseVersions(c.Request.Context(), userID.(uint), surveyID, responseID, &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    diff,
+	})
+}
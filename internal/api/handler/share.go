@@ -1,8 +1,12 @@
 package handler
 
 import (
+	"bytes"
+	"html/template"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"survey-system/internal/dto/request"
@@ -23,27 +27,31 @@ func NewShareHandler(shareService service.ShareService) *ShareHandler {
 }
 
 // GenerateShareLink handles POST /api/v1/surveys/:id/share
+// It accepts either the owner's JWT or a delegation token for the survey
 func (h *ShareHandler) GenerateShareLink(c *gin.Context) {
 	surveyID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"error": gin.H{
-				"code":    "INVALID_ID",
-				"message": "Invalid survey ID",
-			},
-		})
+		handleError(c, errors.ErrInvalidID)
 		return
 	}
 
 	var req request.GenerateShareLinkRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"error": gin.H{
-				"code":    "VALIDATION_ERROR",
-				"message": err.Error(),
-			},
+		handleError(c, err)
+		return
+	}
+
+	// Field coordinators authenticate with a scoped delegation token instead of a JWT
+	if delegationToken := c.GetString("delegation_token"); delegationToken != "" {
+		shareLink, err := h.shareService.GenerateDelegatedShareLink(c.Request.Context(), delegationToken, uint(surveyID), &req)
+		if err != nil {
+			handleError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{
+			"success": true,
+			"data":    shareLink,
 		})
 		return
 	}
@@ -51,13 +59,7 @@ func (h *ShareHandler) GenerateShareLink(c *gin.Context) {
 	// Get user ID from context (set by auth middleware)
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"success": false,
-			"error": gin.H{
-				"code":    errors.ErrUnauthorized.Code,
-				"message": errors.ErrUnauthorized.Message,
-			},
-		})
+		handleError(c, errors.ErrUnauthorized)
 		return
 	}
 
@@ -73,28 +75,192 @@ func (h *ShareHandler) GenerateShareLink(c *gin.Context) {
 	})
 }
 
+// GeneratePreviewLink handles POST /api/v1/surveys/:id/preview
+func (h *ShareHandler) GeneratePreviewLink(c *gin.Context) {
+	surveyID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		handleError(c, errors.ErrInvalidID)
+		return
+	}
+
+	// The body is optional; without it the preview has no prefilled values
+	var req request.GeneratePreviewLinkRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			handleError(c, err)
+			return
+		}
+	}
+
+	// Get user ID from context (set by auth middleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	previewLink, err := h.shareService.GeneratePreviewLink(c.Request.Context(), userID.(uint), uint(surveyID), &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    previewLink,
+	})
+}
+
 // GetSurveyByToken handles GET /api/v1/public/surveys/:id (with token query parameter)
 func (h *ShareHandler) GetSurveyByToken(c *gin.Context) {
 	token := c.Query("token")
 	if token == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"error": gin.H{
-				"code":    "MISSING_TOKEN",
-				"message": "Token parameter is required",
-			},
-		})
+		handleError(c, errors.ErrMissingToken)
 		return
 	}
 
-	survey, err := h.shareService.ValidateAndGetSurvey(c.Request.Context(), token)
+	survey, err := h.shareService.ValidateAndGetSurvey(c.Request.Context(), token, c.ClientIP())
 	if err != nil {
 		handleError(c, err)
 		return
 	}
 
+	// Clients and CDNs may store the payload but must revalidate it, since the link
+	// can be used up or the survey closed at any time
+	c.Header("ETag", survey.ETag)
+	c.Header("Last-Modified", survey.LastModified.UTC().Format(http.TimeFormat))
+	c.Header("Cache-Control", "no-cache")
+	if notModified(c, survey.ETag, survey.LastModified) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
 		"data":    survey,
 	})
 }
+
+// ValidateLink handles GET /api/v1/public/links/validate (with token query parameter)
+// Reports whether the link is valid, expired or used without opening it
+func (h *ShareHandler) ValidateLink(c *gin.Context) {
+	token := c.Query("token")
+	if token == "" {
+		handleError(c, errors.ErrMissingToken)
+		return
+	}
+
+	status, err := h.shareService.CheckLinkStatus(c.Request.Context(), token)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	// The status changes as soon as the link is used
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    status,
+	})
+}
+
+// notModified evaluates If-None-Match, or If-Modified-Since when no ETag was sent,
+// against the current validators of a resource
+func notModified(c *gin.Context, etag string, lastModified time.Time) bool {
+	if match := c.GetHeader("If-None-Match"); match != "" {
+		for _, candidate := range strings.Split(match, ",") {
+			candidate = strings.TrimSpace(candidate)
+			if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+				return true
+			}
+		}
+		return false
+	}
+
+	if since, err := http.ParseTime(c.GetHeader("If-Modified-Since")); err == nil {
+		return !lastModified.Truncate(time.Second).After(since)
+	}
+	return false
+}
+
+// embedTemplate renders a minimal HTML page that frames the survey frontend
+var embedTemplate = template.Must(template.New("embed").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>html,body,iframe{margin:0;padding:0;width:100%;height:100%;border:0}</style>
+</head>
+<body>
+<iframe src="{{.URL}}" title="{{.Title}}" allow="clipboard-write"></iframe>
+</body>
+</html>
+`))
+
+// GetSurveyMeta handles GET /api/v1/public/surveys/:id/meta
+func (h *ShareHandler) GetSurveyMeta(c *gin.Context) {
+	surveyID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		handleError(c, errors.ErrInvalidID)
+		return
+	}
+
+	meta, err := h.shareService.GetSurveyMeta(c.Request.Context(), uint(surveyID))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	// Publishing or archiving the survey changes the result
+	c.Header("Cache-Control", "no-cache")
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    meta,
+	})
+}
+
+// GetEmbed handles GET /api/v1/public/surveys/:id/embed
+// Returns JSON by default or an iframe-ready HTML page when format=html
+func (h *ShareHandler) GetEmbed(c *gin.Context) {
+	surveyID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		handleError(c, errors.ErrInvalidID)
+		return
+	}
+
+	embed, err := h.shareService.GetEmbedInfo(c.Request.Context(), uint(surveyID), c.Query("token"))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	// Relax framing and CORS according to the survey's embedding allowlist
+	// Without an allowlist no other site may frame the survey
+	frameAncestors := "'self'"
+	if len(embed.AllowedDomains) > 0 {
+		frameAncestors += " " + strings.Join(embed.AllowedDomains, " ")
+	}
+	c.Header("Content-Security-Policy", "frame-ancestors "+frameAncestors)
+
+	origin := c.GetHeader("Origin")
+	if origin != "" && service.EmbedOriginAllowed(origin, embed.AllowedDomains) {
+		c.Header("Access-Control-Allow-Origin", origin)
+		c.Header("Vary", "Origin")
+	}
+
+	if c.Query("format") == "html" {
+		var buf bytes.Buffer
+		if err := embedTemplate.Execute(&buf, embed); err != nil {
+			handleError(c, err)
+			return
+		}
+		c.Data(http.StatusOK, "text/html; charset=utf-8", buf.Bytes())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    embed,
+	})
+}
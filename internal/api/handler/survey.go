@@ -26,26 +26,14 @@ func NewSurveyHandler(surveyService service.SurveyService) *SurveyHandler {
 func (h *SurveyHandler) CreateSurvey(c *gin.Context) {
 	var req request.CreateSurveyRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"error": gin.H{
-				"code":    "VALIDATION_ERROR",
-				"message": err.Error(),
-			},
-		})
+		handleError(c, err)
 		return
 	}
 
 	// Get user ID from context (set by auth middleware)
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"success": false,
-			"error": gin.H{
-				"code":    errors.ErrUnauthorized.Code,
-				"message": errors.ErrUnauthorized.Message,
-			},
-		})
+		handleError(c, errors.ErrUnauthorized)
 		return
 	}
 
@@ -65,37 +53,19 @@ func (h *SurveyHandler) CreateSurvey(c *gin.Context) {
 func (h *SurveyHandler) UpdateSurvey(c *gin.Context) {
 	surveyID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"error": gin.H{
-				"code":    "INVALID_ID",
-				"message": "Invalid survey ID",
-			},
-		})
+		handleError(c, errors.ErrInvalidID)
 		return
 	}
 
 	var req request.UpdateSurveyRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"error": gin.H{
-				"code":    "VALIDATION_ERROR",
-				"message": err.Error(),
-			},
-		})
+		handleError(c, err)
 		return
 	}
 
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"success": false,
-			"error": gin.H{
-				"code":    errors.ErrUnauthorized.Code,
-				"message": errors.ErrUnauthorized.Message,
-			},
-		})
+		handleError(c, errors.ErrUnauthorized)
 		return
 	}
 
@@ -115,25 +85,13 @@ func (h *SurveyHandler) UpdateSurvey(c *gin.Context) {
 func (h *SurveyHandler) DeleteSurvey(c *gin.Context) {
 	surveyID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"error": gin.H{
-				"code":    "INVALID_ID",
-				"message": "Invalid survey ID",
-			},
-		})
+		handleError(c, errors.ErrInvalidID)
 		return
 	}
 
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"success": false,
-			"error": gin.H{
-				"code":    errors.ErrUnauthorized.Code,
-				"message": errors.ErrUnauthorized.Message,
-			},
-		})
+		handleError(c, errors.ErrUnauthorized)
 		return
 	}
 
@@ -152,17 +110,17 @@ func (h *SurveyHandler) DeleteSurvey(c *gin.Context) {
 func (h *SurveyHandler) GetSurvey(c *gin.Context) {
 	surveyID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"error": gin.H{
-				"code":    "INVALID_ID",
-				"message": "Invalid survey ID",
-			},
-		})
+		handleError(c, errors.ErrInvalidID)
 		return
 	}
 
-	survey, err := h.surveyService.GetSurvey(c.Request.Context(), uint(surveyID))
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	survey, err := h.surveyService.GetSurvey(c.Request.Context(), userID.(uint), uint(surveyID))
 	if err != nil {
 		handleError(c, err)
 		return
@@ -178,13 +136,7 @@ func (h *SurveyHandler) GetSurvey(c *gin.Context) {
 func (h *SurveyHandler) ListSurveys(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"success": false,
-			"error": gin.H{
-				"code":    errors.ErrUnauthorized.Code,
-				"message": errors.ErrUnauthorized.Message,
-			},
-		})
+		handleError(c, errors.ErrUnauthorized)
 		return
 	}
 
@@ -192,7 +144,7 @@ func (h *SurveyHandler) ListSurveys(c *gin.Context) {
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
 
-	surveys, err := h.surveyService.ListSurveys(c.Request.Context(), userID.(uint), page, pageSize)
+	surveys, err := h.surveyService.ListSurveys(c.Request.Context(), userID.(uint), c.Query("status"), page, pageSize)
 	if err != nil {
 		handleError(c, err)
 		return
@@ -209,25 +161,13 @@ func (h *SurveyHandler) ListSurveys(c *gin.Context) {
 func (h *SurveyHandler) PublishSurvey(c *gin.Context) {
 	surveyID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"error": gin.H{
-				"code":    "INVALID_ID",
-				"message": "Invalid survey ID",
-			},
-		})
+		handleError(c, errors.ErrInvalidID)
 		return
 	}
 
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"success": false,
-			"error": gin.H{
-				"code":    errors.ErrUnauthorized.Code,
-				"message": errors.ErrUnauthorized.Message,
-			},
-		})
+		handleError(c, errors.ErrUnauthorized)
 		return
 	}
 
@@ -242,25 +182,58 @@ func (h *SurveyHandler) PublishSurvey(c *gin.Context) {
 	})
 }
 
-// handleError handles errors and returns appropriate HTTP responses
-func handleError(c *gin.Context, err error) {
-	if appErr, ok := err.(*errors.AppError); ok {
-		c.JSON(appErr.Status, gin.H{
-			"success": false,
-			"error": gin.H{
-				"code":    appErr.Code,
-				"message": appErr.Message,
-			},
-		})
+// ArchiveSurvey handles POST /api/v1/surveys/:id/archive
+func (h *SurveyHandler) ArchiveSurvey(c *gin.Context) {
+	surveyID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		handleError(c, errors.ErrInvalidID)
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
 		return
 	}
 
-	// Default to internal server error
-	c.JSON(http.StatusInternalServerError, gin.H{
-		"success": false,
-		"error": gin.H{
-			"code":    errors.ErrInternalServer.Code,
-			"message": err.Error(),
-		},
+	if err := h.surveyService.ArchiveSurvey(c.Request.Context(), userID.(uint), uint(surveyID)); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Survey archived successfully",
 	})
 }
+
+// UnarchiveSurvey handles POST /api/v1/surveys/:id/unarchive
+func (h *SurveyHandler) UnarchiveSurvey(c *gin.Context) {
+	surveyID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		handleError(c, errors.ErrInvalidID)
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	if err := h.surveyService.UnarchiveSurvey(c.Request.Context(), userID.(uint), uint(surveyID)); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Survey unarchived successfully",
+	})
+}
+
+// handleError attaches the error to the context so the error middleware renders the standard envelope
+func handleError(c *gin.Context, err error) {
+	_ = c.Error(err)
+	c.Abort()
+}
@@ -0,0 +1,283 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"survey-system/internal/dto/request"
+	"survey-system/internal/service"
+	"survey-system/pkg/errors"
+)
+
+// TeamHandler handles team and team membership HTTP requests
+type TeamHandler struct {
+	teamService service.TeamService
+}
+
+// NewTeamHandler creates a new team handler instance
+func NewTeamHandler(teamService service.TeamService) *TeamHandler {
+	return &TeamHandler{
+		teamService: teamService,
+	}
+}
+
+// parseTeamPath reads the team and (when present) member user IDs from the URL
+func parseTeamPath(c *gin.Context) (teamID, memberID uint, err error) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return 0, 0, errors.ErrInvalidID
+	}
+
+	if raw := c.Param("userId"); raw != "" {
+		uid, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			return 0, 0, errors.ErrInvalidID
+		}
+		memberID = uint(uid)
+	}
+	return uint(id), memberID, nil
+}
+
+// ListTeams handles GET /api/v1/teams
+func (h *TeamHandler) ListTeams(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	teams, err := h.teamService.ListTeams(c.Request.Context(), userID.(uint))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    teams,
+	})
+}
+
+// CreateTeam handles POST /api/v1/teams
+func (h *TeamHandler) CreateTeam(c *gin.Context) {
+	var req request.TeamRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	team, err := h.teamService.CreateTeam(c.Request.Context(), userID.(uint), &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    team,
+	})
+}
+
+// GetTeam handles GET /api/v1/teams/:id
+func (h *TeamHandler) GetTeam(c *gin.Context) {
+	teamID, _, err := parseTeamPath(c)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	team, err := h.teamService.GetTeam(c.Request.Context(), userID.(uint), teamID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    team,
+	})
+}
+
+// UpdateTeam handles PUT /api/v1/teams/:id
+func (h *TeamHandler) UpdateTeam(c *gin.Context) {
+	teamID, _, err := parseTeamPath(c)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	var req request.TeamRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	team, err := h.teamService.UpdateTeam(c.Request.Context(), userID.(uint), teamID, &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    team,
+	})
+}
+
+// DeleteTeam handles DELETE /api/v1/teams/:id
+func (h *TeamHandler) DeleteTeam(c *gin.Context) {
+	teamID, _, err := parseTeamPath(c)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	if err := h.teamService.DeleteTeam(c.Request.Context(), userID.(uint), teamID); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Team deleted successfully",
+	})
+}
+
+// AddMember handles POST /api/v1/teams/:id/members
+func (h *TeamHandler) AddMember(c *gin.Context) {
+	teamID, _, err := parseTeamPath(c)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	var req request.AddTeamMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	member, err := h.teamService.AddMember(c.Request.Context(), userID.(uint), teamID, &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    member,
+	})
+}
+
+// RemoveMember handles DELETE /api/v1/teams/:id/members/:userId
+func (h *TeamHandler) RemoveMember(c *gin.Context) {
+	teamID, memberID, err := parseTeamPath(c)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	if err := h.teamService.RemoveMember(c.Request.Context(), userID.(uint), teamID, memberID); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Team member removed successfully",
+	})
+}
+
+// ListTeamSurveys handles GET /api/v1/teams/:id/surveys
+func (h *TeamHandler) ListTeamSurveys(c *gin.Context) {
+	teamID, _, err := parseTeamPath(c)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	surveys, err := h.teamService.ListTeamSurveys(c.Request.Context(), userID.(uint), teamID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    surveys,
+	})
+}
+
+// SetSurveyTeam handles PUT /api/v1/surveys/:id/team
+func (h *TeamHandler) SetSurveyTeam(c *gin.Context) {
+	surveyID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		handleError(c, errors.ErrInvalidID)
+		return
+	}
+
+	var req request.SetSurveyTeamRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	if err := h.teamService.SetSurveyTeam(c.Request.Context(), userID.(uint), uint(surveyID), &req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Survey team updated successfully",
+	})
+}
@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"survey-system/internal/service"
+	"survey-system/pkg/errors"
+)
+
+// UsageHandler handles per-user quota HTTP requests
+type UsageHandler struct {
+	usageService service.UsageService
+}
+
+// NewUsageHandler creates a new usage handler instance
+func NewUsageHandler(usageService service.UsageService) *UsageHandler {
+	return &UsageHandler{
+		usageService: usageService,
+	}
+}
+
+// GetUsage handles GET /api/v1/usage
+func (h *UsageHandler) GetUsage(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		handleError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	usage, err := h.usageService.GetUsage(c.Request.Context(), userID.(uint))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    usage,
+	})
+}
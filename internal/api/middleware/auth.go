@@ -1,41 +1,45 @@
 package middleware
 
 import (
+	"context"
 	"net/http"
 	"strings"
+	"survey-system/internal/model"
+	"survey-system/pkg/errors"
 	"survey-system/pkg/utils"
 
 	"github.com/gin-gonic/gin"
 )
 
+// UserStatusFunc reports why the user a valid token belongs to may not make requests,
+// e.g. because the account has been deactivated; it returns nil for active users
+type UserStatusFunc func(ctx context.Context, userID uint) error
+
+// SessionStatusFunc reports why the login session a valid token was issued for may not
+// make requests, e.g. because the user revoked it; it returns nil for active sessions
+type SessionStatusFunc func(ctx context.Context, userID uint, tokenID string) error
+
+// ImpersonationAuditFunc records a request made with an impersonation token in the audit log
+type ImpersonationAuditFunc func(ctx context.Context, entry *model.AuditLog)
+
 // AuthMiddleware creates a middleware for JWT authentication
-func AuthMiddleware(jwtUtil *utils.JWTUtil) gin.HandlerFunc {
+// The account status is checked on every request, so deactivating a user takes
+// effect immediately rather than when their token expires; so is the token's login
+// session, so a revoked session is logged out immediately. Requests other than
+// reads made with an impersonation token are passed to audit once handled.
+func AuthMiddleware(jwtUtil *utils.JWTUtil, userStatus UserStatusFunc, sessionStatus SessionStatusFunc, audit ImpersonationAuditFunc) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Extract token from Authorization header
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"success": false,
-				"error": gin.H{
-					"code":    "UNAUTHORIZED",
-					"message": "未授权访问：缺少认证令牌",
-				},
-			})
-			c.Abort()
+			RenderError(c, errors.ErrMissingAuthToken)
 			return
 		}
 
 		// Check if the header starts with "Bearer "
 		parts := strings.SplitN(authHeader, " ", 2)
 		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"success": false,
-				"error": gin.H{
-					"code":    "UNAUTHORIZED",
-					"message": "未授权访问：令牌格式错误",
-				},
-			})
-			c.Abort()
+			RenderError(c, errors.ErrMalformedAuthToken)
 			return
 		}
 
@@ -44,21 +48,95 @@ func AuthMiddleware(jwtUtil *utils.JWTUtil) gin.HandlerFunc {
 		// Validate token
 		claims, err := jwtUtil.ValidateToken(tokenString)
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"success": false,
-				"error": gin.H{
-					"code":    "UNAUTHORIZED",
-					"message": "未授权访问：令牌无效或已过期",
-				},
-			})
-			c.Abort()
+			RenderError(c, errors.ErrInvalidAuthToken)
 			return
 		}
 
+		if err := userStatus(c.Request.Context(), claims.UserID); err != nil {
+			RenderError(c, err)
+			return
+		}
+		if err := sessionStatus(c.Request.Context(), claims.UserID, claims.ID); err != nil {
+			RenderError(c, err)
+			return
+		}
+
+		// An impersonation session ends as soon as the admin is deactivated
+		if claims.ImpersonatorID != 0 {
+			if err := userStatus(c.Request.Context(), claims.ImpersonatorID); err != nil {
+				RenderError(c, errors.ErrInvalidAuthToken)
+				return
+			}
+			c.Set("impersonator_id", claims.ImpersonatorID)
+		}
+
 		// Store user information in context
 		c.Set("user_id", claims.UserID)
 		c.Set("user_role", claims.Role)
+		if claims.ID != "" {
+			c.Set("token_id", claims.ID)
+		}
+
+		c.Next()
 
+		if claims.ImpersonatorID != 0 && !readOnlyMethod(c.Request.Method) {
+			userID := claims.UserID
+			impersonationID := claims.ImpersonationID
+			audit(context.WithoutCancel(c.Request.Context()), &model.AuditLog{
+				ActorID:         claims.ImpersonatorID,
+				Action:          model.AuditImpersonatedRequest,
+				TargetUserID:    &userID,
+				ImpersonationID: &impersonationID,
+				Method:          c.Request.Method,
+				Path:            c.Request.URL.Path,
+				Status:          c.Writer.Status(),
+				IPAddress:       c.ClientIP(),
+			})
+		}
+	}
+}
+
+// readOnlyMethod reports whether requests with the HTTP method do not change anything
+func readOnlyMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+// RejectImpersonation refuses requests made with an impersonation token, for routes
+// an admin must not use while acting as another user
+// It must run after AuthMiddleware
+func RejectImpersonation() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := GetImpersonatorID(c); ok {
+			RenderError(c, errors.ErrImpersonationDenied)
+			return
+		}
+		c.Next()
+	}
+}
+
+// DelegationOrAuth lets a route accept a delegation token in place of a JWT
+// The raw delegation token is stored in the context as "delegation_token" for the
+// handler to verify; requests without one are authenticated by auth
+func DelegationOrAuth(auth gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		parts := strings.SplitN(c.GetHeader("Authorization"), " ", 2)
+		if len(parts) == 2 && parts[0] == "Bearer" && strings.HasPrefix(parts[1], model.DelegationTokenPrefix) {
+			c.Set("delegation_token", parts[1])
+			c.Next()
+			return
+		}
+		auth(c)
+	}
+}
+
+// RequireRole rejects authenticated users without the given role
+// It must run after AuthMiddleware
+func RequireRole(role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if userRole, ok := GetUserRole(c); !ok || userRole != role {
+			RenderError(c, errors.ErrForbidden)
+			return
+		}
 		c.Next()
 	}
 }
@@ -73,6 +151,28 @@ func GetUserID(c *gin.Context) (uint, bool) {
 	return id, ok
 }
 
+// GetImpersonatorID retrieves the admin acting as the user from the Gin context
+// It is only set for requests made with an impersonation token
+func GetImpersonatorID(c *gin.Context) (uint, bool) {
+	impersonatorID, exists := c.Get("impersonator_id")
+	if !exists {
+		return 0, false
+	}
+	id, ok := impersonatorID.(uint)
+	return id, ok
+}
+
+// GetTokenID retrieves the ID of the login session's token from the Gin context
+// It is not set for impersonation tokens, which have no session
+func GetTokenID(c *gin.Context) (string, bool) {
+	tokenID, exists := c.Get("token_id")
+	if !exists {
+		return "", false
+	}
+	id, ok := tokenID.(string)
+	return id, ok
+}
+
 // GetUserRole retrieves the user role from the Gin context
 func GetUserRole(c *gin.Context) (string, bool) {
 	role, exists := c.Get("user_role")
@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"net/http"
+
+	"survey-system/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BodySizeLimit returns a middleware that rejects request bodies larger than maxBytes.
+// Requests announcing an oversized Content-Length are rejected up front; bodies sent
+// without a length are cut off while being read, which surfaces as *http.MaxBytesError
+// and is rendered as PAYLOAD_TOO_LARGE by RenderError.
+// Routes listed in overrides, keyed by their full route path, use their own limit instead.
+func BodySizeLimit(defaultMaxBytes int64, overrides map[string]int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		maxBytes := defaultMaxBytes
+		if override, ok := overrides[c.FullPath()]; ok {
+			maxBytes = override
+		}
+		if maxBytes <= 0 || c.Request.Body == nil {
+			c.Next()
+			return
+		}
+
+		if c.Request.ContentLength > maxBytes {
+			RenderError(c, errors.ErrPayloadTooLarge)
+			return
+		}
+
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBytes)
+		c.Next()
+	}
+}
@@ -0,0 +1,211 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"survey-system/internal/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+// precompressedTypes are never compressed again, whatever the configuration says.
+// xlsx files are zip archives already.
+var precompressedTypes = map[string]bool{
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet": true,
+	"application/zip":  true,
+	"application/gzip": true,
+}
+
+// Compression returns a middleware that compresses responses with gzip or deflate
+// when the client accepts it. Only responses whose content type is listed in cfg and
+// whose body reaches cfg.MinSize are compressed; smaller bodies are sent as they are.
+func Compression(cfg *config.CompressionConfig) gin.HandlerFunc {
+	contentTypes := make(map[string]bool, len(cfg.ContentTypes))
+	for _, contentType := range cfg.ContentTypes {
+		contentTypes[strings.ToLower(contentType)] = true
+	}
+
+	return func(c *gin.Context) {
+		if !cfg.Enabled || c.Request.Method == http.MethodHead {
+			c.Next()
+			return
+		}
+
+		encoding := negotiateEncoding(c.GetHeader("Accept-Encoding"))
+		if encoding == "" {
+			c.Next()
+			return
+		}
+
+		w := &compressWriter{
+			ResponseWriter: c.Writer,
+			encoding:       encoding,
+			level:          cfg.Level,
+			minSize:        cfg.MinSize,
+			contentTypes:   contentTypes,
+		}
+		c.Writer = w
+		defer func() {
+			w.close()
+			c.Writer = w.ResponseWriter
+		}()
+
+		c.Next()
+	}
+}
+
+// negotiateEncoding picks gzip or deflate from an Accept-Encoding header, preferring gzip
+func negotiateEncoding(header string) string {
+	accepted := make(map[string]bool)
+	for _, part := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if value, err := strconv.ParseFloat(q, 64); err == nil && value == 0 {
+				continue
+			}
+		}
+		accepted[strings.ToLower(strings.TrimSpace(name))] = true
+	}
+
+	switch {
+	case accepted["gzip"]:
+		return "gzip"
+	case accepted["deflate"]:
+		return "deflate"
+	default:
+		return ""
+	}
+}
+
+// flushWriteCloser is implemented by both gzip.Writer and flate.Writer
+type flushWriteCloser interface {
+	io.WriteCloser
+	Flush() error
+}
+
+// compressWriter buffers the start of a response until it knows whether the body is
+// worth compressing, then either compresses or passes it through unchanged
+type compressWriter struct {
+	gin.ResponseWriter
+	encoding     string
+	level        int
+	minSize      int
+	contentTypes map[string]bool
+
+	buf     bytes.Buffer
+	decided bool
+	encoder flushWriteCloser // nil when the response passes through uncompressed
+}
+
+// Write buffers p until the compression decision is made
+func (w *compressWriter) Write(p []byte) (int, error) {
+	if !w.decided {
+		if !w.eligible() {
+			w.passThrough()
+		} else {
+			if w.buf.Len() == 0 {
+				// The body depends on Accept-Encoding once the type is compressible
+				w.Header().Add("Vary", "Accept-Encoding")
+			}
+			w.buf.Write(p)
+			if w.buf.Len() < w.minSize {
+				return len(p), nil
+			}
+			if err := w.startCompression(); err != nil {
+				return 0, err
+			}
+			return len(p), nil
+		}
+	}
+
+	if w.encoder != nil {
+		return w.encoder.Write(p)
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+// WriteString buffers s like Write
+func (w *compressWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Flush sends any buffered output to the client
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		w.passThrough()
+	}
+	if w.encoder != nil {
+		_ = w.encoder.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// eligible reports whether the response may be compressed based on its headers
+func (w *compressWriter) eligible() bool {
+	header := w.Header()
+	if header.Get("Content-Encoding") != "" {
+		return false
+	}
+	// Byte ranges refer to the uncompressed body, so ranged downloads stay uncompressed
+	if header.Get("Accept-Ranges") != "" || header.Get("Content-Range") != "" {
+		return false
+	}
+
+	mediaType, _, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		return false
+	}
+	mediaType = strings.ToLower(mediaType)
+	return w.contentTypes[mediaType] && !precompressedTypes[mediaType]
+}
+
+// startCompression switches the response to the negotiated encoding and writes the buffer
+func (w *compressWriter) startCompression() error {
+	w.decided = true
+
+	var encoder flushWriteCloser
+	var err error
+	if w.encoding == "gzip" {
+		encoder, err = gzip.NewWriterLevel(w.ResponseWriter, w.level)
+	} else {
+		encoder, err = flate.NewWriter(w.ResponseWriter, w.level)
+	}
+	if err != nil {
+		return err
+	}
+	w.encoder = encoder
+
+	header := w.Header()
+	header.Set("Content-Encoding", w.encoding)
+	header.Del("Content-Length")
+
+	_, err = w.encoder.Write(w.buf.Bytes())
+	w.buf.Reset()
+	return err
+}
+
+// passThrough sends the response uncompressed, including anything buffered so far
+func (w *compressWriter) passThrough() {
+	w.decided = true
+	if w.buf.Len() > 0 {
+		_, _ = w.ResponseWriter.Write(w.buf.Bytes())
+		w.buf.Reset()
+	}
+}
+
+// close finishes the response once the handler chain has returned
+func (w *compressWriter) close() {
+	if !w.decided {
+		w.passThrough()
+	}
+	if w.encoder != nil {
+		_ = w.encoder.Close()
+	}
+}
@@ -1,19 +1,33 @@
 package middleware
 
 import (
+	"strconv"
+	"strings"
+
 	"survey-system/internal/config"
 
 	"github.com/gin-gonic/gin"
 )
 
-// CORS returns a middleware that handles CORS
-func CORS(cfg *config.Config) gin.HandlerFunc {
+// CORS returns a middleware that handles CORS using the current settings of store
+// Requests under publicPrefix use the public policy and all others the top-level one.
+// It runs for every route rather than per group so preflight requests, which match
+// no route, are answered with the policy of the route they are asking about.
+func CORS(store *config.Store, publicPrefix string) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		cfg := store.Get()
+		policy := cfg.CORS.CORSPolicy
+		if strings.HasPrefix(c.Request.URL.Path, publicPrefix) {
+			policy = cfg.CORS.PublicPolicy()
+		}
 		origin := c.Request.Header.Get("Origin")
-		
+
+		// The allowed origin depends on the request's Origin, so shared caches must key on it
+		c.Writer.Header().Add("Vary", "Origin")
+
 		// Check if origin is allowed
 		allowed := false
-		for _, allowedOrigin := range cfg.CORS.AllowedOrigins {
+		for _, allowedOrigin := range policy.AllowedOrigins {
 			if allowedOrigin == "*" || allowedOrigin == origin {
 				allowed = true
 				break
@@ -23,14 +37,16 @@ func CORS(cfg *config.Config) gin.HandlerFunc {
 		if allowed {
 			if origin != "" {
 				c.Writer.Header().Set("Access-Control-Allow-Origin", origin)
-			} else if len(cfg.CORS.AllowedOrigins) > 0 {
-				c.Writer.Header().Set("Access-Control-Allow-Origin", cfg.CORS.AllowedOrigins[0])
+			} else if len(policy.AllowedOrigins) > 0 {
+				c.Writer.Header().Set("Access-Control-Allow-Origin", policy.AllowedOrigins[0])
 			}
-			
+
 			c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-			c.Writer.Header().Set("Access-Control-Allow-Methods", joinStrings(cfg.CORS.AllowedMethods, ", "))
-			c.Writer.Header().Set("Access-Control-Allow-Headers", joinStrings(cfg.CORS.AllowedHeaders, ", "))
-			c.Writer.Header().Set("Access-Control-Max-Age", "86400")
+			c.Writer.Header().Set("Access-Control-Allow-Methods", strings.Join(policy.AllowedMethods, ", "))
+			c.Writer.Header().Set("Access-Control-Allow-Headers", strings.Join(policy.AllowedHeaders, ", "))
+			if seconds := int(policy.MaxAge.Seconds()); seconds > 0 {
+				c.Writer.Header().Set("Access-Control-Max-Age", strconv.Itoa(seconds))
+			}
 		}
 
 		if c.Request.Method == "OPTIONS" {
@@ -41,14 +57,3 @@ func CORS(cfg *config.Config) gin.HandlerFunc {
 		c.Next()
 	}
 }
-
-func joinStrings(strs []string, sep string) string {
-	if len(strs) == 0 {
-		return ""
-	}
-	result := strs[0]
-	for i := 1; i < len(strs); i++ {
-		result += sep + strs[i]
-	}
-	return result
-}
@@ -0,0 +1,201 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"reflect"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+
+	"survey-system/pkg/errors"
+	"survey-system/pkg/i18n"
+)
+
+// FieldError describes a single invalid request field in the error envelope
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Param   string `json:"param,omitempty"`
+	Message string `json:"message"`
+}
+
+// ErrorHandler returns a middleware that renders errors attached via c.Error
+// into the standard error envelope once the handler chain has finished
+func ErrorHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		RenderError(c, c.Errors.Last().Err)
+	}
+}
+
+// Recovery returns a middleware that recovers from panics and renders an internal error envelope
+func Recovery() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			if r := recover(); r != nil {
+				log.Printf("panic recovered: %v\n%s", r, debug.Stack())
+				if !c.Writer.Written() {
+					RenderError(c, errors.ErrInternalServer)
+				}
+				c.Abort()
+			}
+		}()
+
+		c.Next()
+	}
+}
+
+// RenderError converts an error into the standard error envelope and aborts the request
+// Handles AppError, validator.ValidationErrors, malformed requests and unknown errors
+func RenderError(c *gin.Context, err error) {
+	lang := RequestLang(c)
+
+	var appErr *errors.AppError
+	if stderrors.As(err, &appErr) {
+		abortWithEnvelope(c, appErr.Status, appErr.Code, appErr.Localize(lang), nil)
+		return
+	}
+
+	var validationErrs validator.ValidationErrors
+	if stderrors.As(err, &validationErrs) {
+		details := make([]FieldError, len(validationErrs))
+		for i, fe := range validationErrs {
+			details[i] = FieldError{
+				Field:   fieldPath(fe),
+				Rule:    fe.Tag(),
+				Param:   fe.Param(),
+				Message: fieldMessage(lang, fe),
+			}
+		}
+		abortWithEnvelope(c, http.StatusBadRequest, errors.ErrValidationFailed.Code, errors.ErrValidationFailed.Localize(lang), details)
+		return
+	}
+
+	// Errors from lower layers that services passed through unchanged
+	if stderrors.Is(err, gorm.ErrRecordNotFound) {
+		abortWithEnvelope(c, errors.ErrNotFound.Status, errors.ErrNotFound.Code, errors.ErrNotFound.Localize(lang), nil)
+		return
+	}
+	if stderrors.Is(err, context.DeadlineExceeded) {
+		log.Printf("timeout on %s %s: %v", c.Request.Method, c.Request.URL.Path, err)
+		abortWithEnvelope(c, errors.ErrRequestTimeout.Status, errors.ErrRequestTimeout.Code, errors.ErrRequestTimeout.Localize(lang), nil)
+		return
+	}
+
+	var maxBytesErr *http.MaxBytesError
+	if stderrors.As(err, &maxBytesErr) {
+		abortWithEnvelope(c, errors.ErrPayloadTooLarge.Status, errors.ErrPayloadTooLarge.Code, errors.ErrPayloadTooLarge.Localize(lang), nil)
+		return
+	}
+
+	if isBindingError(err) {
+		detail := err.Error()
+		if stderrors.Is(err, io.EOF) {
+			detail = "request body is empty"
+		}
+		abortWithEnvelope(c, http.StatusBadRequest, errors.ErrBadRequest.Code, errors.ErrBadRequest.Localize(lang)+": "+detail, nil)
+		return
+	}
+
+	// Unknown errors are logged but never exposed to clients
+	log.Printf("unhandled error on %s %s: %v", c.Request.Method, c.Request.URL.Path, err)
+	abortWithEnvelope(c, http.StatusInternalServerError, errors.ErrInternalServer.Code, errors.ErrInternalServer.Localize(lang), nil)
+}
+
+// isBindingError reports whether err comes from decoding a malformed request: an empty or
+// truncated body, invalid JSON, or query and form values of the wrong type
+func isBindingError(err error) bool {
+	if stderrors.Is(err, io.EOF) || stderrors.Is(err, io.ErrUnexpectedEOF) ||
+		stderrors.Is(err, binding.ErrConvertMapStringSlice) || stderrors.Is(err, binding.ErrConvertToMapString) ||
+		stderrors.Is(err, binding.ErrMultiFileHeader) || stderrors.Is(err, binding.ErrMultiFileHeaderLenInvalid) {
+		return true
+	}
+
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	var numErr *strconv.NumError
+	var timeErr *time.ParseError
+	return stderrors.As(err, &syntaxErr) || stderrors.As(err, &typeErr) ||
+		stderrors.As(err, &numErr) || stderrors.As(err, &timeErr)
+}
+
+// RequestLang returns the response language negotiated from the Accept-Language header
+func RequestLang(c *gin.Context) string {
+	return i18n.FromAcceptLanguage(c.GetHeader("Accept-Language"))
+}
+
+// UseJSONFieldNames makes validation errors report JSON field names instead of Go struct field names
+func UseJSONFieldNames() {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		for _, tag := range []string{"json", "form"} {
+			name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+			if name == "-" {
+				return ""
+			}
+			if name != "" {
+				return name
+			}
+		}
+		return field.Name
+	})
+}
+
+// abortWithEnvelope writes the standard error envelope and aborts the request
+func abortWithEnvelope(c *gin.Context, status int, code, message string, details []FieldError) {
+	body := gin.H{
+		"code":    code,
+		"message": message,
+	}
+	if len(details) > 0 {
+		body["details"] = details
+	}
+
+	c.AbortWithStatusJSON(status, gin.H{
+		"success": false,
+		"error":   body,
+	})
+}
+
+// fieldPath returns the field path without the top-level request struct name
+func fieldPath(fe validator.FieldError) string {
+	namespace := fe.Namespace()
+	if _, rest, found := strings.Cut(namespace, "."); found {
+		return rest
+	}
+	return fe.Field()
+}
+
+// fieldMessage returns a localized message for a single field validation failure
+func fieldMessage(lang string, fe validator.FieldError) string {
+	key := "validation.rule." + fe.Tag()
+	message := i18n.T(lang, key)
+	switch {
+	case message == key:
+		message = i18n.T(lang, "validation.rule.default", fe.Tag())
+	case strings.Contains(message, "%s"):
+		message = i18n.T(lang, key, fe.Param())
+	}
+	return fmt.Sprintf("%s: %s", fieldPath(fe), message)
+}
@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FeatureRequireFunc fails when a feature flag is off for a user
+type FeatureRequireFunc func(ctx context.Context, flag string, userID uint) error
+
+// RequireFeature returns a middleware that rejects requests to a feature whose flag is
+// off for the authenticated user. Requests without a user are checked against the
+// flag's state for everyone.
+func RequireFeature(require FeatureRequireFunc, flag string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var userID uint
+		if value, exists := c.Get("user_id"); exists {
+			userID = value.(uint)
+		}
+
+		if err := require(c.Request.Context(), flag, userID); err != nil {
+			RenderError(c, err)
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"log"
+	"math"
+	"strconv"
+	"time"
+
+	"survey-system/internal/cache"
+	"survey-system/internal/config"
+	"survey-system/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+)
+
+// RateLimit returns a middleware that allows at most limit requests per window from
+// each client IP. Requests are counted in Redis per fixed window, so the limit holds
+// across server instances; requests over it get 429 with a Retry-After header.
+// When Redis cannot be reached requests are let through rather than rejected.
+// The rule is read on every request so it can be reloaded; a limit or window of 0
+// disables the middleware.
+func RateLimit(client *redis.Client, keys cache.Keys, name string, rule func() config.RateLimitRule) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		current := rule()
+		limit, window := current.Requests, current.Window
+		if limit <= 0 || window <= 0 {
+			c.Next()
+			return
+		}
+
+		now := time.Now()
+		slot := now.UnixNano() / int64(window)
+		key := keys.RateLimit(name, c.ClientIP(), slot)
+
+		ctx := c.Request.Context()
+		pipe := client.TxPipeline()
+		count := pipe.Incr(ctx, key)
+		pipe.Expire(ctx, key, window)
+		if _, err := pipe.Exec(ctx); err != nil {
+			log.Printf("rate limit check for %s failed: %v", name, err)
+			c.Next()
+			return
+		}
+
+		if count.Val() > int64(limit) {
+			retryAfter := time.Duration((slot+1)*int64(window) - now.UnixNano())
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			RenderError(c, errors.ErrTooManyRequests)
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	stderrors "errors"
+	"log"
+	"math"
+	"strconv"
+	"time"
+
+	"survey-system/internal/cache"
+	"survey-system/internal/config"
+	"survey-system/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+)
+
+// InvalidTokenGuard returns a middleware that temporarily blocks client IPs sending
+// too many invalid link tokens, so tokens cannot be enumerated. Requests whose handler
+// fails with INVALID_TOKEN are counted in Redis per IP and fixed window; once an IP
+// reaches attempts its requests get 429 with a Retry-After header for the block duration.
+// When Redis cannot be reached requests are let through rather than rejected.
+// The rule is read on every request so it can be reloaded; attempts, window or block
+// of 0 disables the middleware.
+func InvalidTokenGuard(client *redis.Client, keys cache.Keys, rule func() config.InvalidTokenRule) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		current := rule()
+		attempts, window, block := current.Attempts, current.Window, current.Block
+		if attempts <= 0 || window <= 0 || block <= 0 {
+			c.Next()
+			return
+		}
+
+		ctx := c.Request.Context()
+		blockKey := keys.TokenBlock(c.ClientIP())
+
+		ttl, err := client.TTL(ctx, blockKey).Result()
+		if err != nil {
+			log.Printf("invalid token guard check failed: %v", err)
+		} else if ttl > 0 {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(ttl.Seconds()))))
+			RenderError(c, errors.ErrTooManyRequests)
+			return
+		}
+
+		c.Next()
+
+		if !hasInvalidToken(c) {
+			return
+		}
+
+		slot := time.Now().UnixNano() / int64(window)
+		countKey := keys.InvalidTokens(c.ClientIP(), slot)
+		pipe := client.TxPipeline()
+		count := pipe.Incr(ctx, countKey)
+		pipe.Expire(ctx, countKey, window)
+		if _, err := pipe.Exec(ctx); err != nil {
+			log.Printf("failed to count invalid token: %v", err)
+			return
+		}
+
+		if count.Val() >= int64(attempts) {
+			if err := client.Set(ctx, blockKey, "1", block).Err(); err != nil {
+				log.Printf("failed to block client %s: %v", c.ClientIP(), err)
+			}
+		}
+	}
+}
+
+// hasInvalidToken reports whether the handler rejected the request's link token
+func hasInvalidToken(c *gin.Context) bool {
+	for _, ginErr := range c.Errors {
+		if stderrors.Is(ginErr.Err, errors.ErrInvalidToken) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,282 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/url"
+	"strings"
+	"time"
+
+	"survey-system/internal/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+// redacted replaces logged values that must not appear in logs
+const redacted = "[REDACTED]"
+
+// secretFieldNames are substrings of JSON fields and query parameters whose values are never logged
+var secretFieldNames = []string{"password", "token", "secret", "authorization", "api_key"}
+
+// SensitiveQuestionsFunc returns which of the given questions are marked sensitive
+type SensitiveQuestionsFunc func(ctx context.Context, questionIDs []uint) ([]uint, error)
+
+// TrafficLog returns a middleware that logs the request and response bodies of the
+// routes listed in the traffic_log settings of store, for troubleshooting. The route
+// list is read on every request, so logging can be switched on and off at runtime.
+// Only JSON bodies within the size limit are logged. Passwords and tokens are redacted,
+// as is everything next to the question_id of a question marked sensitive; when the
+// sensitive questions cannot be looked up all answers are redacted.
+func TrafficLog(store *config.Store, sensitive SensitiveQuestionsFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		settings := store.Get().TrafficLog
+		if !logsRoute(settings.Routes, c.Request.Method, c.FullPath()) {
+			c.Next()
+			return
+		}
+
+		start := time.Now()
+		var requestBody []byte
+		requestTruncated := false
+		if c.Request.Body != nil {
+			requestBody, _ = io.ReadAll(io.LimitReader(c.Request.Body, int64(settings.MaxBodySize)+1))
+			requestTruncated = len(requestBody) > settings.MaxBodySize
+			c.Request.Body = struct {
+				io.Reader
+				io.Closer
+			}{io.MultiReader(bytes.NewReader(requestBody), c.Request.Body), c.Request.Body}
+		}
+
+		recorder := &bodyRecorder{ResponseWriter: c.Writer, limit: settings.MaxBodySize}
+		c.Writer = recorder
+		c.Next()
+
+		request := decodeLoggedBody(requestBody, requestTruncated, c.ContentType())
+		response := decodeLoggedBody(recorder.body.Bytes(), recorder.truncated, recorder.Header().Get("Content-Type"))
+
+		ids := make(map[uint]bool)
+		collectQuestionIDs(request.value, ids)
+		collectQuestionIDs(response.value, ids)
+		sensitiveIDs, lookupFailed := lookupSensitive(context.WithoutCancel(c.Request.Context()), sensitive, ids)
+
+		log.Printf("traffic: %s %s -> %d in %v request=%s response=%s",
+			c.Request.Method,
+			redactURL(c.Request.URL),
+			c.Writer.Status(),
+			time.Since(start),
+			request.render(sensitiveIDs, lookupFailed),
+			response.render(sensitiveIDs, lookupFailed),
+		)
+	}
+}
+
+// logsRoute reports whether a route is listed as "METHOD /path" or routes contains "*"
+// Requests matching no route are never logged
+func logsRoute(routes []string, method, fullPath string) bool {
+	if fullPath == "" {
+		return false
+	}
+	for _, route := range routes {
+		if route == "*" || route == method+" "+fullPath {
+			return true
+		}
+	}
+	return false
+}
+
+// lookupSensitive returns the sensitive questions among ids and whether the lookup failed
+func lookupSensitive(ctx context.Context, sensitive SensitiveQuestionsFunc, ids map[uint]bool) (map[uint]bool, bool) {
+	if len(ids) == 0 {
+		return nil, false
+	}
+	questionIDs := make([]uint, 0, len(ids))
+	for id := range ids {
+		questionIDs = append(questionIDs, id)
+	}
+
+	found, err := sensitive(ctx, questionIDs)
+	if err != nil {
+		log.Printf("traffic: failed to look up sensitive questions, redacting all answers: %v", err)
+		return nil, true
+	}
+	sensitiveIDs := make(map[uint]bool, len(found))
+	for _, id := range found {
+		sensitiveIDs[id] = true
+	}
+	return sensitiveIDs, false
+}
+
+// loggedBody is a request or response body prepared for logging
+type loggedBody struct {
+	value   interface{} // Decoded JSON; nil when the body is not logged
+	summary string      // Shown instead of the body when value is nil
+}
+
+// decodeLoggedBody decodes a JSON body, or describes a body that is not logged
+func decodeLoggedBody(body []byte, truncated bool, contentType string) loggedBody {
+	if len(body) == 0 {
+		return loggedBody{summary: "-"}
+	}
+	if mediaType, _, _ := mime.ParseMediaType(contentType); mediaType != "application/json" {
+		return loggedBody{summary: fmt.Sprintf("<%s body not logged>", contentType)}
+	}
+	if truncated {
+		return loggedBody{summary: "<body over max_body_size not logged>"}
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return loggedBody{summary: "<invalid JSON body not logged>"}
+	}
+	return loggedBody{value: value}
+}
+
+// render encodes the body with secrets and sensitive answers redacted
+func (b loggedBody) render(sensitiveIDs map[uint]bool, redactAllAnswers bool) string {
+	if b.value == nil {
+		return b.summary
+	}
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(redactValue(b.value, sensitiveIDs, redactAllAnswers)); err != nil {
+		return "<body not logged>"
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// collectQuestionIDs adds the question_id of every JSON object in value to ids
+func collectQuestionIDs(value interface{}, ids map[uint]bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if id, ok := questionID(v); ok {
+			ids[id] = true
+		}
+		for _, field := range v {
+			collectQuestionIDs(field, ids)
+		}
+	case []interface{}:
+		for _, item := range v {
+			collectQuestionIDs(item, ids)
+		}
+	}
+}
+
+// questionID returns the question an object refers to through its question_id field
+func questionID(object map[string]interface{}) (uint, bool) {
+	number, ok := object["question_id"].(float64)
+	if !ok || number <= 0 || number != float64(uint(number)) {
+		return 0, false
+	}
+	return uint(number), true
+}
+
+// redactValue returns a copy of value with secret fields redacted, and with every field
+// but question_id redacted in objects referring to a sensitive question
+func redactValue(value interface{}, sensitiveIDs map[uint]bool, redactAllAnswers bool) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		id, isAnswer := questionID(v)
+		sensitive := isAnswer && (redactAllAnswers || sensitiveIDs[id])
+
+		result := make(map[string]interface{}, len(v))
+		for name, field := range v {
+			switch {
+			case sensitive && name != "question_id", isSecretField(name):
+				result[name] = redacted
+			default:
+				result[name] = redactValue(field, sensitiveIDs, redactAllAnswers)
+			}
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			result[i] = redactValue(item, sensitiveIDs, redactAllAnswers)
+		}
+		return result
+	case string:
+		// Share links carry their token in the query string
+		if strings.Contains(v, "?") {
+			if u, err := url.Parse(v); err == nil {
+				redactQuery(u)
+				return u.String()
+			}
+		}
+		return v
+	default:
+		return value
+	}
+}
+
+// isSecretField reports whether a JSON field or query parameter holds a password or token
+func isSecretField(name string) bool {
+	name = strings.ToLower(name)
+	for _, secret := range secretFieldNames {
+		if strings.Contains(name, secret) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactURL returns the path and query of u with secret query parameters redacted
+func redactURL(u *url.URL) string {
+	logged := *u
+	redactQuery(&logged)
+	return logged.RequestURI()
+}
+
+// redactQuery replaces the values of secret query parameters of u
+func redactQuery(u *url.URL) {
+	query := u.Query()
+	changed := false
+	for name, values := range query {
+		if isSecretField(name) {
+			for i := range values {
+				values[i] = redacted
+			}
+			changed = true
+		}
+	}
+	if changed {
+		u.RawQuery = query.Encode()
+	}
+}
+
+// bodyRecorder passes a response through while keeping its first limit bytes
+type bodyRecorder struct {
+	gin.ResponseWriter
+	body      bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+// Write records and writes a part of the response body
+func (w *bodyRecorder) Write(data []byte) (int, error) {
+	w.record(data)
+	return w.ResponseWriter.Write(data)
+}
+
+// WriteString records and writes a part of the response body
+func (w *bodyRecorder) WriteString(s string) (int, error) {
+	w.record([]byte(s))
+	return w.ResponseWriter.WriteString(s)
+}
+
+// record keeps data up to the limit and notes when the body exceeds it
+func (w *bodyRecorder) record(data []byte) {
+	if room := w.limit - w.body.Len(); room < len(data) {
+		w.truncated = true
+		if room > 0 {
+			w.body.Write(data[:room])
+		}
+		return
+	}
+	w.body.Write(data)
+}
@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UsageReserveFunc counts one action of a user against their daily quota of kind and
+// returns a function giving it back; it fails once the quota is used up
+type UsageReserveFunc func(ctx context.Context, userID uint, kind string) (func(), error)
+
+// UsageQuota returns a middleware that counts requests of authenticated users against
+// their daily quota of kind and rejects them once it is used up. Failed requests are
+// not counted. Requests without a user, e.g. with a delegation token, pass through; the
+// service counts those against the quota of the user they act for.
+func UsageQuota(reserve UsageReserveFunc, kind string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := c.Get("user_id")
+		if !exists {
+			c.Next()
+			return
+		}
+
+		release, err := reserve(c.Request.Context(), userID.(uint), kind)
+		if err != nil {
+			RenderError(c, err)
+			return
+		}
+
+		c.Next()
+
+		if c.Writer.Status() >= 400 || len(c.Errors) > 0 {
+			release()
+		}
+	}
+}
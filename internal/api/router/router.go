@@ -1,9 +1,15 @@
 package router
 
 import (
+	"expvar"
+	"log"
+
 	"survey-system/internal/api/handler"
 	"survey-system/internal/api/middleware"
+	"survey-system/internal/cache"
 	"survey-system/internal/config"
+	"survey-system/internal/model"
+	"survey-system/internal/service"
 	"survey-system/pkg/utils"
 
 	"github.com/gin-gonic/gin"
@@ -17,17 +23,65 @@ func SetupRouter(
 	shareHandler *handler.ShareHandler,
 	responseHandler *handler.ResponseHandler,
 	authHandler *handler.AuthHandler,
+	draftHandler *handler.DraftHandler,
+	commentHandler *handler.CommentHandler,
+	activityHandler *handler.ActivityHandler,
+	reportHandler *handler.ReportHandler,
+	channelHandler *handler.ChannelHandler,
+	hookHandler *handler.HookHandler,
+	exportJobHandler *handler.ExportJobHandler,
+	fileHandler *handler.FileHandler,
+	adminHandler *handler.AdminHandler,
+	usageHandler *handler.UsageHandler,
+	archiveHandler *handler.ArchiveHandler,
+	featureHandler *handler.FeatureHandler,
+	teamHandler *handler.TeamHandler,
 	jwtUtil *utils.JWTUtil,
-	cfg *config.Config,
+	userStatus middleware.UserStatusFunc,
+	sessionStatus middleware.SessionStatusFunc,
+	impersonationAudit middleware.ImpersonationAuditFunc,
+	sensitiveQuestions middleware.SensitiveQuestionsFunc,
+	reserveUsage middleware.UsageReserveFunc,
+	requireFeature middleware.FeatureRequireFunc,
+	cfgStore *config.Store,
 	redisClient *redis.Client,
+	cacheKeys cache.Keys,
 ) *gin.Engine {
-	router := gin.Default()
+	cfg := cfgStore.Get()
+	router := gin.New()
+
+	// Restrict which proxies may supply the client IP; with none configured the
+	// X-Forwarded-For header is ignored and the client IP is the peer address
+	if err := router.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		log.Fatalf("Invalid trusted proxies: %v", err)
+	}
+
+	// Report JSON field names in validation error details
+	middleware.UseJSONFieldNames()
 
 	// Apply global middleware
-	router.Use(middleware.CORS(cfg))
+	router.Use(gin.Logger())
+	router.Use(middleware.Recovery())
+	router.Use(middleware.Compression(&cfg.Compression))
+	router.Use(middleware.ErrorHandler())
+	router.Use(middleware.CORS(cfgStore, "/api/v1/public"))
+	router.Use(middleware.BodySizeLimit(cfg.Server.MaxBodySize, map[string]int64{
+		"/api/v1/surveys/:id/responses/import": cfg.Import.MaxFileSize,
+	}))
+	router.Use(middleware.TrafficLog(cfgStore, sensitiveQuestions))
 
 	// Create auth middleware
-	authMiddleware := middleware.AuthMiddleware(jwtUtil)
+	authMiddleware := middleware.AuthMiddleware(jwtUtil, userStatus, sessionStatus, impersonationAudit)
+
+	// Per-user daily quotas
+	shareLinkQuota := middleware.UsageQuota(reserveUsage, service.UsageShareLinks)
+	exportQuota := middleware.UsageQuota(reserveUsage, service.UsageExports)
+
+	// Feature flags of risky features
+	asyncExports := middleware.RequireFeature(requireFeature, service.FeatureAsyncExports)
+
+	// Public keys verifying issued tokens, for other services
+	router.GET("/.well-known/jwks.json", authHandler.JWKS)
 
 	// API v1 routes
 	v1 := router.Group("/api/v1")
@@ -37,9 +91,17 @@ func SetupRouter(
 		{
 			// Public routes (no authentication required)
 			auth.POST("/login", authHandler.Login)
+			auth.POST("/register", authHandler.Register)
+			auth.POST("/verify-email", authHandler.VerifyEmail)
+			auth.POST("/resend-verification", authHandler.ResendVerification)
+			auth.POST("/forgot-password", authHandler.ForgotPassword)
+			auth.POST("/reset-password", authHandler.ResetPassword)
 
 			// Protected routes (authentication required)
-			auth.PUT("/profile", authMiddleware, authHandler.UpdateProfile)
+			auth.PUT("/profile", authMiddleware, middleware.RejectImpersonation(), authHandler.UpdateProfile)
+			auth.GET("/sessions", authMiddleware, authHandler.ListSessions)
+			auth.DELETE("/sessions/:id", authMiddleware, middleware.RejectImpersonation(), authHandler.RevokeSession)
+			auth.GET("/login-history", authMiddleware, authHandler.ListLoginHistory)
 		}
 		// Survey routes (protected)
 		surveys := v1.Group("/surveys")
@@ -51,17 +113,97 @@ func SetupRouter(
 			surveys.PUT("/:id", surveyHandler.UpdateSurvey)
 			surveys.DELETE("/:id", surveyHandler.DeleteSurvey)
 			surveys.POST("/:id/publish", surveyHandler.PublishSurvey)
+			surveys.POST("/:id/archive", surveyHandler.ArchiveSurvey)
+			surveys.POST("/:id/unarchive", surveyHandler.UnarchiveSurvey)
+			surveys.GET("/:id/activity", activityHandler.GetActivity)
+
+			// Summary report subscriptions (protected)
+			surveys.GET("/:id/reports", reportHandler.ListSubscriptions)
+			surveys.POST("/:id/reports", reportHandler.Subscribe)
+			surveys.DELETE("/:id/reports/:reportId", reportHandler.Unsubscribe)
+
+			// Chat notification channels (protected)
+			surveys.GET("/:id/channels", channelHandler.ListChannels)
+			surveys.POST("/:id/channels", channelHandler.CreateChannel)
+			surveys.PUT("/:id/channels/:channelId", channelHandler.UpdateChannel)
+			surveys.DELETE("/:id/channels/:channelId", channelHandler.DeleteChannel)
+			surveys.POST("/:id/channels/:channelId/test", channelHandler.SendTestMessage)
+
+			// Dry run of answers against visibility and validation rules (protected)
+			surveys.POST("/:id/simulate", responseHandler.SimulateResponse)
 
 			// Share link generation (protected)
-			surveys.POST("/:id/share", shareHandler.GenerateShareLink)
+			surveys.POST("/:id/preview", shareHandler.GeneratePreviewLink)
+
+			// Bulk revocation of generated links (protected)
+			surveys.POST("/:id/links/revoke", shareHandler.RevokeLinks)
+
+			// Links pre-generated without prefill data and bound to it later (protected)
+			surveys.POST("/:id/links/pool", shareHandler.GenerateLinkPool)
+			surveys.POST("/:id/links/pool/claim", shareHandler.ClaimPoolLink)
+
+			// Delegation tokens for generating share links without an account (protected)
+			surveys.GET("/:id/delegations", shareHandler.ListDelegations)
+			surveys.POST("/:id/delegations", middleware.RejectImpersonation(), shareHandler.CreateDelegation)
+			surveys.DELETE("/:id/delegations/:delegationId", shareHandler.RevokeDelegation)
+
+			// Saved link templates (protected)
+			surveys.GET("/:id/link-templates", shareHandler.ListLinkTemplates)
+			surveys.POST("/:id/link-templates", shareHandler.CreateLinkTemplate)
+			surveys.PUT("/:id/link-templates/:templateId", shareHandler.UpdateLinkTemplate)
+			surveys.DELETE("/:id/link-templates/:templateId", shareHandler.DeleteLinkTemplate)
 
 			// Response management routes (protected)
 			surveys.GET("/:id/responses", responseHandler.GetResponses)
+			surveys.GET("/:id/responses/sample", responseHandler.SampleResponses)
+			surveys.PATCH("/:id/responses/status", responseHandler.UpdateReviewStatus)
 			surveys.GET("/:id/statistics", responseHandler.GetStatistics)
-			surveys.GET("/:id/export", responseHandler.ExportResponses)
+			surveys.GET("/:id/statistics/crosstab", responseHandler.GetCrosstab)
+			surveys.GET("/:id/statistics/compare", responseHandler.CompareStatistics)
+			surveys.GET("/:id/statistics/assessment", responseHandler.GetAssessmentStatistics)
+			surveys.GET("/:id/export", exportQuota, responseHandler.ExportResponses)
+			surveys.POST("/:id/responses/import", responseHandler.ImportResponses)
+
+			// Asynchronous export routes (protected)
+			surveys.POST("/:id/exports", asyncExports, exportQuota, exportJobHandler.CreateExportJob)
+			surveys.GET("/:id/exports/:jobId", exportJobHandler.GetExportJob)
+			surveys.GET("/:id/exports/:jobId/download", exportJobHandler.DownloadExportJob)
+
+			// Response comment routes (protected)
+			surveys.GET("/:id/responses/:responseId/comments", commentHandler.ListComments)
+			surveys.POST("/:id/responses/:responseId/comments", commentHandler.CreateComment)
+			surveys.PUT("/:id/responses/:responseId/comments/:commentId", commentHandler.UpdateComment)
+			surveys.DELETE("/:id/responses/:responseId/comments/:commentId", commentHandler.DeleteComment)
+
+			// Versions of responses corrected within the survey's edit window (protected)
+			surveys.GET("/:id/responses/:responseId/versions", responseHandler.ListResponseVersions)
+			surveys.GET("/:id/responses/:responseId/versions/diff", responseHandler.DiffResponseVersions)
 
 			// Question reorder route (nested under surveys)
 			surveys.PUT("/:id/questions/reorder", questionHandler.ReorderQuestions)
+
+			// Estimated public payload size and recommended limit warnings (protected)
+			surveys.GET("/:id/size-report", questionHandler.GetSizeReport)
+
+			// Sharing with a team whose members generate links, review and export (protected)
+			surveys.PUT("/:id/team", teamHandler.SetSurveyTeam)
+		}
+
+		// Share link generation, protected by a JWT or a delegation token of the survey
+		v1.POST("/surveys/:id/share", middleware.DelegationOrAuth(authMiddleware), shareLinkQuota, shareHandler.GenerateShareLink)
+
+		// Team routes (protected)
+		teams := v1.Group("/teams")
+		teams.Use(authMiddleware)
+		{
+			teams.GET("", teamHandler.ListTeams)
+			teams.POST("", teamHandler.CreateTeam)
+			teams.GET("/:id", teamHandler.GetTeam)
+			teams.PUT("/:id", teamHandler.UpdateTeam)
+			teams.DELETE("/:id", teamHandler.DeleteTeam)
+			teams.POST("/:id/members", teamHandler.AddMember)
+			teams.DELETE("/:id/members/:userId", teamHandler.RemoveMember)
+			teams.GET("/:id/surveys", teamHandler.ListTeamSurveys)
 		}
 
 		// Question routes (protected)
@@ -71,17 +213,96 @@ func SetupRouter(
 			questions.POST("", questionHandler.CreateQuestion)
 			questions.PUT("/:id", questionHandler.UpdateQuestion)
 			questions.DELETE("/:id", questionHandler.DeleteQuestion)
+			questions.GET("/:id/history", questionHandler.GetQuestionHistory)
+			questions.POST("/:id/history/:versionId/restore", questionHandler.RestoreQuestion)
+			questions.POST("/:id/options/import", questionHandler.ImportQuestionOptions)
+		}
+
+		// Built-in option sets choice questions can import (protected)
+		v1.GET("/option-sets", authMiddleware, questionHandler.ListOptionSets)
+
+		// REST Hooks subscriptions for Zapier, Make and similar tools (protected)
+		hooks := v1.Group("/hooks")
+		hooks.Use(authMiddleware)
+		{
+			hooks.GET("", hookHandler.ListHooks)
+			hooks.POST("", hookHandler.Subscribe)
+			hooks.GET("/sample", hookHandler.SamplePayloads)
+			hooks.DELETE("/:id", hookHandler.Unsubscribe)
+			hooks.GET("/:id/deliveries", hookHandler.ListDeliveries)
+			hooks.POST("/deliveries/:id/retry", hookHandler.RetryDelivery)
+		}
+
+		// Daily quota consumption of the current user (protected)
+		v1.GET("/usage", authMiddleware, usageHandler.GetUsage)
+
+		// Feature flags as they apply to the current user (protected)
+		v1.GET("/features", authMiddleware, featureHandler.GetFeatures)
+
+		// Admin routes (protected, admin role only)
+		admin := v1.Group("/admin")
+		admin.Use(authMiddleware, middleware.RejectImpersonation(), middleware.RequireRole(model.UserRoleAdmin))
+		{
+			admin.POST("/surveys/:id/transfer", adminHandler.TransferSurvey)
+			admin.POST("/surveys/:id/clone", adminHandler.CloneSurvey)
+			admin.POST("/users/:id/deactivate", adminHandler.DeactivateUser)
+			admin.POST("/users/:id/reactivate", adminHandler.ReactivateUser)
+
+			// Short-lived tokens for acting as a user, recorded in the audit log
+			admin.POST("/impersonate/:userId", adminHandler.ImpersonateUser)
+			admin.GET("/audit-logs", adminHandler.ListAuditLogs)
+
+			// Feature flags, overridden at runtime for everyone or single users
+			admin.GET("/features", featureHandler.ListFeatures)
+			admin.PUT("/features/:name", featureHandler.SetFeature)
+			admin.DELETE("/features/:name", featureHandler.ClearFeature)
+
+			// Archives of responses purged by the retention policy
+			admin.GET("/archives", archiveHandler.ListArchives)
+			admin.GET("/archives/:id/download", archiveHandler.DownloadArchive)
+
+			// Runtime metrics, including the hit rates of both cache tiers
+			admin.GET("/debug/vars", gin.WrapH(expvar.Handler()))
 		}
 
 		// Public routes (no authentication required)
 		public := v1.Group("/public")
+		public.Use(middleware.InvalidTokenGuard(redisClient, cacheKeys, func() config.InvalidTokenRule {
+			return cfgStore.Get().RateLimit.InvalidToken
+		}))
 		{
 			// Get survey by token (public access for respondents)
 			public.GET("/surveys/:id", shareHandler.GetSurveyByToken)
 
+			// Check a link before opening it (does not count as an access)
+			public.GET("/links/validate",
+				middleware.RateLimit(redisClient, cacheKeys, "link_validate", func() config.RateLimitRule {
+					return cfgStore.Get().RateLimit.LinkValidate
+				}),
+				shareHandler.ValidateLink,
+			)
+
+			// Whether a survey is open, for landing pages shown before a link is opened
+			public.GET("/surveys/:id/meta",
+				middleware.RateLimit(redisClient, cacheKeys, "survey_meta", func() config.RateLimitRule {
+					return cfgStore.Get().RateLimit.SurveyMeta
+				}),
+				shareHandler.GetSurveyMeta,
+			)
+
+			// Embeddable survey widget (iframe-safe, per-survey allowlist)
+			public.GET("/surveys/:id/embed", shareHandler.GetEmbed)
+
 			// Submit response (public access for respondents)
 			public.POST("/responses", responseHandler.SubmitResponse)
+
+			// Save progress and resume later via emailed link
+			public.POST("/drafts", draftHandler.SaveDraft)
+			public.GET("/drafts/resume", draftHandler.ResumeDraft)
 		}
+
+		// Stored files behind signed, expiring links (no authentication required)
+		v1.GET("/files/*key", fileHandler.DownloadFile)
 	}
 
 	return router
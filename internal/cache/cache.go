@@ -3,7 +3,9 @@ package cache
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -16,34 +18,141 @@ type Cache interface {
 	GetSurvey(ctx context.Context, surveyID uint) (*model.Survey, error)
 	SetSurvey(ctx context.Context, survey *model.Survey, expiration time.Duration) error
 	DeleteSurvey(ctx context.Context, surveyID uint) error
+	InvalidateSurvey(ctx context.Context, surveyID uint) error
 
 	// OneLink status cache operations
 	GetOneLinkStatus(ctx context.Context, token string) (bool, error)
 	SetOneLinkStatus(ctx context.Context, token string, used bool, expiration time.Duration) error
+	GetSubmittedResponse(ctx context.Context, token string) (uint, error)
+	SetSubmittedResponse(ctx context.Context, token string, responseID uint, expiration time.Duration) error
+	IsTokenInvalid(ctx context.Context, token string) (bool, error)
+	MarkTokenInvalid(ctx context.Context, token string, expiration time.Duration) error
+	DeleteOneLinks(ctx context.Context, tokens []string) error
 
 	// Distributed lock operations
 	AcquireLock(ctx context.Context, key string, expiration time.Duration) (bool, error)
 	ReleaseLock(ctx context.Context, key string) error
 
+	// Quota counter operations
+	ReserveQuota(ctx context.Context, surveyID uint, segment string, limit int) (int64, error)
+	SeedQuota(ctx context.Context, surveyID uint, segment string, count int64, expiration time.Duration) error
+	ReleaseQuota(ctx context.Context, surveyID uint, segment string) error
+
+	// Per-user daily usage counter operations
+	GetUsage(ctx context.Context, userID uint, day string) (map[string]int64, error)
+	ReserveUsage(ctx context.Context, userID uint, day, kind string, count, limit int) (int64, error)
+	ReleaseUsage(ctx context.Context, userID uint, day, kind string, count int) error
+
+	// Submission burst counter operations
+	CountSubmission(ctx context.Context, surveyID uint, subnet string, slot int64, expiration time.Duration) (int64, error)
+
+	// Statistics counter operations
+	GetStats(ctx context.Context, surveyID uint) (map[string]float64, error)
+	SetStats(ctx context.Context, surveyID uint, fields map[string]float64, expiration time.Duration) error
+	IncrementStats(ctx context.Context, surveyID uint, delta map[string]float64) error
+	DeleteStats(ctx context.Context, surveyID uint) error
+
+	// Feature flag override operations
+	GetFeatureOverrides(ctx context.Context) (map[string]bool, error)
+	SetFeatureOverride(ctx context.Context, field string, enabled bool) error
+	DeleteFeatureOverride(ctx context.Context, field string) error
+
 	// Health check
 	HealthCheck(ctx context.Context) error
 }
 
+// Quota counter errors
+var (
+	ErrQuotaNotSeeded = errors.New("quota counter not seeded")
+	ErrQuotaExceeded  = errors.New("quota exceeded")
+)
+
+// reserveQuotaScript increments a quota counter only while it is below the limit
+// Returns -1 when the counter does not exist, -2 when the quota is full, otherwise the new count
+var reserveQuotaScript = redis.NewScript(`
+local current = redis.call('GET', KEYS[1])
+if not current then
+	return -1
+end
+if tonumber(current) >= tonumber(ARGV[1]) then
+	return -2
+end
+return redis.call('INCR', KEYS[1])
+`)
+
+// releaseQuotaScript decrements a quota counter only while it exists and is positive, so
+// releasing after the counter expired never creates a negative counter without expiry
+var releaseQuotaScript = redis.NewScript(`
+local current = redis.call('GET', KEYS[1])
+if not current or tonumber(current) <= 0 then
+	return 0
+end
+return redis.call('DECR', KEYS[1])
+`)
+
+// reserveUsageScript adds ARGV[4] to a usage hash field only if that stays within the limit
+// and keeps the hash for ARGV[3] seconds. Returns -1 when the limit would be exceeded, otherwise the new count
+var reserveUsageScript = redis.NewScript(`
+local current = tonumber(redis.call('HGET', KEYS[1], ARGV[1]) or '0')
+if current + tonumber(ARGV[4]) > tonumber(ARGV[2]) then
+	return -1
+end
+local count = redis.call('HINCRBY', KEYS[1], ARGV[1], ARGV[4])
+redis.call('EXPIRE', KEYS[1], ARGV[3])
+return count
+`)
+
+// usageExpiration is how long daily usage counters are kept, covering time zone differences
+const usageExpiration = 48 * time.Hour
+
+// Prefixes of statistics fields that keep an extreme value instead of a running sum
+const (
+	StatsMinPrefix = "min:"
+	StatsMaxPrefix = "max:"
+)
+
+// incrementStatsScript applies a statistics delta only while the counters exist,
+// so a submission never creates a partial hash that would hide the rebuild
+var incrementStatsScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return 0
+end
+for i = 1, #ARGV, 2 do
+	local field = ARGV[i]
+	local value = tonumber(ARGV[i + 1])
+	local prefix = string.sub(field, 1, 4)
+	if prefix == 'min:' or prefix == 'max:' then
+		local current = redis.call('HGET', KEYS[1], field)
+		if not current or (prefix == 'min:' and value < tonumber(current)) or (prefix == 'max:' and value > tonumber(current)) then
+			redis.call('HSET', KEYS[1], field, ARGV[i + 1])
+		end
+	else
+		redis.call('HINCRBYFLOAT', KEYS[1], field, ARGV[i + 1])
+	end
+end
+return 1
+`)
+
+// scanBatchSize is the number of keys requested per SCAN call and removed per UNLINK
+const scanBatchSize = 100
+
 // RedisCache implements the Cache interface using Redis
 type RedisCache struct {
 	client *redis.Client
+	keys   Keys
 }
 
 // NewRedisCache creates a new Redis cache instance
-func NewRedisCache(client *redis.Client) Cache {
+func NewRedisCache(client *redis.Client, keys Keys) Cache {
 	return &RedisCache{
 		client: client,
+		keys:   keys,
 	}
 }
 
 // GetSurvey retrieves a survey from cache
 func (c *RedisCache) GetSurvey(ctx context.Context, surveyID uint) (*model.Survey, error) {
-	key := fmt.Sprintf("survey:%d", surveyID)
+	key := c.keys.Survey(surveyID)
 	
 	data, err := c.client.Get(ctx, key).Bytes()
 	if err != nil {
@@ -63,7 +172,7 @@ func (c *RedisCache) GetSurvey(ctx context.Context, surveyID uint) (*model.Surve
 
 // SetSurvey stores a survey in cache
 func (c *RedisCache) SetSurvey(ctx context.Context, survey *model.Survey, expiration time.Duration) error {
-	key := fmt.Sprintf("survey:%d", survey.ID)
+	key := c.keys.Survey(survey.ID)
 	
 	data, err := json.Marshal(survey)
 	if err != nil {
@@ -79,7 +188,7 @@ func (c *RedisCache) SetSurvey(ctx context.Context, survey *model.Survey, expira
 
 // DeleteSurvey removes a survey from cache
 func (c *RedisCache) DeleteSurvey(ctx context.Context, surveyID uint) error {
-	key := fmt.Sprintf("survey:%d", surveyID)
+	key := c.keys.Survey(surveyID)
 	
 	if err := c.client.Del(ctx, key).Err(); err != nil {
 		return fmt.Errorf("failed to delete survey from cache: %w", err)
@@ -88,9 +197,47 @@ func (c *RedisCache) DeleteSurvey(ctx context.Context, surveyID uint) error {
 	return nil
 }
 
+// InvalidateSurvey removes a survey and every key derived from it, such as its statistics counters
+func (c *RedisCache) InvalidateSurvey(ctx context.Context, surveyID uint) error {
+	if err := c.DeleteSurvey(ctx, surveyID); err != nil {
+		return err
+	}
+
+	if err := c.deleteMatching(ctx, c.keys.SurveyPattern(surveyID)); err != nil {
+		return fmt.Errorf("failed to invalidate survey cache: %w", err)
+	}
+
+	return nil
+}
+
+// deleteMatching removes all keys matching a pattern
+// Keys are found with SCAN rather than KEYS so Redis is not blocked on large keyspaces
+func (c *RedisCache) deleteMatching(ctx context.Context, pattern string) error {
+	iter := c.client.Scan(ctx, 0, pattern, scanBatchSize).Iterator()
+
+	batch := make([]string, 0, scanBatchSize)
+	for iter.Next(ctx) {
+		batch = append(batch, iter.Val())
+		if len(batch) == scanBatchSize {
+			if err := c.client.Unlink(ctx, batch...).Err(); err != nil {
+				return err
+			}
+			batch = batch[:0]
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return err
+	}
+
+	if len(batch) > 0 {
+		return c.client.Unlink(ctx, batch...).Err()
+	}
+	return nil
+}
+
 // GetOneLinkStatus retrieves the used status of a one-time link from cache
 func (c *RedisCache) GetOneLinkStatus(ctx context.Context, token string) (bool, error) {
-	key := fmt.Sprintf("onelink:status:%s", token)
+	key := c.keys.LinkStatus(token)
 	
 	status, err := c.client.Get(ctx, key).Result()
 	if err != nil {
@@ -105,7 +252,7 @@ func (c *RedisCache) GetOneLinkStatus(ctx context.Context, token string) (bool,
 
 // SetOneLinkStatus stores the used status of a one-time link in cache
 func (c *RedisCache) SetOneLinkStatus(ctx context.Context, token string, used bool, expiration time.Duration) error {
-	key := fmt.Sprintf("onelink:status:%s", token)
+	key := c.keys.LinkStatus(token)
 	
 	status := "unused"
 	if used {
@@ -119,9 +266,74 @@ func (c *RedisCache) SetOneLinkStatus(ctx context.Context, token string, used bo
 	return nil
 }
 
+// GetSubmittedResponse returns the ID of the response recently submitted through a link, or 0 if none is recorded
+func (c *RedisCache) GetSubmittedResponse(ctx context.Context, token string) (uint, error) {
+	key := c.keys.LinkResponse(token)
+
+	id, err := c.client.Get(ctx, key).Uint64()
+	if err != nil {
+		if err == redis.Nil {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to get submitted response from cache: %w", err)
+	}
+
+	return uint(id), nil
+}
+
+// SetSubmittedResponse records the response submitted through a link for a short re-submit window
+func (c *RedisCache) SetSubmittedResponse(ctx context.Context, token string, responseID uint, expiration time.Duration) error {
+	key := c.keys.LinkResponse(token)
+
+	if err := c.client.Set(ctx, key, responseID, expiration).Err(); err != nil {
+		return fmt.Errorf("failed to set submitted response in cache: %w", err)
+	}
+
+	return nil
+}
+
+// IsTokenInvalid reports whether a token was recently looked up and found to have no link
+func (c *RedisCache) IsTokenInvalid(ctx context.Context, token string) (bool, error) {
+	key := c.keys.LinkInvalid(token)
+
+	n, err := c.client.Exists(ctx, key).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to check invalid token in cache: %w", err)
+	}
+
+	return n > 0, nil
+}
+
+// MarkTokenInvalid remembers that a token has no link, so repeated lookups skip the database
+func (c *RedisCache) MarkTokenInvalid(ctx context.Context, token string, expiration time.Duration) error {
+	key := c.keys.LinkInvalid(token)
+
+	if err := c.client.Set(ctx, key, "1", expiration).Err(); err != nil {
+		return fmt.Errorf("failed to mark token invalid in cache: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteOneLinks removes the cached status and submitted response of links
+func (c *RedisCache) DeleteOneLinks(ctx context.Context, tokens []string) error {
+	for start := 0; start < len(tokens); start += scanBatchSize {
+		end := min(start+scanBatchSize, len(tokens))
+		keys := make([]string, 0, 2*(end-start))
+		for _, token := range tokens[start:end] {
+			keys = append(keys, c.keys.LinkStatus(token), c.keys.LinkResponse(token))
+		}
+		if err := c.client.Unlink(ctx, keys...).Err(); err != nil {
+			return fmt.Errorf("failed to delete onelinks from cache: %w", err)
+		}
+	}
+
+	return nil
+}
+
 // AcquireLock attempts to acquire a distributed lock
 func (c *RedisCache) AcquireLock(ctx context.Context, key string, expiration time.Duration) (bool, error) {
-	lockKey := fmt.Sprintf("lock:%s", key)
+	lockKey := c.keys.Lock(key)
 	
 	// Use SET NX (set if not exists) with expiration
 	success, err := c.client.SetNX(ctx, lockKey, "1", expiration).Result()
@@ -134,7 +346,7 @@ func (c *RedisCache) AcquireLock(ctx context.Context, key string, expiration tim
 
 // ReleaseLock releases a distributed lock
 func (c *RedisCache) ReleaseLock(ctx context.Context, key string) error {
-	lockKey := fmt.Sprintf("lock:%s", key)
+	lockKey := c.keys.Lock(key)
 	
 	if err := c.client.Del(ctx, lockKey).Err(); err != nil {
 		return fmt.Errorf("failed to release lock: %w", err)
@@ -143,6 +355,235 @@ func (c *RedisCache) ReleaseLock(ctx context.Context, key string) error {
 	return nil
 }
 
+// ReserveQuota atomically takes one slot of a segment quota and returns the new count
+// Returns ErrQuotaNotSeeded when the counter must first be seeded from the database
+// and ErrQuotaExceeded when the quota is already full
+func (c *RedisCache) ReserveQuota(ctx context.Context, surveyID uint, segment string, limit int) (int64, error) {
+	key := c.keys.Quota(surveyID, segment)
+
+	result, err := reserveQuotaScript.Run(ctx, c.client, []string{key}, limit).Int64()
+	if err != nil {
+		return 0, fmt.Errorf("failed to reserve quota: %w", err)
+	}
+
+	switch result {
+	case -1:
+		return 0, ErrQuotaNotSeeded
+	case -2:
+		return 0, ErrQuotaExceeded
+	}
+	return result, nil
+}
+
+// SeedQuota initializes a segment quota counter unless another request already did
+func (c *RedisCache) SeedQuota(ctx context.Context, surveyID uint, segment string, count int64, expiration time.Duration) error {
+	key := c.keys.Quota(surveyID, segment)
+
+	if err := c.client.SetNX(ctx, key, count, expiration).Err(); err != nil {
+		return fmt.Errorf("failed to seed quota: %w", err)
+	}
+
+	return nil
+}
+
+// ReleaseQuota gives back a slot taken by ReserveQuota
+func (c *RedisCache) ReleaseQuota(ctx context.Context, surveyID uint, segment string) error {
+	key := c.keys.Quota(surveyID, segment)
+
+	if err := releaseQuotaScript.Run(ctx, c.client, []string{key}).Err(); err != nil {
+		return fmt.Errorf("failed to release quota: %w", err)
+	}
+
+	return nil
+}
+
+// GetUsage returns a user's usage counters of a day keyed by kind; kinds without usage are missing
+func (c *RedisCache) GetUsage(ctx context.Context, userID uint, day string) (map[string]int64, error) {
+	key := c.keys.Usage(userID, day)
+
+	values, err := c.client.HGetAll(ctx, key).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get usage from cache: %w", err)
+	}
+
+	usage := make(map[string]int64, len(values))
+	for kind, value := range values {
+		count, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid usage counter %s: %w", kind, err)
+		}
+		usage[kind] = count
+	}
+
+	return usage, nil
+}
+
+// ReserveUsage atomically counts count uses of kind by a user on a day and returns the new count
+// Returns ErrQuotaExceeded, counting nothing, when they would take the user past limit
+func (c *RedisCache) ReserveUsage(ctx context.Context, userID uint, day, kind string, count, limit int) (int64, error) {
+	key := c.keys.Usage(userID, day)
+
+	result, err := reserveUsageScript.Run(ctx, c.client, []string{key}, kind, limit, int(usageExpiration.Seconds()), count).Int64()
+	if err != nil {
+		return 0, fmt.Errorf("failed to reserve usage: %w", err)
+	}
+	if result < 0 {
+		return 0, ErrQuotaExceeded
+	}
+	return result, nil
+}
+
+// ReleaseUsage gives back uses counted by ReserveUsage
+func (c *RedisCache) ReleaseUsage(ctx context.Context, userID uint, day, kind string, count int) error {
+	key := c.keys.Usage(userID, day)
+
+	if err := c.client.HIncrBy(ctx, key, kind, -int64(count)).Err(); err != nil {
+		return fmt.Errorf("failed to release usage: %w", err)
+	}
+
+	return nil
+}
+
+// CountSubmission counts a submission from a subnet to a survey in a window slot and
+// returns the number counted so far; the counter expires after expiration
+func (c *RedisCache) CountSubmission(ctx context.Context, surveyID uint, subnet string, slot int64, expiration time.Duration) (int64, error) {
+	key := c.keys.Submissions(surveyID, subnet, slot)
+
+	pipe := c.client.TxPipeline()
+	count := pipe.Incr(ctx, key)
+	pipe.Expire(ctx, key, expiration)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, fmt.Errorf("failed to count submission: %w", err)
+	}
+
+	return count.Val(), nil
+}
+
+// GetStats retrieves the statistics counters of a survey
+// Returns nil when the counters have not been built or have expired
+func (c *RedisCache) GetStats(ctx context.Context, surveyID uint) (map[string]float64, error) {
+	key := c.keys.SurveyStats(surveyID)
+
+	values, err := c.client.HGetAll(ctx, key).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stats from cache: %w", err)
+	}
+	if len(values) == 0 {
+		return nil, nil // Cache miss
+	}
+
+	fields := make(map[string]float64, len(values))
+	for field, value := range values {
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid stats field %s: %w", field, err)
+		}
+		fields[field] = number
+	}
+
+	return fields, nil
+}
+
+// SetStats replaces the statistics counters of a survey
+func (c *RedisCache) SetStats(ctx context.Context, surveyID uint, fields map[string]float64, expiration time.Duration) error {
+	key := c.keys.SurveyStats(surveyID)
+
+	values := make(map[string]interface{}, len(fields))
+	for field, value := range fields {
+		values[field] = strconv.FormatFloat(value, 'f', -1, 64)
+	}
+
+	_, err := c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, key)
+		if len(values) > 0 {
+			pipe.HSet(ctx, key, values)
+			pipe.Expire(ctx, key, expiration)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set stats in cache: %w", err)
+	}
+
+	return nil
+}
+
+// IncrementStats adds delta to the statistics counters of a survey
+// Fields prefixed with StatsMinPrefix or StatsMaxPrefix keep the smallest or largest value.
+// Nothing is written when the counters do not exist yet
+func (c *RedisCache) IncrementStats(ctx context.Context, surveyID uint, delta map[string]float64) error {
+	if len(delta) == 0 {
+		return nil
+	}
+
+	key := c.keys.SurveyStats(surveyID)
+
+	args := make([]interface{}, 0, len(delta)*2)
+	for field, value := range delta {
+		args = append(args, field, strconv.FormatFloat(value, 'f', -1, 64))
+	}
+
+	if err := incrementStatsScript.Run(ctx, c.client, []string{key}, args...).Err(); err != nil {
+		return fmt.Errorf("failed to increment stats: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteStats removes the statistics counters of a survey
+func (c *RedisCache) DeleteStats(ctx context.Context, surveyID uint) error {
+	key := c.keys.SurveyStats(surveyID)
+
+	if err := c.client.Del(ctx, key).Err(); err != nil {
+		return fmt.Errorf("failed to delete stats from cache: %w", err)
+	}
+
+	return nil
+}
+
+// GetFeatureOverrides returns the feature flag overrides set at runtime keyed by field
+func (c *RedisCache) GetFeatureOverrides(ctx context.Context) (map[string]bool, error) {
+	key := c.keys.FeatureOverrides()
+
+	values, err := c.client.HGetAll(ctx, key).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feature overrides from cache: %w", err)
+	}
+
+	overrides := make(map[string]bool, len(values))
+	for field, value := range values {
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid feature override %s: %w", field, err)
+		}
+		overrides[field] = enabled
+	}
+
+	return overrides, nil
+}
+
+// SetFeatureOverride turns a feature flag override on or off; overrides do not expire
+func (c *RedisCache) SetFeatureOverride(ctx context.Context, field string, enabled bool) error {
+	key := c.keys.FeatureOverrides()
+
+	if err := c.client.HSet(ctx, key, field, strconv.FormatBool(enabled)).Err(); err != nil {
+		return fmt.Errorf("failed to set feature override: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteFeatureOverride removes a feature flag override
+func (c *RedisCache) DeleteFeatureOverride(ctx context.Context, field string) error {
+	key := c.keys.FeatureOverrides()
+
+	if err := c.client.HDel(ctx, key, field).Err(); err != nil {
+		return fmt.Errorf("failed to delete feature override: %w", err)
+	}
+
+	return nil
+}
+
 // HealthCheck performs a health check on the Redis connection
 func (c *RedisCache) HealthCheck(ctx context.Context) error {
 	return c.client.Ping(ctx).Err()
@@ -0,0 +1,101 @@
+package cache
+
+import (
+	"fmt"
+
+	"survey-system/internal/model"
+)
+
+// KeyVersion is part of every key; bump it when the layout of cached values changes
+// so entries written by older releases are ignored instead of misread
+const KeyVersion = 1
+
+// Keys builds Redis keys under an application prefix and KeyVersion,
+// e.g. "survey:v1:survey:42:stats", so several deployments can share one Redis
+// All keys of a survey share the Survey key as prefix and can be removed together
+type Keys struct {
+	base string
+}
+
+// NewKeys creates a key builder for the given application prefix
+func NewKeys(prefix string) Keys {
+	return Keys{base: fmt.Sprintf("%s:v%d:", prefix, KeyVersion)}
+}
+
+// Survey is the key of a cached survey
+func (k Keys) Survey(surveyID uint) string {
+	return fmt.Sprintf("%ssurvey:%d", k.base, surveyID)
+}
+
+// SurveyStats is the key of a survey's statistics counters
+func (k Keys) SurveyStats(surveyID uint) string {
+	return k.Survey(surveyID) + ":stats"
+}
+
+// SurveyPattern matches every key derived from the Survey key, e.g. SurveyStats
+func (k Keys) SurveyPattern(surveyID uint) string {
+	return k.Survey(surveyID) + ":*"
+}
+
+// Quota is the key of a quota segment counter
+// Counters are kept outside the survey's keys so invalidating a survey does not reset them
+func (k Keys) Quota(surveyID uint, segment string) string {
+	return fmt.Sprintf("%squota:%d:%s", k.base, surveyID, segment)
+}
+
+// Submissions is the key counting a subnet's submissions to a survey in a window
+// Counters are kept outside the survey's keys so invalidating a survey does not reset them
+func (k Keys) Submissions(surveyID uint, subnet string, slot int64) string {
+	return fmt.Sprintf("%ssubmissions:%d:%s:%d", k.base, surveyID, subnet, slot)
+}
+
+// Usage is the key of a user's usage counters for one day, with one hash field per kind of action
+func (k Keys) Usage(userID uint, day string) string {
+	return fmt.Sprintf("%susage:%d:%s", k.base, userID, day)
+}
+
+// FeatureOverrides is the key of the feature flag overrides set at runtime, with one hash
+// field per flag or per flag and user
+func (k Keys) FeatureOverrides() string {
+	return k.base + "features"
+}
+
+// LinkStatus is the key of a link's used status
+func (k Keys) LinkStatus(token string) string {
+	return k.link("status", token)
+}
+
+// LinkResponse is the key of the response recently submitted through a link
+func (k Keys) LinkResponse(token string) string {
+	return k.link("response", token)
+}
+
+// LinkInvalid is the key marking a token without a link
+func (k Keys) LinkInvalid(token string) string {
+	return k.link("invalid", token)
+}
+
+// link keys link data by token hash, as tokens are several hundred characters long
+func (k Keys) link(kind, token string) string {
+	return fmt.Sprintf("%sonelink:%s:%s", k.base, kind, model.HashLinkToken(token))
+}
+
+// Lock is the key of a distributed lock
+func (k Keys) Lock(name string) string {
+	return fmt.Sprintf("%slock:%s", k.base, name)
+}
+
+// RateLimit is the key counting a client's requests to a rate limited endpoint in a window
+func (k Keys) RateLimit(name, clientIP string, slot int64) string {
+	return fmt.Sprintf("%sratelimit:%s:%s:%d", k.base, name, clientIP, slot)
+}
+
+// InvalidTokens is the key counting a client's invalid link tokens in a window
+func (k Keys) InvalidTokens(clientIP string, slot int64) string {
+	return fmt.Sprintf("%stokenguard:invalid:%s:%d", k.base, clientIP, slot)
+}
+
+// TokenBlock is the key marking a client blocked for sending too many invalid tokens
+func (k Keys) TokenBlock(clientIP string) string {
+	return fmt.Sprintf("%stokenguard:block:%s", k.base, clientIP)
+}
@@ -0,0 +1,198 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"survey-system/internal/model"
+)
+
+// TieredCache keeps recently read surveys in process memory in front of another Cache,
+// sparing hot published surveys a Redis round trip and JSON decoding on every request.
+// Local entries live only for a short TTL, so changes made through other server
+// instances show up after at most that long; changes made through this instance
+// are visible immediately. All other operations go straight to the backing cache.
+type TieredCache struct {
+	Cache
+
+	local *surveyLRU
+
+	localHits    atomic.Int64
+	localMisses  atomic.Int64
+	remoteHits   atomic.Int64
+	remoteMisses atomic.Int64
+}
+
+// TierStats reports how often reads were answered by one cache tier
+type TierStats struct {
+	Hits    int64   `json:"hits"`
+	Misses  int64   `json:"misses"`
+	HitRate float64 `json:"hit_rate"`
+}
+
+// TieredStats reports survey reads per tier; Redis only sees reads the local tier missed
+type TieredStats struct {
+	Local TierStats `json:"local"`
+	Redis TierStats `json:"redis"`
+}
+
+// NewTieredCache puts an in-memory tier holding up to size surveys for ttl in front of backing
+// A size or ttl of 0 disables the local tier; hit rates are still tracked
+func NewTieredCache(backing Cache, size int, ttl time.Duration) *TieredCache {
+	t := &TieredCache{Cache: backing}
+	if size > 0 && ttl > 0 {
+		t.local = newSurveyLRU(size, ttl)
+	}
+	return t
+}
+
+// GetSurvey retrieves a survey from memory, falling back to the backing cache
+// The returned survey may be shared with other requests and must not be modified
+func (t *TieredCache) GetSurvey(ctx context.Context, surveyID uint) (*model.Survey, error) {
+	if t.local != nil {
+		if survey, ok := t.local.get(surveyID); ok {
+			t.localHits.Add(1)
+			return survey, nil
+		}
+		t.localMisses.Add(1)
+	}
+
+	survey, err := t.Cache.GetSurvey(ctx, surveyID)
+	if err != nil {
+		return nil, err
+	}
+	if survey == nil {
+		t.remoteMisses.Add(1)
+		return nil, nil
+	}
+	t.remoteHits.Add(1)
+
+	if t.local != nil {
+		t.local.set(surveyID, survey)
+	}
+	return survey, nil
+}
+
+// SetSurvey stores a survey in both tiers
+func (t *TieredCache) SetSurvey(ctx context.Context, survey *model.Survey, expiration time.Duration) error {
+	if err := t.Cache.SetSurvey(ctx, survey, expiration); err != nil {
+		return err
+	}
+	if t.local != nil {
+		t.local.set(survey.ID, survey)
+	}
+	return nil
+}
+
+// DeleteSurvey removes a survey from both tiers
+func (t *TieredCache) DeleteSurvey(ctx context.Context, surveyID uint) error {
+	if t.local != nil {
+		t.local.delete(surveyID)
+	}
+	return t.Cache.DeleteSurvey(ctx, surveyID)
+}
+
+// InvalidateSurvey removes a survey from memory and everything cached for it from the backing cache
+func (t *TieredCache) InvalidateSurvey(ctx context.Context, surveyID uint) error {
+	if t.local != nil {
+		t.local.delete(surveyID)
+	}
+	return t.Cache.InvalidateSurvey(ctx, surveyID)
+}
+
+// Stats returns the hit rates of both tiers since startup
+func (t *TieredCache) Stats() TieredStats {
+	return TieredStats{
+		Local: tierStats(t.localHits.Load(), t.localMisses.Load()),
+		Redis: tierStats(t.remoteHits.Load(), t.remoteMisses.Load()),
+	}
+}
+
+// tierStats computes the hit rate of a tier
+func tierStats(hits, misses int64) TierStats {
+	stats := TierStats{Hits: hits, Misses: misses}
+	if total := hits + misses; total > 0 {
+		stats.HitRate = float64(hits) / float64(total)
+	}
+	return stats
+}
+
+// surveyLRU is a size-bounded, least recently used set of surveys with a fixed TTL
+type surveyLRU struct {
+	mu      sync.Mutex
+	size    int
+	ttl     time.Duration
+	order   *list.List // Front is the most recently used
+	entries map[uint]*list.Element
+}
+
+// surveyEntry is an element of surveyLRU.order
+type surveyEntry struct {
+	id        uint
+	survey    *model.Survey
+	expiresAt time.Time
+}
+
+// newSurveyLRU creates an empty LRU holding up to size surveys for ttl each
+func newSurveyLRU(size int, ttl time.Duration) *surveyLRU {
+	return &surveyLRU{
+		size:    size,
+		ttl:     ttl,
+		order:   list.New(),
+		entries: make(map[uint]*list.Element, size),
+	}
+}
+
+// get returns an unexpired survey and marks it as recently used
+func (l *surveyLRU) get(id uint) (*model.Survey, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	elem, ok := l.entries[id]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*surveyEntry)
+	if time.Now().After(entry.expiresAt) {
+		l.order.Remove(elem)
+		delete(l.entries, id)
+		return nil, false
+	}
+	l.order.MoveToFront(elem)
+	return entry.survey, true
+}
+
+// set stores a survey, evicting the least recently used one when full
+func (l *surveyLRU) set(id uint, survey *model.Survey) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	expiresAt := time.Now().Add(l.ttl)
+	if elem, ok := l.entries[id]; ok {
+		entry := elem.Value.(*surveyEntry)
+		entry.survey, entry.expiresAt = survey, expiresAt
+		l.order.MoveToFront(elem)
+		return
+	}
+
+	l.entries[id] = l.order.PushFront(&surveyEntry{id: id, survey: survey, expiresAt: expiresAt})
+	if l.order.Len() > l.size {
+		oldest := l.order.Back()
+		l.order.Remove(oldest)
+		delete(l.entries, oldest.Value.(*surveyEntry).id)
+	}
+}
+
+// delete removes a survey if present
+func (l *surveyLRU) delete(id uint) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if elem, ok := l.entries[id]; ok {
+		l.order.Remove(elem)
+		delete(l.entries, id)
+	}
+}
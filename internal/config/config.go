@@ -2,20 +2,44 @@ package config
 
 import (
 	"fmt"
+	"slices"
 	"time"
 
+	"survey-system/pkg/optionset"
+
 	"github.com/spf13/viper"
 )
 
 // Config holds all configuration for the application
 type Config struct {
-	Server     ServerConfig     `mapstructure:"server"`
-	Database   DatabaseConfig   `mapstructure:"database"`
-	Redis      RedisConfig      `mapstructure:"redis"`
-	JWT        JWTConfig        `mapstructure:"jwt"`
-	Encryption EncryptionConfig `mapstructure:"encryption"`
-	CORS       CORSConfig       `mapstructure:"cors"`
-	OneLink    OneLinkConfig    `mapstructure:"onelink"`
+	Server      ServerConfig      `mapstructure:"server"`
+	Database    DatabaseConfig    `mapstructure:"database"`
+	Redis       RedisConfig       `mapstructure:"redis"`
+	JWT         JWTConfig         `mapstructure:"jwt"`
+	Encryption  EncryptionConfig  `mapstructure:"encryption"`
+	CORS        CORSConfig        `mapstructure:"cors"`
+	OneLink     OneLinkConfig     `mapstructure:"onelink"`
+	Email       EmailConfig       `mapstructure:"email"`
+	Submission  SubmissionConfig  `mapstructure:"submission"`
+	Notifier    NotifierConfig    `mapstructure:"notifier"`
+	Reports     ReportsConfig     `mapstructure:"reports"`
+	Export      ExportConfig      `mapstructure:"export"`
+	Storage     StorageConfig     `mapstructure:"storage"`
+	Import      ImportConfig      `mapstructure:"import"`
+	Statistics  StatisticsConfig  `mapstructure:"statistics"`
+	Seed        SeedConfig        `mapstructure:"seed"`
+	Secrets     SecretsConfig     `mapstructure:"secrets"`
+	Compression CompressionConfig `mapstructure:"compression"`
+	RateLimit   RateLimitConfig   `mapstructure:"rate_limit"`
+	LocalCache  LocalCacheConfig  `mapstructure:"local_cache"`
+	TrafficLog  TrafficLogConfig  `mapstructure:"traffic_log"`
+	Usage       UsageConfig       `mapstructure:"usage"`
+	Retention   RetentionConfig   `mapstructure:"retention"`
+	OptionSets  OptionSetsConfig  `mapstructure:"option_sets"`
+	Questions   QuestionsConfig   `mapstructure:"questions"`
+	Features    FeaturesConfig    `mapstructure:"features"`
+	Anomaly     AnomalyConfig     `mapstructure:"anomaly"`
+	Account     AccountConfig     `mapstructure:"account"`
 }
 
 // ServerConfig holds server configuration
@@ -24,6 +48,73 @@ type ServerConfig struct {
 	Mode         string        `mapstructure:"mode"`
 	ReadTimeout  time.Duration `mapstructure:"read_timeout"`
 	WriteTimeout time.Duration `mapstructure:"write_timeout"`
+	MaxBodySize  int64         `mapstructure:"max_body_size"` // Maximum request body size in bytes
+
+	// Proxies whose X-Forwarded-For header is trusted when resolving client IPs.
+	// Empty trusts no proxy, so the client IP is always the peer address.
+	TrustedProxies []string `mapstructure:"trusted_proxies"`
+}
+
+// CompressionConfig holds response compression configuration
+type CompressionConfig struct {
+	Enabled      bool     `mapstructure:"enabled"`
+	Level        int      `mapstructure:"level"`         // 1 (fastest) to 9 (smallest)
+	MinSize      int      `mapstructure:"min_size"`      // Responses smaller than this many bytes are sent uncompressed
+	ContentTypes []string `mapstructure:"content_types"` // Media types that are compressed
+}
+
+// TrafficLogConfig holds settings of the debug logger recording request and response bodies
+// Passwords, tokens and answers to sensitive questions are redacted before logging
+type TrafficLogConfig struct {
+	Routes      []string `mapstructure:"routes"`        // Logged routes as "METHOD /path" with gin parameters, e.g. "POST /api/v1/public/responses"; "*" logs every route, empty disables logging
+	MaxBodySize int      `mapstructure:"max_body_size"` // Bytes of each body that are logged; longer bodies are truncated
+}
+
+// LocalCacheConfig holds settings of the in-process cache in front of Redis
+type LocalCacheConfig struct {
+	Size int           `mapstructure:"size"` // Surveys kept in memory; 0 disables the in-process cache
+	TTL  time.Duration `mapstructure:"ttl"`  // How long other instances' changes may go unseen
+}
+
+// RateLimitConfig holds per-client request limits of public endpoints
+type RateLimitConfig struct {
+	LinkValidate RateLimitRule    `mapstructure:"link_validate"` // GET /api/v1/public/links/validate
+	SurveyMeta   RateLimitRule    `mapstructure:"survey_meta"`   // GET /api/v1/public/surveys/:id/meta
+	InvalidToken InvalidTokenRule `mapstructure:"invalid_token"` // Invalid link tokens sent to /api/v1/public/*
+}
+
+// RateLimitRule allows Requests per Window from one client IP; 0 requests disables the limit
+type RateLimitRule struct {
+	Requests int           `mapstructure:"requests"`
+	Window   time.Duration `mapstructure:"window"`
+}
+
+// FeaturesConfig holds the feature flags gating risky features. Flags that are not
+// configured are on; overrides set through the admin API win over the configuration
+type FeaturesConfig struct {
+	Environment string                       `mapstructure:"environment"` // Name of this deployment, e.g. "staging", matched against each flag's environments
+	Flags       map[string]FeatureFlagConfig `mapstructure:"flags"`       // Flags by name: async_exports, nps_questions, slider_questions, cascade_columns
+}
+
+// FeatureFlagConfig holds the configured state of one feature flag
+type FeatureFlagConfig struct {
+	Enabled      bool     `mapstructure:"enabled"`      // On for everyone
+	Environments []string `mapstructure:"environments"` // On for everyone in these environments
+	Users        []uint   `mapstructure:"users"`        // On for these users wherever it is off
+}
+
+// UsageConfig holds per-account daily quotas, counted in Redis; 0 leaves an action unlimited
+type UsageConfig struct {
+	ShareLinks int `mapstructure:"share_links"` // Share links generated per user and day
+	Exports    int `mapstructure:"exports"`     // Exports per user and day, synchronous and asynchronous
+}
+
+// InvalidTokenRule blocks a client IP for Block once it sends Attempts invalid link tokens
+// within Window, slowing down token enumeration; 0 attempts disables blocking
+type InvalidTokenRule struct {
+	Attempts int           `mapstructure:"attempts"`
+	Window   time.Duration `mapstructure:"window"`
+	Block    time.Duration `mapstructure:"block"`
 }
 
 // DatabaseConfig holds database configuration
@@ -36,21 +127,38 @@ type DatabaseConfig struct {
 	MaxOpenConns    int           `mapstructure:"max_open_conns"`
 	MaxIdleConns    int           `mapstructure:"max_idle_conns"`
 	ConnMaxLifetime time.Duration `mapstructure:"conn_max_lifetime"`
+	ReadTimeout     time.Duration `mapstructure:"read_timeout"`  // Statement timeout for queries; 0 disables it
+	WriteTimeout    time.Duration `mapstructure:"write_timeout"` // Statement timeout for inserts, updates and deletes; 0 disables it
+
+	LogLevel           string        `mapstructure:"log_level"`            // silent, error, warn (default) or info, which logs every statement
+	SlowQueryThreshold time.Duration `mapstructure:"slow_query_threshold"` // Statements taking longer are logged as warnings and counted; 0 disables it
+
+	PoolReportInterval time.Duration `mapstructure:"pool_report_interval"` // How often the connection pool usage is logged; 0 disables the report
+	PoolWaitThreshold  time.Duration `mapstructure:"pool_wait_threshold"`  // Total wait for connections per report above which a warning is logged; 0 disables it
 }
 
 // RedisConfig holds Redis configuration
 type RedisConfig struct {
-	Host     string `mapstructure:"host"`
-	Port     int    `mapstructure:"port"`
-	Password string `mapstructure:"password"`
-	DB       int    `mapstructure:"db"`
-	PoolSize int    `mapstructure:"pool_size"`
+	Host      string `mapstructure:"host"`
+	Port      int    `mapstructure:"port"`
+	Password  string `mapstructure:"password"`
+	DB        int    `mapstructure:"db"`
+	PoolSize  int    `mapstructure:"pool_size"`
+	KeyPrefix string `mapstructure:"key_prefix"` // Prepended to every key, so several deployments can share one Redis
 }
 
 // JWTConfig holds JWT configuration
 type JWTConfig struct {
 	Secret     string        `mapstructure:"secret"`
 	Expiration time.Duration `mapstructure:"expiration"`
+
+	ImpersonationExpiration time.Duration `mapstructure:"impersonation_expiration"` // Lifetime of the tokens admins get to act as another user
+
+	// Signing with a key pair lets other services verify tokens with the public key
+	// published at /.well-known/jwks.json instead of sharing the secret
+	Algorithm  string `mapstructure:"algorithm"`   // HS256 (secret), RS256 or EdDSA (private_key)
+	PrivateKey string `mapstructure:"private_key"` // PEM private key for RS256 or EdDSA
+	KeyID      string `mapstructure:"key_id"`      // kid of the key pair; defaults to its JWK thumbprint
 }
 
 // EncryptionConfig holds encryption configuration
@@ -59,10 +167,33 @@ type EncryptionConfig struct {
 }
 
 // CORSConfig holds CORS configuration
+// The top-level policy applies to authenticated routes; Public applies to /api/v1/public
 type CORSConfig struct {
-	AllowedOrigins []string `mapstructure:"allowed_origins"`
-	AllowedMethods []string `mapstructure:"allowed_methods"`
-	AllowedHeaders []string `mapstructure:"allowed_headers"`
+	CORSPolicy `mapstructure:",squash"`
+	Public     CORSPolicy `mapstructure:"public"` // Empty origin, method or header lists fall back to the top-level policy
+}
+
+// CORSPolicy holds the CORS settings of one route group
+type CORSPolicy struct {
+	AllowedOrigins []string      `mapstructure:"allowed_origins"`
+	AllowedMethods []string      `mapstructure:"allowed_methods"`
+	AllowedHeaders []string      `mapstructure:"allowed_headers"`
+	MaxAge         time.Duration `mapstructure:"max_age"` // How long browsers may cache preflight results; 0 omits Access-Control-Max-Age
+}
+
+// PublicPolicy returns the policy of the public routes with unset lists taken from the top-level policy
+func (c CORSConfig) PublicPolicy() CORSPolicy {
+	policy := c.Public
+	if len(policy.AllowedOrigins) == 0 {
+		policy.AllowedOrigins = c.AllowedOrigins
+	}
+	if len(policy.AllowedMethods) == 0 {
+		policy.AllowedMethods = c.AllowedMethods
+	}
+	if len(policy.AllowedHeaders) == 0 {
+		policy.AllowedHeaders = c.AllowedHeaders
+	}
+	return policy
 }
 
 // OneLinkConfig holds one-time link configuration
@@ -70,6 +201,136 @@ type OneLinkConfig struct {
 	BaseURL           string        `mapstructure:"base_url"`
 	DefaultExpiration time.Duration `mapstructure:"default_expiration"`
 	MaxExpiration     time.Duration `mapstructure:"max_expiration"`
+	PreviewExpiration time.Duration `mapstructure:"preview_expiration"` // Lifetime of survey preview tokens
+	RedirectDomains   []string      `mapstructure:"redirect_domains"`   // Allowed thank-you redirect hosts, e.g. example.com or *.example.com; empty disables redirects
+}
+
+// EmailConfig holds SMTP configuration for outgoing emails
+// When Host is empty, emails are written to the log instead of being sent
+type EmailConfig struct {
+	Host     string `mapstructure:"host"`
+	Port     int    `mapstructure:"port"`
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+	From     string `mapstructure:"from"`
+}
+
+// SubmissionConfig holds server-side limits applied to submitted answers
+type SubmissionConfig struct {
+	MaxTextLength int `mapstructure:"max_text_length"` // Maximum characters per text answer or table cell
+	MaxTableRows  int `mapstructure:"max_table_rows"`  // Maximum rows per table answer
+
+	ResubmitWindow time.Duration `mapstructure:"resubmit_window"` // How long a re-submit through a just-used link returns the original result; 0 disables
+
+	NumberPrefix string `mapstructure:"number_prefix"` // Put before respondent numbers, e.g. R- for R-0001
+	NumberWidth  int    `mapstructure:"number_width"`  // Minimum digits of respondent numbers, padded with zeros
+}
+
+// NotifierConfig holds settings for the link expiration notifier and the reminders of
+// surveys without responses
+type NotifierConfig struct {
+	Interval       time.Duration `mapstructure:"interval"`        // How often to scan for expiring links and surveys without responses; 0 disables both
+	WebhookSecret  string        `mapstructure:"webhook_secret"`  // HMAC-SHA256 key used to sign webhook payloads; required
+	WebhookTimeout time.Duration `mapstructure:"webhook_timeout"` // Timeout for a single webhook delivery
+}
+
+// ReportsConfig holds settings for the scheduled summary email reports
+type ReportsConfig struct {
+	Interval   time.Duration `mapstructure:"interval"`    // How often to look for due reports; 0 disables the scheduler
+	SendHour   int           `mapstructure:"send_hour"`   // Local hour after which daily reports, and weekly reports on Mondays, are sent
+	TopOptions int           `mapstructure:"top_options"` // Options listed per choice question
+}
+
+// ExportConfig holds settings for asynchronous export jobs
+type ExportConfig struct {
+	JobTTL time.Duration `mapstructure:"job_ttl"` // How long finished export files can be downloaded
+}
+
+// StorageConfig holds settings for storing generated files such as exports
+type StorageConfig struct {
+	Driver          string        `mapstructure:"driver"`           // local or s3
+	LocalDir        string        `mapstructure:"local_dir"`        // Directory of the local driver
+	PublicURL       string        `mapstructure:"public_url"`       // Base URL of this API, used in signed local download links
+	SigningKey      string        `mapstructure:"signing_key"`      // HMAC key of signed local download links; defaults to the JWT secret
+	PresignExpiry   time.Duration `mapstructure:"presign_expiry"`   // Lifetime of pre-signed download links
+	Retention       time.Duration `mapstructure:"retention"`        // Files older than this are deleted by the cleanup; 0 keeps them
+	CleanupInterval time.Duration `mapstructure:"cleanup_interval"` // How often expired files are deleted; 0 disables the cleanup
+	S3              S3Config      `mapstructure:"s3"`
+}
+
+// AnomalyConfig holds the detection of automated submissions, such as bursts from one
+// network or implausibly fast answers. Such submissions are stored but flagged
+type AnomalyConfig struct {
+	BurstSubmissions int           `mapstructure:"burst_submissions"` // Submissions to a survey from one /24 (IPv4) or /64 (IPv6) subnet allowed per window; 0 disables detection
+	BurstWindow      time.Duration `mapstructure:"burst_window"`      // Length of the fixed counting window
+	MinAnswerTime    time.Duration `mapstructure:"min_answer_time"`   // Average time per answer below which a fully timed response is flagged; 0 disables the check
+}
+
+// AccountConfig holds self-service registration, email verification, password reset and
+// login alerts. Verification and reset links point to the frontend at onelink.base_url
+type AccountConfig struct {
+	Registration      bool          `mapstructure:"registration"`       // Lets anyone sign up through /auth/register
+	VerificationTTL   time.Duration `mapstructure:"verification_ttl"`   // Lifetime of email verification links
+	VerificationGrace time.Duration `mapstructure:"verification_grace"` // Accounts still unverified this long after signing up cannot log in; 0 never locks them out
+	ResetTTL          time.Duration `mapstructure:"reset_ttl"`          // Lifetime of password reset links
+	LoginAlerts       bool          `mapstructure:"login_alerts"`       // Emails users about logins from a new device or network
+}
+
+// RetentionConfig holds settings for purging old responses and hook deliveries
+// Responses are exported to an encrypted archive in storage before they are deleted
+type RetentionConfig struct {
+	Responses       time.Duration `mapstructure:"responses"`        // Responses submitted longer ago than this are purged, by whole days; 0 keeps them
+	Interval        time.Duration `mapstructure:"interval"`         // How often the purge runs
+	ArchivePassword string        `mapstructure:"archive_password"` // Password of the ZIP archives written before purging
+	BatchSize       int           `mapstructure:"batch_size"`       // Responses or hook deliveries deleted per statement
+	HookDeliveries  time.Duration `mapstructure:"hook_deliveries"`  // Hook delivery attempts older than this are deleted with their payloads; 0 keeps them
+}
+
+// S3Config holds settings for S3-compatible object storage
+type S3Config struct {
+	Endpoint        string        `mapstructure:"endpoint"` // e.g. https://s3.us-east-1.amazonaws.com or a MinIO URL
+	Region          string        `mapstructure:"region"`
+	Bucket          string        `mapstructure:"bucket"`
+	AccessKeyID     string        `mapstructure:"access_key_id"`
+	SecretAccessKey string        `mapstructure:"secret_access_key"`
+	PathStyle       bool          `mapstructure:"path_style"` // Address the bucket in the path instead of the host name (MinIO)
+	Timeout         time.Duration `mapstructure:"timeout"`
+}
+
+// ImportConfig holds limits for importing responses from CSV and Excel files
+type ImportConfig struct {
+	MaxFileSize int64 `mapstructure:"max_file_size"` // Maximum upload size in bytes, replacing server.max_body_size for the import endpoint
+	MaxRows     int   `mapstructure:"max_rows"`      // Maximum data rows per file
+	BatchSize   int   `mapstructure:"batch_size"`    // Responses inserted per statement
+}
+
+// QuestionsConfig holds the size limits of surveys and questions. Rows of table questions
+// are limited by submission.max_table_rows
+type QuestionsConfig struct {
+	MaxPerSurvey int `mapstructure:"max_per_survey"` // Maximum questions per survey
+	MaxOptions   int `mapstructure:"max_options"`    // Maximum options per choice question, select column or cascade parent value
+	MaxColumns   int `mapstructure:"max_columns"`    // Maximum columns per table question
+}
+
+// OptionSetsConfig holds settings of importing choice options into questions
+type OptionSetsConfig struct {
+	Enabled    []string `mapstructure:"enabled"`     // Built-in option sets offered for import, e.g. "countries", "cn_provinces"
+	MaxOptions int      `mapstructure:"max_options"` // Maximum options a question can have after an import
+}
+
+// StatisticsConfig holds settings for the cached statistics counters
+type StatisticsConfig struct {
+	CacheThreshold    int64         `mapstructure:"cache_threshold"`    // Surveys with at least this many responses are served from Redis counters; 0 disables caching
+	ReconcileInterval time.Duration `mapstructure:"reconcile_interval"` // How long counters are trusted before being rebuilt from the database
+}
+
+// SeedConfig holds settings for creating the initial admin account on an empty database
+type SeedConfig struct {
+	Enabled        bool   `mapstructure:"enabled"` // Create an admin account when no users exist
+	AdminUsername  string `mapstructure:"admin_username"`
+	AdminPassword  string `mapstructure:"admin_password"` // A random password is generated and printed once when empty
+	AdminEmail     string `mapstructure:"admin_email"`
+	AllowInRelease bool   `mapstructure:"allow_in_release"` // Seeding is refused in release mode unless set
 }
 
 // Load loads configuration from file and environment variables
@@ -86,6 +347,78 @@ func Load(configPath string) (*Config, error) {
 		v.AddConfigPath(".")
 	}
 
+	// Defaults for optional settings
+	v.SetDefault("database.read_timeout", 30*time.Second)
+	v.SetDefault("database.write_timeout", 10*time.Second)
+	v.SetDefault("database.log_level", "warn")
+	v.SetDefault("database.slow_query_threshold", 200*time.Millisecond)
+	v.SetDefault("database.pool_report_interval", 10*time.Minute)
+	v.SetDefault("database.pool_wait_threshold", time.Second)
+	v.SetDefault("redis.key_prefix", "survey")
+	v.SetDefault("cors.max_age", 24*time.Hour)
+	v.SetDefault("cors.public.max_age", 24*time.Hour)
+	v.SetDefault("local_cache.size", 1000)
+	v.SetDefault("local_cache.ttl", 5*time.Second)
+	v.SetDefault("server.max_body_size", 1<<20)
+	v.SetDefault("jwt.impersonation_expiration", 30*time.Minute)
+	v.SetDefault("jwt.algorithm", "HS256")
+	v.SetDefault("submission.max_text_length", 5000)
+	v.SetDefault("submission.max_table_rows", 200)
+	v.SetDefault("submission.resubmit_window", "5m")
+	v.SetDefault("submission.number_prefix", "R-")
+	v.SetDefault("submission.number_width", 4)
+	v.SetDefault("notifier.interval", 10*time.Minute)
+	v.SetDefault("notifier.webhook_timeout", 10*time.Second)
+	v.SetDefault("reports.interval", 15*time.Minute)
+	v.SetDefault("reports.send_hour", 8)
+	v.SetDefault("reports.top_options", 3)
+	v.SetDefault("onelink.preview_expiration", 24*time.Hour)
+	v.SetDefault("export.job_ttl", 24*time.Hour)
+	v.SetDefault("storage.driver", "local")
+	v.SetDefault("storage.local_dir", "./data/storage")
+	v.SetDefault("storage.public_url", "http://localhost:8080")
+	v.SetDefault("storage.presign_expiry", 15*time.Minute)
+	v.SetDefault("storage.retention", 7*24*time.Hour)
+	v.SetDefault("storage.cleanup_interval", time.Hour)
+	v.SetDefault("storage.s3.region", "us-east-1")
+	v.SetDefault("storage.s3.timeout", 30*time.Second)
+	v.SetDefault("anomaly.burst_submissions", 50)
+	v.SetDefault("anomaly.burst_window", time.Minute)
+	v.SetDefault("anomaly.min_answer_time", time.Second)
+	v.SetDefault("account.verification_ttl", 48*time.Hour)
+	v.SetDefault("account.verification_grace", 72*time.Hour)
+	v.SetDefault("account.reset_ttl", time.Hour)
+	v.SetDefault("account.login_alerts", true)
+	v.SetDefault("retention.interval", 24*time.Hour)
+	v.SetDefault("retention.batch_size", 1000)
+	v.SetDefault("retention.hook_deliveries", 30*24*time.Hour)
+	v.SetDefault("import.max_file_size", 10<<20)
+	v.SetDefault("import.max_rows", 10000)
+	v.SetDefault("import.batch_size", 500)
+	v.SetDefault("option_sets.enabled", optionset.Names())
+	v.SetDefault("option_sets.max_options", 1000)
+	v.SetDefault("questions.max_per_survey", 200)
+	v.SetDefault("questions.max_options", 1000)
+	v.SetDefault("questions.max_columns", 50)
+	v.SetDefault("statistics.cache_threshold", 1000)
+	v.SetDefault("statistics.reconcile_interval", time.Hour)
+	v.SetDefault("seed.enabled", true)
+	v.SetDefault("seed.admin_username", "admin")
+	v.SetDefault("seed.admin_email", "admin@example.com")
+	v.SetDefault("secrets.vault_timeout", 10*time.Second)
+	v.SetDefault("traffic_log.max_body_size", 4096)
+	v.SetDefault("compression.enabled", true)
+	v.SetDefault("compression.level", 5)
+	v.SetDefault("compression.min_size", 1024)
+	v.SetDefault("compression.content_types", []string{"application/json", "text/csv", "text/plain", "text/html"})
+	v.SetDefault("rate_limit.link_validate.requests", 10)
+	v.SetDefault("rate_limit.link_validate.window", time.Minute)
+	v.SetDefault("rate_limit.survey_meta.requests", 60)
+	v.SetDefault("rate_limit.survey_meta.window", time.Minute)
+	v.SetDefault("rate_limit.invalid_token.attempts", 20)
+	v.SetDefault("rate_limit.invalid_token.window", 10*time.Minute)
+	v.SetDefault("rate_limit.invalid_token.block", 30*time.Minute)
+
 	// Read config file
 	if err := v.ReadInConfig(); err != nil {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
@@ -102,21 +435,139 @@ func Load(configPath string) (*Config, error) {
 	v.BindEnv("database.username", "DB_USERNAME")
 	v.BindEnv("database.password", "DB_PASSWORD")
 	v.BindEnv("database.database", "DB_DATABASE")
+	v.BindEnv("database.read_timeout", "DB_READ_TIMEOUT")
+	v.BindEnv("database.write_timeout", "DB_WRITE_TIMEOUT")
+	v.BindEnv("database.log_level", "DB_LOG_LEVEL")
+	v.BindEnv("database.slow_query_threshold", "DB_SLOW_QUERY_THRESHOLD")
 
 	// Redis
 	v.BindEnv("redis.host", "REDIS_HOST")
 	v.BindEnv("redis.port", "REDIS_PORT")
 	v.BindEnv("redis.password", "REDIS_PASSWORD")
+	v.BindEnv("redis.key_prefix", "REDIS_KEY_PREFIX")
+	v.BindEnv("local_cache.size", "LOCAL_CACHE_SIZE")
+	v.BindEnv("local_cache.ttl", "LOCAL_CACHE_TTL")
 
 	// JWT
 	v.BindEnv("jwt.secret", "JWT_SECRET")
+	v.BindEnv("jwt.impersonation_expiration", "JWT_IMPERSONATION_EXPIRATION")
+	v.BindEnv("jwt.algorithm", "JWT_ALGORITHM")
+	v.BindEnv("jwt.private_key", "JWT_PRIVATE_KEY")
+	v.BindEnv("jwt.key_id", "JWT_KEY_ID")
 
 	// Encryption
 	v.BindEnv("encryption.key", "ENCRYPTION_KEY")
 
+	// Email
+	v.BindEnv("email.host", "SMTP_HOST")
+	v.BindEnv("email.port", "SMTP_PORT")
+	v.BindEnv("email.username", "SMTP_USERNAME")
+	v.BindEnv("email.password", "SMTP_PASSWORD")
+	v.BindEnv("email.from", "SMTP_FROM")
+
 	// Server
 	v.BindEnv("server.port", "SERVER_PORT")
 	v.BindEnv("server.mode", "SERVER_MODE")
+	v.BindEnv("server.max_body_size", "SERVER_MAX_BODY_SIZE")
+
+	// Submission limits
+	v.BindEnv("submission.max_text_length", "SUBMISSION_MAX_TEXT_LENGTH")
+	v.BindEnv("submission.max_table_rows", "SUBMISSION_MAX_TABLE_ROWS")
+	v.BindEnv("submission.resubmit_window", "SUBMISSION_RESUBMIT_WINDOW")
+	v.BindEnv("submission.number_prefix", "SUBMISSION_NUMBER_PREFIX")
+	v.BindEnv("submission.number_width", "SUBMISSION_NUMBER_WIDTH")
+
+	// Notifier
+	v.BindEnv("notifier.interval", "NOTIFIER_INTERVAL")
+	v.BindEnv("notifier.webhook_secret", "NOTIFIER_WEBHOOK_SECRET")
+
+	// Summary reports
+	v.BindEnv("reports.interval", "REPORTS_INTERVAL")
+	v.BindEnv("reports.send_hour", "REPORTS_SEND_HOUR")
+
+	// Preview links
+	v.BindEnv("onelink.preview_expiration", "ONELINK_PREVIEW_EXPIRATION")
+
+	// Export jobs
+	v.BindEnv("export.job_ttl", "EXPORT_JOB_TTL")
+
+	// Storage
+	v.BindEnv("storage.driver", "STORAGE_DRIVER")
+	v.BindEnv("storage.local_dir", "STORAGE_LOCAL_DIR")
+	v.BindEnv("storage.public_url", "STORAGE_PUBLIC_URL")
+	v.BindEnv("storage.signing_key", "STORAGE_SIGNING_KEY")
+	v.BindEnv("storage.presign_expiry", "STORAGE_PRESIGN_EXPIRY")
+	v.BindEnv("storage.retention", "STORAGE_RETENTION")
+	v.BindEnv("storage.cleanup_interval", "STORAGE_CLEANUP_INTERVAL")
+	v.BindEnv("storage.s3.endpoint", "STORAGE_S3_ENDPOINT")
+	v.BindEnv("storage.s3.region", "STORAGE_S3_REGION")
+	v.BindEnv("storage.s3.bucket", "STORAGE_S3_BUCKET")
+	v.BindEnv("storage.s3.access_key_id", "STORAGE_S3_ACCESS_KEY_ID")
+	v.BindEnv("storage.s3.secret_access_key", "STORAGE_S3_SECRET_ACCESS_KEY")
+	v.BindEnv("storage.s3.path_style", "STORAGE_S3_PATH_STYLE")
+
+	// Response retention
+	v.BindEnv("retention.responses", "RETENTION_RESPONSES")
+	v.BindEnv("retention.archive_password", "RETENTION_ARCHIVE_PASSWORD")
+	v.BindEnv("retention.hook_deliveries", "RETENTION_HOOK_DELIVERIES")
+
+	// Response import
+	v.BindEnv("import.max_file_size", "IMPORT_MAX_FILE_SIZE")
+	v.BindEnv("import.max_rows", "IMPORT_MAX_ROWS")
+	v.BindEnv("import.batch_size", "IMPORT_BATCH_SIZE")
+	v.BindEnv("option_sets.max_options", "OPTION_SETS_MAX_OPTIONS")
+	v.BindEnv("questions.max_per_survey", "QUESTIONS_MAX_PER_SURVEY")
+	v.BindEnv("questions.max_options", "QUESTIONS_MAX_OPTIONS")
+	v.BindEnv("questions.max_columns", "QUESTIONS_MAX_COLUMNS")
+
+	// Statistics
+	v.BindEnv("statistics.cache_threshold", "STATISTICS_CACHE_THRESHOLD")
+	v.BindEnv("statistics.reconcile_interval", "STATISTICS_RECONCILE_INTERVAL")
+
+	// Initial admin account
+	v.BindEnv("seed.enabled", "SEED_ENABLED")
+	v.BindEnv("seed.admin_username", "SEED_ADMIN_USERNAME")
+	v.BindEnv("seed.admin_password", "SEED_ADMIN_PASSWORD")
+	v.BindEnv("seed.admin_email", "SEED_ADMIN_EMAIL")
+	v.BindEnv("seed.allow_in_release", "SEED_ALLOW_IN_RELEASE")
+
+	// Compression
+	v.BindEnv("compression.enabled", "COMPRESSION_ENABLED")
+	v.BindEnv("compression.level", "COMPRESSION_LEVEL")
+	v.BindEnv("compression.min_size", "COMPRESSION_MIN_SIZE")
+
+	// Rate limits
+	v.BindEnv("rate_limit.link_validate.requests", "RATE_LIMIT_LINK_VALIDATE_REQUESTS")
+	v.BindEnv("rate_limit.link_validate.window", "RATE_LIMIT_LINK_VALIDATE_WINDOW")
+	v.BindEnv("rate_limit.survey_meta.requests", "RATE_LIMIT_SURVEY_META_REQUESTS")
+	v.BindEnv("rate_limit.survey_meta.window", "RATE_LIMIT_SURVEY_META_WINDOW")
+	v.BindEnv("rate_limit.invalid_token.attempts", "RATE_LIMIT_INVALID_TOKEN_ATTEMPTS")
+	v.BindEnv("rate_limit.invalid_token.window", "RATE_LIMIT_INVALID_TOKEN_WINDOW")
+	v.BindEnv("rate_limit.invalid_token.block", "RATE_LIMIT_INVALID_TOKEN_BLOCK")
+
+	// Per-account daily quotas
+	v.BindEnv("usage.share_links", "USAGE_SHARE_LINKS")
+	v.BindEnv("usage.exports", "USAGE_EXPORTS")
+
+	// Feature flags
+	v.BindEnv("features.environment", "FEATURES_ENVIRONMENT")
+
+	// Submission burst detection
+	v.BindEnv("anomaly.burst_submissions", "ANOMALY_BURST_SUBMISSIONS")
+	v.BindEnv("anomaly.burst_window", "ANOMALY_BURST_WINDOW")
+	v.BindEnv("anomaly.min_answer_time", "ANOMALY_MIN_ANSWER_TIME")
+
+	// Self-service accounts
+	v.BindEnv("account.registration", "ACCOUNT_REGISTRATION")
+	v.BindEnv("account.verification_ttl", "ACCOUNT_VERIFICATION_TTL")
+	v.BindEnv("account.verification_grace", "ACCOUNT_VERIFICATION_GRACE")
+	v.BindEnv("account.reset_ttl", "ACCOUNT_RESET_TTL")
+	v.BindEnv("account.login_alerts", "ACCOUNT_LOGIN_ALERTS")
+
+	// Vault
+	v.BindEnv("secrets.vault_addr", "VAULT_ADDR")
+	v.BindEnv("secrets.vault_token", "VAULT_TOKEN")
+	v.BindEnv("secrets.vault_path", "VAULT_PATH")
 
 	// Unmarshal config into struct
 	var config Config
@@ -124,6 +575,16 @@ func Load(configPath string) (*Config, error) {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
 
+	// Load secrets from files and Vault
+	if err := loadSecrets(&config); err != nil {
+		return nil, fmt.Errorf("failed to load secrets: %w", err)
+	}
+
+	// Signed local download links fall back to the JWT secret
+	if config.Storage.SigningKey == "" {
+		config.Storage.SigningKey = config.JWT.Secret
+	}
+
 	// Validate configuration
 	if err := validate(&config); err != nil {
 		return nil, fmt.Errorf("config validation failed: %w", err)
@@ -139,9 +600,25 @@ func validate(config *Config) error {
 		return fmt.Errorf("encryption key must be exactly 32 bytes, got %d bytes", len(config.Encryption.Key))
 	}
 
-	// Validate JWT secret is not empty
-	if config.JWT.Secret == "" {
-		return fmt.Errorf("JWT secret cannot be empty")
+	// Validate JWT secret is long enough for HS256
+	if len(config.JWT.Secret) < minJWTSecretLength {
+		return fmt.Errorf("JWT secret must be at least %d bytes, got %d bytes", minJWTSecretLength, len(config.JWT.Secret))
+	}
+	// Webhook payloads signed with an empty key could be forged by anyone
+	if config.Notifier.WebhookSecret == "" {
+		return fmt.Errorf("notifier webhook secret is required")
+	}
+	if config.JWT.ImpersonationExpiration <= 0 {
+		return fmt.Errorf("jwt impersonation expiration must be positive, got %v", config.JWT.ImpersonationExpiration)
+	}
+	switch config.JWT.Algorithm {
+	case "HS256":
+	case "RS256", "EdDSA":
+		if config.JWT.PrivateKey == "" {
+			return fmt.Errorf("jwt private key is required for %s", config.JWT.Algorithm)
+		}
+	default:
+		return fmt.Errorf("jwt algorithm must be HS256, RS256 or EdDSA, got %q", config.JWT.Algorithm)
 	}
 
 	// Validate database configuration
@@ -151,12 +628,106 @@ func validate(config *Config) error {
 	if config.Database.Database == "" {
 		return fmt.Errorf("database name cannot be empty")
 	}
+	switch config.Database.LogLevel {
+	case "silent", "error", "warn", "info":
+	default:
+		return fmt.Errorf("database log level must be silent, error, warn or info, got %q", config.Database.LogLevel)
+	}
+	if config.Database.SlowQueryThreshold < 0 {
+		return fmt.Errorf("database slow query threshold cannot be negative, got %v", config.Database.SlowQueryThreshold)
+	}
 
 	// Validate Redis configuration
 	if config.Redis.Host == "" {
 		return fmt.Errorf("redis host cannot be empty")
 	}
 
+	// Validate compression level
+	if config.Compression.Enabled && (config.Compression.Level < 1 || config.Compression.Level > 9) {
+		return fmt.Errorf("compression level must be between 1 and 9, got %d", config.Compression.Level)
+	}
+
+	// Validate report send hour
+	if config.Reports.SendHour < 0 || config.Reports.SendHour > 23 {
+		return fmt.Errorf("reports send hour must be between 0 and 23, got %d", config.Reports.SendHour)
+	}
+
+	// Validate storage driver
+	switch config.Storage.Driver {
+	case "local":
+		if config.Storage.LocalDir == "" {
+			return fmt.Errorf("storage local dir cannot be empty")
+		}
+	case "s3":
+		if config.Storage.S3.Endpoint == "" || config.Storage.S3.Bucket == "" {
+			return fmt.Errorf("storage s3 endpoint and bucket are required")
+		}
+	default:
+		return fmt.Errorf("storage driver must be local or s3, got %q", config.Storage.Driver)
+	}
+	// The retention sweep would otherwise delete exports that can still be downloaded
+	if config.Storage.Retention > 0 && config.Storage.Retention < config.Export.JobTTL {
+		return fmt.Errorf("storage retention must not be shorter than the export job ttl")
+	}
+
+	// Purged responses must be archived, and only complete days are purged
+	if config.Retention.Responses > 0 {
+		if config.Retention.Responses < 24*time.Hour {
+			return fmt.Errorf("response retention must be at least 24h")
+		}
+		if len(config.Retention.ArchivePassword) < minArchivePasswordLength {
+			return fmt.Errorf("retention archive password must be at least %d characters when response retention is set", minArchivePasswordLength)
+		}
+	}
+	if (config.Retention.Responses > 0 || config.Retention.HookDeliveries > 0) && config.Retention.BatchSize <= 0 {
+		return fmt.Errorf("retention batch size must be positive")
+	}
+
+	// Burst detection counts in windows of a positive length
+	if config.Anomaly.BurstSubmissions > 0 && config.Anomaly.BurstWindow <= 0 {
+		return fmt.Errorf("anomaly burst window must be positive when burst detection is enabled")
+	}
+	if config.Anomaly.MinAnswerTime < 0 {
+		return fmt.Errorf("anomaly min answer time cannot be negative")
+	}
+
+	// Verification and reset links must not be valid forever
+	if config.Account.VerificationTTL <= 0 || config.Account.ResetTTL <= 0 {
+		return fmt.Errorf("account verification and reset ttl must be positive")
+	}
+	if config.Account.VerificationGrace < 0 {
+		return fmt.Errorf("account verification grace cannot be negative")
+	}
+
+	// Validate respondent number format
+	if config.Submission.NumberWidth < 1 || config.Submission.NumberWidth > 10 {
+		return fmt.Errorf("submission number width must be between 1 and 10")
+	}
+	if len(config.Submission.NumberPrefix) > 20 {
+		return fmt.Errorf("submission number prefix must be at most 20 characters")
+	}
+
+	// Validate import limits
+	if config.Import.MaxRows <= 0 || config.Import.BatchSize <= 0 {
+		return fmt.Errorf("import max rows and batch size must be positive")
+	}
+
+	// Validate option import
+	if config.OptionSets.MaxOptions <= 0 {
+		return fmt.Errorf("option sets max options must be positive")
+	}
+	builtin := optionset.Names()
+	for _, name := range config.OptionSets.Enabled {
+		if !slices.Contains(builtin, name) {
+			return fmt.Errorf("unknown option set %q, available: %v", name, builtin)
+		}
+	}
+
+	// Validate question limits
+	if config.Questions.MaxPerSurvey <= 0 || config.Questions.MaxOptions <= 0 || config.Questions.MaxColumns <= 0 {
+		return fmt.Errorf("questions max per survey, max options and max columns must be positive")
+	}
+
 	// Validate server port
 	if config.Server.Port <= 0 || config.Server.Port > 65535 {
 		return fmt.Errorf("invalid server port: %d", config.Server.Port)
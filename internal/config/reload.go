@@ -0,0 +1,203 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// Store holds the active configuration and applies reloadable settings at runtime.
+// Only the settings listed in sectionReload can change without a restart; a reload that
+// touches any other setting is rejected as a whole.
+type Store struct {
+	path      string
+	current   atomic.Pointer[Config]
+	mu        sync.Mutex // serializes reloads
+	listeners []func(*Config)
+}
+
+// reloadMode tells how a config section is treated on reload
+type reloadMode int
+
+const (
+	restartRequired  reloadMode = iota // read once at startup; changes reject the reload
+	reloadable                         // replaced as a whole
+	partlyReloadable                   // the settings copied by applyReloadable change, all others require a restart
+)
+
+// sectionReload lists every config section by its mapstructure name. Sections that are
+// not listed are treated as requiring a restart.
+var sectionReload = map[string]reloadMode{
+	"server":      restartRequired,
+	"database":    partlyReloadable, // log_level and slow_query_threshold
+	"redis":       restartRequired,
+	"jwt":         restartRequired,
+	"encryption":  restartRequired,
+	"cors":        reloadable,
+	"onelink":     partlyReloadable, // default_expiration, max_expiration and preview_expiration
+	"email":       restartRequired,
+	"submission":  restartRequired,
+	"notifier":    restartRequired,
+	"reports":     restartRequired,
+	"export":      restartRequired,
+	"storage":     restartRequired,
+	"import":      restartRequired,
+	"statistics":  restartRequired,
+	"seed":        restartRequired,
+	"secrets":     restartRequired,
+	"compression": restartRequired,
+	"rate_limit":  reloadable,
+	"local_cache": restartRequired,
+	"traffic_log": reloadable,
+	"usage":       restartRequired,
+	"retention":   restartRequired,
+	"option_sets": restartRequired,
+	"questions":   restartRequired,
+	"features":    restartRequired,
+	"anomaly":     restartRequired,
+	"account":     restartRequired,
+}
+
+// NewStore creates a store serving cfg, which was loaded from path
+func NewStore(path string, cfg *Config) *Store {
+	if path != "" {
+		if abs, err := filepath.Abs(path); err == nil {
+			path = abs
+		}
+	}
+	s := &Store{path: path}
+	s.current.Store(cfg)
+	return s
+}
+
+// Get returns the active configuration. Callers must not modify it.
+func (s *Store) Get() *Config {
+	return s.current.Load()
+}
+
+// OnReload registers fn to be called with the new configuration after each applied reload,
+// for settings held outside the store. It must be called before Watch.
+func (s *Store) OnReload(fn func(*Config)) {
+	s.listeners = append(s.listeners, fn)
+}
+
+// Reload re-reads the configuration and applies the reloadable settings
+func (s *Store) Reload() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	next, err := Load(s.path)
+	if err != nil {
+		return err
+	}
+
+	current := s.Get()
+	if changed := immutableChanges(current, next); len(changed) > 0 {
+		return fmt.Errorf("settings that require a restart changed: %s", strings.Join(changed, ", "))
+	}
+
+	updated := applyReloadable(*current, next)
+	s.current.Store(&updated)
+	for _, fn := range s.listeners {
+		fn(&updated)
+	}
+	return nil
+}
+
+// Watch reloads the configuration on SIGHUP and whenever the config file changes,
+// until ctx is cancelled
+func (s *Store) Watch(ctx context.Context) error {
+	var events chan fsnotify.Event
+	var errs chan error
+	var watcher *fsnotify.Watcher
+	if s.path != "" {
+		var err error
+		watcher, err = fsnotify.NewWatcher()
+		if err != nil {
+			return fmt.Errorf("failed to create config watcher: %w", err)
+		}
+		// Watch the directory so editors and mounted volumes that replace the file are noticed
+		if err := watcher.Add(filepath.Dir(s.path)); err != nil {
+			watcher.Close()
+			return fmt.Errorf("failed to watch config directory: %w", err)
+		}
+		events, errs = watcher.Events, watcher.Errors
+	}
+
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+
+	go func() {
+		defer signal.Stop(hup)
+		if watcher != nil {
+			defer watcher.Close()
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-hup:
+				s.reload("SIGHUP")
+			case event := <-events:
+				if filepath.Clean(event.Name) == s.path && event.Has(fsnotify.Write|fsnotify.Create) {
+					s.reload("file change")
+				}
+			case err := <-errs:
+				log.Printf("Config watcher error: %v", err)
+			}
+		}
+	}()
+	return nil
+}
+
+// reload applies a reload and logs its outcome
+func (s *Store) reload(trigger string) {
+	if err := s.Reload(); err != nil {
+		log.Printf("Configuration reload (%s) rejected, keeping current settings: %v", trigger, err)
+		return
+	}
+	log.Printf("Configuration reloaded (%s)", trigger)
+}
+
+// applyReloadable copies the settings that may change at runtime from next into current
+func applyReloadable(current Config, next *Config) Config {
+	merged := reflect.ValueOf(&current).Elem()
+	updated := reflect.ValueOf(next).Elem()
+	for i := 0; i < merged.NumField(); i++ {
+		if sectionReload[merged.Type().Field(i).Tag.Get("mapstructure")] == reloadable {
+			merged.Field(i).Set(updated.Field(i))
+		}
+	}
+
+	current.Database.LogLevel = next.Database.LogLevel
+	current.Database.SlowQueryThreshold = next.Database.SlowQueryThreshold
+	current.OneLink.DefaultExpiration = next.OneLink.DefaultExpiration
+	current.OneLink.MaxExpiration = next.OneLink.MaxExpiration
+	current.OneLink.PreviewExpiration = next.OneLink.PreviewExpiration
+	return current
+}
+
+// immutableChanges lists the config sections whose non-reloadable settings differ
+func immutableChanges(current, next *Config) []string {
+	merged := reflect.ValueOf(applyReloadable(*current, next))
+	updated := reflect.ValueOf(*next)
+
+	var changed []string
+	for i := 0; i < merged.NumField(); i++ {
+		if !reflect.DeepEqual(merged.Field(i).Interface(), updated.Field(i).Interface()) {
+			changed = append(changed, merged.Type().Field(i).Tag.Get("mapstructure"))
+		}
+	}
+	return changed
+}
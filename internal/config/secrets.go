@@ -0,0 +1,127 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// SecretsConfig holds settings for loading secrets from HashiCorp Vault
+type SecretsConfig struct {
+	VaultAddr    string        `mapstructure:"vault_addr"`    // Vault server address; empty disables Vault
+	VaultToken   string        `mapstructure:"vault_token"`   // Token used to read the secret
+	VaultPath    string        `mapstructure:"vault_path"`    // KV path of the secret, e.g. secret/data/survey-system
+	VaultTimeout time.Duration `mapstructure:"vault_timeout"` // Timeout for the Vault request
+}
+
+// minJWTSecretLength is the minimum HMAC key size for HS256 (RFC 7518 section 3.2)
+const minJWTSecretLength = 32
+
+// minArchivePasswordLength is the minimum length of the response archive password
+const minArchivePasswordLength = 16
+
+// secretTarget ties a secret to its environment variable and Vault key
+type secretTarget struct {
+	env      string  // environment variable; <env>_FILE names a file holding the value
+	vaultKey string  // key in the Vault secret; empty if the secret is not read from Vault
+	value    *string // config field receiving the value
+}
+
+func secretTargets(config *Config) []secretTarget {
+	return []secretTarget{
+		{env: "JWT_SECRET", vaultKey: "jwt_secret", value: &config.JWT.Secret},
+		{env: "JWT_PRIVATE_KEY", vaultKey: "jwt_private_key", value: &config.JWT.PrivateKey},
+		{env: "DB_PASSWORD", vaultKey: "db_password", value: &config.Database.Password},
+		{env: "ENCRYPTION_KEY", vaultKey: "encryption_key", value: &config.Encryption.Key},
+		{env: "STORAGE_SIGNING_KEY", vaultKey: "storage_signing_key", value: &config.Storage.SigningKey},
+		{env: "STORAGE_S3_SECRET_ACCESS_KEY", vaultKey: "storage_s3_secret_access_key", value: &config.Storage.S3.SecretAccessKey},
+		{env: "RETENTION_ARCHIVE_PASSWORD", vaultKey: "retention_archive_password", value: &config.Retention.ArchivePassword},
+		{env: "NOTIFIER_WEBHOOK_SECRET", vaultKey: "notifier_webhook_secret", value: &config.Notifier.WebhookSecret},
+		{env: "VAULT_TOKEN", value: &config.Secrets.VaultToken},
+	}
+}
+
+// loadSecrets overrides secrets with values from *_FILE environment variables
+// (e.g. Docker secrets) and then from Vault when it is configured
+func loadSecrets(config *Config) error {
+	targets := secretTargets(config)
+
+	for _, target := range targets {
+		path := os.Getenv(target.env + "_FILE")
+		if path == "" {
+			continue
+		}
+		if os.Getenv(target.env) != "" {
+			return fmt.Errorf("both %s and %s_FILE are set", target.env, target.env)
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s_FILE: %w", target.env, err)
+		}
+		*target.value = strings.TrimRight(string(data), "\r\n")
+	}
+
+	if config.Secrets.VaultAddr == "" {
+		return nil
+	}
+
+	secrets, err := readVaultSecret(&config.Secrets)
+	if err != nil {
+		return err
+	}
+	for _, target := range targets {
+		if target.vaultKey == "" {
+			continue
+		}
+		if value, ok := secrets[target.vaultKey].(string); ok && value != "" {
+			*target.value = value
+		}
+	}
+	return nil
+}
+
+// readVaultSecret reads the key/value pairs stored at cfg.VaultPath
+// Both KV version 1 and version 2 responses are accepted
+func readVaultSecret(cfg *SecretsConfig) (map[string]interface{}, error) {
+	if cfg.VaultToken == "" || cfg.VaultPath == "" {
+		return nil, fmt.Errorf("vault_token and vault_path are required when vault_addr is set")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.VaultTimeout)
+	defer cancel()
+
+	url := strings.TrimRight(cfg.VaultAddr, "/") + "/v1/" + strings.TrimLeft(cfg.VaultPath, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Vault request: %w", err)
+	}
+	req.Header.Set("X-Vault-Token", cfg.VaultToken)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read secrets from Vault: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to read secrets from Vault: status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode Vault response: %w", err)
+	}
+
+	// KV version 2 nests the values under data.data
+	if nested, ok := body.Data["data"].(map[string]interface{}); ok {
+		return nested, nil
+	}
+	return body.Data, nil
+}
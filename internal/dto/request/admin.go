@@ -0,0 +1,31 @@
+package request
+
+// TransferSurveyRequest represents the request to hand a survey over to another user
+type TransferSurveyRequest struct {
+	UserID uint `json:"user_id" binding:"required"` // New owner
+}
+
+// CloneSurveyRequest represents the request to copy a survey into another user's account
+type CloneSurveyRequest struct {
+	UserID uint   `json:"user_id" binding:"required"` // Owner of the copy
+	Title  string `json:"title" binding:"max=200"`    // Defaults to the title of the original survey
+}
+
+// DeactivateUserRequest represents the request to deactivate a user account
+type DeactivateUserRequest struct {
+	TransferTo uint `json:"transfer_to"` // Reassigns all of the user's surveys to this user when set
+}
+
+// ImpersonateUserRequest represents the request to act as another user for support
+type ImpersonateUserRequest struct {
+	Reason string `json:"reason" binding:"required,max=500"` // Why the session is needed, e.g. a ticket number; kept in the audit log
+}
+
+// AuditLogQuery represents the query parameters of the audit log listing
+type AuditLogQuery struct {
+	ActorID         uint `form:"actor_id"`
+	TargetUserID    uint `form:"target_user_id"`
+	ImpersonationID uint `form:"impersonation_id"` // Entry of an impersonation session; lists it with the requests made in it
+	Page            int  `form:"page"`
+	PageSize        int  `form:"page_size"`
+}
@@ -10,7 +10,30 @@ type LoginRequest struct {
 type RegisterRequest struct {
 	Username string `json:"username" binding:"required,min=3,max=50"`
 	Password string `json:"password" binding:"required,min=6"`
-	Email    string `json:"email" binding:"omitempty,email,max=100"`
+	Email    string `json:"email" binding:"required,email,max=100"` // Receives the verification link
+}
+
+// VerifyEmailRequest represents the request to verify an email address
+type VerifyEmailRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// AccountEmailRequest represents a request that emails a link to the account with the
+// email address, i.e. resending the verification or a password reset
+type AccountEmailRequest struct {
+	Email string `json:"email" binding:"required,email,max=100"`
+}
+
+// ResetPasswordRequest represents the request to set a new password with a reset link's token
+type ResetPasswordRequest struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required,min=6"`
+}
+
+// LoginHistoryQuery represents the query of the login history listing
+type LoginHistoryQuery struct {
+	Page     int `form:"page"`
+	PageSize int `form:"page_size"`
 }
 
 // UpdateProfileRequest represents the request to update user profile
@@ -0,0 +1,11 @@
+package request
+
+// ChannelRequest represents the request to create or replace a notification channel
+type ChannelRequest struct {
+	Type           string `json:"type" binding:"required,oneof=slack dingtalk wecom"`
+	WebhookURL     string `json:"webhook_url" binding:"required,url,max=500"`
+	Secret         string `json:"secret" binding:"max=255"` // DingTalk signing secret
+	OnResponse     bool   `json:"on_response"`
+	DailyThreshold int    `json:"daily_threshold" binding:"min=0"`
+	Enabled        *bool  `json:"enabled"` // Defaults to true
+}
@@ -0,0 +1,6 @@
+package request
+
+// CommentRequest represents the request to add or edit a comment on a response
+type CommentRequest struct {
+	Content string `json:"content" binding:"required,max=5000"`
+}
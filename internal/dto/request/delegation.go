@@ -0,0 +1,11 @@
+package request
+
+import "time"
+
+// CreateDelegationRequest represents the request to issue a delegation token
+type CreateDelegationRequest struct {
+	Name        string                 `json:"name" binding:"required,max=100"`
+	PrefillData map[string]interface{} `json:"prefill_data"`                                                  // Prefill values locked into every link
+	AllowedKeys []string               `json:"allowed_keys" binding:"omitempty,max=50,dive,required,max=100"` // Other prefill keys the delegate may set
+	ExpiresAt   *time.Time             `json:"expires_at"`                                                    // Optional; the token never expires without it
+}
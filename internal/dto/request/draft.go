@@ -0,0 +1,8 @@
+package request
+
+// SaveDraftRequest represents the request to save a response draft and email a resume link
+type SaveDraftRequest struct {
+	Token   string          `json:"token" binding:"required"`
+	Email   string          `json:"email" binding:"required,email,max=100"`
+	Answers []AnswerRequest `json:"answers"`
+}
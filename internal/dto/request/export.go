@@ -0,0 +1,23 @@
+package request
+
+// ExportResponsesRequest represents the query parameters for exporting responses
+// The JSON names are used when the same options are sent in an export job body
+type ExportResponsesRequest struct {
+	Format      string `form:"format" json:"format" binding:"omitempty,oneof=csv excel"`
+	BOM         bool   `form:"bom" json:"bom"`                                                           // Prepend UTF-8 BOM (CSV only)
+	Delimiter   string `form:"delimiter" json:"delimiter" binding:"omitempty,oneof=comma semicolon tab"` // CSV field delimiter
+	LineEnding  string `form:"line_ending" json:"line_ending" binding:"omitempty,oneof=lf crlf"`         // CSV line ending style
+	Layout      string `form:"layout" json:"layout" binding:"omitempty,oneof=long wide"`                 // long: one row per table row, wide: one row per response
+	TableFormat string `form:"table_format" json:"table_format" binding:"omitempty,oneof=columns json"`  // Table serialization in wide layout
+	From        string `form:"from" json:"from" binding:"omitempty,datetime=2006-01-02"`                 // First submission day to include
+	To          string `form:"to" json:"to" binding:"omitempty,datetime=2006-01-02"`                     // Last submission day to include
+	Campaign    string `form:"campaign" json:"campaign" binding:"omitempty,max=100"`                     // Only responses from links with this campaign label
+	IncludeTest bool   `form:"include_test" json:"include_test"`                                         // Also export responses submitted through test links
+}
+
+// CreateExportJobRequest represents the request to start an asynchronous export
+type CreateExportJobRequest struct {
+	ExportResponsesRequest
+	Encrypt  bool   `json:"encrypt"`                                    // Wrap the file in an AES-256 encrypted ZIP
+	Password string `json:"password" binding:"omitempty,min=8,max=128"` // Archive password; generated when encrypting without one
+}
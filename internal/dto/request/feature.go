@@ -0,0 +1,12 @@
+package request
+
+// SetFeatureRequest represents the request body for overriding a feature flag at runtime
+type SetFeatureRequest struct {
+	Enabled *bool `json:"enabled" binding:"required"`
+	UserID  uint  `json:"user_id"` // Overrides the flag for this user only when set
+}
+
+// FeatureOverrideQuery represents the query parameters for removing a feature flag override
+type FeatureOverrideQuery struct {
+	UserID uint `form:"user_id"` // Removes the override of this user instead of the one for everyone
+}
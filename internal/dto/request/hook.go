@@ -0,0 +1,21 @@
+package request
+
+// SubscribeHookRequest represents a REST Hooks subscription, as sent by Zapier or Make
+type SubscribeHookRequest struct {
+	SurveyID  uint   `json:"survey_id" binding:"required"`
+	Event     string `json:"event" binding:"required,oneof=response.submitted"`
+	TargetURL string `json:"target_url" binding:"required,url,max=500"`
+}
+
+// HookSampleQuery represents the query of the sample payload endpoint
+type HookSampleQuery struct {
+	SurveyID uint   `form:"survey_id" binding:"required"`
+	Event    string `form:"event" binding:"omitempty,oneof=response.submitted"` // Defaults to response.submitted
+}
+
+// HookDeliveryQuery represents the query of the hook delivery listing
+type HookDeliveryQuery struct {
+	Failed   bool `form:"failed"` // Only failed attempts
+	Page     int  `form:"page"`
+	PageSize int  `form:"page_size"`
+}
@@ -0,0 +1,8 @@
+package request
+
+// ImportResponsesRequest represents the options of a response import
+// The file itself is uploaded as the multipart field "file"
+type ImportResponsesRequest struct {
+	DryRun      bool `form:"dry_run"`      // Validate the file without storing responses
+	SkipInvalid bool `form:"skip_invalid"` // Store the valid rows even if other rows have errors
+}
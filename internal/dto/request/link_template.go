@@ -0,0 +1,11 @@
+package request
+
+// LinkTemplateRequest represents the request to create or replace a link template
+type LinkTemplateRequest struct {
+	Name           string                 `json:"name" binding:"required,max=100"`
+	PrefillData    map[string]interface{} `json:"prefill_data"`                                               // Fixed prefill values copied into every link
+	Variables      []string               `json:"variables" binding:"omitempty,max=50,dive,required,max=100"` // Prefill keys whose values are given per link
+	ExpiresInHours int                    `json:"expires_in_hours" binding:"min=0,max=8760"`                  // 0 uses the survey or global default
+	RedirectURL    string                 `json:"redirect_url" binding:"omitempty,url,max=500"`
+	Campaign       string                 `json:"campaign" binding:"max=100"`
+}
@@ -5,27 +5,65 @@ import "survey-system/internal/model"
 // CreateQuestionRequest represents the request to create a question
 type CreateQuestionRequest struct {
 	SurveyID    uint                 `json:"survey_id" binding:"required"`
-	Type        string               `json:"type" binding:"required,oneof=text single multiple table"`
+	Type        string               `json:"type" binding:"required,oneof=text single multiple table nps slider"`
 	Title       string               `json:"title" binding:"required,max=500"`
 	Description string               `json:"description" binding:"max=5000"`
 	Required    bool                 `json:"required"`
 	Order       *int                 `json:"order" binding:"required,min=0"`
 	Config      model.QuestionConfig `json:"config"`
 	PrefillKey  string               `json:"prefill_key" binding:"max=100"`
+	PrefillType string               `json:"prefill_type" binding:"omitempty,oneof=string number option"`
+	LockPrefill bool                 `json:"lock_prefill"` // Prefilled answers are read-only for respondents
+	Hidden      bool                 `json:"hidden"`       // Answered only from prefill data, never shown to respondents
+	Sensitive   bool                 `json:"sensitive"`    // Answers are redacted from debug traffic logs
+
+	Pool    string `json:"pool" binding:"max=50"`    // Key of a question pool of the survey; empty shows the question to every respondent
+	Variant string `json:"variant" binding:"max=50"` // Survey variant receiving the question; empty shows it in every variant
 }
 
 // UpdateQuestionRequest represents the request to update a question
 type UpdateQuestionRequest struct {
-	Type        string               `json:"type" binding:"required,oneof=text single multiple table"`
+	Type        string               `json:"type" binding:"required,oneof=text single multiple table nps slider"`
 	Title       string               `json:"title" binding:"required,max=500"`
 	Description string               `json:"description" binding:"max=5000"`
 	Required    bool                 `json:"required"`
 	Order       *int                 `json:"order" binding:"required,min=0"`
 	Config      model.QuestionConfig `json:"config"`
 	PrefillKey  string               `json:"prefill_key" binding:"max=100"`
+	PrefillType string               `json:"prefill_type" binding:"omitempty,oneof=string number option"`
+	LockPrefill bool                 `json:"lock_prefill"` // Prefilled answers are read-only for respondents
+	Hidden      bool                 `json:"hidden"`       // Answered only from prefill data, never shown to respondents
+	Sensitive   bool                 `json:"sensitive"`    // Answers are redacted from debug traffic logs
+
+	Pool    string `json:"pool" binding:"max=50"`    // Key of a question pool of the survey; empty shows the question to every respondent
+	Variant string `json:"variant" binding:"max=50"` // Survey variant receiving the question; empty shows it in every variant
+
+	OptionRemoval
+}
+
+// OptionRemoval tells how an edit removing choice options treats the options chosen in responses
+type OptionRemoval struct {
+	// Removed options chosen in responses are replaced in the stored answers by the mapped options
+	OptionRemap        map[string]string `json:"option_remap"`
+	ForceOptionRemoval bool              `json:"force_option_removal"` // Remove options chosen in responses without a remap
 }
 
 // ReorderQuestionsRequest represents the request to reorder questions
 type ReorderQuestionsRequest struct {
 	QuestionIDs []uint `json:"question_ids" binding:"required,min=1"`
 }
+
+// ImportQuestionOptionsRequest represents the request to import choice options from CSV text or a built-in set
+// CSV rows hold "label", "id,label" or "id,label,score"; a header row naming these columns is skipped
+type ImportQuestionOptionsRequest struct {
+	CSV  string `json:"csv" binding:"required_without=Set,excluded_with=Set"`
+	Set  string `json:"set" binding:"required_without=CSV,max=50"`     // Name of a built-in option set, e.g. "countries"
+	Mode string `json:"mode" binding:"omitempty,oneof=append replace"` // append (default) keeps existing options, replace drops them
+
+	OptionRemoval
+}
+
+// RestoreQuestionRequest represents the optional body of a question restore
+type RestoreQuestionRequest struct {
+	OptionRemoval
+}
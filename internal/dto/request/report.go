@@ -0,0 +1,7 @@
+package request
+
+// SubscribeReportRequest represents the request to subscribe to summary reports of a survey
+type SubscribeReportRequest struct {
+	Frequency string `json:"frequency" binding:"required,oneof=daily weekly"`
+	Email     string `json:"email" binding:"omitempty,email,max=100"` // Defaults to the owner's email
+}
@@ -2,12 +2,32 @@ package request
 
 // SubmitResponseRequest represents the request to submit a survey response
 type SubmitResponseRequest struct {
-	Token   string                   `json:"token" binding:"required"`
-	Answers []AnswerRequest          `json:"answers" binding:"required,min=1"`
+	Token    string          `json:"token" binding:"required"`
+	Answers  []AnswerRequest `json:"answers" binding:"required,min=1"`
+	Honeypot string          `json:"honeypot,omitempty"` // Value of the honeypot field; people leave it empty
 }
 
 // AnswerRequest represents an answer to a single question
 type AnswerRequest struct {
 	QuestionID uint        `json:"question_id" binding:"required"`
 	Value      interface{} `json:"value" binding:"required"`
+	DurationMs int64       `json:"duration_ms" binding:"min=0,max=86400000"` // Milliseconds spent on the question; optional
+}
+
+// UpdateReviewStatusRequest sets the review status of responses in bulk
+// Exactly one of ids or filter selects the responses
+type UpdateReviewStatusRequest struct {
+	IDs    []uint              `json:"ids" binding:"omitempty,max=1000"`
+	Filter *ReviewStatusFilter `json:"filter"`
+	Status string              `json:"status" binding:"required,oneof=pending reviewed rejected"`
+}
+
+// ReviewStatusFilter selects responses as the response listing filters do
+type ReviewStatusFilter struct {
+	Campaign     string `json:"campaign" binding:"max=100"`
+	From         string `json:"from" binding:"omitempty,datetime=2006-01-02"` // First submission day to include
+	To           string `json:"to" binding:"omitempty,datetime=2006-01-02"`   // Last submission day to include
+	Flagged      bool   `json:"flagged"`                                      // Only responses flagged as suspicious
+	IncludeTest  bool   `json:"include_test"`
+	ReviewStatus string `json:"review_status" binding:"omitempty,oneof=pending reviewed rejected"` // Only responses currently in this status
 }
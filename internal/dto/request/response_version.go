@@ -0,0 +1,8 @@
+package request
+
+// ResponseDiffRequest represents the query parameters for comparing two versions of a response
+// From defaults to the version before To, and To to the current version
+type ResponseDiffRequest struct {
+	From int `form:"from" binding:"omitempty,min=1"`
+	To   int `form:"to" binding:"omitempty,min=1"`
+}
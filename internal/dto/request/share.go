@@ -4,6 +4,46 @@ import "time"
 
 // GenerateShareLinkRequest represents the request to generate a share link
 type GenerateShareLinkRequest struct {
-	PrefillData map[string]interface{} `json:"prefill_data"` // Map of prefill_key to value
-	ExpiresAt   *time.Time             `json:"expires_at"`   // Optional expiration time
+	PrefillData  map[string]interface{} `json:"prefill_data"`                                 // Map of prefill_key to value
+	ExpiresAt    *time.Time             `json:"expires_at"`                                   // Optional expiration time
+	RedirectURL  string                 `json:"redirect_url" binding:"omitempty,url,max=500"` // Where respondents are sent after submitting
+	Campaign     string                 `json:"campaign" binding:"max=100"`                   // Distribution channel label, e.g. newsletter or utm_campaign
+	RespondentID string                 `json:"respondent_id" binding:"max=255"`              // Binds the link to a known respondent who may respond only once
+	Test         bool                   `json:"test"`                                         // Flags responses through the link as test data
+	TemplateID   uint                   `json:"template_id"`                                  // Link template supplying fixed prefill values and defaults
+	Variant      string                 `json:"variant" binding:"max=50"`                     // Survey variant of the link; assigned at random when empty
+}
+
+// GeneratePreviewLinkRequest represents the request to generate a survey preview link
+type GeneratePreviewLinkRequest struct {
+	PrefillData map[string]interface{} `json:"prefill_data"`             // Map of prefill_key to value, as for share links
+	Variant     string                 `json:"variant" binding:"max=50"` // Survey variant to preview; assigned at random when empty
+}
+
+// RevokeLinksRequest selects the links of a survey to revoke in bulk
+// At least one of created_from, created_to or campaign is required
+type RevokeLinksRequest struct {
+	CreatedFrom string `json:"created_from" binding:"omitempty,datetime=2006-01-02"` // First creation day to include
+	CreatedTo   string `json:"created_to" binding:"omitempty,datetime=2006-01-02"`   // Last creation day to include
+	Campaign    string `json:"campaign" binding:"max=100"`
+	UnusedOnly  *bool  `json:"unused_only"` // Defaults to true; used links cannot be opened again anyway
+	DryRun      bool   `json:"dry_run"`     // Count the matching links without revoking them
+}
+
+// GenerateLinkPoolRequest represents the request to pre-generate links without prefill data,
+// e.g. to print them before an event and bind respondents to them on site
+type GenerateLinkPoolRequest struct {
+	Count       int        `json:"count" binding:"required,min=1,max=1000"`
+	ExpiresAt   *time.Time `json:"expires_at"` // Optional expiration time, as for share links
+	RedirectURL string     `json:"redirect_url" binding:"omitempty,url,max=500"`
+	Campaign    string     `json:"campaign" binding:"max=100"`
+	Test        bool       `json:"test"`
+	Variant     string     `json:"variant" binding:"max=50"` // Survey variant of every link; assigned per link at random when empty
+}
+
+// ClaimPoolLinkRequest represents the request to bind prefill data to a pool link
+type ClaimPoolLinkRequest struct {
+	Token        string                 `json:"token"` // Pool link to claim; the oldest unclaimed one when empty
+	PrefillData  map[string]interface{} `json:"prefill_data"`
+	RespondentID string                 `json:"respondent_id" binding:"max=255"`
 }
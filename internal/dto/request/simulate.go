@@ -0,0 +1,7 @@
+package request
+
+// SimulateResponseRequest represents hypothetical answers checked by the survey simulator
+type SimulateResponseRequest struct {
+	Answers     []AnswerRequest        `json:"answers"`
+	PrefillData map[string]interface{} `json:"prefill_data"` // Prefill data of the simulated link, answering hidden questions
+}
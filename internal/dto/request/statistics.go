@@ -0,0 +1,13 @@
+package request
+
+// CompareStatisticsRequest represents the query parameters for comparing statistics
+// The current period is the survey in the URL limited to from/to; the base period is
+// base_survey_id (defaulting to the same survey) limited to base_from/base_to
+type CompareStatisticsRequest struct {
+	From         string `form:"from" binding:"omitempty,datetime=2006-01-02"`
+	To           string `form:"to" binding:"omitempty,datetime=2006-01-02"`
+	BaseSurveyID uint   `form:"base_survey_id"`
+	BaseFrom     string `form:"base_from" binding:"omitempty,datetime=2006-01-02"`
+	BaseTo       string `form:"base_to" binding:"omitempty,datetime=2006-01-02"`
+	IncludeTest  bool   `form:"include_test"` // Also compare responses submitted through test links
+}
@@ -2,12 +2,82 @@ package request
 
 // CreateSurveyRequest represents the request to create a survey
 type CreateSurveyRequest struct {
-	Title       string `json:"title" binding:"required,max=200"`
-	Description string `json:"description" binding:"max=5000"`
+	Title        string   `json:"title" binding:"required,max=200"`
+	Description  string   `json:"description" binding:"max=5000"`
+	EmbedEnabled bool     `json:"embed_enabled"`
+	EmbedDomains []string `json:"embed_domains" binding:"omitempty,max=50,dive,required,max=255"`
+	AllowedIPs   []string `json:"allowed_ips" binding:"omitempty,max=100,dive,required,max=64"`
+	Anonymous    bool     `json:"anonymous"` // Do not store IP address, user agent or respondent identity of responses
+
+	ExpiryNotifyHours int    `json:"expiry_notify_hours" binding:"min=0,max=168"`
+	ExpiryWebhookURL  string `json:"expiry_webhook_url" binding:"omitempty,url,max=500"`
+	ExpiryNotifyEmail string `json:"expiry_notify_email" binding:"omitempty,email,max=255"`
+
+	LinkDefaultExpiryHours int `json:"link_default_expiry_hours" binding:"min=0,max=8760"` // 0 uses onelink.default_expiration
+	LinkMaxExpiryHours     int `json:"link_max_expiry_hours" binding:"min=0,max=8760"`     // 0 uses onelink.max_expiration
+
+	EditWindowMinutes int `json:"edit_window_minutes" binding:"min=0,max=43200"` // 0 disables respondent corrections
+	TimeLimitMinutes  int `json:"time_limit_minutes" binding:"min=0,max=1440"`   // 0 disables the response time limit
+
+	Assessment  bool    `json:"assessment"`                           // Score responses against the correct answers of the questions
+	ShowScore   bool    `json:"show_score"`                           // Return the score to respondents when they submit
+	PassPercent float64 `json:"pass_percent" binding:"min=0,max=100"` // 0 disables pass/fail grading
+
+	NoResponseReminderDays int    `json:"no_response_reminder_days" binding:"min=0,max=365"` // 0 disables the reminder
+	ReminderWebhookURL     string `json:"reminder_webhook_url" binding:"omitempty,url,max=500"`
+
+	HoneypotAction string `json:"honeypot_action" binding:"omitempty,oneof=flag discard"` // Empty disables the honeypot
+
+	Quotas []QuotaRuleRequest `json:"quotas" binding:"omitempty,max=50,dive"`
+
+	QuestionPools []QuestionPoolRequest `json:"question_pools" binding:"omitempty,max=20,dive"`
+	Variants      []string              `json:"variants" binding:"omitempty,max=10,dive,required,max=50"` // A/B variant keys; none or at least two
 }
 
 // UpdateSurveyRequest represents the request to update a survey
 type UpdateSurveyRequest struct {
-	Title       string `json:"title" binding:"required,max=200"`
-	Description string `json:"description" binding:"max=5000"`
+	Title        string   `json:"title" binding:"required,max=200"`
+	Description  string   `json:"description" binding:"max=5000"`
+	EmbedEnabled bool     `json:"embed_enabled"`
+	EmbedDomains []string `json:"embed_domains" binding:"omitempty,max=50,dive,required,max=255"`
+	AllowedIPs   []string `json:"allowed_ips" binding:"omitempty,max=100,dive,required,max=64"`
+	Anonymous    bool     `json:"anonymous"` // Do not store IP address, user agent or respondent identity of responses
+
+	ExpiryNotifyHours int    `json:"expiry_notify_hours" binding:"min=0,max=168"`
+	ExpiryWebhookURL  string `json:"expiry_webhook_url" binding:"omitempty,url,max=500"`
+	ExpiryNotifyEmail string `json:"expiry_notify_email" binding:"omitempty,email,max=255"`
+
+	LinkDefaultExpiryHours int `json:"link_default_expiry_hours" binding:"min=0,max=8760"` // 0 uses onelink.default_expiration
+	LinkMaxExpiryHours     int `json:"link_max_expiry_hours" binding:"min=0,max=8760"`     // 0 uses onelink.max_expiration
+
+	EditWindowMinutes int `json:"edit_window_minutes" binding:"min=0,max=43200"` // 0 disables respondent corrections
+	TimeLimitMinutes  int `json:"time_limit_minutes" binding:"min=0,max=1440"`   // 0 disables the response time limit
+
+	Assessment  bool    `json:"assessment"`                           // Score responses against the correct answers of the questions
+	ShowScore   bool    `json:"show_score"`                           // Return the score to respondents when they submit
+	PassPercent float64 `json:"pass_percent" binding:"min=0,max=100"` // 0 disables pass/fail grading
+
+	NoResponseReminderDays int    `json:"no_response_reminder_days" binding:"min=0,max=365"` // 0 disables the reminder
+	ReminderWebhookURL     string `json:"reminder_webhook_url" binding:"omitempty,url,max=500"`
+
+	HoneypotAction string `json:"honeypot_action" binding:"omitempty,oneof=flag discard"` // Empty disables the honeypot
+
+	Quotas []QuotaRuleRequest `json:"quotas" binding:"omitempty,max=50,dive"`
+
+	QuestionPools []QuestionPoolRequest `json:"question_pools" binding:"omitempty,max=20,dive"`
+	Variants      []string              `json:"variants" binding:"omitempty,max=10,dive,required,max=50"` // A/B variant keys; none or at least two
+}
+
+// QuotaRuleRequest represents a response quota for one prefill segment
+type QuotaRuleRequest struct {
+	Field      string `json:"field" binding:"required,max=100"`
+	Value      string `json:"value" binding:"required,max=255"`
+	Limit      int    `json:"limit" binding:"required,min=1"`
+	CloseLinks bool   `json:"close_links"`
+}
+
+// QuestionPoolRequest represents a pool of questions of which each respondent receives a random subset
+type QuestionPoolRequest struct {
+	Key  string `json:"key" binding:"required,max=50"`
+	Draw int    `json:"draw" binding:"required,min=1"`
 }
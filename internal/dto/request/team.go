@@ -0,0 +1,16 @@
+package request
+
+// TeamRequest represents the request to create or rename a team
+type TeamRequest struct {
+	Name string `json:"name" binding:"required,max=100"`
+}
+
+// AddTeamMemberRequest represents the request to add a user to a team
+type AddTeamMemberRequest struct {
+	Username string `json:"username" binding:"required,max=50"`
+}
+
+// SetSurveyTeamRequest shares a survey with a team; a null team_id stops sharing it
+type SetSurveyTeamRequest struct {
+	TeamID *uint `json:"team_id"`
+}
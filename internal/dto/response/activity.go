@@ -0,0 +1,17 @@
+package response
+
+import "time"
+
+// ActivityItem is one entry of a survey's activity feed
+// Consecutive link generations and edits of the same question are merged into one item
+type ActivityItem struct {
+	Type       string    `json:"type"` // survey.published, question.updated, link.generated, responses.received, ...
+	OccurredAt time.Time `json:"occurred_at"`
+	Count      int64     `json:"count"`                 // Merged events, or responses received on the day
+	ActorID    uint      `json:"actor_id,omitempty"`    // User who made the change
+	QuestionID *uint     `json:"question_id,omitempty"` // Set for question events
+	Title      string    `json:"title,omitempty"`       // Question title
+	Campaign   string    `json:"campaign,omitempty"`    // Campaign label of generated links
+	Subnet     string    `json:"subnet,omitempty"`      // Network of a submission burst
+	Date       string    `json:"date,omitempty"`        // Day of responses.received items, YYYY-MM-DD
+}
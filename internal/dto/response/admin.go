@@ -0,0 +1,63 @@
+package response
+
+import (
+	"time"
+
+	"survey-system/internal/model"
+)
+
+// DeactivateUserResponse represents the response after deactivating a user account
+type DeactivateUserResponse struct {
+	User               UserResponse `json:"user"`
+	TransferredSurveys []uint       `json:"transferred_surveys"` // IDs of the surveys reassigned to transfer_to
+}
+
+// ImpersonationResponse represents the token issued for acting as another user
+type ImpersonationResponse struct {
+	Token           string       `json:"token"`
+	ExpiresAt       time.Time    `json:"expires_at"`
+	User            UserResponse `json:"user"`             // The impersonated user
+	ImpersonationID uint         `json:"impersonation_id"` // Audit log entry of the session
+}
+
+// AuditLogResponse represents an entry of the audit log
+type AuditLogResponse struct {
+	ID              uint       `json:"id"`
+	ActorID         uint       `json:"actor_id"`
+	ActorName       string     `json:"actor_name"`
+	Action          string     `json:"action"`
+	TargetUserID    *uint      `json:"target_user_id,omitempty"`
+	ImpersonationID *uint      `json:"impersonation_id,omitempty"`
+	Reason          string     `json:"reason,omitempty"`
+	Method          string     `json:"method,omitempty"`
+	Path            string     `json:"path,omitempty"`
+	Status          int        `json:"status,omitempty"`
+	IPAddress       string     `json:"ip_address"`
+	ExpiresAt       *time.Time `json:"expires_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// PaginatedAuditLogResponse represents a page of the audit log
+type PaginatedAuditLogResponse struct {
+	Data []AuditLogResponse `json:"data"`
+	Meta PaginationMeta     `json:"meta"`
+}
+
+// ToAuditLogResponse converts an AuditLog model with its actor preloaded to AuditLogResponse
+func ToAuditLogResponse(entry *model.AuditLog) AuditLogResponse {
+	return AuditLogResponse{
+		ID:              entry.ID,
+		ActorID:         entry.ActorID,
+		ActorName:       entry.Actor.Username,
+		Action:          entry.Action,
+		TargetUserID:    entry.TargetUserID,
+		ImpersonationID: entry.ImpersonationID,
+		Reason:          entry.Reason,
+		Method:          entry.Method,
+		Path:            entry.Path,
+		Status:          entry.Status,
+		IPAddress:       entry.IPAddress,
+		ExpiresAt:       entry.ExpiresAt,
+		CreatedAt:       entry.CreatedAt,
+	}
+}
@@ -0,0 +1,23 @@
+package response
+
+// AssessmentStatisticsResponse grades the scored responses of an assessment
+type AssessmentStatisticsResponse struct {
+	SurveyID       uint             `json:"survey_id"`
+	Responses      int              `json:"responses"`       // Responses with a score
+	AveragePercent float64          `json:"average_percent"` // Average score as a percentage of the maximum score
+	PassPercent    float64          `json:"pass_percent"`
+	Passed         *int             `json:"passed,omitempty"`    // Only when the survey sets a pass threshold
+	PassRate       *float64         `json:"pass_rate,omitempty"` // Percent of responses passing
+	Items          []ItemDifficulty `json:"items"`               // Scored questions in survey order
+}
+
+// ItemDifficulty describes how often a scored question was answered correctly
+type ItemDifficulty struct {
+	QuestionID uint    `json:"question_id"`
+	Title      string  `json:"title"`
+	Points     float64 `json:"points"`
+	Exposed    int     `json:"exposed"`    // Scored responses the question was shown to
+	Answered   int     `json:"answered"`   // Exposed responses answering the question
+	Correct    int     `json:"correct"`    // Exposed responses answering it correctly
+	Difficulty float64 `json:"difficulty"` // Percent of exposed responses answering correctly
+}
@@ -1,6 +1,10 @@
 package response
 
-import "time"
+import (
+	"time"
+
+	"survey-system/internal/model"
+)
 
 // LoginResponse represents the response after successful login
 type LoginResponse struct {
@@ -10,11 +14,71 @@ type LoginResponse struct {
 
 // UserResponse represents user information in responses
 type UserResponse struct {
-	ID        uint      `json:"id"`
-	Username  string    `json:"username"`
-	Email     string    `json:"email"`
-	Role      string    `json:"role"`
-	CreatedAt time.Time `json:"created_at"`
+	ID            uint       `json:"id"`
+	Username      string     `json:"username"`
+	Email         string     `json:"email"`
+	Role          string     `json:"role"`
+	DeactivatedAt *time.Time `json:"deactivated_at,omitempty"`
+	LastLoginAt   *time.Time `json:"last_login_at,omitempty"`
+	LastLoginIP   string     `json:"last_login_ip,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// ToUserResponse converts a model.User to UserResponse
+func ToUserResponse(user *model.User) UserResponse {
+	return UserResponse{
+		ID:            user.ID,
+		Username:      user.Username,
+		Email:         user.Email,
+		Role:          user.Role,
+		DeactivatedAt: user.DeactivatedAt,
+		LastLoginAt:   user.LastLoginAt,
+		LastLoginIP:   user.LastLoginIP,
+		CreatedAt:     user.CreatedAt,
+	}
+}
+
+// SessionResponse represents an active login session of the current user
+type SessionResponse struct {
+	ID         uint       `json:"id"`
+	UserAgent  string     `json:"user_agent"`
+	IPAddress  string     `json:"ip_address"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	Current    bool       `json:"current"` // The session of the token the request was made with
+}
+
+// LoginRecordResponse represents an attempt to log in to the current user's account
+type LoginRecordResponse struct {
+	ID          uint      `json:"id"`
+	Succeeded   bool      `json:"succeeded"`
+	Failure     string    `json:"failure,omitempty"` // invalid_password, account_deactivated or email_not_verified
+	IPAddress   string    `json:"ip_address"`
+	UserAgent   string    `json:"user_agent"`
+	NewDevice   bool      `json:"new_device"`   // First successful login with the user agent
+	NewLocation bool      `json:"new_location"` // First successful login from the /24 or /64 subnet
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// ToLoginRecordResponse converts a model.LoginRecord to LoginRecordResponse
+func ToLoginRecordResponse(record *model.LoginRecord) LoginRecordResponse {
+	return LoginRecordResponse{
+		ID:          record.ID,
+		Succeeded:   record.Succeeded,
+		Failure:     record.Failure,
+		IPAddress:   record.IPAddress,
+		UserAgent:   record.UserAgent,
+		NewDevice:   record.NewDevice,
+		NewLocation: record.NewLocation,
+		CreatedAt:   record.CreatedAt,
+	}
+}
+
+// PaginatedLoginHistoryResponse represents a page of the current user's login history
+type PaginatedLoginHistoryResponse struct {
+	Data []LoginRecordResponse `json:"data"`
+	Meta PaginationMeta        `json:"meta"`
 }
 
 // RegisterResponse represents the response after successful registration
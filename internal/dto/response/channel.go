@@ -0,0 +1,37 @@
+package response
+
+import (
+	"time"
+
+	"survey-system/internal/model"
+)
+
+// ChannelResponse represents a notification channel; the signing secret is never returned
+type ChannelResponse struct {
+	ID             uint      `json:"id"`
+	SurveyID       uint      `json:"survey_id"`
+	Type           string    `json:"type"`
+	WebhookURL     string    `json:"webhook_url"`
+	HasSecret      bool      `json:"has_secret"`
+	OnResponse     bool      `json:"on_response"`
+	DailyThreshold int       `json:"daily_threshold"`
+	Enabled        bool      `json:"enabled"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// ToChannelResponse converts a NotificationChannel model to ChannelResponse
+func ToChannelResponse(channel *model.NotificationChannel) ChannelResponse {
+	return ChannelResponse{
+		ID:             channel.ID,
+		SurveyID:       channel.SurveyID,
+		Type:           channel.Type,
+		WebhookURL:     channel.WebhookURL,
+		HasSecret:      channel.Secret != "",
+		OnResponse:     channel.OnResponse,
+		DailyThreshold: channel.DailyThreshold,
+		Enabled:        channel.Enabled,
+		CreatedAt:      channel.CreatedAt,
+		UpdatedAt:      channel.UpdatedAt,
+	}
+}
@@ -0,0 +1,33 @@
+package response
+
+import (
+	"time"
+
+	"survey-system/internal/model"
+)
+
+// CommentResponse represents a comment on a response
+type CommentResponse struct {
+	ID         uint      `json:"id"`
+	ResponseID uint      `json:"response_id"`
+	AuthorID   uint      `json:"author_id"`
+	AuthorName string    `json:"author_name"`
+	Content    string    `json:"content"`
+	Edited     bool      `json:"edited"` // Whether the comment was changed after it was posted
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// ToCommentResponse converts a ResponseComment model with its author preloaded to CommentResponse
+func ToCommentResponse(comment *model.ResponseComment) CommentResponse {
+	return CommentResponse{
+		ID:         comment.ID,
+		ResponseID: comment.ResponseID,
+		AuthorID:   comment.UserID,
+		AuthorName: comment.User.Username,
+		Content:    comment.Content,
+		Edited:     comment.UpdatedAt.After(comment.CreatedAt),
+		CreatedAt:  comment.CreatedAt,
+		UpdatedAt:  comment.UpdatedAt,
+	}
+}
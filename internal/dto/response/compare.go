@@ -0,0 +1,44 @@
+package response
+
+// ComparisonResponse represents per-question statistics of two periods and their deltas
+type ComparisonResponse struct {
+	Current             ComparisonPeriod     `json:"current"`
+	Base                ComparisonPeriod     `json:"base"`
+	TotalResponsesDelta int64                `json:"total_responses_delta"`
+	Questions           []QuestionComparison `json:"questions"` // In current survey question order
+}
+
+// ComparisonPeriod describes the responses one side of a comparison is computed from
+type ComparisonPeriod struct {
+	SurveyID       uint   `json:"survey_id"`
+	From           string `json:"from,omitempty"`
+	To             string `json:"to,omitempty"`
+	TotalResponses int64  `json:"total_responses"`
+}
+
+// QuestionComparison compares the statistics of a question between two periods
+// Deltas are current minus base and are absent when either side has no value
+type QuestionComparison struct {
+	QuestionID         uint                `json:"question_id"`
+	BaseQuestionID     *uint               `json:"base_question_id,omitempty"` // Absent when the base survey has no matching question
+	Title              string              `json:"title"`
+	Type               string              `json:"type"`
+	Current            QuestionStatistics  `json:"current"`
+	Base               *QuestionStatistics `json:"base,omitempty"`
+	AnswerRateDelta    *float64            `json:"answer_rate_delta,omitempty"`
+	AverageScoreDelta  *float64            `json:"average_score_delta,omitempty"`
+	NPSDelta           *float64            `json:"nps_delta,omitempty"`
+	MeanDelta          *float64            `json:"mean_delta,omitempty"` // Slider mean
+	AverageLengthDelta *float64            `json:"average_length_delta,omitempty"`
+	AverageRowsDelta   *float64            `json:"average_rows_delta,omitempty"`
+	Options            []OptionComparison  `json:"options,omitempty"` // For choice questions
+}
+
+// OptionComparison compares how often an option was chosen, as a share of the answers
+type OptionComparison struct {
+	ID             string  `json:"id"`
+	Label          string  `json:"label"`
+	CurrentPercent float64 `json:"current_percent"`
+	BasePercent    float64 `json:"base_percent"`
+	Delta          float64 `json:"delta"` // Percentage points
+}
@@ -0,0 +1,41 @@
+package response
+
+// CrosstabResponse represents a contingency table of two choice questions
+type CrosstabResponse struct {
+	SurveyID uint             `json:"survey_id"`
+	Row      CrosstabQuestion `json:"row"`
+	Column   CrosstabQuestion `json:"column"`
+	Rows     []CrosstabRow    `json:"rows"`
+	Columns  []CrosstabTotal  `json:"columns"` // Column totals in column option order
+	Total    int              `json:"total"`   // Responses that answered both questions
+}
+
+// CrosstabQuestion identifies one of the cross-tabulated questions
+type CrosstabQuestion struct {
+	QuestionID uint   `json:"question_id"`
+	Title      string `json:"title"`
+	Type       string `json:"type"`
+}
+
+// CrosstabRow holds the cells of one option of the row question
+type CrosstabRow struct {
+	OptionID string         `json:"option_id"`
+	Label    string         `json:"label"`
+	Total    int            `json:"total"`
+	Cells    []CrosstabCell `json:"cells"` // In column option order
+}
+
+// CrosstabCell counts the responses that chose both a row and a column option
+type CrosstabCell struct {
+	OptionID      string  `json:"option_id"` // Column option
+	Count         int     `json:"count"`
+	RowPercent    float64 `json:"row_percent"`    // Share of the row total, in percent
+	ColumnPercent float64 `json:"column_percent"` // Share of the column total, in percent
+}
+
+// CrosstabTotal is the total of one option of the column question
+type CrosstabTotal struct {
+	OptionID string `json:"option_id"`
+	Label    string `json:"label"`
+	Total    int    `json:"total"`
+}
@@ -0,0 +1,52 @@
+package response
+
+import (
+	"time"
+
+	"survey-system/internal/model"
+)
+
+// DelegationResponse represents an issued delegation token without the token itself
+type DelegationResponse struct {
+	ID          uint                   `json:"id"`
+	SurveyID    uint                   `json:"survey_id"`
+	Name        string                 `json:"name"`
+	PrefillData map[string]interface{} `json:"prefill_data"`
+	AllowedKeys []string               `json:"allowed_keys"`
+	ExpiresAt   *time.Time             `json:"expires_at"`
+	RevokedAt   *time.Time             `json:"revoked_at"`
+	LastUsedAt  *time.Time             `json:"last_used_at"`
+	LinkCount   int                    `json:"link_count"`
+	CreatedAt   time.Time              `json:"created_at"`
+}
+
+// CreateDelegationResponse represents a newly issued delegation token
+// The token is only returned here and cannot be retrieved later
+type CreateDelegationResponse struct {
+	DelegationResponse
+	Token string `json:"token"`
+}
+
+// ToDelegationResponse converts a Delegation model to DelegationResponse
+func ToDelegationResponse(delegation *model.Delegation) DelegationResponse {
+	prefillData := map[string]interface{}(delegation.PrefillData)
+	if prefillData == nil {
+		prefillData = map[string]interface{}{}
+	}
+	allowedKeys := []string(delegation.AllowedKeys)
+	if allowedKeys == nil {
+		allowedKeys = []string{}
+	}
+	return DelegationResponse{
+		ID:          delegation.ID,
+		SurveyID:    delegation.SurveyID,
+		Name:        delegation.Name,
+		PrefillData: prefillData,
+		AllowedKeys: allowedKeys,
+		ExpiresAt:   delegation.ExpiresAt,
+		RevokedAt:   delegation.RevokedAt,
+		LastUsedAt:  delegation.LastUsedAt,
+		LinkCount:   delegation.LinkCount,
+		CreatedAt:   delegation.CreatedAt,
+	}
+}
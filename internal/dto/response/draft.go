@@ -0,0 +1,22 @@
+package response
+
+import (
+	"survey-system/internal/model"
+	"time"
+)
+
+// SaveDraftResponse represents the response after saving a response draft
+type SaveDraftResponse struct {
+	DraftID   uint      `json:"draft_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+	EmailSent bool      `json:"email_sent"`
+	Message   string    `json:"message"`
+}
+
+// DraftResponse represents a resumed response draft
+type DraftResponse struct {
+	SurveyID  uint           `json:"survey_id"`
+	Token     string         `json:"token"` // One-time link token to load and submit the survey
+	Answers   []model.Answer `json:"answers"`
+	ExpiresAt time.Time      `json:"expires_at"`
+}
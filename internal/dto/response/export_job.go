@@ -0,0 +1,41 @@
+package response
+
+import (
+	"time"
+
+	"survey-system/internal/model"
+)
+
+// ExportJobResponse represents the state of an asynchronous export
+type ExportJobResponse struct {
+	ID          uint       `json:"id"`
+	SurveyID    uint       `json:"survey_id"`
+	Status      string     `json:"status"` // pending, running, completed, failed
+	Format      string     `json:"format"`
+	Encrypted   bool       `json:"encrypted"`
+	Filename    string     `json:"filename,omitempty"`
+	Size        int64      `json:"size,omitempty"`
+	Error       string     `json:"error,omitempty"`
+	Password    string     `json:"password,omitempty"`     // Generated archive password, only returned when the job is created
+	DownloadURL string     `json:"download_url,omitempty"` // Pre-signed link to the file once the job is completed
+	ExpiresAt   time.Time  `json:"expires_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// ToExportJobResponse converts an ExportJob model to ExportJobResponse
+func ToExportJobResponse(job *model.ExportJob) *ExportJobResponse {
+	return &ExportJobResponse{
+		ID:          job.ID,
+		SurveyID:    job.SurveyID,
+		Status:      job.Status,
+		Format:      job.Format,
+		Encrypted:   job.Encrypted,
+		Filename:    job.Filename,
+		Size:        job.Size,
+		Error:       job.Error,
+		ExpiresAt:   job.ExpiresAt,
+		CompletedAt: job.CompletedAt,
+		CreatedAt:   job.CreatedAt,
+	}
+}
@@ -0,0 +1,17 @@
+package response
+
+// FeatureFlagResponse reports how a feature flag is configured and overridden
+type FeatureFlagResponse struct {
+	Name            string                `json:"name"`
+	Enabled         bool                  `json:"enabled"`          // State for users without a flag of their own
+	Configured      bool                  `json:"configured"`       // State from the configuration of this environment
+	ConfiguredUsers []uint                `json:"configured_users"` // Users the configuration turns the flag on for
+	Override        *bool                 `json:"override"`         // Runtime override for everyone; null when there is none
+	UserOverrides   []FeatureUserOverride `json:"user_overrides"`   // Runtime overrides of single users
+}
+
+// FeatureUserOverride is a feature flag overridden at runtime for one user
+type FeatureUserOverride struct {
+	UserID  uint `json:"user_id"`
+	Enabled bool `json:"enabled"`
+}
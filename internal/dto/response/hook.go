@@ -0,0 +1,86 @@
+package response
+
+import (
+	"encoding/json"
+	"time"
+
+	"survey-system/internal/model"
+)
+
+// HookResponse represents a REST hook subscription
+type HookResponse struct {
+	ID        uint      `json:"id"`
+	SurveyID  uint      `json:"survey_id"`
+	Event     string    `json:"event"`
+	TargetURL string    `json:"target_url"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ToHookResponse converts a Hook model to HookResponse
+func ToHookResponse(hook *model.Hook) HookResponse {
+	return HookResponse{
+		ID:        hook.ID,
+		SurveyID:  hook.SurveyID,
+		Event:     hook.Event,
+		TargetURL: hook.TargetURL,
+		CreatedAt: hook.CreatedAt,
+	}
+}
+
+// HookDeliveryResponse represents an attempt to deliver an event to a hook
+type HookDeliveryResponse struct {
+	ID         uint            `json:"id"`
+	HookID     uint            `json:"hook_id"`
+	Event      string          `json:"event"`
+	StatusCode int             `json:"status_code"` // 0 when the endpoint did not answer
+	Error      string          `json:"error,omitempty"`
+	Succeeded  bool            `json:"succeeded"`
+	RetryOf    *uint           `json:"retry_of"`
+	DurationMs int64           `json:"duration_ms"`
+	Payload    json.RawMessage `json:"payload"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+// PaginatedHookDeliveryResponse represents a page of the delivery attempts of a hook
+type PaginatedHookDeliveryResponse struct {
+	Data []HookDeliveryResponse `json:"data"`
+	Meta PaginationMeta         `json:"meta"`
+}
+
+// ToHookDeliveryResponse converts a HookDelivery model to HookDeliveryResponse
+func ToHookDeliveryResponse(delivery *model.HookDelivery) HookDeliveryResponse {
+	return HookDeliveryResponse{
+		ID:         delivery.ID,
+		HookID:     delivery.HookID,
+		Event:      delivery.Event,
+		StatusCode: delivery.StatusCode,
+		Error:      delivery.Error,
+		Succeeded:  delivery.Succeeded,
+		RetryOf:    delivery.RetryOf,
+		DurationMs: delivery.DurationMs,
+		Payload:    json.RawMessage(delivery.Payload),
+		CreatedAt:  delivery.CreatedAt,
+	}
+}
+
+// ResponseSubmittedPayload is delivered to response.submitted hooks
+// Answers are flattened for no-code tools: one entry per question, labelled by its title
+type ResponseSubmittedPayload struct {
+	Event            string       `json:"event"`
+	SurveyID         uint         `json:"survey_id"`
+	SurveyTitle      string       `json:"survey_title"`
+	ResponseID       uint         `json:"response_id"`
+	RespondentNumber string       `json:"respondent_number"`
+	RespondentID     string       `json:"respondent_id"`
+	Campaign         string       `json:"campaign"`
+	Variant          string       `json:"variant"`
+	SubmittedAt      time.Time    `json:"submitted_at"`
+	Answers          []HookAnswer `json:"answers"`
+}
+
+// HookAnswer is one answer of a ResponseSubmittedPayload
+type HookAnswer struct {
+	QuestionID uint        `json:"question_id"`
+	Question   string      `json:"question"`
+	Value      interface{} `json:"value"`
+}
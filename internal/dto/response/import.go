@@ -0,0 +1,29 @@
+package response
+
+// ImportResponsesResult reports the outcome of a response import
+type ImportResponsesResult struct {
+	DryRun          bool             `json:"dry_run"`
+	TotalRows       int              `json:"total_rows"` // Data rows in the file, not counting empty rows
+	ValidRows       int              `json:"valid_rows"`
+	InvalidRows     int              `json:"invalid_rows"`
+	Imported        int              `json:"imported"`         // Responses stored; always 0 for dry runs
+	Columns         []ImportColumn   `json:"columns"`          // How the header columns were matched
+	IgnoredColumns  []string         `json:"ignored_columns"`  // Columns that match no question or field
+	Errors          []ImportRowError `json:"errors"`           // Errors of the first invalid rows
+	ErrorsTruncated bool             `json:"errors_truncated"` // More rows failed than are listed in errors
+}
+
+// ImportColumn describes what a header column of an import file was matched to
+type ImportColumn struct {
+	Column     string `json:"column"`
+	Field      string `json:"field"`                 // answer, submitted_at, ip_address, respondent_id or campaign
+	QuestionID uint   `json:"question_id,omitempty"` // Question of an answer column
+	TableRow   int    `json:"table_row,omitempty"`   // Table row of a wide layout table column
+}
+
+// ImportRowError explains why a row of an import file was rejected
+type ImportRowError struct {
+	Row     int    `json:"row"` // Line number in the file, the header being line 1
+	Column  string `json:"column,omitempty"`
+	Message string `json:"message"`
+}
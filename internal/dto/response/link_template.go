@@ -0,0 +1,45 @@
+package response
+
+import (
+	"time"
+
+	"survey-system/internal/model"
+)
+
+// LinkTemplateResponse represents a saved link template
+type LinkTemplateResponse struct {
+	ID             uint                   `json:"id"`
+	SurveyID       uint                   `json:"survey_id"`
+	Name           string                 `json:"name"`
+	PrefillData    map[string]interface{} `json:"prefill_data"`
+	Variables      []string               `json:"variables"`
+	ExpiresInHours int                    `json:"expires_in_hours"`
+	RedirectURL    string                 `json:"redirect_url,omitempty"`
+	Campaign       string                 `json:"campaign,omitempty"`
+	CreatedAt      time.Time              `json:"created_at"`
+	UpdatedAt      time.Time              `json:"updated_at"`
+}
+
+// ToLinkTemplateResponse converts a LinkTemplate model to LinkTemplateResponse
+func ToLinkTemplateResponse(template *model.LinkTemplate) LinkTemplateResponse {
+	prefillData := map[string]interface{}(template.PrefillData)
+	if prefillData == nil {
+		prefillData = map[string]interface{}{}
+	}
+	variables := []string(template.Variables)
+	if variables == nil {
+		variables = []string{}
+	}
+	return LinkTemplateResponse{
+		ID:             template.ID,
+		SurveyID:       template.SurveyID,
+		Name:           template.Name,
+		PrefillData:    prefillData,
+		Variables:      variables,
+		ExpiresInHours: template.ExpiresInHours,
+		RedirectURL:    template.RedirectURL,
+		Campaign:       template.Campaign,
+		CreatedAt:      template.CreatedAt,
+		UpdatedAt:      template.UpdatedAt,
+	}
+}
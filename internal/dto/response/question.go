@@ -16,6 +16,12 @@ type QuestionResponse struct {
 	Order       int                  `json:"order"`
 	Config      model.QuestionConfig `json:"config"`
 	PrefillKey  string               `json:"prefill_key,omitempty"`
+	PrefillType string               `json:"prefill_type,omitempty"`
+	LockPrefill bool                 `json:"lock_prefill,omitempty"`
+	Hidden      bool                 `json:"hidden,omitempty"`
+	Sensitive   bool                 `json:"sensitive,omitempty"`
+	Pool        string               `json:"pool,omitempty"`
+	Variant     string               `json:"variant,omitempty"`
 	CreatedAt   time.Time            `json:"created_at"`
 	UpdatedAt   time.Time            `json:"updated_at"`
 }
@@ -32,7 +38,115 @@ func ToQuestionResponse(question *model.Question) *QuestionResponse {
 		Order:       question.Order,
 		Config:      question.Config,
 		PrefillKey:  question.PrefillKey,
+		PrefillType: question.PrefillType,
+		LockPrefill: question.LockPrefill,
+		Hidden:      question.Hidden,
+		Sensitive:   question.Sensitive,
+		Pool:        question.Pool,
+		Variant:     question.Variant,
 		CreatedAt:   question.CreatedAt,
 		UpdatedAt:   question.UpdatedAt,
 	}
 }
+
+// QuestionChangeResponse represents an entry of a question's change history
+type QuestionChangeResponse struct {
+	ID             uint                `json:"id"`
+	QuestionID     uint                `json:"question_id"`
+	Action         string              `json:"action"`
+	RestoredFromID *uint               `json:"restored_from_id,omitempty"` // History entry whose version was restored
+	EditorID       uint                `json:"editor_id"`
+	EditorName     string              `json:"editor_name"`
+	Fields         []model.FieldChange `json:"fields"`
+	CreatedAt      time.Time           `json:"created_at"`
+}
+
+// ToQuestionChangeResponse converts a QuestionChange model with its editor preloaded to QuestionChangeResponse
+func ToQuestionChangeResponse(change *model.QuestionChange) QuestionChangeResponse {
+	fields := []model.FieldChange(change.Fields)
+	if fields == nil {
+		fields = []model.FieldChange{}
+	}
+	return QuestionChangeResponse{
+		ID:             change.ID,
+		QuestionID:     change.QuestionID,
+		Action:         change.Action,
+		RestoredFromID: change.RestoredFromID,
+		EditorID:       change.UserID,
+		EditorName:     change.User.Username,
+		Fields:         fields,
+		CreatedAt:      change.CreatedAt,
+	}
+}
+
+// ImportQuestionOptionsResponse represents the result of importing choice options
+type ImportQuestionOptionsResponse struct {
+	Question   *QuestionResponse `json:"question"`
+	Imported   int               `json:"imported"`   // Options added to the question
+	Duplicates int               `json:"duplicates"` // Rows skipped because their option ID was already present
+}
+
+// OptionSetResponse represents a built-in option set offered for import
+type OptionSetResponse struct {
+	Name    string `json:"name"`
+	Options int    `json:"options"`
+}
+
+// SurveySizeReport estimates the size of a survey's public payload and lists where the
+// survey exceeds the recommended limits
+type SurveySizeReport struct {
+	SurveyID        uint           `json:"survey_id"`
+	PayloadBytes    int            `json:"payload_bytes"`    // Public survey payload as JSON, without prefill data
+	CompressedBytes int            `json:"compressed_bytes"` // The same payload gzip compressed
+	Questions       int            `json:"questions"`        // Questions shown to respondents
+	HiddenQuestions int            `json:"hidden_questions"` // Questions answered from prefill data, not sent to respondents
+	Options         int            `json:"options"`          // Options of all choice questions, select and cascade columns
+	QuestionSizes   []QuestionSize `json:"question_sizes"`   // Visible questions in display order
+	Warnings        []SizeWarning  `json:"warnings"`         // Empty when the survey stays within the recommended limits
+}
+
+// QuestionSize reports the size of a single question in the public payload
+type QuestionSize struct {
+	QuestionID   uint   `json:"question_id"`
+	Title        string `json:"title"`
+	Type         string `json:"type"`
+	Options      int    `json:"options"`
+	Columns      int    `json:"columns"`
+	PayloadBytes int    `json:"payload_bytes"`
+}
+
+// SizeWarning reports a recommended limit the survey or one of its questions exceeds
+type SizeWarning struct {
+	Code       string `json:"code"`                  // e.g. payload_too_large, too_many_options
+	QuestionID uint   `json:"question_id,omitempty"` // Set for warnings about a single question
+	Value      int    `json:"value"`                 // Measured value
+	Limit      int    `json:"limit"`                 // Recommended maximum
+}
+
+// QuestionUpdateImpact reports how a question update would affect the responses collected
+// so far and the links that can still be opened, without applying the update
+type QuestionUpdateImpact struct {
+	QuestionID       uint                  `json:"question_id"`
+	Changes          []model.FieldChange   `json:"changes"`           // Fields the update would change
+	CheckedResponses int64                 `json:"checked_responses"` // Responses answering the question, test ones included
+	InvalidAnswers   int64                 `json:"invalid_answers"`   // Answers that would fail validation after the update
+	CheckedLinks     int64                 `json:"checked_links"`     // Unused, unrevoked and unexpired links carrying prefill data
+	BrokenLinks      int64                 `json:"broken_links"`      // Links whose prefill data would be rejected after the update
+	AnswerSamples    []InvalidAnswerSample `json:"answer_samples"`    // First invalid answers, newest first
+	LinkSamples      []BrokenLinkSample    `json:"link_samples"`      // First broken links, oldest first
+}
+
+// InvalidAnswerSample is an answer of a response that the updated question would reject
+type InvalidAnswerSample struct {
+	ResponseID uint        `json:"response_id"`
+	Value      interface{} `json:"value"`
+	Reason     string      `json:"reason"`
+}
+
+// BrokenLinkSample is an outstanding link whose prefill data the updated question would reject
+type BrokenLinkSample struct {
+	LinkID    uint      `json:"link_id"`
+	Campaign  string    `json:"campaign,omitempty"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Reason    string    `json:"reason"`
+}
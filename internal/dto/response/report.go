@@ -0,0 +1,29 @@
+package response
+
+import (
+	"time"
+
+	"survey-system/internal/model"
+)
+
+// ReportSubscriptionResponse represents a summary report subscription
+type ReportSubscriptionResponse struct {
+	ID         uint       `json:"id"`
+	SurveyID   uint       `json:"survey_id"`
+	Frequency  string     `json:"frequency"`
+	Email      string     `json:"email"`
+	LastSentAt *time.Time `json:"last_sent_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// ToReportSubscriptionResponse converts a ReportSubscription model to ReportSubscriptionResponse
+func ToReportSubscriptionResponse(subscription *model.ReportSubscription) ReportSubscriptionResponse {
+	return ReportSubscriptionResponse{
+		ID:         subscription.ID,
+		SurveyID:   subscription.SurveyID,
+		Frequency:  subscription.Frequency,
+		Email:      subscription.Email,
+		LastSentAt: subscription.LastSentAt,
+		CreatedAt:  subscription.CreatedAt,
+	}
+}
@@ -4,21 +4,47 @@ import "time"
 
 // SubmitResponseResponse represents the response after submitting a survey response
 type SubmitResponseResponse struct {
-	ID          uint      `json:"id"`
-	SurveyID    uint      `json:"survey_id"`
-	SubmittedAt time.Time `json:"submitted_at"`
-	Message     string    `json:"message"`
+	ID               uint      `json:"id"`
+	SurveyID         uint      `json:"survey_id"`
+	RespondentNumber string    `json:"respondent_number,omitempty"` // Sequential number within the survey, e.g. R-0001; none for test responses
+	Version          int       `json:"version,omitempty"`           // Raised by each correction within the survey's edit window
+	SubmittedAt      time.Time `json:"submitted_at"`
+	Message          string    `json:"message"`
+	RedirectURL      string    `json:"redirect_url,omitempty"` // Thank-you page set on the share link
+	Preview          bool      `json:"preview,omitempty"`      // Sandbox submission through a preview token; nothing was stored
+	Score            *float64  `json:"score,omitempty"`        // Only for assessments showing respondents their score
+	MaxScore         *float64  `json:"max_score,omitempty"`    // Points the questions shown to the respondent were worth
 }
 
 // ResponseListItem represents a single response in the list
 type ResponseListItem struct {
-	ID          uint                   `json:"id"`
-	SurveyID    uint                   `json:"survey_id"`
-	Data        map[string]interface{} `json:"data"`
-	IPAddress   string                 `json:"ip_address"`
-	UserAgent   string                 `json:"user_agent"`
-	SubmittedAt time.Time              `json:"submitted_at"`
-	CreatedAt   time.Time              `json:"created_at"`
+	ID               uint                   `json:"id"`
+	SurveyID         uint                   `json:"survey_id"`
+	Data             map[string]interface{} `json:"data"`
+	IPAddress        string                 `json:"ip_address"`
+	UserAgent        string                 `json:"user_agent"`
+	Campaign         string                 `json:"campaign,omitempty"`
+	Variant          string                 `json:"variant,omitempty"`
+	RespondentID     string                 `json:"respondent_id,omitempty"`
+	RespondentNumber string                 `json:"respondent_number,omitempty"`
+	IsTest           bool                   `json:"is_test"`
+	CommentCount     int64                  `json:"comment_count"`
+	Version          int                    `json:"version"`
+	Score            *float64               `json:"score,omitempty"` // Only for assessment responses
+	MaxScore         *float64               `json:"max_score,omitempty"`
+	EditedAt         *time.Time             `json:"edited_at"` // Last correction by the respondent, null if never corrected
+	Flagged          bool                   `json:"flagged"`
+	FlagReason       string                 `json:"flag_reason,omitempty"` // Why the response looks automated, e.g. burst
+	ReviewStatus     string                 `json:"review_status"`         // pending, reviewed or rejected
+	ReviewedAt       *time.Time             `json:"reviewed_at"`
+	SubmittedAt      time.Time              `json:"submitted_at"`
+	CreatedAt        time.Time              `json:"created_at"`
+}
+
+// UpdateReviewStatusResponse reports the outcome of a bulk review status update
+type UpdateReviewStatusResponse struct {
+	Status  string `json:"status"`
+	Updated int64  `json:"updated"` // Matching responses whose status changed; those already in the status are not counted
 }
 
 // PaginatedResponseMeta represents pagination metadata
@@ -28,9 +54,126 @@ type PaginatedResponseMeta struct {
 	Total    int64 `json:"total"`
 }
 
+// CursorResponseMeta represents pagination metadata for cursor-based listing
+type CursorResponseMeta struct {
+	PageSize   int    `json:"page_size"`
+	NextCursor string `json:"next_cursor"` // Empty when there are no more records
+	HasMore    bool   `json:"has_more"`
+}
+
+// SampleResponseMeta describes how a random response sample was drawn
+type SampleResponseMeta struct {
+	Size       int             `json:"size"`                  // Number of sampled responses
+	Population int64           `json:"population"`            // Responses the sample was drawn from
+	StratifyBy uint            `json:"stratify_by,omitempty"` // Choice question used for stratification
+	Strata     []SampleStratum `json:"strata,omitempty"`
+}
+
+// SampleStratum is the share of a stratified sample drawn from one option
+type SampleStratum struct {
+	OptionID   string `json:"option_id"`
+	Label      string `json:"label"`
+	Population int64  `json:"population"` // Responses that chose the option
+	Sampled    int    `json:"sampled"`
+}
+
 // StatisticsResponse represents survey statistics
 type StatisticsResponse struct {
-	SurveyID       uint    `json:"survey_id"`
-	TotalResponses int64   `json:"total_responses"`
-	CompletionRate float64 `json:"completion_rate"`
+	SurveyID        uint                 `json:"survey_id"`
+	TotalResponses  int64                `json:"total_responses"`
+	CompletionRate  float64              `json:"completion_rate"`
+	Questions       []QuestionStatistics `json:"questions"`
+	Campaigns       []CampaignStatistics `json:"campaigns"`           // Links and responses per campaign label
+	Variants        []VariantStatistics  `json:"variants,omitempty"`  // Links, responses and question statistics per A/B variant
+	Scores          *ScoreStatistics     `json:"scores,omitempty"`    // Score distribution of assessments
+	HoneypotCatches int64                `json:"honeypot_catches"`    // Submissions caught by the honeypot, including discarded ones
+	CachedAt        *time.Time           `json:"cached_at,omitempty"` // When the counters were last rebuilt; absent for live statistics
+}
+
+// CampaignStatistics compares the links generated for a campaign with the responses they produced
+type CampaignStatistics struct {
+	Campaign     string  `json:"campaign"` // Empty for links without a campaign
+	Links        int64   `json:"links"`
+	Responses    int64   `json:"responses"`
+	ResponseRate float64 `json:"response_rate"` // Responses per link, in percent
+}
+
+// VariantStatistics breaks the statistics of a survey with A/B variants down by variant
+type VariantStatistics struct {
+	Variant      string               `json:"variant"`
+	Links        int64                `json:"links"`
+	Responses    int64                `json:"responses"`
+	ResponseRate float64              `json:"response_rate"` // Responses per link, in percent
+	Questions    []QuestionStatistics `json:"questions"`     // The variant's questions and the common ones, over its responses
+}
+
+// ScoreStatistics describes the scores of an assessment's responses
+type ScoreStatistics struct {
+	Points       *NumericStatistics `json:"points"`       // Scores in points
+	Percent      *NumericStatistics `json:"percent"`      // Scores as a percentage of the points each response was worth
+	Distribution []ScoreBand        `json:"distribution"` // Responses per band of 10 percentage points
+}
+
+// ScoreBand counts the responses scoring within a percentage band; the last band includes 100
+type ScoreBand struct {
+	From  int `json:"from"`
+	To    int `json:"to"`
+	Count int `json:"count"`
+}
+
+// QuestionStatistics represents answer statistics for a single question
+type QuestionStatistics struct {
+	QuestionID        uint                    `json:"question_id"`
+	Title             string                  `json:"title"`
+	Type              string                  `json:"type"`
+	Answered          int                     `json:"answered"`
+	Exposed           *int                    `json:"exposed,omitempty"`             // Responses the question was shown in, only for pool and variant questions
+	Skipped           *int                    `json:"skipped,omitempty"`             // Responses without an answer, only for optional questions
+	AnswerRate        float64                 `json:"answer_rate"`                   // Answered responses, in percent
+	AverageLength     *float64                `json:"average_length,omitempty"`      // Mean character count of text answers
+	AverageRows       *float64                `json:"average_rows,omitempty"`        // Mean row count of table answers
+	AverageDurationMs *float64                `json:"average_duration_ms,omitempty"` // Mean time spent on the question, over answers the client timed
+	Options           []OptionStatistics      `json:"options,omitempty"`             // For choice questions
+	AverageScore      *float64                `json:"average_score,omitempty"`       // Only when options carry scores
+	NPS               *NPSStatistics          `json:"nps,omitempty"`                 // For NPS questions
+	Numeric           *NumericStatistics      `json:"numeric,omitempty"`             // For slider questions
+	Totals            []ColumnTotalStatistics `json:"totals,omitempty"`              // For table columns with totals
+}
+
+// ColumnTotalStatistics represents the totals of a table column over responses
+type ColumnTotalStatistics struct {
+	ColumnID    string             `json:"column_id"`
+	Label       string             `json:"label"`
+	Sum         float64            `json:"sum"`                    // Sum of the column over all responses
+	PerResponse *NumericStatistics `json:"per_response,omitempty"` // Distribution of the column's total per response
+}
+
+// NumericStatistics represents the distribution of numeric answers
+type NumericStatistics struct {
+	Count  int     `json:"count"`
+	Min    float64 `json:"min"`
+	Max    float64 `json:"max"`
+	Mean   float64 `json:"mean"`
+	Median float64 `json:"median"`
+	StdDev float64 `json:"stddev"` // Population standard deviation
+	Unit   string  `json:"unit,omitempty"`
+}
+
+// NPSStatistics represents the Net Promoter Score breakdown of an NPS question
+type NPSStatistics struct {
+	Promoters         int     `json:"promoters"`
+	Passives          int     `json:"passives"`
+	Detractors        int     `json:"detractors"`
+	PromotersPercent  float64 `json:"promoters_percent"`
+	PassivesPercent   float64 `json:"passives_percent"`
+	DetractorsPercent float64 `json:"detractors_percent"`
+	Score             float64 `json:"score"` // Promoters percent minus detractors percent, -100 to 100
+}
+
+// OptionStatistics represents how often an option was selected
+type OptionStatistics struct {
+	ID    string   `json:"id"`
+	Label string   `json:"label"`
+	Score *float64 `json:"score,omitempty"`
+	Count int      `json:"count"`
 }
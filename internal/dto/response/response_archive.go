@@ -0,0 +1,35 @@
+package response
+
+import (
+	"time"
+
+	"survey-system/internal/model"
+)
+
+// ResponseArchiveResponse represents an archive of responses written before they were purged
+type ResponseArchiveResponse struct {
+	ID              uint      `json:"id"`
+	SurveyID        uint      `json:"survey_id"`
+	SurveyTitle     string    `json:"survey_title"`
+	OwnerID         uint      `json:"owner_id"`
+	ResponseCount   int64     `json:"response_count"`
+	SubmittedBefore time.Time `json:"submitted_before"` // The archive holds the responses submitted before this time
+	Filename        string    `json:"filename"`
+	Size            int64     `json:"size"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// ToResponseArchiveResponse converts a ResponseArchive model to ResponseArchiveResponse
+func ToResponseArchiveResponse(archive *model.ResponseArchive) ResponseArchiveResponse {
+	return ResponseArchiveResponse{
+		ID:              archive.ID,
+		SurveyID:        archive.SurveyID,
+		SurveyTitle:     archive.SurveyTitle,
+		OwnerID:         archive.OwnerID,
+		ResponseCount:   archive.ResponseCount,
+		SubmittedBefore: archive.SubmittedBefore,
+		Filename:        archive.Filename,
+		Size:            archive.Size,
+		CreatedAt:       archive.CreatedAt,
+	}
+}
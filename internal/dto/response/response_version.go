@@ -0,0 +1,36 @@
+package response
+
+import (
+	"time"
+
+	"survey-system/internal/model"
+)
+
+// ResponseVersionResponse represents one version of a corrected response
+type ResponseVersionResponse struct {
+	Version     int            `json:"version"`
+	Current     bool           `json:"current"` // The answers the response holds now
+	Answers     []model.Answer `json:"answers"`
+	SubmittedAt time.Time      `json:"submitted_at"`
+}
+
+// ResponseDiffResponse lists the answers that changed between two versions of a response
+type ResponseDiffResponse struct {
+	ResponseID      uint           `json:"response_id"`
+	From            int            `json:"from"`
+	To              int            `json:"to"`
+	FromSubmittedAt time.Time      `json:"from_submitted_at"`
+	ToSubmittedAt   time.Time      `json:"to_submitted_at"`
+	Changes         []AnswerChange `json:"changes"`
+	Unchanged       int            `json:"unchanged"` // Answers that are the same in both versions
+}
+
+// AnswerChange describes how the answer to one question differs between two versions
+type AnswerChange struct {
+	QuestionID    uint        `json:"question_id"`
+	QuestionTitle string      `json:"question_title"` // Empty when the question was deleted since
+	QuestionType  string      `json:"question_type,omitempty"`
+	Change        string      `json:"change"` // added, removed or changed
+	Before        interface{} `json:"before"`
+	After         interface{} `json:"after"`
+}
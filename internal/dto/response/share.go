@@ -4,22 +4,134 @@ import "time"
 
 // ShareLinkResponse represents the response for a generated share link
 type ShareLinkResponse struct {
+	Token        string    `json:"token"`
+	URL          string    `json:"url"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	RedirectURL  string    `json:"redirect_url,omitempty"`
+	Campaign     string    `json:"campaign,omitempty"`
+	RespondentID string    `json:"respondent_id,omitempty"`
+	Test         bool      `json:"test,omitempty"`
+	TemplateID   uint      `json:"template_id,omitempty"`
+	Variant      string    `json:"variant,omitempty"`
+}
+
+// PreviewLinkResponse represents a generated survey preview link
+type PreviewLinkResponse struct {
 	Token     string    `json:"token"`
 	URL       string    `json:"url"`
 	ExpiresAt time.Time `json:"expires_at"`
+	Variant   string    `json:"variant,omitempty"`
+}
+
+// Statuses reported when checking a link
+const (
+	LinkStatusValid   = "valid"
+	LinkStatusExpired = "expired"
+	LinkStatusUsed    = "used"
+	LinkStatusRevoked = "revoked"
+)
+
+// LinkStatusResponse represents the result of checking a link without opening it
+type LinkStatusResponse struct {
+	Status    string    `json:"status"` // valid, expired, used or revoked
+	SurveyID  uint      `json:"survey_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Preview   bool      `json:"preview,omitempty"` // Owner preview link
+}
+
+// SurveyMetaResponse represents the public metadata of a survey, shown by landing pages
+// before the respondent opens a link
+type SurveyMetaResponse struct {
+	ID          uint       `json:"id"`
+	Title       string     `json:"title"`
+	Status      string     `json:"status"`       // published or archived
+	Open        bool       `json:"open"`         // Accepting responses
+	PublishedAt *time.Time `json:"published_at"` // Start of the current publication
 }
 
 // SurveyWithPrefillResponse represents a survey with prefilled values
 type SurveyWithPrefillResponse struct {
-	ID          uint                   `json:"id"`
-	Title       string                 `json:"title"`
-	Description string                 `json:"description"`
-	Questions   []QuestionWithPrefill  `json:"questions"`
-	PrefillData map[string]interface{} `json:"prefill_data"`
+	ID              uint                   `json:"id"`
+	Title           string                 `json:"title"`
+	Description     string                 `json:"description"`
+	DescriptionHTML string                 `json:"description_html"` // Sanitized HTML rendered from the Markdown description
+	Questions       []QuestionWithPrefill  `json:"questions"`
+	PrefillData     map[string]interface{} `json:"prefill_data"`
+	Anonymous       bool                   `json:"anonymous"`          // Responses are stored without IP address, user agent or identity
+	Preview         bool                   `json:"preview,omitempty"`  // Opened with a preview token; submissions are not stored
+	Honeypot        *HoneypotField         `json:"honeypot,omitempty"` // Spam trap field to render invisibly, when the survey enables it
+
+	// Timed session of surveys with a time limit, started when the link was first opened
+	TimeLimitMinutes int        `json:"time_limit_minutes,omitempty"`
+	StartedAt        *time.Time `json:"started_at,omitempty"`
+	Deadline         *time.Time `json:"deadline,omitempty"`          // Submissions after it are rejected with TIME_LIMIT_EXCEEDED
+	RemainingSeconds *int64     `json:"remaining_seconds,omitempty"` // Seconds until the deadline when the survey was loaded
+
+	// Cache validators for conditional requests, sent as headers
+	ETag         string    `json:"-"`
+	LastModified time.Time `json:"-"`
 }
 
 // QuestionWithPrefill represents a question with optional prefilled value
 type QuestionWithPrefill struct {
 	QuestionResponse
-	PrefillValue interface{} `json:"prefill_value,omitempty"`
+	DescriptionHTML string      `json:"description_html"` // Sanitized HTML rendered from the Markdown description
+	PrefillValue    interface{} `json:"prefill_value,omitempty"`
+}
+
+// HoneypotField is an extra form field that clients render hidden from people, e.g.
+// positioned off-screen with autocomplete disabled. Whatever is typed into it is sent
+// back as the honeypot of the submission
+type HoneypotField struct {
+	Name  string `json:"name"`  // Input name, chosen to tempt form-filling bots
+	Label string `json:"label"` // Label of the input
+}
+
+// EmbedResponse represents the embeddable widget payload of a survey
+type EmbedResponse struct {
+	SurveyID        uint     `json:"survey_id"`
+	Title           string   `json:"title"`
+	Description     string   `json:"description"`
+	DescriptionHTML string   `json:"description_html"`
+	URL             string   `json:"url"`
+	AllowedDomains  []string `json:"allowed_domains"`
+}
+
+// RevokeLinksResponse reports the outcome of a bulk link revocation
+type RevokeLinksResponse struct {
+	DryRun  bool  `json:"dry_run"`
+	Matched int64 `json:"matched"` // Links matching the filter that were not revoked yet
+	Revoked int64 `json:"revoked"` // Links revoked; always 0 for dry runs
+}
+
+// LinkPoolResponse lists the links of a generated link pool
+type LinkPoolResponse struct {
+	Count       int            `json:"count"`
+	ExpiresAt   time.Time      `json:"expires_at"`
+	RedirectURL string         `json:"redirect_url,omitempty"`
+	Campaign    string         `json:"campaign,omitempty"`
+	Test        bool           `json:"test,omitempty"`
+	Links       []PoolLinkItem `json:"links"`
+}
+
+// PoolLinkItem is a single link of a link pool
+type PoolLinkItem struct {
+	ID      uint   `json:"id"`
+	Token   string `json:"token"`
+	URL     string `json:"url"`
+	Variant string `json:"variant,omitempty"`
+}
+
+// ClaimedLinkResponse represents a pool link bound to prefill data
+type ClaimedLinkResponse struct {
+	ID           uint                   `json:"id"`
+	Token        string                 `json:"token"`
+	URL          string                 `json:"url"`
+	ExpiresAt    time.Time              `json:"expires_at"`
+	Campaign     string                 `json:"campaign,omitempty"`
+	RespondentID string                 `json:"respondent_id,omitempty"`
+	Variant      string                 `json:"variant,omitempty"`
+	PrefillData  map[string]interface{} `json:"prefill_data"`
+	ClaimedAt    time.Time              `json:"claimed_at"`
+	Remaining    int64                  `json:"remaining"` // Pool links of the survey still claimable
 }
@@ -0,0 +1,27 @@
+package response
+
+// SimulationResponse reports how the survey would treat a set of hypothetical answers
+type SimulationResponse struct {
+	Valid     bool                `json:"valid"` // The answers would be accepted on submission
+	Questions []SimulatedQuestion `json:"questions"`
+	Errors    []SimulationError   `json:"errors"` // Every problem found, not only the first one
+}
+
+// SimulatedQuestion is the outcome of the simulation for one question, in survey order
+type SimulatedQuestion struct {
+	QuestionID uint        `json:"question_id"`
+	Title      string      `json:"title"`
+	Type       string      `json:"type"`
+	Visible    bool        `json:"visible"` // Shown to respondents
+	Answered   bool        `json:"answered"`
+	Source     string      `json:"source,omitempty"` // answer or prefill
+	Value      interface{} `json:"value,omitempty"`
+	Error      string      `json:"error,omitempty"`
+}
+
+// SimulationError describes a problem with the simulated answers
+// QuestionID is 0 for problems not tied to a question of the survey
+type SimulationError struct {
+	QuestionID uint   `json:"question_id,omitempty"`
+	Message    string `json:"message"`
+}
@@ -7,25 +7,69 @@ import (
 
 // SurveyResponse represents a basic survey response
 type SurveyResponse struct {
-	ID          uint      `json:"id"`
-	UserID      uint      `json:"user_id"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	Status      string    `json:"status"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID                     uint                 `json:"id"`
+	UserID                 uint                 `json:"user_id"`
+	Title                  string               `json:"title"`
+	Description            string               `json:"description"`
+	Status                 string               `json:"status"`
+	EmbedEnabled           bool                 `json:"embed_enabled"`
+	EmbedDomains           []string             `json:"embed_domains"`
+	AllowedIPs             []string             `json:"allowed_ips"`
+	Anonymous              bool                 `json:"anonymous"`
+	ExpiryNotifyHours      int                  `json:"expiry_notify_hours"`
+	ExpiryWebhookURL       string               `json:"expiry_webhook_url"`
+	ExpiryNotifyEmail      string               `json:"expiry_notify_email"`
+	LinkDefaultExpiryHours int                  `json:"link_default_expiry_hours"`
+	LinkMaxExpiryHours     int                  `json:"link_max_expiry_hours"`
+	EditWindowMinutes      int                  `json:"edit_window_minutes"`
+	TimeLimitMinutes       int                  `json:"time_limit_minutes"`
+	Assessment             bool                 `json:"assessment"`
+	ShowScore              bool                 `json:"show_score"`
+	PassPercent            float64              `json:"pass_percent"`
+	NoResponseReminderDays int                  `json:"no_response_reminder_days"`
+	ReminderWebhookURL     string               `json:"reminder_webhook_url"`
+	PublishedAt            *time.Time           `json:"published_at"`
+	HoneypotAction         string               `json:"honeypot_action"`
+	TeamID                 *uint                `json:"team_id"` // Team the survey is shared with, null when not shared
+	Quotas                 []model.QuotaRule    `json:"quotas"`
+	QuestionPools          []model.QuestionPool `json:"question_pools"`
+	Variants               []string             `json:"variants"`
+	CreatedAt              time.Time            `json:"created_at"`
+	UpdatedAt              time.Time            `json:"updated_at"`
 }
 
 // SurveyDetailResponse represents a detailed survey response with questions
 type SurveyDetailResponse struct {
-	ID          uint               `json:"id"`
-	UserID      uint               `json:"user_id"`
-	Title       string             `json:"title"`
-	Description string             `json:"description"`
-	Status      string             `json:"status"`
-	CreatedAt   time.Time          `json:"created_at"`
-	UpdatedAt   time.Time          `json:"updated_at"`
-	Questions   []QuestionResponse `json:"questions"`
+	ID                     uint                 `json:"id"`
+	UserID                 uint                 `json:"user_id"`
+	Title                  string               `json:"title"`
+	Description            string               `json:"description"`
+	Status                 string               `json:"status"`
+	EmbedEnabled           bool                 `json:"embed_enabled"`
+	EmbedDomains           []string             `json:"embed_domains"`
+	AllowedIPs             []string             `json:"allowed_ips"`
+	Anonymous              bool                 `json:"anonymous"`
+	ExpiryNotifyHours      int                  `json:"expiry_notify_hours"`
+	ExpiryWebhookURL       string               `json:"expiry_webhook_url"`
+	ExpiryNotifyEmail      string               `json:"expiry_notify_email"`
+	LinkDefaultExpiryHours int                  `json:"link_default_expiry_hours"`
+	LinkMaxExpiryHours     int                  `json:"link_max_expiry_hours"`
+	EditWindowMinutes      int                  `json:"edit_window_minutes"`
+	TimeLimitMinutes       int                  `json:"time_limit_minutes"`
+	Assessment             bool                 `json:"assessment"`
+	ShowScore              bool                 `json:"show_score"`
+	PassPercent            float64              `json:"pass_percent"`
+	NoResponseReminderDays int                  `json:"no_response_reminder_days"`
+	ReminderWebhookURL     string               `json:"reminder_webhook_url"`
+	PublishedAt            *time.Time           `json:"published_at"`
+	HoneypotAction         string               `json:"honeypot_action"`
+	TeamID                 *uint                `json:"team_id"`
+	Quotas                 []model.QuotaRule    `json:"quotas"`
+	QuestionPools          []model.QuestionPool `json:"question_pools"`
+	Variants               []string             `json:"variants"`
+	CreatedAt              time.Time            `json:"created_at"`
+	UpdatedAt              time.Time            `json:"updated_at"`
+	Questions              []QuestionResponse   `json:"questions"`
 }
 
 // PaginatedSurveyResponse represents a paginated list of surveys
@@ -45,13 +89,35 @@ type PaginationMeta struct {
 // ToSurveyResponse converts a model.Survey to SurveyResponse
 func ToSurveyResponse(survey *model.Survey) *SurveyResponse {
 	return &SurveyResponse{
-		ID:          survey.ID,
-		UserID:      survey.UserID,
-		Title:       survey.Title,
-		Description: survey.Description,
-		Status:      survey.Status,
-		CreatedAt:   survey.CreatedAt,
-		UpdatedAt:   survey.UpdatedAt,
+		ID:                     survey.ID,
+		UserID:                 survey.UserID,
+		Title:                  survey.Title,
+		Description:            survey.Description,
+		Status:                 survey.Status,
+		EmbedEnabled:           survey.EmbedEnabled,
+		EmbedDomains:           survey.EmbedDomains,
+		AllowedIPs:             survey.AllowedIPs,
+		Anonymous:              survey.Anonymous,
+		ExpiryNotifyHours:      survey.ExpiryNotifyHours,
+		ExpiryWebhookURL:       survey.ExpiryWebhookURL,
+		ExpiryNotifyEmail:      survey.ExpiryNotifyEmail,
+		LinkDefaultExpiryHours: survey.LinkDefaultExpiryHours,
+		LinkMaxExpiryHours:     survey.LinkMaxExpiryHours,
+		EditWindowMinutes:      survey.EditWindowMinutes,
+		TimeLimitMinutes:       survey.TimeLimitMinutes,
+		Assessment:             survey.Assessment,
+		ShowScore:              survey.ShowScore,
+		PassPercent:            survey.PassPercent,
+		NoResponseReminderDays: survey.NoResponseReminderDays,
+		ReminderWebhookURL:     survey.ReminderWebhookURL,
+		PublishedAt:            survey.PublishedAt,
+		HoneypotAction:         survey.HoneypotAction,
+		TeamID:                 survey.TeamID,
+		Quotas:                 survey.Quotas,
+		QuestionPools:          survey.QuestionPools,
+		Variants:               survey.Variants,
+		CreatedAt:              survey.CreatedAt,
+		UpdatedAt:              survey.UpdatedAt,
 	}
 }
 
@@ -63,13 +129,35 @@ func ToSurveyDetailResponse(survey *model.Survey) *SurveyDetailResponse {
 	}
 
 	return &SurveyDetailResponse{
-		ID:          survey.ID,
-		UserID:      survey.UserID,
-		Title:       survey.Title,
-		Description: survey.Description,
-		Status:      survey.Status,
-		CreatedAt:   survey.CreatedAt,
-		UpdatedAt:   survey.UpdatedAt,
-		Questions:   questions,
+		ID:                     survey.ID,
+		UserID:                 survey.UserID,
+		Title:                  survey.Title,
+		Description:            survey.Description,
+		Status:                 survey.Status,
+		EmbedEnabled:           survey.EmbedEnabled,
+		EmbedDomains:           survey.EmbedDomains,
+		AllowedIPs:             survey.AllowedIPs,
+		Anonymous:              survey.Anonymous,
+		ExpiryNotifyHours:      survey.ExpiryNotifyHours,
+		ExpiryWebhookURL:       survey.ExpiryWebhookURL,
+		ExpiryNotifyEmail:      survey.ExpiryNotifyEmail,
+		LinkDefaultExpiryHours: survey.LinkDefaultExpiryHours,
+		LinkMaxExpiryHours:     survey.LinkMaxExpiryHours,
+		EditWindowMinutes:      survey.EditWindowMinutes,
+		TimeLimitMinutes:       survey.TimeLimitMinutes,
+		Assessment:             survey.Assessment,
+		ShowScore:              survey.ShowScore,
+		PassPercent:            survey.PassPercent,
+		NoResponseReminderDays: survey.NoResponseReminderDays,
+		ReminderWebhookURL:     survey.ReminderWebhookURL,
+		PublishedAt:            survey.PublishedAt,
+		HoneypotAction:         survey.HoneypotAction,
+		TeamID:                 survey.TeamID,
+		Quotas:                 survey.Quotas,
+		QuestionPools:          survey.QuestionPools,
+		Variants:               survey.Variants,
+		CreatedAt:              survey.CreatedAt,
+		UpdatedAt:              survey.UpdatedAt,
+		Questions:              questions,
 	}
 }
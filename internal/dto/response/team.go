@@ -0,0 +1,66 @@
+package response
+
+import (
+	"time"
+
+	"survey-system/internal/model"
+)
+
+// TeamResponse represents a team; members are only included when a single team is fetched
+type TeamResponse struct {
+	ID        uint                 `json:"id"`
+	Name      string               `json:"name"`
+	OwnerID   uint                 `json:"owner_id"`
+	Role      string               `json:"role"` // Role of the requesting user in the team
+	Members   []TeamMemberResponse `json:"members,omitempty"`
+	CreatedAt time.Time            `json:"created_at"`
+	UpdatedAt time.Time            `json:"updated_at"`
+}
+
+// TeamMemberResponse represents a member of a team
+type TeamMemberResponse struct {
+	UserID    uint      `json:"user_id"`
+	Username  string    `json:"username"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"` // owner or member
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TeamSurveyResponse represents a survey shared with a team
+type TeamSurveyResponse struct {
+	ID        uint      `json:"id"`
+	UserID    uint      `json:"user_id"` // Owner of the survey
+	Title     string    `json:"title"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ToTeamResponse converts a Team model to TeamResponse for a user with the given role
+func ToTeamResponse(team *model.Team, role string) TeamResponse {
+	result := TeamResponse{
+		ID:        team.ID,
+		Name:      team.Name,
+		OwnerID:   team.OwnerID,
+		Role:      role,
+		CreatedAt: team.CreatedAt,
+		UpdatedAt: team.UpdatedAt,
+	}
+	if len(team.Members) > 0 {
+		result.Members = make([]TeamMemberResponse, len(team.Members))
+		for i := range team.Members {
+			result.Members[i] = ToTeamMemberResponse(&team.Members[i])
+		}
+	}
+	return result
+}
+
+// ToTeamMemberResponse converts a TeamMember model with its user to TeamMemberResponse
+func ToTeamMemberResponse(member *model.TeamMember) TeamMemberResponse {
+	return TeamMemberResponse{
+		UserID:    member.UserID,
+		Username:  member.User.Username,
+		Email:     member.User.Email,
+		Role:      member.Role,
+		CreatedAt: member.CreatedAt,
+	}
+}
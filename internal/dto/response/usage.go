@@ -0,0 +1,18 @@
+package response
+
+import "time"
+
+// UsageResponse reports a user's consumption of their daily quotas
+type UsageResponse struct {
+	Date     string       `json:"date"`      // Day being counted, in server time
+	ResetsAt time.Time    `json:"resets_at"` // When the counters start over
+	Quotas   []UsageQuota `json:"quotas"`
+}
+
+// UsageQuota reports the consumption of one kind of action
+type UsageQuota struct {
+	Kind      string `json:"kind"`
+	Used      int64  `json:"used"`
+	Limit     int    `json:"limit"`               // 0 means unlimited
+	Remaining *int64 `json:"remaining,omitempty"` // Omitted when unlimited
+}
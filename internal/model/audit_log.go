@@ -0,0 +1,35 @@
+package model
+
+import "time"
+
+// AuditLog records an administrative action that is not tied to a single survey,
+// such as starting an impersonation session and the changes made during it
+type AuditLog struct {
+	ID           uint   `gorm:"primaryKey" json:"id"`
+	ActorID      uint   `gorm:"index;not null" json:"actor_id"` // Admin who acted
+	Action       string `gorm:"size:50;not null;index" json:"action"`
+	TargetUserID *uint  `gorm:"index" json:"target_user_id"` // User acted on or impersonated
+	// Audit log entry of the impersonation session a request was made in
+	ImpersonationID *uint      `gorm:"index" json:"impersonation_id"`
+	Reason          string     `gorm:"size:500" json:"reason"`
+	Method          string     `gorm:"size:10" json:"method"`
+	Path            string     `gorm:"size:500" json:"path"`
+	Status          int        `json:"status"` // HTTP status of an audited request
+	IPAddress       string     `gorm:"size:45" json:"ip_address"`
+	ExpiresAt       *time.Time `json:"expires_at"` // End of an impersonation session
+	CreatedAt       time.Time  `gorm:"index" json:"created_at"`
+
+	// Associations
+	Actor User `gorm:"foreignKey:ActorID;constraint:OnDelete:CASCADE" json:"actor,omitempty"`
+}
+
+// TableName specifies the table name for AuditLog model
+func (AuditLog) TableName() string {
+	return "audit_logs"
+}
+
+// Audit log actions
+const (
+	AuditUserImpersonated    = "user.impersonated"     // An admin started acting as another user
+	AuditImpersonatedRequest = "impersonation.request" // A change made with an impersonation token
+)
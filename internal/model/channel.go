@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+// NotificationChannel posts survey notifications to a Slack, DingTalk or WeCom webhook
+type NotificationChannel struct {
+	ID                  uint      `gorm:"primaryKey" json:"id"`
+	SurveyID            uint      `gorm:"index;not null" json:"survey_id"`
+	Type                string    `gorm:"size:20;not null" json:"type"` // slack, dingtalk, wecom
+	WebhookURL          string    `gorm:"size:500;not null" json:"webhook_url"`
+	Secret              string    `gorm:"size:255" json:"-"` // DingTalk signing secret
+	OnResponse          bool      `gorm:"default:false" json:"on_response"`
+	DailyThreshold      int       `gorm:"default:0" json:"daily_threshold"`     // Notify once a day when responses reach this count; 0 disables
+	ThresholdNotifiedOn string    `gorm:"size:10" json:"threshold_notified_on"` // Day the threshold was last announced, YYYY-MM-DD
+	Enabled             bool      `gorm:"not null" json:"enabled"`
+	CreatedAt           time.Time `json:"created_at"`
+	UpdatedAt           time.Time `json:"updated_at"`
+
+	// Associations
+	Survey Survey `gorm:"foreignKey:SurveyID;constraint:OnDelete:CASCADE" json:"survey,omitempty"`
+}
+
+// TableName specifies the table name for NotificationChannel model
+func (NotificationChannel) TableName() string {
+	return "notification_channels"
+}
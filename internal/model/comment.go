@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+// ResponseComment is a note left by a survey reviewer on a single response
+type ResponseComment struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	ResponseID uint      `gorm:"index;not null" json:"response_id"`
+	UserID     uint      `gorm:"index;not null" json:"user_id"` // Author
+	Content    string    `gorm:"type:text;not null" json:"content"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+
+	// Associations
+	Response Response `gorm:"foreignKey:ResponseID;constraint:OnDelete:CASCADE" json:"response,omitempty"`
+	User     User     `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"user,omitempty"`
+}
+
+// TableName specifies the table name for ResponseComment model
+func (ResponseComment) TableName() string {
+	return "response_comments"
+}
@@ -0,0 +1,40 @@
+package model
+
+import "time"
+
+// DelegationTokenPrefix marks bearer tokens that are delegation tokens rather than JWTs
+const DelegationTokenPrefix = "dlg_"
+
+// Delegation is a scoped token issued by a survey owner that only permits generating
+// share links for the survey, e.g. for a field coordinator without an account
+// Its prefill values are locked into every link generated with it
+type Delegation struct {
+	ID          uint            `gorm:"primaryKey" json:"id"`
+	SurveyID    uint            `gorm:"index;not null" json:"survey_id"`
+	Name        string          `gorm:"size:100;not null" json:"name"`         // Who the token was issued to, e.g. "North region coordinator"
+	TokenHash   string          `gorm:"uniqueIndex;size:64;not null" json:"-"` // SHA-256 of the token; the token itself is never stored
+	PrefillData PrefillDataType `gorm:"type:json" json:"prefill_data"`         // Locked prefill values
+	AllowedKeys StringList      `gorm:"type:json" json:"allowed_keys"`         // Other prefill keys the delegate may set
+	ExpiresAt   *time.Time      `json:"expires_at"`                            // Nil never expires
+	RevokedAt   *time.Time      `json:"revoked_at"`
+	LastUsedAt  *time.Time      `json:"last_used_at"`
+	LinkCount   int             `gorm:"default:0" json:"link_count"` // Share links generated with the token
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+
+	// Associations
+	Survey Survey `gorm:"foreignKey:SurveyID;constraint:OnDelete:CASCADE" json:"survey,omitempty"`
+}
+
+// TableName specifies the table name for Delegation model
+func (Delegation) TableName() string {
+	return "delegations"
+}
+
+// IsActive reports whether the token is neither revoked nor expired
+func (d *Delegation) IsActive() bool {
+	if d.RevokedAt != nil {
+		return false
+	}
+	return d.ExpiresAt == nil || time.Now().Before(*d.ExpiresAt)
+}
@@ -0,0 +1,30 @@
+package model
+
+import "time"
+
+// ResponseDraft represents a partially completed response saved by a respondent
+// so they can resume it later through an emailed link
+type ResponseDraft struct {
+	ID        uint         `gorm:"primaryKey" json:"id"`
+	SurveyID  uint         `gorm:"index;not null" json:"survey_id"`
+	OneLinkID uint         `gorm:"uniqueIndex;not null" json:"one_link_id"`
+	Email     string       `gorm:"size:100;not null" json:"email"`
+	Data      ResponseData `gorm:"type:json;not null" json:"data"`
+	ExpiresAt time.Time    `gorm:"index;not null" json:"expires_at"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+
+	// Associations
+	Survey  Survey  `gorm:"foreignKey:SurveyID;constraint:OnDelete:CASCADE" json:"survey,omitempty"`
+	OneLink OneLink `gorm:"foreignKey:OneLinkID;constraint:OnDelete:CASCADE" json:"one_link,omitempty"`
+}
+
+// TableName specifies the table name for ResponseDraft model
+func (ResponseDraft) TableName() string {
+	return "response_drafts"
+}
+
+// IsExpired checks if the draft has expired
+func (d *ResponseDraft) IsExpired() bool {
+	return time.Now().After(d.ExpiresAt)
+}
@@ -0,0 +1,38 @@
+package model
+
+import "time"
+
+// SurveyEvent records a change made to a survey, feeding the survey activity feed
+type SurveyEvent struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	SurveyID   uint      `gorm:"index:idx_survey_events_survey_created;not null" json:"survey_id"`
+	UserID     uint      `gorm:"not null" json:"user_id"` // Who made the change
+	Type       string    `gorm:"size:50;not null" json:"type"`
+	QuestionID *uint     `json:"question_id"`              // Set for question events
+	Title      string    `gorm:"size:500" json:"title"`    // Question title at the time of the event
+	Campaign   string    `gorm:"size:100" json:"campaign"` // Campaign label of generated links
+	Subnet     string    `gorm:"size:50" json:"subnet"`    // Network of a submission burst
+	CreatedAt  time.Time `gorm:"index:idx_survey_events_survey_created" json:"created_at"`
+
+	// Associations
+	Survey Survey `gorm:"foreignKey:SurveyID;constraint:OnDelete:CASCADE" json:"survey,omitempty"`
+}
+
+// TableName specifies the table name for SurveyEvent model
+func (SurveyEvent) TableName() string {
+	return "survey_events"
+}
+
+// Survey event types
+const (
+	EventSurveyPublished   = "survey.published"
+	EventSurveyArchived    = "survey.archived"
+	EventSurveyUnarchived  = "survey.unarchived"
+	EventSurveyTransferred = "survey.transferred"
+	EventQuestionCreated   = "question.created"
+	EventQuestionUpdated   = "question.updated"
+	EventQuestionDeleted   = "question.deleted"
+	EventLinkGenerated     = "link.generated"
+	EventLinksRevoked      = "links.revoked"
+	EventResponsesBurst    = "responses.burst" // Recorded by the system, UserID is 0
+)
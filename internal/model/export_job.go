@@ -0,0 +1,37 @@
+package model
+
+import "time"
+
+// ExportJob is an export generated in the background and kept for download until it expires
+type ExportJob struct {
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	SurveyID    uint       `gorm:"index;not null" json:"survey_id"`
+	UserID      uint       `gorm:"index;not null" json:"user_id"`
+	Status      string     `gorm:"size:20;not null" json:"status"`
+	Format      string     `gorm:"size:10;not null" json:"format"`
+	Encrypted   bool       `gorm:"not null" json:"encrypted"` // Wrapped in a password-protected ZIP
+	Filename    string     `gorm:"size:255" json:"filename"`
+	ContentType string     `gorm:"size:100" json:"content_type"`
+	StorageKey  string     `gorm:"size:255" json:"-"` // Location of the file in the configured storage
+	Size        int64      `json:"size"`
+	Error       string     `gorm:"size:500" json:"error"`
+	ExpiresAt   time.Time  `gorm:"index;not null" json:"expires_at"`
+	CompletedAt *time.Time `json:"completed_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+
+	// Associations
+	Survey Survey `gorm:"foreignKey:SurveyID;constraint:OnDelete:CASCADE" json:"survey,omitempty"`
+}
+
+// TableName specifies the table name for ExportJob model
+func (ExportJob) TableName() string {
+	return "export_jobs"
+}
+
+// Export job status constants
+const (
+	ExportJobPending   = "pending"
+	ExportJobRunning   = "running"
+	ExportJobCompleted = "completed"
+	ExportJobFailed    = "failed"
+)
@@ -0,0 +1,51 @@
+package model
+
+import "time"
+
+// Hook is a REST Hooks subscription, e.g. created by Zapier or Make, that receives
+// survey events at its target URL until it is unsubscribed
+type Hook struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	UserID    uint      `gorm:"index;not null" json:"user_id"` // Who subscribed
+	SurveyID  uint      `gorm:"index;not null" json:"survey_id"`
+	Event     string    `gorm:"size:50;not null" json:"event"`
+	TargetURL string    `gorm:"size:500;not null" json:"target_url"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Associations
+	Survey Survey `gorm:"foreignKey:SurveyID;constraint:OnDelete:CASCADE" json:"survey,omitempty"`
+}
+
+// TableName specifies the table name for Hook model
+func (Hook) TableName() string {
+	return "hooks"
+}
+
+// Hook event types
+const (
+	HookEventResponseSubmitted = "response.submitted"
+)
+
+// HookDelivery is one attempt to deliver an event to a hook. The delivered payload is
+// kept so failed deliveries can be sent again; each retry is recorded as a new attempt
+type HookDelivery struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	HookID     uint      `gorm:"index;not null" json:"hook_id"`
+	Event      string    `gorm:"size:50;not null" json:"event"`
+	Payload    string    `gorm:"type:mediumtext;not null" json:"-"` // JSON body as delivered
+	StatusCode int       `gorm:"default:0" json:"status_code"`      // 0 when the endpoint did not answer
+	Error      string    `gorm:"size:500" json:"error"`
+	Succeeded  bool      `gorm:"index;default:false" json:"succeeded"`
+	RetryOf    *uint     `gorm:"index" json:"retry_of"` // Delivery this attempt retried
+	ResponseID *uint     `gorm:"index" json:"-"`        // Response the payload was built from
+	DurationMs int64     `gorm:"default:0" json:"duration_ms"`
+	CreatedAt  time.Time `gorm:"index" json:"created_at"`
+
+	// Associations
+	Hook Hook `gorm:"foreignKey:HookID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// TableName specifies the table name for HookDelivery model
+func (HookDelivery) TableName() string {
+	return "hook_deliveries"
+}
@@ -0,0 +1,27 @@
+package model
+
+import "time"
+
+// LinkTemplate is a named set of share link settings saved for a survey
+// Its fixed prefill values are copied into every link generated from it, while
+// the values of its variables are supplied for each link
+type LinkTemplate struct {
+	ID             uint            `gorm:"primaryKey" json:"id"`
+	SurveyID       uint            `gorm:"uniqueIndex:idx_link_templates_survey_name,priority:1;not null" json:"survey_id"`
+	Name           string          `gorm:"size:100;uniqueIndex:idx_link_templates_survey_name,priority:2;not null" json:"name"`
+	PrefillData    PrefillDataType `gorm:"type:json" json:"prefill_data"`     // Fixed prefill values
+	Variables      StringList      `gorm:"type:json" json:"variables"`        // Prefill keys whose values are given per link
+	ExpiresInHours int             `gorm:"default:0" json:"expires_in_hours"` // Link lifetime; 0 uses the survey or global default
+	RedirectURL    string          `gorm:"size:500" json:"redirect_url"`      // Default thank-you page
+	Campaign       string          `gorm:"size:100" json:"campaign"`          // Default campaign label
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+
+	// Associations
+	Survey Survey `gorm:"foreignKey:SurveyID;constraint:OnDelete:CASCADE" json:"survey,omitempty"`
+}
+
+// TableName specifies the table name for LinkTemplate model
+func (LinkTemplate) TableName() string {
+	return "link_templates"
+}
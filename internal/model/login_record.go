@@ -0,0 +1,34 @@
+package model
+
+import "time"
+
+// Reasons a login attempt of a known user failed
+const (
+	LoginFailedPassword    = "invalid_password"
+	LoginFailedDeactivated = "account_deactivated"
+	LoginFailedUnverified  = "email_not_verified"
+)
+
+// LoginRecord is a login attempt to an existing account, kept as the account's login history
+type LoginRecord struct {
+	ID        uint   `gorm:"primaryKey" json:"id"`
+	UserID    uint   `gorm:"index:idx_login_history_user;not null" json:"-"`
+	Succeeded bool   `gorm:"not null" json:"succeeded"`
+	Failure   string `gorm:"size:30" json:"failure,omitempty"` // Why a failed attempt was rejected
+	IPAddress string `gorm:"size:45" json:"ip_address"`
+	Network   string `gorm:"size:50" json:"-"` // /24 (IPv4) or /64 (IPv6) subnet of the IP address, the location new logins are compared by
+	UserAgent string `gorm:"size:500" json:"user_agent"`
+	// Set on a successful login with a user agent or from a network the user never logged
+	// in with before; the user is notified of such logins
+	NewDevice   bool      `gorm:"not null" json:"new_device"`
+	NewLocation bool      `gorm:"not null" json:"new_location"`
+	CreatedAt   time.Time `gorm:"index:idx_login_history_user" json:"created_at"`
+
+	// Associations
+	User User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// TableName specifies the table name for LoginRecord model
+func (LoginRecord) TableName() string {
+	return "login_history"
+}
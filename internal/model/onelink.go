@@ -1,7 +1,9 @@
 package model
 
 import (
+	"crypto/sha256"
 	"database/sql/driver"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"time"
@@ -9,15 +11,26 @@ import (
 
 // OneLink represents a one-time access link for a survey
 type OneLink struct {
-	ID          uint            `gorm:"primaryKey" json:"id"`
-	SurveyID    uint            `gorm:"index;not null" json:"survey_id"`
-	Token       string          `gorm:"uniqueIndex;size:500;not null" json:"token"` // Encrypted token
-	PrefillData PrefillDataType `gorm:"type:json" json:"prefill_data"`              // JSON prefill values
-	ExpiresAt   time.Time       `gorm:"index;not null" json:"expires_at"`
-	Used        bool            `gorm:"default:false;index" json:"used"`
-	UsedAt      *time.Time      `json:"used_at"`
-	AccessedAt  *time.Time      `json:"accessed_at"`
-	CreatedAt   time.Time       `json:"created_at"`
+	ID           uint            `gorm:"primaryKey" json:"id"`
+	SurveyID     uint            `gorm:"index;not null" json:"survey_id"`
+	TokenHash    string          `gorm:"uniqueIndex;size:64;not null" json:"-"` // SHA-256 of Token, used for lookups
+	Token        string          `gorm:"size:500;not null" json:"token"`        // Encrypted token
+	PrefillData  PrefillDataType `gorm:"type:json" json:"prefill_data"`         // JSON prefill values
+	ExpiresAt    time.Time       `gorm:"index;not null" json:"expires_at"`
+	Used         bool            `gorm:"default:false;index" json:"used"`
+	UsedAt       *time.Time      `json:"used_at"`
+	AccessedAt   *time.Time      `json:"accessed_at"`
+	StartedAt    *time.Time      `json:"started_at"`                          // First opening while the survey had a time limit; its timed session starts here
+	NotifiedAt   *time.Time      `json:"notified_at"`                         // When the expiration notification was sent
+	RevokedAt    *time.Time      `json:"revoked_at"`                          // Set when the owner revoked the link; revoked links cannot be opened
+	RedirectURL  string          `gorm:"size:500" json:"redirect_url"`        // Thank-you page returned after submission
+	Campaign     string          `gorm:"size:100;index" json:"campaign"`      // Distribution channel label, copied to the response
+	RespondentID string          `gorm:"size:255;index" json:"respondent_id"` // Known respondent the link is bound to, e.g. employee number or email hash
+	IsTest       bool            `gorm:"default:false" json:"is_test"`        // Responses through the link are flagged as test data
+	Pooled       bool            `gorm:"default:false;index" json:"pooled"`   // Generated into a pool without prefill data, which is bound when claimed
+	Variant      string          `gorm:"size:50;index" json:"variant"`        // Survey variant assigned to the link, copied to the response
+	ClaimedAt    *time.Time      `json:"claimed_at"`                          // When a pool link was claimed
+	CreatedAt    time.Time       `json:"created_at"`
 
 	// Associations
 	Survey    Survey     `gorm:"foreignKey:SurveyID;constraint:OnDelete:CASCADE" json:"survey,omitempty"`
@@ -29,14 +42,26 @@ func (OneLink) TableName() string {
 	return "one_links"
 }
 
+// HashLinkToken returns the lookup key of a link token
+// Tokens are several hundred characters long, so links are indexed and cached by this hash
+func HashLinkToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
 // IsExpired checks if the link has expired
 func (o *OneLink) IsExpired() bool {
 	return time.Now().After(o.ExpiresAt)
 }
 
-// IsValid checks if the link is valid (not used and not expired)
+// IsRevoked checks if the owner revoked the link
+func (o *OneLink) IsRevoked() bool {
+	return o.RevokedAt != nil
+}
+
+// IsValid checks if the link is valid (not used, revoked or expired)
 func (o *OneLink) IsValid() bool {
-	return !o.Used && !o.IsExpired()
+	return !o.Used && !o.IsRevoked() && !o.IsExpired()
 }
 
 // PrefillDataType is a custom type for handling JSON prefill data
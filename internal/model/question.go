@@ -11,13 +11,19 @@ import (
 type Question struct {
 	ID          uint           `gorm:"primaryKey" json:"id"`
 	SurveyID    uint           `gorm:"index;not null" json:"survey_id"`
-	Type        string         `gorm:"size:20;not null" json:"type"` // text, single, multiple, table
+	Type        string         `gorm:"size:20;not null" json:"type"` // text, single, multiple, table, nps, slider
 	Title       string         `gorm:"size:500;not null" json:"title"`
 	Description string         `gorm:"type:text" json:"description"`
 	Required    bool           `gorm:"default:false" json:"required"`
 	Order       int            `gorm:"not null" json:"order"`
 	Config      QuestionConfig `gorm:"type:json" json:"config"`
 	PrefillKey  string         `gorm:"size:100" json:"prefill_key"`
+	PrefillType string         `gorm:"size:20" json:"prefill_type"`       // string, number or option; empty accepts any value
+	LockPrefill bool           `gorm:"default:false" json:"lock_prefill"` // Prefilled answers cannot be changed by respondents
+	Hidden      bool           `gorm:"default:false" json:"hidden"`       // Not shown to respondents; answered only from the link's prefill data
+	Sensitive   bool           `gorm:"default:false" json:"sensitive"`    // Answers are redacted from debug traffic logs
+	Pool        string         `gorm:"size:50" json:"pool"`               // Key of the survey question pool the question is drawn from; empty for every respondent
+	Variant     string         `gorm:"size:50" json:"variant"`            // Survey variant whose links receive the question; empty for every variant
 	CreatedAt   time.Time      `json:"created_at"`
 	UpdatedAt   time.Time      `json:"updated_at"`
 
@@ -36,26 +42,156 @@ const (
 	QuestionTypeSingle   = "single"
 	QuestionTypeMultiple = "multiple"
 	QuestionTypeTable    = "table"
+	QuestionTypeNPS      = "nps"
+	QuestionTypeSlider   = "slider"
 )
 
+// Prefill type constants, checked against share link prefill values
+const (
+	PrefillTypeString = "string"
+	PrefillTypeNumber = "number"
+	PrefillTypeOption = "option" // Option ID of a choice question; a list of IDs for multiple choice
+)
+
+// NPS categories of a 0-10 recommendation score
+const (
+	NPSPromoter  = "promoter"  // 9-10
+	NPSPassive   = "passive"   // 7-8
+	NPSDetractor = "detractor" // 0-6
+)
+
+// NPSScore extracts a 0-10 integer score from an NPS answer value
+func NPSScore(value interface{}) (int, bool) {
+	number, ok := value.(float64)
+	if !ok || number != float64(int(number)) || number < 0 || number > 10 {
+		return 0, false
+	}
+	return int(number), true
+}
+
+// NPSCategory returns the NPS category of a 0-10 score
+func NPSCategory(score int) string {
+	switch {
+	case score >= 9:
+		return NPSPromoter
+	case score >= 7:
+		return NPSPassive
+	default:
+		return NPSDetractor
+	}
+}
+
 // QuestionConfig holds the configuration for different question types
 type QuestionConfig struct {
+	// For text questions; 0 falls back to the server-wide limit
+	MaxLength int `json:"max_length,omitempty"`
+
 	// For single/multiple choice questions
-	Options []string `json:"options,omitempty"`
+	Options []QuestionOption `json:"options,omitempty"`
+
+	// For slider questions
+	Min  *float64 `json:"min,omitempty"`
+	Max  *float64 `json:"max,omitempty"`
+	Step float64  `json:"step,omitempty"` // 0 allows any value between min and max
+	Unit string   `json:"unit,omitempty"` // Display unit, e.g. "km" or "%"
 
 	// For table questions
 	Columns   []TableColumn `json:"columns,omitempty"`
 	MinRows   int           `json:"min_rows,omitempty"`
 	MaxRows   int           `json:"max_rows,omitempty"`
 	CanAddRow bool          `json:"can_add_row,omitempty"`
+
+	// Largest accepted difference between a submitted column total and the sum of the column
+	TotalTolerance float64 `json:"total_tolerance,omitempty"`
+
+	// For assessments: a correct answer earns Points. Correct holds option IDs for choice
+	// questions, of which a single choice answer must pick any and a multiple choice answer
+	// exactly all, or the accepted answers of a text question, compared ignoring case
+	Correct []string `json:"correct,omitempty"`
+	Points  float64  `json:"points,omitempty"`
+}
+
+// QuestionOption represents a choice of a single/multiple choice question
+// Answers reference options by ID
+type QuestionOption struct {
+	ID       string   `json:"id"`
+	Label    string   `json:"label"`
+	Score    *float64 `json:"score,omitempty"`     // Numeric value used for average scores in statistics
+	ImageURL string   `json:"image_url,omitempty"` // Optional picture shown with the option
+}
+
+// UnmarshalJSON accepts both structured options and legacy plain strings,
+// in which case the string is used as both ID and label
+func (o *QuestionOption) UnmarshalJSON(data []byte) error {
+	var label string
+	if err := json.Unmarshal(data, &label); err == nil {
+		*o = QuestionOption{ID: label, Label: label}
+		return nil
+	}
+
+	type plainOption QuestionOption
+	var option plainOption
+	if err := json.Unmarshal(data, &option); err != nil {
+		return err
+	}
+	*o = QuestionOption(option)
+	if o.ID == "" {
+		o.ID = o.Label
+	}
+	return nil
+}
+
+// FindOption returns the option with the given ID
+func (c *QuestionConfig) FindOption(id string) (*QuestionOption, bool) {
+	for i := range c.Options {
+		if c.Options[i].ID == id {
+			return &c.Options[i], true
+		}
+	}
+	return nil, false
+}
+
+// OptionLabel returns the label of the option with the given ID, or the ID itself if unknown
+func (c *QuestionConfig) OptionLabel(id string) string {
+	if option, ok := c.FindOption(id); ok {
+		return option.Label
+	}
+	return id
+}
+
+// HasScores reports whether any option carries a score
+func (c *QuestionConfig) HasScores() bool {
+	for _, option := range c.Options {
+		if option.Score != nil {
+			return true
+		}
+	}
+	return false
 }
 
 // TableColumn represents a column in a table question
 type TableColumn struct {
 	ID      string   `json:"id"`
-	Type    string   `json:"type"` // text, number, select
+	Type    string   `json:"type"` // text, number, select, cascade
 	Label   string   `json:"label"`
 	Options []string `json:"options,omitempty"` // for select type
+
+	// For cascade type: the earlier select or cascade column whose value decides
+	// the options, and the options offered for each of its values
+	DependsOn string              `json:"depends_on,omitempty"`
+	Cascade   map[string][]string `json:"cascade,omitempty"`
+
+	// Display hints for respondents
+	Placeholder string `json:"placeholder,omitempty"`
+	HelpText    string `json:"help_text,omitempty"`
+	Width       int    `json:"width,omitempty"` // Suggested width in pixels; 0 lets the client decide
+
+	// Default prefills the cells of new rows; with ApplyDefault, cells submitted empty are stored as Default
+	Default      string `json:"default,omitempty"`
+	ApplyDefault bool   `json:"apply_default,omitempty"`
+
+	// For number type: answers end with a totals row whose cell must equal the column's sum
+	Total bool `json:"total,omitempty"`
 }
 
 // Scan implements the sql.Scanner interface for QuestionConfig
@@ -75,8 +211,13 @@ func (c *QuestionConfig) Scan(value interface{}) error {
 
 // Value implements the driver.Valuer interface for QuestionConfig
 func (c QuestionConfig) Value() (driver.Value, error) {
-	if c.Options == nil && c.Columns == nil {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	// Store NULL instead of an empty object for questions without configuration
+	if string(data) == "{}" {
 		return nil, nil
 	}
-	return json.Marshal(c)
+	return data, nil
 }
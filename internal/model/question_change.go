@@ -0,0 +1,75 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// QuestionChange records one edit to a question for its change history
+// Changes outlive the question, so deleted questions keep their history
+type QuestionChange struct {
+	ID         uint         `gorm:"primaryKey" json:"id"`
+	QuestionID uint         `gorm:"index:idx_question_changes_question_created;not null" json:"question_id"`
+	SurveyID   uint         `gorm:"index;not null" json:"survey_id"`
+	UserID     uint         `gorm:"not null" json:"user_id"`          // Editor
+	Action     string       `gorm:"size:20;not null" json:"action"`   // created, updated, reordered, restored or deleted
+	Fields     FieldChanges `gorm:"type:json;not null" json:"fields"` // What changed, from old to new value
+	// For restored changes, the entry whose version was restored
+	RestoredFromID *uint     `json:"restored_from_id"`
+	CreatedAt      time.Time `gorm:"index:idx_question_changes_question_created" json:"created_at"`
+
+	// Associations
+	Survey Survey `gorm:"foreignKey:SurveyID;constraint:OnDelete:CASCADE" json:"survey,omitempty"`
+	User   User   `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"user,omitempty"`
+}
+
+// TableName specifies the table name for QuestionChange model
+func (QuestionChange) TableName() string {
+	return "question_changes"
+}
+
+// Question change actions
+const (
+	QuestionChangeCreated   = "created"
+	QuestionChangeUpdated   = "updated"
+	QuestionChangeReordered = "reordered"
+	QuestionChangeRestored  = "restored"
+	QuestionChangeDeleted   = "deleted"
+)
+
+// FieldChange is the old and new value of one question field
+// Config settings are listed separately, e.g. "config.options"; Old is nil for
+// fields set on creation and New is nil for fields cleared or deleted
+type FieldChange struct {
+	Field string      `json:"field"`
+	Old   interface{} `json:"old"`
+	New   interface{} `json:"new"`
+}
+
+// FieldChanges is the list of fields changed by a QuestionChange
+type FieldChanges []FieldChange
+
+// Scan implements the sql.Scanner interface for FieldChanges
+func (f *FieldChanges) Scan(value interface{}) error {
+	if value == nil {
+		*f = FieldChanges{}
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("failed to unmarshal FieldChanges value: %v", value)
+	}
+
+	return json.Unmarshal(bytes, f)
+}
+
+// Value implements the driver.Valuer interface for FieldChanges
+func (f FieldChanges) Value() (driver.Value, error) {
+	if f == nil {
+		return json.Marshal([]FieldChange{})
+	}
+	return json.Marshal([]FieldChange(f))
+}
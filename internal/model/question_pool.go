@@ -0,0 +1,50 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// QuestionPool draws a random subset of the questions marked with its key for each
+// respondent, e.g. 3 of 10 knowledge questions
+type QuestionPool struct {
+	Key  string `json:"key"`  // Referenced by the pool field of questions
+	Draw int    `json:"draw"` // Questions each respondent receives; the whole pool when it has no more
+}
+
+// QuestionPools is a custom type for storing question pools as JSON
+type QuestionPools []QuestionPool
+
+// Find returns the pool with the given key
+func (p QuestionPools) Find(key string) (QuestionPool, bool) {
+	for _, pool := range p {
+		if pool.Key == key {
+			return pool, true
+		}
+	}
+	return QuestionPool{}, false
+}
+
+// Scan implements the sql.Scanner interface for QuestionPools
+func (p *QuestionPools) Scan(value interface{}) error {
+	if value == nil {
+		*p = QuestionPools{}
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("failed to unmarshal QuestionPools value: %v", value)
+	}
+
+	return json.Unmarshal(bytes, p)
+}
+
+// Value implements the driver.Valuer interface for QuestionPools
+func (p QuestionPools) Value() (driver.Value, error) {
+	if len(p) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(p)
+}
@@ -0,0 +1,51 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// QuotaRule caps the number of responses for one prefill segment,
+// e.g. at most 100 responses where prefill.department = "Sales"
+type QuotaRule struct {
+	Field      string `json:"field"`       // Prefill key the segment is defined on
+	Value      string `json:"value"`       // Prefill value identifying the segment
+	Limit      int    `json:"limit"`       // Maximum number of responses in the segment
+	CloseLinks bool   `json:"close_links"` // Expire the segment's unused links once the quota is full
+}
+
+// Matches reports whether a link's prefill data belongs to the rule's segment
+func (r *QuotaRule) Matches(prefill map[string]interface{}) bool {
+	value, ok := prefill[r.Field]
+	if !ok || value == nil {
+		return false
+	}
+	return fmt.Sprint(value) == r.Value
+}
+
+// QuotaRules is a custom type for storing quota rules as JSON
+type QuotaRules []QuotaRule
+
+// Scan implements the sql.Scanner interface for QuotaRules
+func (q *QuotaRules) Scan(value interface{}) error {
+	if value == nil {
+		*q = QuotaRules{}
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("failed to unmarshal QuotaRules value: %v", value)
+	}
+
+	return json.Unmarshal(bytes, q)
+}
+
+// Value implements the driver.Valuer interface for QuotaRules
+func (q QuotaRules) Value() (driver.Value, error) {
+	if len(q) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(q)
+}
@@ -0,0 +1,29 @@
+package model
+
+import "time"
+
+// ReportSubscription subscribes an email address to periodic summary reports of a survey
+type ReportSubscription struct {
+	ID         uint       `gorm:"primaryKey" json:"id"`
+	SurveyID   uint       `gorm:"uniqueIndex:idx_report_subscriptions_survey_frequency_email;not null" json:"survey_id"`
+	UserID     uint       `gorm:"index;not null" json:"user_id"` // Owner who created the subscription
+	Frequency  string     `gorm:"size:10;uniqueIndex:idx_report_subscriptions_survey_frequency_email;not null" json:"frequency"`
+	Email      string     `gorm:"size:100;uniqueIndex:idx_report_subscriptions_survey_frequency_email;not null" json:"email"`
+	LastSentAt *time.Time `gorm:"index" json:"last_sent_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+
+	// Associations
+	Survey Survey `gorm:"foreignKey:SurveyID;constraint:OnDelete:CASCADE" json:"survey,omitempty"`
+}
+
+// TableName specifies the table name for ReportSubscription model
+func (ReportSubscription) TableName() string {
+	return "report_subscriptions"
+}
+
+// Report frequency constants
+const (
+	ReportFrequencyDaily  = "daily"
+	ReportFrequencyWeekly = "weekly" // sent on Mondays
+)
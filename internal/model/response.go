@@ -9,14 +9,42 @@ import (
 
 // Response represents a survey response/submission
 type Response struct {
-	ID          uint         `gorm:"primaryKey" json:"id"`
-	SurveyID    uint         `gorm:"index;not null" json:"survey_id"`
-	OneLinkID   uint         `gorm:"index" json:"one_link_id"`
-	Data        ResponseData `gorm:"type:json;not null" json:"data"`
-	IPAddress   string       `gorm:"size:45" json:"ip_address"`
-	UserAgent   string       `gorm:"size:500" json:"user_agent"`
-	SubmittedAt time.Time    `gorm:"not null;index" json:"submitted_at"`
-	CreatedAt   time.Time    `json:"created_at"`
+	ID        uint         `gorm:"primaryKey" json:"id"`
+	SurveyID  uint         `gorm:"index;index:idx_responses_survey_submitted,priority:1;uniqueIndex:idx_responses_survey_respondent,priority:1;uniqueIndex:idx_responses_survey_number,priority:1;not null" json:"survey_id"`
+	OneLinkID *uint        `gorm:"index" json:"one_link_id"` // NULL for imported responses
+	Data      ResponseData `gorm:"type:json;not null" json:"data"`
+	IPAddress string       `gorm:"size:45" json:"ip_address"`
+	UserAgent string       `gorm:"size:500" json:"user_agent"`
+	Campaign  string       `gorm:"size:100;index" json:"campaign"`     // Campaign label of the one-time link used
+	Variant   string       `gorm:"size:50;index" json:"variant"`       // Survey variant of the one-time link used
+	IsTest    bool         `gorm:"default:false;index" json:"is_test"` // Submitted through a test link, left out of statistics and exports by default
+	// Respondent of a bound link; NULL for anonymous links so the unique index only
+	// allows one response per known respondent
+	RespondentID *string `gorm:"size:255;uniqueIndex:idx_responses_survey_respondent,priority:2" json:"respondent_id"`
+	// Sequential number within the survey, shown as e.g. R-0001; NULL for test
+	// responses and responses stored before numbering was introduced
+	RespondentNumber *uint     `gorm:"uniqueIndex:idx_responses_survey_number,priority:2" json:"respondent_number"`
+	SubmittedAt      time.Time `gorm:"not null;index;index:idx_responses_survey_submitted,priority:2" json:"submitted_at"`
+	CreatedAt        time.Time `json:"created_at"`
+
+	// Corrections made by the respondent within the survey's edit window; the answers
+	// replaced by each correction are kept as a ResponseVersion
+	Version  int        `gorm:"default:1;not null" json:"version"`
+	EditedAt *time.Time `json:"edited_at"` // NULL until the first correction
+
+	// Score of an assessment response and the points its questions were worth; NULL when
+	// the survey was not an assessment
+	Score    *float64 `json:"score"`
+	MaxScore *float64 `json:"max_score"`
+
+	// Set on responses that look automated, e.g. submitted in a burst from one network
+	Flagged    bool   `gorm:"default:false;index" json:"flagged"`
+	FlagReason string `gorm:"size:50" json:"flag_reason"`
+
+	// Review of the response by the survey's owner; ReviewedAt and ReviewedBy are NULL while pending
+	ReviewStatus string     `gorm:"size:20;default:'pending';not null;index" json:"review_status"`
+	ReviewedAt   *time.Time `json:"reviewed_at"`
+	ReviewedBy   *uint      `json:"reviewed_by"`
 
 	// Associations
 	Survey  Survey  `gorm:"foreignKey:SurveyID;constraint:OnDelete:CASCADE" json:"survey,omitempty"`
@@ -28,15 +56,49 @@ func (Response) TableName() string {
 	return "responses"
 }
 
+// Reasons a response is flagged for
+const (
+	FlagReasonBurst    = "burst"    // Submitted after too many submissions from the same subnet in a short time
+	FlagReasonHoneypot = "honeypot" // The honeypot field of the survey was filled in
+	FlagReasonTooFast  = "too_fast" // Answered in less time per question than a person plausibly needs
+)
+
+// Review statuses of a response
+const (
+	ReviewStatusPending  = "pending"
+	ReviewStatusReviewed = "reviewed"
+	ReviewStatusRejected = "rejected"
+)
+
+// ResponseVersion holds answers of a response that were replaced by a correction
+type ResponseVersion struct {
+	ID          uint         `gorm:"primaryKey" json:"id"`
+	ResponseID  uint         `gorm:"uniqueIndex:idx_response_versions_response_version,priority:1;not null" json:"response_id"`
+	Version     int          `gorm:"uniqueIndex:idx_response_versions_response_version,priority:2;not null" json:"version"`
+	Data        ResponseData `gorm:"type:json;not null" json:"data"`
+	SubmittedAt time.Time    `gorm:"not null" json:"submitted_at"` // When these answers were submitted
+	CreatedAt   time.Time    `json:"created_at"`                   // When they were replaced
+
+	// Associations
+	Response Response `gorm:"foreignKey:ResponseID;constraint:OnDelete:CASCADE" json:"response,omitempty"`
+}
+
+// TableName specifies the table name for ResponseVersion model
+func (ResponseVersion) TableName() string {
+	return "response_versions"
+}
+
 // ResponseData holds the actual response data
 type ResponseData struct {
-	Answers []Answer `json:"answers"`
+	Answers  []Answer `json:"answers"`
+	Withheld []uint   `json:"withheld,omitempty"` // Questions the respondent never saw: pool questions not drawn and questions of other variants
 }
 
 // Answer represents an answer to a single question
 type Answer struct {
 	QuestionID uint        `json:"question_id"`
-	Value      interface{} `json:"value"` // string for text/single, []string for multiple, [][]interface{} for table
+	Value      interface{} `json:"value"`                 // string for text/single, []string for multiple, [][]interface{} for table
+	DurationMs int64       `json:"duration_ms,omitempty"` // Time the respondent spent on the question as reported by the client; 0 when unknown
 }
 
 // Scan implements the sql.Scanner interface for ResponseData
@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+// ResponseArchive is an encrypted export of responses written before the retention
+// purge deleted them, kept in storage so they can still be retrieved later.
+// It has no foreign key to the survey, so it outlives the survey as well.
+type ResponseArchive struct {
+	ID              uint      `gorm:"primaryKey" json:"id"`
+	SurveyID        uint      `gorm:"index;not null" json:"survey_id"`
+	SurveyTitle     string    `gorm:"size:200;not null" json:"survey_title"` // Title at the time of archiving
+	OwnerID         uint      `gorm:"not null" json:"owner_id"`              // Survey owner at the time of archiving
+	ResponseCount   int64     `gorm:"not null" json:"response_count"`
+	SubmittedBefore time.Time `gorm:"not null" json:"submitted_before"` // The archive holds the responses submitted before this time
+	Filename        string    `gorm:"size:255;not null" json:"filename"`
+	StorageKey      string    `gorm:"size:255;not null" json:"-"` // Location of the file in the configured storage
+	Size            int64     `json:"size"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// TableName specifies the table name for ResponseArchive model
+func (ResponseArchive) TableName() string {
+	return "response_archives"
+}
@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+// SurveySequence holds the last respondent number handed out for a survey
+// The row is locked while a number is taken so concurrent submissions never share one
+type SurveySequence struct {
+	SurveyID             uint      `gorm:"primaryKey;autoIncrement:false" json:"survey_id"`
+	LastRespondentNumber uint      `gorm:"not null;default:0" json:"last_respondent_number"`
+	UpdatedAt            time.Time `json:"updated_at"`
+
+	// Associations
+	Survey Survey `gorm:"foreignKey:SurveyID;constraint:OnDelete:CASCADE" json:"survey,omitempty"`
+}
+
+// TableName specifies the table name for SurveySequence model
+func (SurveySequence) TableName() string {
+	return "survey_sequences"
+}
@@ -0,0 +1,30 @@
+package model
+
+import "time"
+
+// UserSession is a login, tracked by the ID (jti) of the token issued for it so the
+// user can list their active logins and revoke one, e.g. when a device is stolen
+type UserSession struct {
+	ID         uint       `gorm:"primaryKey" json:"id"`
+	UserID     uint       `gorm:"index;not null" json:"-"`
+	TokenID    string     `gorm:"uniqueIndex;size:36;not null" json:"-"` // jti claim of the token
+	UserAgent  string     `gorm:"size:500" json:"user_agent"`
+	IPAddress  string     `gorm:"size:45" json:"ip_address"`
+	ExpiresAt  time.Time  `gorm:"index" json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"` // Updated at most every few minutes
+	RevokedAt  *time.Time `json:"-"`
+	CreatedAt  time.Time  `json:"created_at"`
+
+	// Associations
+	User User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// TableName specifies the table name for UserSession model
+func (UserSession) TableName() string {
+	return "user_sessions"
+}
+
+// IsActive reports whether the session is neither revoked nor expired
+func (s *UserSession) IsActive() bool {
+	return s.RevokedAt == nil && time.Now().Before(s.ExpiresAt)
+}
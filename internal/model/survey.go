@@ -1,6 +1,11 @@
 package model
 
-import "time"
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
 
 // Survey represents a survey/questionnaire
 type Survey struct {
@@ -8,10 +13,65 @@ type Survey struct {
 	UserID      uint      `gorm:"index;not null" json:"user_id"`
 	Title       string    `gorm:"size:200;not null" json:"title"`
 	Description string    `gorm:"type:text" json:"description"`
-	Status      string    `gorm:"size:20;default:'draft';index" json:"status"` // draft, published
+	Status      string    `gorm:"size:20;default:'draft';index" json:"status"` // draft, published, archived
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 
+	// Embedding settings
+	EmbedEnabled bool       `gorm:"default:false" json:"embed_enabled"`
+	EmbedDomains StringList `gorm:"type:json" json:"embed_domains"` // Allowed embedding origins, e.g. https://partner.com or *.partner.com
+
+	// Access restrictions
+	AllowedIPs StringList `gorm:"type:json" json:"allowed_ips"` // Allowed respondent networks, e.g. 10.0.0.0/8 or 203.0.113.7; empty allows all
+
+	// Anonymous surveys never store the respondent's IP address, user agent or identity
+	Anonymous bool `gorm:"default:false" json:"anonymous"`
+
+	// Link expiration notifications
+	ExpiryNotifyHours int    `gorm:"default:0" json:"expiry_notify_hours"` // Notify when unused links expire within this many hours; 0 disables
+	ExpiryWebhookURL  string `gorm:"size:500" json:"expiry_webhook_url"`   // Receives signed links.expiring events
+	ExpiryNotifyEmail string `gorm:"size:255" json:"expiry_notify_email"`  // Receives a summary email of expiring links
+
+	// Share link lifetime; 0 falls back to the global onelink settings
+	LinkDefaultExpiryHours int `gorm:"default:0" json:"link_default_expiry_hours"` // Used when a link is generated without expires_at
+	LinkMaxExpiryHours     int `gorm:"default:0" json:"link_max_expiry_hours"`     // Latest allowed expiration; can only tighten the global maximum
+
+	// Respondents may correct their response through the same link for this many minutes
+	// after submitting it; 0 disables corrections
+	EditWindowMinutes int `gorm:"default:0" json:"edit_window_minutes"`
+
+	// Respondents must submit within this many minutes of first opening their link, e.g. for
+	// timed assessments; 0 disables the limit
+	TimeLimitMinutes int `gorm:"default:0" json:"time_limit_minutes"`
+
+	// Assessment mode: responses are scored against the correct answers of the questions
+	Assessment bool `gorm:"default:false" json:"assessment"`
+	ShowScore  bool `gorm:"default:false" json:"show_score"` // Respondents receive their score in the submission reply
+	// Responses scoring at least this percentage of their maximum score pass; 0 disables pass/fail
+	PassPercent float64 `gorm:"default:0" json:"pass_percent"`
+
+	// Owner reminder sent once per publication when a survey collects no responses
+	NoResponseReminderDays int        `gorm:"default:0" json:"no_response_reminder_days"` // Days after publishing without responses before reminding; 0 disables
+	ReminderWebhookURL     string     `gorm:"size:500" json:"reminder_webhook_url"`       // Receives signed survey.no_responses events in addition to the owner's email
+	PublishedAt            *time.Time `json:"published_at"`                               // Start of the current publication
+	RemindedAt             *time.Time `json:"reminded_at"`                                // When the owner was reminded during the current publication
+
+	// Spam trap: an invisible honeypot field in the public payload that only bots fill in
+	HoneypotAction  string `gorm:"size:20" json:"honeypot_action"`    // flag or discard submissions filling it; empty disables the honeypot
+	HoneypotCatches int64  `gorm:"default:0" json:"honeypot_catches"` // Submissions caught by the honeypot, test links excluded
+
+	// Response quotas per prefill segment
+	Quotas QuotaRules `gorm:"type:json" json:"quotas"`
+
+	// Random subsets of questions shown to each respondent
+	QuestionPools QuestionPools `gorm:"type:json" json:"question_pools"`
+
+	// A/B variants: each link is assigned one and receives its questions besides the common ones
+	Variants StringList `gorm:"type:json" json:"variants"`
+
+	// Team the survey is shared with; its members can generate links, review responses and export
+	TeamID *uint `gorm:"index" json:"team_id"`
+
 	// Associations
 	User      User       `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"user,omitempty"`
 	Questions []Question `gorm:"foreignKey:SurveyID;constraint:OnDelete:CASCADE" json:"questions,omitempty"`
@@ -28,4 +88,37 @@ func (Survey) TableName() string {
 const (
 	SurveyStatusDraft     = "draft"
 	SurveyStatusPublished = "published"
+	SurveyStatusArchived  = "archived" // hidden from the default list, data is kept
+)
+
+// Honeypot actions applied to submissions that fill in the honeypot field
+const (
+	HoneypotFlag    = "flag"    // store the response flagged for review
+	HoneypotDiscard = "discard" // drop the response while reporting success to the submitter
 )
+
+// StringList is a custom type for storing a list of strings as JSON
+type StringList []string
+
+// Scan implements the sql.Scanner interface for StringList
+func (l *StringList) Scan(value interface{}) error {
+	if value == nil {
+		*l = StringList{}
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("failed to unmarshal StringList value: %v", value)
+	}
+
+	return json.Unmarshal(bytes, l)
+}
+
+// Value implements the driver.Valuer interface for StringList
+func (l StringList) Value() (driver.Value, error) {
+	if len(l) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(l)
+}
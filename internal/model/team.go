@@ -0,0 +1,46 @@
+package model
+
+import "time"
+
+// Team is a group of users that jointly work on the surveys shared with it. Members
+// can generate share links, review responses and export them; editing, publishing and
+// deleting a survey stay with its owner
+type Team struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Name      string    `gorm:"size:100;not null" json:"name"`
+	OwnerID   uint      `gorm:"index;not null" json:"owner_id"` // Manages the team's members; always a member
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Associations
+	Owner   User         `gorm:"foreignKey:OwnerID;constraint:OnDelete:CASCADE" json:"-"`
+	Members []TeamMember `gorm:"foreignKey:TeamID;constraint:OnDelete:CASCADE" json:"members,omitempty"`
+}
+
+// TableName specifies the table name for Team model
+func (Team) TableName() string {
+	return "teams"
+}
+
+// Team member roles
+const (
+	TeamRoleOwner  = "owner"
+	TeamRoleMember = "member"
+)
+
+// TeamMember is a user's membership in a team
+type TeamMember struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	TeamID    uint      `gorm:"uniqueIndex:idx_team_members_team_user,priority:1;not null" json:"team_id"`
+	UserID    uint      `gorm:"uniqueIndex:idx_team_members_team_user,priority:2;index;not null" json:"user_id"`
+	Role      string    `gorm:"size:20;default:'member';not null" json:"role"` // owner or member
+	CreatedAt time.Time `json:"created_at"`
+
+	// Associations
+	User User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"user,omitempty"`
+}
+
+// TableName specifies the table name for TeamMember model
+func (TeamMember) TableName() string {
+	return "team_members"
+}
@@ -4,16 +4,40 @@ import "time"
 
 // User represents a user in the system
 type User struct {
-	ID        uint      `gorm:"primaryKey" json:"id"`
-	Username  string    `gorm:"uniqueIndex;size:50;not null" json:"username"`
-	Password  string    `gorm:"size:255;not null" json:"-"` // bcrypt hashed, never expose in JSON
-	Email     string    `gorm:"uniqueIndex;size:100" json:"email"`
-	Role      string    `gorm:"size:20;default:'admin'" json:"role"` // admin
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID            uint       `gorm:"primaryKey" json:"id"`
+	Username      string     `gorm:"uniqueIndex;size:50;not null" json:"username"`
+	Password      string     `gorm:"size:255;not null" json:"-"` // bcrypt hashed, never expose in JSON
+	Email         string     `gorm:"uniqueIndex;size:100" json:"email"`
+	Role          string     `gorm:"size:20;default:'admin'" json:"role"` // admin or user
+	DeactivatedAt *time.Time `json:"deactivated_at"`                      // Set while the account is deactivated; it can neither log in nor use issued tokens
+	// Set once the user followed the verification link sent to their email; self-registered
+	// accounts that stay unverified past the grace period are locked out
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
+	// Set when the user changes their email; the grace period of the new address starts then
+	EmailChangedAt *time.Time `json:"-"`
+	LastLoginAt    *time.Time `json:"last_login_at"`
+	LastLoginIP    string     `gorm:"size:45" json:"last_login_ip"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
 }
 
+// User role constants
+const (
+	UserRoleAdmin = "admin"
+	UserRoleUser  = "user" // Self-registered accounts, without access to the admin routes
+)
+
 // TableName specifies the table name for User model
 func (User) TableName() string {
 	return "users"
 }
+
+// IsEmailVerified reports whether the user verified their email address
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+// IsActive reports whether the account has not been deactivated
+func (u *User) IsActive() bool {
+	return u.DeactivatedAt == nil
+}
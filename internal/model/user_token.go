@@ -0,0 +1,34 @@
+package model
+
+import "time"
+
+// Purposes of user tokens
+const (
+	UserTokenVerifyEmail   = "verify_email"
+	UserTokenResetPassword = "reset_password"
+)
+
+// UserToken is a single-use token emailed to a user to verify their email address or
+// reset their password. Only the hash of the token is stored
+type UserToken struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	UserID    uint       `gorm:"index;not null" json:"user_id"`
+	Purpose   string     `gorm:"size:20;not null" json:"purpose"`
+	TokenHash string     `gorm:"uniqueIndex;size:64;not null" json:"-"` // SHA-256 of the token
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"` // Set once the token was used or superseded
+	CreatedAt time.Time  `json:"created_at"`
+
+	// Associations
+	User User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// TableName specifies the table name for UserToken model
+func (UserToken) TableName() string {
+	return "user_tokens"
+}
+
+// IsUsable reports whether the token is neither used nor expired
+func (t *UserToken) IsUsable() bool {
+	return t.UsedAt == nil && time.Now().Before(t.ExpiresAt)
+}
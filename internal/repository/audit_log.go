@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+
+	"survey-system/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// AuditLogRepository defines the interface for audit log data operations
+type AuditLogRepository interface {
+	Create(ctx context.Context, entry *model.AuditLog) error
+	FindAll(ctx context.Context, filter AuditLogFilter, page, pageSize int) ([]model.AuditLog, int64, error)
+}
+
+// AuditLogFilter narrows an audit log listing
+type AuditLogFilter struct {
+	ActorID         uint // only entries of this admin when set
+	TargetUserID    uint // only entries acting on this user when set
+	ImpersonationID uint // only the session's start entry and its requests when set
+}
+
+// scope restricts a query to the entries matching the filter
+func (f AuditLogFilter) scope(db *gorm.DB) *gorm.DB {
+	if f.ActorID != 0 {
+		db = db.Where("actor_id = ?", f.ActorID)
+	}
+	if f.TargetUserID != 0 {
+		db = db.Where("target_user_id = ?", f.TargetUserID)
+	}
+	if f.ImpersonationID != 0 {
+		db = db.Where("id = ? OR impersonation_id = ?", f.ImpersonationID, f.ImpersonationID)
+	}
+	return db
+}
+
+// auditLogRepository implements AuditLogRepository interface
+type auditLogRepository struct {
+	db       *gorm.DB
+	timeouts Timeouts
+}
+
+// NewAuditLogRepository creates a new audit log repository instance
+func NewAuditLogRepository(db *gorm.DB, timeouts Timeouts) AuditLogRepository {
+	return &auditLogRepository{db: db, timeouts: timeouts}
+}
+
+// Create records a new audit log entry
+func (r *auditLogRepository) Create(ctx context.Context, entry *model.AuditLog) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Create(entry).Error
+}
+
+// FindAll lists audit log entries matching the filter newest first with their actors
+// preloaded, with pagination
+func (r *auditLogRepository) FindAll(ctx context.Context, filter AuditLogFilter, page, pageSize int) ([]model.AuditLog, int64, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var total int64
+	if err := r.db.WithContext(ctx).Model(&model.AuditLog{}).Scopes(filter.scope).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var entries []model.AuditLog
+	err := r.db.WithContext(ctx).Preload("Actor").Scopes(filter.scope).
+		Order("created_at DESC, id DESC").
+		Limit(pageSize).
+		Offset((page - 1) * pageSize).
+		Find(&entries).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return entries, total, nil
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+
+	"survey-system/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// ChannelRepository defines the interface for notification channel data operations
+type ChannelRepository interface {
+	Create(ctx context.Context, channel *model.NotificationChannel) error
+	FindByID(ctx context.Context, id uint) (*model.NotificationChannel, error)
+	FindBySurveyID(ctx context.Context, surveyID uint) ([]model.NotificationChannel, error)
+	FindEnabledBySurveyID(ctx context.Context, surveyID uint) ([]model.NotificationChannel, error)
+	Update(ctx context.Context, channel *model.NotificationChannel) error
+	Delete(ctx context.Context, id uint) error
+	MarkThresholdNotified(ctx context.Context, id uint, day string) (bool, error)
+}
+
+// channelRepository implements ChannelRepository interface
+type channelRepository struct {
+	db       *gorm.DB
+	timeouts Timeouts
+}
+
+// NewChannelRepository creates a new notification channel repository instance
+func NewChannelRepository(db *gorm.DB, timeouts Timeouts) ChannelRepository {
+	return &channelRepository{db: db, timeouts: timeouts}
+}
+
+// Create creates a new notification channel
+func (r *channelRepository) Create(ctx context.Context, channel *model.NotificationChannel) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Create(channel).Error
+}
+
+// FindByID finds a notification channel by ID
+func (r *channelRepository) FindByID(ctx context.Context, id uint) (*model.NotificationChannel, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var channel model.NotificationChannel
+	err := r.db.WithContext(ctx).First(&channel, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &channel, nil
+}
+
+// FindBySurveyID finds the notification channels of a survey
+func (r *channelRepository) FindBySurveyID(ctx context.Context, surveyID uint) ([]model.NotificationChannel, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var channels []model.NotificationChannel
+	err := r.db.WithContext(ctx).
+		Where("survey_id = ?", surveyID).
+		Order("id ASC").
+		Find(&channels).Error
+	if err != nil {
+		return nil, err
+	}
+	return channels, nil
+}
+
+// FindEnabledBySurveyID finds the enabled notification channels of a survey
+func (r *channelRepository) FindEnabledBySurveyID(ctx context.Context, surveyID uint) ([]model.NotificationChannel, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var channels []model.NotificationChannel
+	err := r.db.WithContext(ctx).
+		Where("survey_id = ? AND enabled = ?", surveyID, true).
+		Order("id ASC").
+		Find(&channels).Error
+	if err != nil {
+		return nil, err
+	}
+	return channels, nil
+}
+
+// Update saves an existing notification channel
+func (r *channelRepository) Update(ctx context.Context, channel *model.NotificationChannel) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Save(channel).Error
+}
+
+// Delete deletes a notification channel
+func (r *channelRepository) Delete(ctx context.Context, id uint) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Delete(&model.NotificationChannel{}, id).Error
+}
+
+// MarkThresholdNotified records that the daily threshold was announced on day
+// It reports false when the day was already recorded, so only one submission
+// across all instances announces the threshold
+func (r *channelRepository) MarkThresholdNotified(ctx context.Context, id uint, day string) (bool, error) {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	result := r.db.WithContext(ctx).Model(&model.NotificationChannel{}).
+		Where("id = ? AND threshold_notified_on <> ?", id, day).
+		Update("threshold_notified_on", day)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+
+	"survey-system/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// CommentRepository defines the interface for response comment data operations
+type CommentRepository interface {
+	Create(ctx context.Context, comment *model.ResponseComment) error
+	FindByID(ctx context.Context, id uint) (*model.ResponseComment, error)
+	FindByResponseID(ctx context.Context, responseID uint) ([]model.ResponseComment, error)
+	Update(ctx context.Context, comment *model.ResponseComment) error
+	Delete(ctx context.Context, id uint) error
+	CountByResponseIDs(ctx context.Context, responseIDs []uint) (map[uint]int64, error)
+}
+
+// commentRepository implements CommentRepository interface
+type commentRepository struct {
+	db       *gorm.DB
+	timeouts Timeouts
+}
+
+// NewCommentRepository creates a new response comment repository instance
+func NewCommentRepository(db *gorm.DB, timeouts Timeouts) CommentRepository {
+	return &commentRepository{db: db, timeouts: timeouts}
+}
+
+// Create creates a new comment
+func (r *commentRepository) Create(ctx context.Context, comment *model.ResponseComment) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Create(comment).Error
+}
+
+// FindByID finds a comment by ID with its author preloaded
+func (r *commentRepository) FindByID(ctx context.Context, id uint) (*model.ResponseComment, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var comment model.ResponseComment
+	err := r.db.WithContext(ctx).Preload("User").First(&comment, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// FindByResponseID finds the comments on a response, oldest first, with their authors preloaded
+func (r *commentRepository) FindByResponseID(ctx context.Context, responseID uint) ([]model.ResponseComment, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var comments []model.ResponseComment
+	err := r.db.WithContext(ctx).Preload("User").
+		Where("response_id = ?", responseID).
+		Order("created_at ASC").
+		Order("id ASC").
+		Find(&comments).Error
+	if err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+// Update saves the content of an existing comment
+func (r *commentRepository) Update(ctx context.Context, comment *model.ResponseComment) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Model(comment).Update("content", comment.Content).Error
+}
+
+// Delete deletes a comment
+func (r *commentRepository) Delete(ctx context.Context, id uint) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Delete(&model.ResponseComment{}, id).Error
+}
+
+// CountByResponseIDs counts the comments on each of the given responses
+// Responses without comments are absent from the result
+func (r *commentRepository) CountByResponseIDs(ctx context.Context, responseIDs []uint) (map[uint]int64, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	counts := make(map[uint]int64)
+	if len(responseIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		ResponseID uint
+		Count      int64
+	}
+	err := r.db.WithContext(ctx).Model(&model.ResponseComment{}).
+		Select("response_id, COUNT(*) AS count").
+		Where("response_id IN ?", responseIDs).
+		Group("response_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		counts[row.ResponseID] = row.Count
+	}
+	return counts, nil
+}
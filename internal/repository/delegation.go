@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"survey-system/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// DelegationRepository defines the interface for delegation token data operations
+type DelegationRepository interface {
+	Create(ctx context.Context, delegation *model.Delegation) error
+	FindByID(ctx context.Context, id uint) (*model.Delegation, error)
+	FindByTokenHash(ctx context.Context, tokenHash string) (*model.Delegation, error)
+	FindBySurveyID(ctx context.Context, surveyID uint) ([]model.Delegation, error)
+	Revoke(ctx context.Context, id uint) error
+	RecordUse(ctx context.Context, id uint) error
+}
+
+// delegationRepository implements DelegationRepository interface
+type delegationRepository struct {
+	db       *gorm.DB
+	timeouts Timeouts
+}
+
+// NewDelegationRepository creates a new delegation repository instance
+func NewDelegationRepository(db *gorm.DB, timeouts Timeouts) DelegationRepository {
+	return &delegationRepository{db: db, timeouts: timeouts}
+}
+
+// Create creates a new delegation
+func (r *delegationRepository) Create(ctx context.Context, delegation *model.Delegation) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Create(delegation).Error
+}
+
+// FindByID finds a delegation by ID
+func (r *delegationRepository) FindByID(ctx context.Context, id uint) (*model.Delegation, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var delegation model.Delegation
+	err := r.db.WithContext(ctx).First(&delegation, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &delegation, nil
+}
+
+// FindByTokenHash finds a delegation by the hash of its token
+func (r *delegationRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*model.Delegation, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var delegation model.Delegation
+	err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&delegation).Error
+	if err != nil {
+		return nil, err
+	}
+	return &delegation, nil
+}
+
+// FindBySurveyID finds the delegations of a survey, newest first
+func (r *delegationRepository) FindBySurveyID(ctx context.Context, surveyID uint) ([]model.Delegation, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var delegations []model.Delegation
+	err := r.db.WithContext(ctx).
+		Where("survey_id = ?", surveyID).
+		Order("created_at DESC").
+		Find(&delegations).Error
+	if err != nil {
+		return nil, err
+	}
+	return delegations, nil
+}
+
+// Revoke marks a delegation as revoked; revoking it again keeps the first revocation time
+func (r *delegationRepository) Revoke(ctx context.Context, id uint) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Model(&model.Delegation{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now()).Error
+}
+
+// RecordUse counts a generated link and updates the last use time
+func (r *delegationRepository) RecordUse(ctx context.Context, id uint) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Model(&model.Delegation{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"link_count":   gorm.Expr("link_count + 1"),
+			"last_used_at": time.Now(),
+		}).Error
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+
+	"survey-system/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// DraftRepository defines the interface for response draft data operations
+type DraftRepository interface {
+	Save(ctx context.Context, draft *model.ResponseDraft) error
+	FindByID(ctx context.Context, id uint) (*model.ResponseDraft, error)
+	FindByOneLinkID(ctx context.Context, oneLinkID uint) (*model.ResponseDraft, error)
+	DeleteByOneLinkID(ctx context.Context, oneLinkID uint) error
+}
+
+// draftRepository implements DraftRepository interface
+type draftRepository struct {
+	db       *gorm.DB
+	timeouts Timeouts
+}
+
+// NewDraftRepository creates a new response draft repository instance
+func NewDraftRepository(db *gorm.DB, timeouts Timeouts) DraftRepository {
+	return &draftRepository{db: db, timeouts: timeouts}
+}
+
+// Save creates or updates a response draft
+func (r *draftRepository) Save(ctx context.Context, draft *model.ResponseDraft) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Save(draft).Error
+}
+
+// FindByID finds a response draft by ID
+func (r *draftRepository) FindByID(ctx context.Context, id uint) (*model.ResponseDraft, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var draft model.ResponseDraft
+	err := r.db.WithContext(ctx).First(&draft, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &draft, nil
+}
+
+// FindByOneLinkID finds the response draft bound to a one-time link
+func (r *draftRepository) FindByOneLinkID(ctx context.Context, oneLinkID uint) (*model.ResponseDraft, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var draft model.ResponseDraft
+	err := r.db.WithContext(ctx).Where("one_link_id = ?", oneLinkID).First(&draft).Error
+	if err != nil {
+		return nil, err
+	}
+	return &draft, nil
+}
+
+// DeleteByOneLinkID deletes the response draft bound to a one-time link
+func (r *draftRepository) DeleteByOneLinkID(ctx context.Context, oneLinkID uint) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Where("one_link_id = ?", oneLinkID).Delete(&model.ResponseDraft{}).Error
+}
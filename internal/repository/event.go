@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"survey-system/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// EventRepository defines the interface for survey event data operations
+type EventRepository interface {
+	Create(ctx context.Context, event *model.SurveyEvent) error
+	FindBySurveyIDSince(ctx context.Context, surveyID uint, since time.Time, limit int) ([]model.SurveyEvent, error)
+}
+
+// eventRepository implements EventRepository interface
+type eventRepository struct {
+	db       *gorm.DB
+	timeouts Timeouts
+}
+
+// NewEventRepository creates a new survey event repository instance
+func NewEventRepository(db *gorm.DB, timeouts Timeouts) EventRepository {
+	return &eventRepository{db: db, timeouts: timeouts}
+}
+
+// Create records a new survey event
+func (r *eventRepository) Create(ctx context.Context, event *model.SurveyEvent) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Create(event).Error
+}
+
+// FindBySurveyIDSince finds the most recent events of a survey since the given time, newest first
+func (r *eventRepository) FindBySurveyIDSince(ctx context.Context, surveyID uint, since time.Time, limit int) ([]model.SurveyEvent, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var events []model.SurveyEvent
+	err := r.db.WithContext(ctx).
+		Where("survey_id = ? AND created_at >= ?", surveyID, since).
+		Order("created_at DESC").
+		Order("id DESC").
+		Limit(limit).
+		Find(&events).Error
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
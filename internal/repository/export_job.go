@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"survey-system/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// ExportJobRepository defines the interface for export job data operations
+type ExportJobRepository interface {
+	Create(ctx context.Context, job *model.ExportJob) error
+	FindByID(ctx context.Context, id uint) (*model.ExportJob, error)
+	MarkRunning(ctx context.Context, id uint) error
+	Complete(ctx context.Context, job *model.ExportJob) error
+	Fail(ctx context.Context, id uint, message string) error
+	FindExpired(ctx context.Context, now time.Time, limit int) ([]model.ExportJob, error)
+	DeleteByIDs(ctx context.Context, ids []uint) error
+}
+
+// exportJobRepository implements ExportJobRepository interface
+type exportJobRepository struct {
+	db       *gorm.DB
+	timeouts Timeouts
+}
+
+// NewExportJobRepository creates a new export job repository instance
+func NewExportJobRepository(db *gorm.DB, timeouts Timeouts) ExportJobRepository {
+	return &exportJobRepository{db: db, timeouts: timeouts}
+}
+
+// Create creates a new export job
+func (r *exportJobRepository) Create(ctx context.Context, job *model.ExportJob) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Create(job).Error
+}
+
+// FindByID finds an export job by ID
+func (r *exportJobRepository) FindByID(ctx context.Context, id uint) (*model.ExportJob, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var job model.ExportJob
+	err := r.db.WithContext(ctx).First(&job, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// MarkRunning records that the export has started
+func (r *exportJobRepository) MarkRunning(ctx context.Context, id uint) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Model(&model.ExportJob{}).
+		Where("id = ?", id).
+		Update("status", model.ExportJobRunning).Error
+}
+
+// Complete records where the exported file was stored and marks the job as completed
+func (r *exportJobRepository) Complete(ctx context.Context, job *model.ExportJob) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Model(&model.ExportJob{}).
+		Where("id = ?", job.ID).
+		Updates(map[string]interface{}{
+			"status":       model.ExportJobCompleted,
+			"filename":     job.Filename,
+			"content_type": job.ContentType,
+			"storage_key":  job.StorageKey,
+			"size":         job.Size,
+			"completed_at": job.CompletedAt,
+		}).Error
+}
+
+// Fail marks the job as failed with the given message
+func (r *exportJobRepository) Fail(ctx context.Context, id uint, message string) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Model(&model.ExportJob{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status": model.ExportJobFailed,
+			"error":  message,
+		}).Error
+}
+
+// FindExpired finds up to limit export jobs whose download period has ended
+func (r *exportJobRepository) FindExpired(ctx context.Context, now time.Time, limit int) ([]model.ExportJob, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var jobs []model.ExportJob
+	err := r.db.WithContext(ctx).
+		Where("expires_at < ?", now).
+		Order("id ASC").
+		Limit(limit).
+		Find(&jobs).Error
+	return jobs, err
+}
+
+// DeleteByIDs deletes the given export jobs
+func (r *exportJobRepository) DeleteByIDs(ctx context.Context, ids []uint) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Where("id IN ?", ids).Delete(&model.ExportJob{}).Error
+}
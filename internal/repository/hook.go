@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"survey-system/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// HookRepository defines the interface for REST hook subscription data operations
+type HookRepository interface {
+	Create(ctx context.Context, hook *model.Hook) error
+	FindByID(ctx context.Context, id uint) (*model.Hook, error)
+	FindByUserID(ctx context.Context, userID uint) ([]model.Hook, error)
+	FindBySurveyAndEvent(ctx context.Context, surveyID uint, event string) ([]model.Hook, error)
+	Delete(ctx context.Context, id uint) error
+	CreateDelivery(ctx context.Context, delivery *model.HookDelivery) error
+	FindDeliveryByID(ctx context.Context, id uint) (*model.HookDelivery, error)
+	FindDeliveries(ctx context.Context, hookID uint, failedOnly bool, page, pageSize int) ([]model.HookDelivery, int64, error)
+	HasSucceededRetry(ctx context.Context, deliveryID uint) (bool, error)
+	DeleteDeliveriesBefore(ctx context.Context, before time.Time, limit int) (int64, error)
+	DeleteDeliveriesOfDeletedResponses(ctx context.Context, surveyID uint) (int64, error)
+}
+
+// hookRepository implements HookRepository interface
+type hookRepository struct {
+	db       *gorm.DB
+	timeouts Timeouts
+}
+
+// NewHookRepository creates a new hook repository instance
+func NewHookRepository(db *gorm.DB, timeouts Timeouts) HookRepository {
+	return &hookRepository{db: db, timeouts: timeouts}
+}
+
+// Create creates a new hook subscription
+func (r *hookRepository) Create(ctx context.Context, hook *model.Hook) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Create(hook).Error
+}
+
+// FindByID finds a hook subscription by ID
+func (r *hookRepository) FindByID(ctx context.Context, id uint) (*model.Hook, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var hook model.Hook
+	err := r.db.WithContext(ctx).First(&hook, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &hook, nil
+}
+
+// FindByUserID finds the hook subscriptions a user created, newest first
+func (r *hookRepository) FindByUserID(ctx context.Context, userID uint) ([]model.Hook, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var hooks []model.Hook
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("id DESC").
+		Find(&hooks).Error
+	return hooks, err
+}
+
+// FindBySurveyAndEvent finds the subscriptions to an event of a survey
+func (r *hookRepository) FindBySurveyAndEvent(ctx context.Context, surveyID uint, event string) ([]model.Hook, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var hooks []model.Hook
+	err := r.db.WithContext(ctx).
+		Where("survey_id = ? AND event = ?", surveyID, event).
+		Find(&hooks).Error
+	return hooks, err
+}
+
+// Delete deletes a hook subscription
+func (r *hookRepository) Delete(ctx context.Context, id uint) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Delete(&model.Hook{}, id).Error
+}
+
+// CreateDelivery records a delivery attempt
+func (r *hookRepository) CreateDelivery(ctx context.Context, delivery *model.HookDelivery) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Create(delivery).Error
+}
+
+// FindDeliveryByID finds a delivery attempt by ID with its hook
+func (r *hookRepository) FindDeliveryByID(ctx context.Context, id uint) (*model.HookDelivery, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var delivery model.HookDelivery
+	err := r.db.WithContext(ctx).Preload("Hook").First(&delivery, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// FindDeliveries finds a page of the delivery attempts of a hook, newest first
+func (r *hookRepository) FindDeliveries(ctx context.Context, hookID uint, failedOnly bool, page, pageSize int) ([]model.HookDelivery, int64, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	query := r.db.WithContext(ctx).Model(&model.HookDelivery{}).Where("hook_id = ?", hookID)
+	if failedOnly {
+		query = query.Where("succeeded = ?", false)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var deliveries []model.HookDelivery
+	err := query.
+		Order("id DESC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&deliveries).Error
+	return deliveries, total, err
+}
+
+// HasSucceededRetry reports whether a retry of a delivery succeeded
+func (r *hookRepository) HasSucceededRetry(ctx context.Context, deliveryID uint) (bool, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var count int64
+	err := r.db.WithContext(ctx).Model(&model.HookDelivery{}).
+		Where("retry_of = ? AND succeeded = ?", deliveryID, true).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// DeleteDeliveriesBefore deletes up to limit delivery attempts created before a time with
+// their payloads and returns how many were deleted
+func (r *hookRepository) DeleteDeliveriesBefore(ctx context.Context, before time.Time, limit int) (int64, error) {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	var ids []uint
+	if err := r.db.WithContext(ctx).Model(&model.HookDelivery{}).
+		Where("created_at < ?", before).
+		Order("id").
+		Limit(limit).
+		Pluck("id", &ids).Error; err != nil {
+		return 0, err
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	result := r.db.WithContext(ctx).Delete(&model.HookDelivery{}, ids)
+	return result.RowsAffected, result.Error
+}
+
+// DeleteDeliveriesOfDeletedResponses deletes the delivery attempts to a survey's hooks
+// whose payload was built from a response that no longer exists
+func (r *hookRepository) DeleteDeliveriesOfDeletedResponses(ctx context.Context, surveyID uint) (int64, error) {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	hooks := r.db.Model(&model.Hook{}).Select("id").Where("survey_id = ?", surveyID)
+	responses := r.db.Model(&model.Response{}).Select("1").Where("responses.id = hook_deliveries.response_id")
+	result := r.db.WithContext(ctx).
+		Where("hook_id IN (?) AND response_id IS NOT NULL AND NOT EXISTS (?)", hooks, responses).
+		Delete(&model.HookDelivery{})
+	return result.RowsAffected, result.Error
+}
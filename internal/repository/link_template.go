@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+
+	"survey-system/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// LinkTemplateRepository defines the interface for link template data operations
+type LinkTemplateRepository interface {
+	Create(ctx context.Context, template *model.LinkTemplate) error
+	FindByID(ctx context.Context, id uint) (*model.LinkTemplate, error)
+	FindBySurveyID(ctx context.Context, surveyID uint) ([]model.LinkTemplate, error)
+	Update(ctx context.Context, template *model.LinkTemplate) error
+	Delete(ctx context.Context, id uint) error
+}
+
+// linkTemplateRepository implements LinkTemplateRepository interface
+type linkTemplateRepository struct {
+	db       *gorm.DB
+	timeouts Timeouts
+}
+
+// NewLinkTemplateRepository creates a new link template repository instance
+func NewLinkTemplateRepository(db *gorm.DB, timeouts Timeouts) LinkTemplateRepository {
+	return &linkTemplateRepository{db: db, timeouts: timeouts}
+}
+
+// Create creates a new link template
+func (r *linkTemplateRepository) Create(ctx context.Context, template *model.LinkTemplate) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Create(template).Error
+}
+
+// FindByID finds a link template by ID
+func (r *linkTemplateRepository) FindByID(ctx context.Context, id uint) (*model.LinkTemplate, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var template model.LinkTemplate
+	err := r.db.WithContext(ctx).First(&template, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// FindBySurveyID finds the link templates of a survey ordered by name
+func (r *linkTemplateRepository) FindBySurveyID(ctx context.Context, surveyID uint) ([]model.LinkTemplate, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var templates []model.LinkTemplate
+	err := r.db.WithContext(ctx).
+		Where("survey_id = ?", surveyID).
+		Order("name ASC").
+		Find(&templates).Error
+	if err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+// Update saves an existing link template
+func (r *linkTemplateRepository) Update(ctx context.Context, template *model.LinkTemplate) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Save(template).Error
+}
+
+// Delete deletes a link template
+func (r *linkTemplateRepository) Delete(ctx context.Context, id uint) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Delete(&model.LinkTemplate{}, id).Error
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+
+	"survey-system/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// LoginRecordRepository defines the interface for login history data operations
+type LoginRecordRepository interface {
+	Create(ctx context.Context, record *model.LoginRecord) error
+	FindByUserID(ctx context.Context, userID uint, page, pageSize int) ([]model.LoginRecord, int64, error)
+	HasSucceeded(ctx context.Context, userID uint, userAgent, network string) (bool, error)
+}
+
+// loginRecordRepository implements LoginRecordRepository interface
+type loginRecordRepository struct {
+	db       *gorm.DB
+	timeouts Timeouts
+}
+
+// NewLoginRecordRepository creates a new login record repository instance
+func NewLoginRecordRepository(db *gorm.DB, timeouts Timeouts) LoginRecordRepository {
+	return &loginRecordRepository{db: db, timeouts: timeouts}
+}
+
+// Create creates a new login record
+func (r *loginRecordRepository) Create(ctx context.Context, record *model.LoginRecord) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Create(record).Error
+}
+
+// FindByUserID finds a page of the login attempts of a user, newest first
+func (r *loginRecordRepository) FindByUserID(ctx context.Context, userID uint, page, pageSize int) ([]model.LoginRecord, int64, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	query := r.db.WithContext(ctx).Model(&model.LoginRecord{}).Where("user_id = ?", userID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var records []model.LoginRecord
+	err := query.
+		Order("id DESC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&records).Error
+	return records, total, err
+}
+
+// HasSucceeded reports whether the user logged in successfully before, with the user
+// agent and from the network when they are not empty
+func (r *loginRecordRepository) HasSucceeded(ctx context.Context, userID uint, userAgent, network string) (bool, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	query := r.db.WithContext(ctx).Model(&model.LoginRecord{}).
+		Where("user_id = ? AND succeeded = ?", userID, true)
+	if userAgent != "" {
+		query = query.Where("user_agent = ?", userAgent)
+	}
+	if network != "" {
+		query = query.Where("network = ?", network)
+	}
+
+	var count int64
+	if err := query.Limit(1).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
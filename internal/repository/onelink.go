@@ -1,40 +1,117 @@
 package repository
 
 import (
+	"context"
+	"strconv"
 	"survey-system/internal/model"
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // OneLinkRepository defines the interface for one-time link data operations
 type OneLinkRepository interface {
-	Create(oneLink *model.OneLink) error
-	FindByToken(token string) (*model.OneLink, error)
-	MarkAsUsed(id uint) error
-	MarkAsAccessed(id uint) error
-	DeleteExpired() error
+	Create(ctx context.Context, oneLink *model.OneLink) error
+	CreateBatch(ctx context.Context, oneLinks []model.OneLink) error
+	FindByID(ctx context.Context, id uint) (*model.OneLink, error)
+	FindByToken(ctx context.Context, token string) (*model.OneLink, error)
+	MarkAsUsed(ctx context.Context, id uint) error
+	MarkAsAccessed(ctx context.Context, id uint) error
+	MarkAsStarted(ctx context.Context, id uint, at time.Time) (bool, error)
+	DeleteExpired(ctx context.Context) error
+	FindExpiringUnnotified(ctx context.Context, now time.Time, limit int) ([]model.OneLink, error)
+	FindOutstandingWithPrefill(ctx context.Context, surveyID, afterID uint, limit int) ([]model.OneLink, error)
+	MarkAsNotified(ctx context.Context, ids []uint) error
+	ExpireUnusedByPrefill(ctx context.Context, surveyID uint, field, value string) (int64, error)
+	CountByCampaign(ctx context.Context, surveyID uint, includeTest bool) ([]CampaignCount, error)
+	CountByVariant(ctx context.Context, surveyID uint, includeTest bool) ([]VariantCount, error)
+	CountRevocable(ctx context.Context, surveyID uint, filter LinkFilter) (int64, error)
+	Revoke(ctx context.Context, surveyID uint, filter LinkFilter) ([]model.OneLink, error)
+	ClaimPooled(ctx context.Context, surveyID uint, token string, prefillData model.PrefillDataType, respondentID string) (*model.OneLink, error)
+	CountClaimable(ctx context.Context, surveyID uint) (int64, error)
 }
 
+// LinkFilter selects the links of a survey for bulk operations
+type LinkFilter struct {
+	CreatedFrom time.Time // only links created at or after this time when set
+	CreatedTo   time.Time // only links created before this time when set
+	Campaign    string    // only links with this campaign label when set
+	UnusedOnly  bool      // only links that were not used yet
+}
+
+// revocable restricts a query to the survey's links matching the filter that are not revoked yet
+func (f LinkFilter) revocable(query *gorm.DB, surveyID uint) *gorm.DB {
+	query = query.Where("survey_id = ? AND revoked_at IS NULL", surveyID)
+	if !f.CreatedFrom.IsZero() {
+		query = query.Where("created_at >= ?", f.CreatedFrom)
+	}
+	if !f.CreatedTo.IsZero() {
+		query = query.Where("created_at < ?", f.CreatedTo)
+	}
+	if f.Campaign != "" {
+		query = query.Where("campaign = ?", f.Campaign)
+	}
+	if f.UnusedOnly {
+		query = query.Where("used = ?", false)
+	}
+	return query
+}
+
+// revokeBatchSize is the number of links revoked per UPDATE statement
+const revokeBatchSize = 500
+
 // oneLinkRepository implements OneLinkRepository interface
 type oneLinkRepository struct {
-	db *gorm.DB
+	db       *gorm.DB
+	timeouts Timeouts
 }
 
 // NewOneLinkRepository creates a new one-time link repository instance
-func NewOneLinkRepository(db *gorm.DB) OneLinkRepository {
-	return &oneLinkRepository{db: db}
+func NewOneLinkRepository(db *gorm.DB, timeouts Timeouts) OneLinkRepository {
+	return &oneLinkRepository{db: db, timeouts: timeouts}
 }
 
 // Create creates a new one-time link record
-func (r *oneLinkRepository) Create(oneLink *model.OneLink) error {
-	return r.db.Create(oneLink).Error
+func (r *oneLinkRepository) Create(ctx context.Context, oneLink *model.OneLink) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	oneLink.TokenHash = model.HashLinkToken(oneLink.Token)
+	return r.db.WithContext(ctx).Create(oneLink).Error
+}
+
+// CreateBatch creates one-time link records in one transaction
+func (r *oneLinkRepository) CreateBatch(ctx context.Context, oneLinks []model.OneLink) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	for i := range oneLinks {
+		oneLinks[i].TokenHash = model.HashLinkToken(oneLinks[i].Token)
+	}
+	return r.db.WithContext(ctx).CreateInBatches(oneLinks, revokeBatchSize).Error
+}
+
+// FindByID finds a one-time link by ID
+func (r *oneLinkRepository) FindByID(ctx context.Context, id uint) (*model.OneLink, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var oneLink model.OneLink
+	err := r.db.WithContext(ctx).First(&oneLink, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &oneLink, nil
 }
 
-// FindByToken finds a one-time link by its token
-func (r *oneLinkRepository) FindByToken(token string) (*model.OneLink, error) {
+// FindByToken finds a one-time link by its token, looked up through the token hash
+func (r *oneLinkRepository) FindByToken(ctx context.Context, token string) (*model.OneLink, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
 	var oneLink model.OneLink
-	err := r.db.Where("token = ?", token).First(&oneLink).Error
+	err := r.db.WithContext(ctx).Where("token_hash = ?", model.HashLinkToken(token)).First(&oneLink).Error
 	if err != nil {
 		return nil, err
 	}
@@ -42,9 +119,12 @@ func (r *oneLinkRepository) FindByToken(token string) (*model.OneLink, error) {
 }
 
 // MarkAsUsed marks a one-time link as used
-func (r *oneLinkRepository) MarkAsUsed(id uint) error {
+func (r *oneLinkRepository) MarkAsUsed(ctx context.Context, id uint) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
 	now := time.Now()
-	return r.db.Model(&model.OneLink{}).
+	return r.db.WithContext(ctx).Model(&model.OneLink{}).
 		Where("id = ?", id).
 		Updates(map[string]interface{}{
 			"used":    true,
@@ -53,14 +133,245 @@ func (r *oneLinkRepository) MarkAsUsed(id uint) error {
 }
 
 // MarkAsAccessed marks a one-time link as accessed (first time viewing)
-func (r *oneLinkRepository) MarkAsAccessed(id uint) error {
+func (r *oneLinkRepository) MarkAsAccessed(ctx context.Context, id uint) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
 	now := time.Now()
-	return r.db.Model(&model.OneLink{}).
+	return r.db.WithContext(ctx).Model(&model.OneLink{}).
 		Where("id = ? AND accessed_at IS NULL", id).
 		Update("accessed_at", now).Error
 }
 
+// MarkAsStarted records the start of a link's timed session unless it already started
+// Reports whether this call started it
+func (r *oneLinkRepository) MarkAsStarted(ctx context.Context, id uint, at time.Time) (bool, error) {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	result := r.db.WithContext(ctx).Model(&model.OneLink{}).
+		Where("id = ? AND started_at IS NULL", id).
+		Update("started_at", at)
+	return result.RowsAffected > 0, result.Error
+}
+
+// FindExpiringUnnotified finds unused links that are within their survey's notification
+// threshold of expiring and have not been notified yet, with the survey preloaded
+func (r *oneLinkRepository) FindExpiringUnnotified(ctx context.Context, now time.Time, limit int) ([]model.OneLink, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var oneLinks []model.OneLink
+	err := r.db.WithContext(ctx).Preload("Survey").
+		Joins("JOIN surveys ON surveys.id = one_links.survey_id").
+		Where("surveys.expiry_notify_hours > 0").
+		Where("one_links.used = ? AND one_links.notified_at IS NULL AND one_links.revoked_at IS NULL", false).
+		Where("one_links.expires_at > ?", now).
+		Where("one_links.expires_at <= DATE_ADD(?, INTERVAL surveys.expiry_notify_hours HOUR)", now).
+		Order("one_links.survey_id, one_links.expires_at").
+		Limit(limit).
+		Find(&oneLinks).Error
+	if err != nil {
+		return nil, err
+	}
+	return oneLinks, nil
+}
+
+// FindOutstandingWithPrefill finds up to limit links of a survey with prefill data that can
+// still be opened, i.e. are unused, unrevoked and unexpired, with IDs above afterID in ID order
+func (r *oneLinkRepository) FindOutstandingWithPrefill(ctx context.Context, surveyID, afterID uint, limit int) ([]model.OneLink, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var oneLinks []model.OneLink
+	err := r.db.WithContext(ctx).
+		Where("survey_id = ? AND id > ?", surveyID, afterID).
+		Where("used = ? AND revoked_at IS NULL AND expires_at > ?", false, time.Now()).
+		Where("prefill_data IS NOT NULL AND JSON_LENGTH(prefill_data) > 0").
+		Order("id").
+		Limit(limit).
+		Find(&oneLinks).Error
+	if err != nil {
+		return nil, err
+	}
+	return oneLinks, nil
+}
+
+// MarkAsNotified records that expiration notifications were sent for the given links
+func (r *oneLinkRepository) MarkAsNotified(ctx context.Context, ids []uint) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Model(&model.OneLink{}).
+		Where("id IN ?", ids).
+		Update("notified_at", time.Now()).Error
+}
+
+// ExpireUnusedByPrefill expires the unused links of a survey that were prefilled with field = value
+func (r *oneLinkRepository) ExpireUnusedByPrefill(ctx context.Context, surveyID uint, field, value string) (int64, error) {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	now := time.Now()
+	result := r.db.WithContext(ctx).Model(&model.OneLink{}).
+		Where("survey_id = ? AND used = ? AND expires_at > ?", surveyID, false, now).
+		Where("JSON_UNQUOTE(JSON_EXTRACT(prefill_data, ?)) = ?", prefillPath(field), value).
+		Update("expires_at", now)
+	return result.RowsAffected, result.Error
+}
+
+// prefillPath builds the JSON path of a prefill key, quoting it so keys with
+// dots or spaces are addressed literally
+func prefillPath(field string) string {
+	return "$." + strconv.Quote(field)
+}
+
 // DeleteExpired deletes all expired one-time links
-func (r *oneLinkRepository) DeleteExpired() error {
-	return r.db.Where("expires_at < ?", time.Now()).Delete(&model.OneLink{}).Error
+func (r *oneLinkRepository) DeleteExpired(ctx context.Context) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Where("expires_at < ?", time.Now()).Delete(&model.OneLink{}).Error
+}
+
+// CountByCampaign counts the links generated for a survey per campaign label
+func (r *oneLinkRepository) CountByCampaign(ctx context.Context, surveyID uint, includeTest bool) ([]CampaignCount, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	query := r.db.WithContext(ctx).Model(&model.OneLink{}).Where("survey_id = ?", surveyID)
+	if !includeTest {
+		query = query.Where("is_test = ?", false)
+	}
+
+	var counts []CampaignCount
+	err := query.Select("campaign, COUNT(*) AS count").
+		Group("campaign").
+		Scan(&counts).Error
+	return counts, err
+}
+
+// VariantCount is the number of links of a survey assigned to a variant
+type VariantCount struct {
+	Variant string
+	Count   int64
+}
+
+// CountByVariant counts the links generated for a survey per variant
+func (r *oneLinkRepository) CountByVariant(ctx context.Context, surveyID uint, includeTest bool) ([]VariantCount, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	query := r.db.WithContext(ctx).Model(&model.OneLink{}).Where("survey_id = ?", surveyID)
+	if !includeTest {
+		query = query.Where("is_test = ?", false)
+	}
+
+	var counts []VariantCount
+	err := query.Select("variant, COUNT(*) AS count").
+		Group("variant").
+		Scan(&counts).Error
+	return counts, err
+}
+
+// CountRevocable counts the links of a survey that Revoke would revoke
+func (r *oneLinkRepository) CountRevocable(ctx context.Context, surveyID uint, filter LinkFilter) (int64, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var count int64
+	err := filter.revocable(r.db.WithContext(ctx).Model(&model.OneLink{}), surveyID).Count(&count).Error
+	return count, err
+}
+
+// Revoke marks the survey's links matching the filter as revoked in one transaction
+// and returns them with their IDs and tokens, so their cache entries can be purged
+func (r *oneLinkRepository) Revoke(ctx context.Context, surveyID uint, filter LinkFilter) ([]model.OneLink, error) {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	var oneLinks []model.OneLink
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		query := filter.revocable(tx.Model(&model.OneLink{}), surveyID).
+			Select("id", "token").
+			Clauses(clause.Locking{Strength: "UPDATE"})
+		if err := query.Find(&oneLinks).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		for start := 0; start < len(oneLinks); start += revokeBatchSize {
+			end := min(start+revokeBatchSize, len(oneLinks))
+			ids := make([]uint, 0, end-start)
+			for _, oneLink := range oneLinks[start:end] {
+				ids = append(ids, oneLink.ID)
+			}
+			if err := tx.Model(&model.OneLink{}).Where("id IN ?", ids).Update("revoked_at", now).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return oneLinks, nil
+}
+
+// claimable restricts a query to the survey's pool links that are not claimed yet and can
+// still be opened
+func claimable(query *gorm.DB, surveyID uint) *gorm.DB {
+	return query.
+		Where("survey_id = ? AND pooled = ? AND claimed_at IS NULL", surveyID, true).
+		Where("used = ? AND revoked_at IS NULL AND expires_at > ?", false, time.Now())
+}
+
+// ClaimPooled binds prefill data and a respondent to a claimable pool link of a survey:
+// the link with the given token, or the oldest one when token is empty. Links locked by a
+// concurrent claim are skipped. Returns gorm.ErrRecordNotFound when no link can be claimed
+func (r *oneLinkRepository) ClaimPooled(ctx context.Context, surveyID uint, token string, prefillData model.PrefillDataType, respondentID string) (*model.OneLink, error) {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	var oneLink model.OneLink
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		query := claimable(tx, surveyID)
+		if token != "" {
+			query = query.Where("token_hash = ?", model.HashLinkToken(token))
+		}
+		err := query.
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Order("id").
+			First(&oneLink).Error
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		oneLink.PrefillData = prefillData
+		oneLink.RespondentID = respondentID
+		oneLink.ClaimedAt = &now
+		return tx.Model(&model.OneLink{}).Where("id = ?", oneLink.ID).Updates(map[string]interface{}{
+			"prefill_data":  prefillData,
+			"respondent_id": respondentID,
+			"claimed_at":    now,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &oneLink, nil
+}
+
+// CountClaimable counts the pool links of a survey that can still be claimed
+func (r *oneLinkRepository) CountClaimable(ctx context.Context, surveyID uint) (int64, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var count int64
+	err := claimable(r.db.WithContext(ctx).Model(&model.OneLink{}), surveyID).Count(&count).Error
+	return count, err
 }
@@ -1,50 +1,68 @@
 package repository
 
 import (
+	"context"
+
 	"survey-system/internal/model"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // QuestionRepository defines the interface for question data operations
 type QuestionRepository interface {
-	Create(question *model.Question) error
-	Update(question *model.Question) error
-	Delete(id uint) error
-	FindByID(id uint) (*model.Question, error)
-	FindBySurveyID(surveyID uint) ([]model.Question, error)
-	BatchUpdateOrder(questions []model.Question) error
+	Create(ctx context.Context, question *model.Question) error
+	Update(ctx context.Context, question *model.Question) error
+	Delete(ctx context.Context, id uint) error
+	FindByID(ctx context.Context, id uint) (*model.Question, error)
+	FindBySurveyID(ctx context.Context, surveyID uint) ([]model.Question, error)
+	BatchUpdateOrder(ctx context.Context, questions []model.Question) error
+	UpdateWithChange(ctx context.Context, question *model.Question, change *model.QuestionChange) error
+	FindSensitiveIDs(ctx context.Context, ids []uint) ([]uint, error)
 }
 
 // questionRepository implements QuestionRepository interface
 type questionRepository struct {
-	db *gorm.DB
+	db       *gorm.DB
+	timeouts Timeouts
 }
 
 // NewQuestionRepository creates a new question repository instance
-func NewQuestionRepository(db *gorm.DB) QuestionRepository {
-	return &questionRepository{db: db}
+func NewQuestionRepository(db *gorm.DB, timeouts Timeouts) QuestionRepository {
+	return &questionRepository{db: db, timeouts: timeouts}
 }
 
 // Create creates a new question
-func (r *questionRepository) Create(question *model.Question) error {
-	return r.db.Create(question).Error
+func (r *questionRepository) Create(ctx context.Context, question *model.Question) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Create(question).Error
 }
 
 // Update updates an existing question
-func (r *questionRepository) Update(question *model.Question) error {
-	return r.db.Save(question).Error
+func (r *questionRepository) Update(ctx context.Context, question *model.Question) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Save(question).Error
 }
 
 // Delete deletes a question by ID
-func (r *questionRepository) Delete(id uint) error {
-	return r.db.Delete(&model.Question{}, id).Error
+func (r *questionRepository) Delete(ctx context.Context, id uint) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Delete(&model.Question{}, id).Error
 }
 
 // FindByID finds a question by ID
-func (r *questionRepository) FindByID(id uint) (*model.Question, error) {
+func (r *questionRepository) FindByID(ctx context.Context, id uint) (*model.Question, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
 	var question model.Question
-	err := r.db.First(&question, id).Error
+	err := r.db.WithContext(ctx).First(&question, id).Error
 	if err != nil {
 		return nil, err
 	}
@@ -52,9 +70,12 @@ func (r *questionRepository) FindByID(id uint) (*model.Question, error) {
 }
 
 // FindBySurveyID finds all questions for a survey, ordered by the order field
-func (r *questionRepository) FindBySurveyID(surveyID uint) ([]model.Question, error) {
+func (r *questionRepository) FindBySurveyID(ctx context.Context, surveyID uint) ([]model.Question, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
 	var questions []model.Question
-	err := r.db.Where("survey_id = ?", surveyID).
+	err := r.db.WithContext(ctx).Where("survey_id = ?", surveyID).
 		Order("\"order\" ASC").
 		Find(&questions).Error
 	if err != nil {
@@ -64,8 +85,11 @@ func (r *questionRepository) FindBySurveyID(surveyID uint) ([]model.Question, er
 }
 
 // BatchUpdateOrder updates the order field for multiple questions in a transaction
-func (r *questionRepository) BatchUpdateOrder(questions []model.Question) error {
-	return r.db.Transaction(func(tx *gorm.DB) error {
+func (r *questionRepository) BatchUpdateOrder(ctx context.Context, questions []model.Question) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		for _, question := range questions {
 			if err := tx.Model(&model.Question{}).
 				Where("id = ?", question.ID).
@@ -76,3 +100,31 @@ func (r *questionRepository) BatchUpdateOrder(questions []model.Question) error
 		return nil
 	})
 }
+
+// UpdateWithChange updates a question and records its history entry in one transaction
+func (r *questionRepository) UpdateWithChange(ctx context.Context, question *model.Question, change *model.QuestionChange) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(question).Error; err != nil {
+			return err
+		}
+		return tx.Omit(clause.Associations).Create(change).Error
+	})
+}
+
+// FindSensitiveIDs returns which of the given questions are marked sensitive
+func (r *questionRepository) FindSensitiveIDs(ctx context.Context, ids []uint) ([]uint, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var sensitive []uint
+	err := r.db.WithContext(ctx).Model(&model.Question{}).
+		Where("id IN ? AND sensitive = ?", ids, true).
+		Pluck("id", &sensitive).Error
+	if err != nil {
+		return nil, err
+	}
+	return sensitive, nil
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+
+	"survey-system/internal/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// QuestionChangeRepository defines the interface for question change history data operations
+type QuestionChangeRepository interface {
+	Create(ctx context.Context, changes []model.QuestionChange) error
+	FindByQuestionID(ctx context.Context, questionID uint) ([]model.QuestionChange, error)
+}
+
+// questionChangeRepository implements QuestionChangeRepository interface
+type questionChangeRepository struct {
+	db       *gorm.DB
+	timeouts Timeouts
+}
+
+// NewQuestionChangeRepository creates a new question change repository instance
+func NewQuestionChangeRepository(db *gorm.DB, timeouts Timeouts) QuestionChangeRepository {
+	return &questionChangeRepository{db: db, timeouts: timeouts}
+}
+
+// Create records question changes
+func (r *questionChangeRepository) Create(ctx context.Context, changes []model.QuestionChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Omit(clause.Associations).Create(&changes).Error
+}
+
+// FindByQuestionID finds the changes of a question, newest first, with their editors preloaded
+func (r *questionChangeRepository) FindByQuestionID(ctx context.Context, questionID uint) ([]model.QuestionChange, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var changes []model.QuestionChange
+	err := r.db.WithContext(ctx).Preload("User").
+		Where("question_id = ?", questionID).
+		Order("created_at DESC").
+		Order("id DESC").
+		Find(&changes).Error
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"survey-system/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// ReportRepository defines the interface for report subscription data operations
+type ReportRepository interface {
+	Create(ctx context.Context, subscription *model.ReportSubscription) error
+	FindByID(ctx context.Context, id uint) (*model.ReportSubscription, error)
+	FindBySurveyID(ctx context.Context, surveyID uint) ([]model.ReportSubscription, error)
+	Delete(ctx context.Context, id uint) error
+	FindDue(ctx context.Context, frequency string, periodStart time.Time, limit int) ([]model.ReportSubscription, error)
+	MarkAsSent(ctx context.Context, id uint, sentAt time.Time) error
+}
+
+// reportRepository implements ReportRepository interface
+type reportRepository struct {
+	db       *gorm.DB
+	timeouts Timeouts
+}
+
+// NewReportRepository creates a new report subscription repository instance
+func NewReportRepository(db *gorm.DB, timeouts Timeouts) ReportRepository {
+	return &reportRepository{db: db, timeouts: timeouts}
+}
+
+// Create creates a new report subscription
+func (r *reportRepository) Create(ctx context.Context, subscription *model.ReportSubscription) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Create(subscription).Error
+}
+
+// FindByID finds a report subscription by ID
+func (r *reportRepository) FindByID(ctx context.Context, id uint) (*model.ReportSubscription, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var subscription model.ReportSubscription
+	err := r.db.WithContext(ctx).First(&subscription, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+// FindBySurveyID finds the report subscriptions of a survey
+func (r *reportRepository) FindBySurveyID(ctx context.Context, surveyID uint) ([]model.ReportSubscription, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var subscriptions []model.ReportSubscription
+	err := r.db.WithContext(ctx).
+		Where("survey_id = ?", surveyID).
+		Order("id ASC").
+		Find(&subscriptions).Error
+	if err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+// Delete deletes a report subscription
+func (r *reportRepository) Delete(ctx context.Context, id uint) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Delete(&model.ReportSubscription{}, id).Error
+}
+
+// FindDue finds subscriptions of the given frequency not yet sent in the period
+// starting at periodStart, with their surveys preloaded
+// Subscriptions created during the period wait for the next one
+func (r *reportRepository) FindDue(ctx context.Context, frequency string, periodStart time.Time, limit int) ([]model.ReportSubscription, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var subscriptions []model.ReportSubscription
+	err := r.db.WithContext(ctx).Preload("Survey").
+		Where("frequency = ? AND created_at < ?", frequency, periodStart).
+		Where("(last_sent_at IS NULL OR last_sent_at < ?)", periodStart).
+		Order("id ASC").
+		Limit(limit).
+		Find(&subscriptions).Error
+	if err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+// MarkAsSent records when a report was last delivered for a subscription
+func (r *reportRepository) MarkAsSent(ctx context.Context, id uint, sentAt time.Time) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Model(&model.ReportSubscription{}).
+		Where("id = ?", id).
+		Update("last_sent_at", sentAt).Error
+}
@@ -1,51 +1,294 @@
 package repository
 
 import (
+	"context"
+	"time"
+
 	"survey-system/internal/model"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // ResponseRepository defines the interface for response data operations
 type ResponseRepository interface {
-	Create(response *model.Response) error
-	FindByID(id uint) (*model.Response, error)
-	FindBySurveyID(surveyID uint, page, pageSize int) ([]model.Response, int64, error)
-	CountBySurveyID(surveyID uint) (int64, error)
+	Create(ctx context.Context, response *model.Response) error
+	CreateBatch(ctx context.Context, responses []model.Response, batchSize int) error
+	FindByID(ctx context.Context, id uint) (*model.Response, error)
+	FindBySurveyID(ctx context.Context, surveyID uint, filter ResponseFilter, page, pageSize int) ([]model.Response, int64, error)
+	FindBySurveyIDAfter(ctx context.Context, surveyID uint, filter ResponseFilter, after *ResponseCursor, limit int) ([]model.Response, error)
+	CountBySurveyID(ctx context.Context, surveyID uint) (int64, error)
+	CountBySurveyAndPrefill(ctx context.Context, surveyID uint, field, value string) (int64, error)
+	CountByCampaign(ctx context.Context, surveyID uint, includeTest bool) ([]CampaignCount, error)
+	ExistsByRespondent(ctx context.Context, surveyID uint, respondentID string) (bool, error)
+	FindExistingRespondents(ctx context.Context, surveyID uint, respondentIDs []string) ([]string, error)
+	CountByDay(ctx context.Context, surveyID uint, since time.Time) ([]DailyCount, error)
+	CountByFilter(ctx context.Context, surveyID uint, filter ResponseFilter) (int64, error)
+	FindScores(ctx context.Context, surveyID uint, filter ResponseFilter) ([]ResponseScore, error)
+	SampleIDs(ctx context.Context, surveyID uint, filter ResponseFilter, exclude []uint, limit int) ([]uint, error)
+	FindByIDs(ctx context.Context, ids []uint) ([]model.Response, error)
+	FindSurveyIDsSubmittedBefore(ctx context.Context, before time.Time) ([]uint, error)
+	DeleteByFilter(ctx context.Context, surveyID uint, filter ResponseFilter, batchSize int) (int64, error)
+	UpdateReviewStatus(ctx context.Context, surveyID uint, filter ResponseFilter, status string, reviewerID uint) (int64, error)
+	FindByOneLinkID(ctx context.Context, oneLinkID uint) (*model.Response, error)
+	UpdateAnswers(ctx context.Context, response *model.Response, previous *model.ResponseVersion) error
+	ReplaceData(ctx context.Context, responses []model.Response) error
+	FindVersions(ctx context.Context, responseID uint) ([]model.ResponseVersion, error)
+}
+
+// ResponseFilter narrows a response listing
+type ResponseFilter struct {
+	Campaign string    // only responses from links with this campaign label when set
+	Variant  string    // only responses from links of this survey variant when set
+	From     time.Time // only responses submitted at or after this time when set
+	To       time.Time // only responses submitted before this time when set
+
+	// Only responses that answered QuestionID when set, and chose OptionID of it when also set
+	QuestionID uint
+	OptionID   string
+
+	IncludeTest bool // also match responses submitted through test links
+	FlaggedOnly bool // only responses flagged as suspicious
+
+	ReviewStatus string // only responses with this review status when set
+	IDs          []uint // only these responses when set
+}
+
+// scope restricts a query to the survey's responses matching the filter
+func (f ResponseFilter) scope(surveyID uint) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		db = db.Where("survey_id = ?", surveyID)
+		if !f.IncludeTest {
+			db = db.Where("is_test = ?", false)
+		}
+		if f.Campaign != "" {
+			db = db.Where("campaign = ?", f.Campaign)
+		}
+		if f.Variant != "" {
+			db = db.Where("variant = ?", f.Variant)
+		}
+		if f.FlaggedOnly {
+			db = db.Where("flagged = ?", true)
+		}
+		if f.ReviewStatus != "" {
+			db = db.Where("review_status = ?", f.ReviewStatus)
+		}
+		if len(f.IDs) > 0 {
+			db = db.Where("id IN ?", f.IDs)
+		}
+		if !f.From.IsZero() {
+			db = db.Where("submitted_at >= ?", f.From)
+		}
+		if !f.To.IsZero() {
+			db = db.Where("submitted_at < ?", f.To)
+		}
+		if f.QuestionID != 0 {
+			if f.OptionID != "" {
+				// Matches both a single choice value and an element of a multiple choice array
+				db = db.Where("JSON_CONTAINS(data, JSON_OBJECT('question_id', ?, 'value', ?), '$.answers')", f.QuestionID, f.OptionID)
+			} else {
+				db = db.Where("JSON_CONTAINS(data, JSON_OBJECT('question_id', ?), '$.answers')", f.QuestionID)
+			}
+		}
+		return db
+	}
+}
+
+// CampaignCount is the number of records of a survey carrying a campaign label
+type CampaignCount struct {
+	Campaign string
+	Count    int64
+}
+
+// DailyCount is the number of responses a survey received on one day
+type DailyCount struct {
+	Day    time.Time // midnight of the day in the database time zone
+	Count  int64
+	Latest time.Time // most recent submission of the day
+}
+
+// ResponseCursor marks a position in the responses of a survey ordered by
+// submitted_at and id, both descending
+type ResponseCursor struct {
+	SubmittedAt time.Time
+	ID          uint
 }
 
 // responseRepository implements ResponseRepository interface
 type responseRepository struct {
-	db *gorm.DB
+	db       *gorm.DB
+	timeouts Timeouts
 }
 
 // NewResponseRepository creates a new response repository instance
-func NewResponseRepository(db *gorm.DB) ResponseRepository {
-	return &responseRepository{db: db}
+func NewResponseRepository(db *gorm.DB, timeouts Timeouts) ResponseRepository {
+	return &responseRepository{db: db, timeouts: timeouts}
 }
 
 // Create creates a new response record
-func (r *responseRepository) Create(response *model.Response) error {
-	return r.db.Create(response).Error
+// Non-test responses are given the next respondent number of the survey in the
+// same transaction, so a failed insert does not leave a gap
+func (r *responseRepository) Create(ctx context.Context, response *model.Response) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if !response.IsTest {
+			number, err := nextRespondentNumbers(tx, response.SurveyID, 1)
+			if err != nil {
+				return err
+			}
+			response.RespondentNumber = &number
+		}
+		return tx.Create(response).Error
+	})
+}
+
+// CreateBatch inserts responses of one survey in batches within a single
+// transaction, so either all of them are stored or none
+// Non-test responses are numbered consecutively in slice order
+func (r *responseRepository) CreateBatch(ctx context.Context, responses []model.Response, batchSize int) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	if len(responses) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var numbered []*model.Response
+		for i := range responses {
+			if !responses[i].IsTest {
+				numbered = append(numbered, &responses[i])
+			}
+		}
+		if len(numbered) > 0 {
+			first, err := nextRespondentNumbers(tx, responses[0].SurveyID, len(numbered))
+			if err != nil {
+				return err
+			}
+			for i, response := range numbered {
+				number := first + uint(i)
+				response.RespondentNumber = &number
+			}
+		}
+		return tx.CreateInBatches(responses, batchSize).Error
+	})
+}
+
+// nextRespondentNumbers takes count consecutive respondent numbers of a survey
+// and returns the first one. The sequence row stays locked until tx ends, which
+// serializes concurrent submissions to the same survey
+func nextRespondentNumbers(tx *gorm.DB, surveyID uint, count int) (uint, error) {
+	// Create the sequence on first use; an existing row is left untouched
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&model.SurveySequence{SurveyID: surveyID}).Error; err != nil {
+		return 0, err
+	}
+
+	var sequence model.SurveySequence
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&sequence, "survey_id = ?", surveyID).Error; err != nil {
+		return 0, err
+	}
+
+	first := sequence.LastRespondentNumber + 1
+	err := tx.Model(&sequence).Update("last_respondent_number", sequence.LastRespondentNumber+uint(count)).Error
+	return first, err
 }
 
 // FindByID finds a response by ID
-func (r *responseRepository) FindByID(id uint) (*model.Response, error) {
+func (r *responseRepository) FindByID(ctx context.Context, id uint) (*model.Response, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
 	var response model.Response
-	err := r.db.First(&response, id).Error
+	err := r.db.WithContext(ctx).First(&response, id).Error
 	if err != nil {
 		return nil, err
 	}
 	return &response, nil
 }
 
+// FindByOneLinkID finds the response submitted through a one-time link
+func (r *responseRepository) FindByOneLinkID(ctx context.Context, oneLinkID uint) (*model.Response, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var response model.Response
+	err := r.db.WithContext(ctx).Where("one_link_id = ?", oneLinkID).First(&response).Error
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// UpdateAnswers stores the corrected answers of a response together with the version
+// they replace, in one transaction. The update only applies while the stored response
+// is still at previous.Version; otherwise gorm.ErrRecordNotFound is returned
+func (r *responseRepository) UpdateAnswers(ctx context.Context, response *model.Response, previous *model.ResponseVersion) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.Response{}).
+			Where("id = ? AND version = ?", response.ID, previous.Version).
+			Updates(map[string]interface{}{
+				"data":      response.Data,
+				"version":   response.Version,
+				"edited_at": response.EditedAt,
+				"score":     response.Score,
+				"max_score": response.MaxScore,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.Create(previous).Error
+	})
+}
+
+// ReplaceData overwrites the answers of responses in one transaction, without recording
+// a version or touching edited_at. Used to migrate stored answers to a changed question
+func (r *responseRepository) ReplaceData(ctx context.Context, responses []model.Response) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i := range responses {
+			err := tx.Model(&model.Response{}).
+				Where("id = ?", responses[i].ID).
+				UpdateColumn("data", responses[i].Data).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// FindVersions finds the replaced versions of a response, oldest first
+func (r *responseRepository) FindVersions(ctx context.Context, responseID uint) ([]model.ResponseVersion, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var versions []model.ResponseVersion
+	err := r.db.WithContext(ctx).
+		Where("response_id = ?", responseID).
+		Order("version ASC").
+		Find(&versions).Error
+	return versions, err
+}
+
 // FindBySurveyID finds all responses for a survey with pagination
-func (r *responseRepository) FindBySurveyID(surveyID uint, page, pageSize int) ([]model.Response, int64, error) {
+func (r *responseRepository) FindBySurveyID(ctx context.Context, surveyID uint, filter ResponseFilter, page, pageSize int) ([]model.Response, int64, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
 	var responses []model.Response
 	var total int64
 
 	// Count total records
-	if err := r.db.Model(&model.Response{}).Where("survey_id = ?", surveyID).Count(&total).Error; err != nil {
+	if err := r.db.WithContext(ctx).Model(&model.Response{}).Scopes(filter.scope(surveyID)).Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
@@ -53,8 +296,9 @@ func (r *responseRepository) FindBySurveyID(surveyID uint, page, pageSize int) (
 	offset := (page - 1) * pageSize
 
 	// Query with pagination
-	err := r.db.Where("survey_id = ?", surveyID).
+	err := r.db.WithContext(ctx).Scopes(filter.scope(surveyID)).
 		Order("submitted_at DESC").
+		Order("id DESC").
 		Limit(pageSize).
 		Offset(offset).
 		Find(&responses).Error
@@ -66,9 +310,242 @@ func (r *responseRepository) FindBySurveyID(surveyID uint, page, pageSize int) (
 	return responses, total, nil
 }
 
-// CountBySurveyID counts the total number of responses for a survey
-func (r *responseRepository) CountBySurveyID(surveyID uint) (int64, error) {
+// FindBySurveyIDAfter finds up to limit responses for a survey that come after the cursor
+// A nil cursor starts from the most recent response. Keyset pagination keeps the
+// cost of deep pages constant and is not affected by concurrent inserts
+func (r *responseRepository) FindBySurveyIDAfter(ctx context.Context, surveyID uint, filter ResponseFilter, after *ResponseCursor, limit int) ([]model.Response, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var responses []model.Response
+
+	query := r.db.WithContext(ctx).Scopes(filter.scope(surveyID))
+	if after != nil {
+		query = query.Where("submitted_at < ? OR (submitted_at = ? AND id < ?)", after.SubmittedAt, after.SubmittedAt, after.ID)
+	}
+
+	err := query.Order("submitted_at DESC").
+		Order("id DESC").
+		Limit(limit).
+		Find(&responses).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return responses, nil
+}
+
+// CountBySurveyID counts the total number of responses for a survey, not
+// counting test responses
+func (r *responseRepository) CountBySurveyID(ctx context.Context, surveyID uint) (int64, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var count int64
+	err := r.db.WithContext(ctx).Model(&model.Response{}).Where("survey_id = ? AND is_test = ?", surveyID, false).Count(&count).Error
+	return count, err
+}
+
+// CountBySurveyAndPrefill counts the non-test responses of a survey whose
+// one-time link was prefilled with field = value
+func (r *responseRepository) CountBySurveyAndPrefill(ctx context.Context, surveyID uint, field, value string) (int64, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var count int64
+	err := r.db.WithContext(ctx).Model(&model.Response{}).
+		Joins("JOIN one_links ON one_links.id = responses.one_link_id").
+		Where("responses.survey_id = ? AND responses.is_test = ?", surveyID, false).
+		Where("JSON_UNQUOTE(JSON_EXTRACT(one_links.prefill_data, ?)) = ?", prefillPath(field), value).
+		Count(&count).Error
+	return count, err
+}
+
+// CountByCampaign counts the responses of a survey per campaign label
+func (r *responseRepository) CountByCampaign(ctx context.Context, surveyID uint, includeTest bool) ([]CampaignCount, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	query := r.db.WithContext(ctx).Model(&model.Response{}).Where("survey_id = ?", surveyID)
+	if !includeTest {
+		query = query.Where("is_test = ?", false)
+	}
+
+	var counts []CampaignCount
+	err := query.Select("campaign, COUNT(*) AS count").
+		Group("campaign").
+		Scan(&counts).Error
+	return counts, err
+}
+
+// ExistsByRespondent reports whether a known respondent has already responded to a survey
+func (r *responseRepository) ExistsByRespondent(ctx context.Context, surveyID uint, respondentID string) (bool, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var count int64
+	err := r.db.WithContext(ctx).Model(&model.Response{}).
+		Where("survey_id = ? AND respondent_id = ?", surveyID, respondentID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// FindExistingRespondents returns which of the given respondent IDs already responded to a survey
+func (r *responseRepository) FindExistingRespondents(ctx context.Context, surveyID uint, respondentIDs []string) ([]string, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var existing []string
+	if len(respondentIDs) == 0 {
+		return existing, nil
+	}
+	err := r.db.WithContext(ctx).Model(&model.Response{}).
+		Where("survey_id = ? AND respondent_id IN ?", surveyID, respondentIDs).
+		Pluck("respondent_id", &existing).Error
+	return existing, err
+}
+
+// CountByDay counts the non-test responses of a survey submitted since the given time per day
+func (r *responseRepository) CountByDay(ctx context.Context, surveyID uint, since time.Time) ([]DailyCount, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var counts []DailyCount
+	err := r.db.WithContext(ctx).Model(&model.Response{}).
+		Select("DATE(submitted_at) AS day, COUNT(*) AS count, MAX(submitted_at) AS latest").
+		Where("survey_id = ? AND submitted_at >= ? AND is_test = ?", surveyID, since, false).
+		Group("DATE(submitted_at)").
+		Scan(&counts).Error
+	return counts, err
+}
+
+// CountByFilter counts the responses of a survey matching the filter
+func (r *responseRepository) CountByFilter(ctx context.Context, surveyID uint, filter ResponseFilter) (int64, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
 	var count int64
-	err := r.db.Model(&model.Response{}).Where("survey_id = ?", surveyID).Count(&count).Error
+	err := r.db.WithContext(ctx).Model(&model.Response{}).Scopes(filter.scope(surveyID)).Count(&count).Error
 	return count, err
 }
+
+// ResponseScore is the score of an assessment response and the points it was worth
+type ResponseScore struct {
+	Score    float64
+	MaxScore float64
+}
+
+// FindScores lists the scores of the survey's scored responses matching the filter
+func (r *responseRepository) FindScores(ctx context.Context, surveyID uint, filter ResponseFilter) ([]ResponseScore, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var scores []ResponseScore
+	err := r.db.WithContext(ctx).Model(&model.Response{}).
+		Scopes(filter.scope(surveyID)).
+		Where("score IS NOT NULL AND max_score IS NOT NULL").
+		Select("score, max_score").
+		Scan(&scores).Error
+	return scores, err
+}
+
+// FindSurveyIDsSubmittedBefore lists the surveys having responses, test ones included, submitted before a time
+func (r *responseRepository) FindSurveyIDsSubmittedBefore(ctx context.Context, before time.Time) ([]uint, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var surveyIDs []uint
+	err := r.db.WithContext(ctx).Model(&model.Response{}).
+		Where("submitted_at < ?", before).
+		Distinct().
+		Pluck("survey_id", &surveyIDs).Error
+	return surveyIDs, err
+}
+
+// DeleteByFilter deletes the responses of a survey matching the filter, batchSize at a time
+// so no single statement holds locks for long, and returns how many were deleted
+func (r *responseRepository) DeleteByFilter(ctx context.Context, surveyID uint, filter ResponseFilter, batchSize int) (int64, error) {
+	var deleted int64
+	for {
+		n, err := r.deleteBatch(ctx, surveyID, filter, batchSize)
+		deleted += n
+		if err != nil || n < int64(batchSize) {
+			return deleted, err
+		}
+	}
+}
+
+// deleteBatch deletes up to limit responses of a survey matching the filter
+func (r *responseRepository) deleteBatch(ctx context.Context, surveyID uint, filter ResponseFilter, limit int) (int64, error) {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	var ids []uint
+	if err := r.db.WithContext(ctx).Model(&model.Response{}).
+		Scopes(filter.scope(surveyID)).
+		Order("id").
+		Limit(limit).
+		Pluck("id", &ids).Error; err != nil {
+		return 0, err
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	result := r.db.WithContext(ctx).Delete(&model.Response{}, ids)
+	return result.RowsAffected, result.Error
+}
+
+// UpdateReviewStatus sets the review status of the survey's responses matching the filter
+// in a single statement and returns how many changed; responses already in the status
+// are left untouched. Moving responses back to pending clears their reviewer
+func (r *responseRepository) UpdateReviewStatus(ctx context.Context, surveyID uint, filter ResponseFilter, status string, reviewerID uint) (int64, error) {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	updates := map[string]interface{}{
+		"review_status": status,
+		"reviewed_at":   nil,
+		"reviewed_by":   nil,
+	}
+	if status != model.ReviewStatusPending {
+		updates["reviewed_at"] = time.Now()
+		updates["reviewed_by"] = reviewerID
+	}
+
+	result := r.db.WithContext(ctx).Model(&model.Response{}).
+		Scopes(filter.scope(surveyID)).
+		Where("review_status <> ?", status).
+		UpdateColumns(updates)
+	return result.RowsAffected, result.Error
+}
+
+// SampleIDs picks up to limit random IDs of the survey's responses matching the filter
+// Only the IDs are shuffled by the database, the response data is not read
+func (r *responseRepository) SampleIDs(ctx context.Context, surveyID uint, filter ResponseFilter, exclude []uint, limit int) ([]uint, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	query := r.db.WithContext(ctx).Model(&model.Response{}).Scopes(filter.scope(surveyID))
+	if len(exclude) > 0 {
+		query = query.Where("id NOT IN ?", exclude)
+	}
+
+	var ids []uint
+	err := query.Order("RAND()").Limit(limit).Pluck("id", &ids).Error
+	return ids, err
+}
+
+// FindByIDs finds the responses with the given IDs
+func (r *responseRepository) FindByIDs(ctx context.Context, ids []uint) ([]model.Response, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var responses []model.Response
+	if len(ids) == 0 {
+		return responses, nil
+	}
+	err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&responses).Error
+	return responses, err
+}
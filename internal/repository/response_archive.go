@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+
+	"survey-system/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// ResponseArchiveRepository defines the interface for response archive data operations
+type ResponseArchiveRepository interface {
+	Create(ctx context.Context, archive *model.ResponseArchive) error
+	FindByID(ctx context.Context, id uint) (*model.ResponseArchive, error)
+	FindAll(ctx context.Context, surveyID uint) ([]model.ResponseArchive, error)
+}
+
+// responseArchiveRepository implements ResponseArchiveRepository interface
+type responseArchiveRepository struct {
+	db       *gorm.DB
+	timeouts Timeouts
+}
+
+// NewResponseArchiveRepository creates a new response archive repository instance
+func NewResponseArchiveRepository(db *gorm.DB, timeouts Timeouts) ResponseArchiveRepository {
+	return &responseArchiveRepository{db: db, timeouts: timeouts}
+}
+
+// Create records a new response archive
+func (r *responseArchiveRepository) Create(ctx context.Context, archive *model.ResponseArchive) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Create(archive).Error
+}
+
+// FindByID finds a response archive by ID
+func (r *responseArchiveRepository) FindByID(ctx context.Context, id uint) (*model.ResponseArchive, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var archive model.ResponseArchive
+	if err := r.db.WithContext(ctx).First(&archive, id).Error; err != nil {
+		return nil, err
+	}
+	return &archive, nil
+}
+
+// FindAll lists response archives newest first, only those of surveyID when it is not 0
+func (r *responseArchiveRepository) FindAll(ctx context.Context, surveyID uint) ([]model.ResponseArchive, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	query := r.db.WithContext(ctx).Order("created_at DESC, id DESC")
+	if surveyID != 0 {
+		query = query.Where("survey_id = ?", surveyID)
+	}
+
+	var archives []model.ResponseArchive
+	if err := query.Find(&archives).Error; err != nil {
+		return nil, err
+	}
+	return archives, nil
+}
@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"survey-system/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// SessionRepository defines the interface for login session data operations
+type SessionRepository interface {
+	Create(ctx context.Context, session *model.UserSession) error
+	FindByID(ctx context.Context, id uint) (*model.UserSession, error)
+	FindByTokenID(ctx context.Context, tokenID string) (*model.UserSession, error)
+	FindActiveByUserID(ctx context.Context, userID uint) ([]model.UserSession, error)
+	Revoke(ctx context.Context, id uint) error
+	RevokeByUser(ctx context.Context, userID uint) error
+	RevokeOthersByUser(ctx context.Context, userID uint, keepTokenID string) error
+	Touch(ctx context.Context, id uint, usedAt time.Time) error
+	DeleteExpired(ctx context.Context, userID uint) error
+}
+
+// sessionRepository implements SessionRepository interface
+type sessionRepository struct {
+	db       *gorm.DB
+	timeouts Timeouts
+}
+
+// NewSessionRepository creates a new session repository instance
+func NewSessionRepository(db *gorm.DB, timeouts Timeouts) SessionRepository {
+	return &sessionRepository{db: db, timeouts: timeouts}
+}
+
+// Create creates a new session
+func (r *sessionRepository) Create(ctx context.Context, session *model.UserSession) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Create(session).Error
+}
+
+// FindByID finds a session by ID
+func (r *sessionRepository) FindByID(ctx context.Context, id uint) (*model.UserSession, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var session model.UserSession
+	err := r.db.WithContext(ctx).First(&session, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// FindByTokenID finds a session by the ID of its token
+func (r *sessionRepository) FindByTokenID(ctx context.Context, tokenID string) (*model.UserSession, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var session model.UserSession
+	err := r.db.WithContext(ctx).Where("token_id = ?", tokenID).First(&session).Error
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// FindActiveByUserID finds the unrevoked, unexpired sessions of a user, newest first
+func (r *sessionRepository) FindActiveByUserID(ctx context.Context, userID uint) ([]model.UserSession, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var sessions []model.UserSession
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, time.Now()).
+		Order("created_at DESC").
+		Find(&sessions).Error
+	if err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// Revoke marks a session as revoked; revoking it again keeps the first revocation time
+func (r *sessionRepository) Revoke(ctx context.Context, id uint) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Model(&model.UserSession{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now()).Error
+}
+
+// RevokeByUser revokes every active session of a user
+func (r *sessionRepository) RevokeByUser(ctx context.Context, userID uint) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Model(&model.UserSession{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error
+}
+
+// RevokeOthersByUser revokes every active session of a user except the one of keepTokenID
+func (r *sessionRepository) RevokeOthersByUser(ctx context.Context, userID uint, keepTokenID string) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Model(&model.UserSession{}).
+		Where("user_id = ? AND token_id <> ? AND revoked_at IS NULL", userID, keepTokenID).
+		Update("revoked_at", time.Now()).Error
+}
+
+// Touch updates the last use time of a session
+func (r *sessionRepository) Touch(ctx context.Context, id uint, usedAt time.Time) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Model(&model.UserSession{}).
+		Where("id = ?", id).
+		Update("last_used_at", usedAt).Error
+}
+
+// DeleteExpired deletes the expired and revoked sessions of a user
+// Revoked sessions are kept until their token would have expired anyway
+func (r *sessionRepository) DeleteExpired(ctx context.Context, userID uint) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).
+		Where("user_id = ? AND expires_at <= ?", userID, time.Now()).
+		Delete(&model.UserSession{}).Error
+}
@@ -1,51 +1,97 @@
 package repository
 
 import (
+	"context"
+	"time"
+
 	"survey-system/internal/model"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // SurveyRepository defines the interface for survey data operations
 type SurveyRepository interface {
-	Create(survey *model.Survey) error
-	Update(survey *model.Survey) error
-	Delete(id uint) error
-	FindByID(id uint) (*model.Survey, error)
-	FindByIDWithQuestions(id uint) (*model.Survey, error)
-	FindByUserID(userID uint, page, pageSize int) ([]model.Survey, int64, error)
-	UpdateStatus(id uint, status string) error
+	Create(ctx context.Context, survey *model.Survey) error
+	Update(ctx context.Context, survey *model.Survey) error
+	Delete(ctx context.Context, id uint) error
+	FindByID(ctx context.Context, id uint) (*model.Survey, error)
+	FindByIDWithQuestions(ctx context.Context, id uint) (*model.Survey, error)
+	FindByUserID(ctx context.Context, userID uint, filter SurveyFilter, page, pageSize int) ([]model.Survey, int64, error)
+	FindIDsByUserID(ctx context.Context, userID uint) ([]uint, error)
+	UpdateStatus(ctx context.Context, id uint, status string) error
+	MarkPublished(ctx context.Context, id uint, at time.Time) error
+	FindUnanswered(ctx context.Context, now time.Time, limit int) ([]model.Survey, error)
+	MarkReminded(ctx context.Context, ids []uint, at time.Time) error
+	IncrementHoneypotCatches(ctx context.Context, id uint) error
+	TransferOwnership(ctx context.Context, id, userID uint) error
+	SetTeam(ctx context.Context, id uint, teamID *uint) error
+	FindByTeamID(ctx context.Context, teamID uint) ([]model.Survey, error)
+	Clone(ctx context.Context, survey *model.Survey) error
+}
+
+// SurveyFilter narrows a survey listing by status
+type SurveyFilter struct {
+	Status        string // only surveys with this status when set
+	ExcludeStatus string // surveys with this status are skipped when set
+}
+
+// scope restricts a query to the user's surveys matching the filter
+func (f SurveyFilter) scope(userID uint) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		db = db.Where("user_id = ?", userID)
+		if f.Status != "" {
+			db = db.Where("status = ?", f.Status)
+		}
+		if f.ExcludeStatus != "" {
+			db = db.Where("status <> ?", f.ExcludeStatus)
+		}
+		return db
+	}
 }
 
 // surveyRepository implements SurveyRepository interface
 type surveyRepository struct {
-	db *gorm.DB
+	db       *gorm.DB
+	timeouts Timeouts
 }
 
 // NewSurveyRepository creates a new survey repository instance
-func NewSurveyRepository(db *gorm.DB) SurveyRepository {
-	return &surveyRepository{db: db}
+func NewSurveyRepository(db *gorm.DB, timeouts Timeouts) SurveyRepository {
+	return &surveyRepository{db: db, timeouts: timeouts}
 }
 
 // Create creates a new survey
-func (r *surveyRepository) Create(survey *model.Survey) error {
-	return r.db.Create(survey).Error
+func (r *surveyRepository) Create(ctx context.Context, survey *model.Survey) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Create(survey).Error
 }
 
 // Update updates an existing survey
-func (r *surveyRepository) Update(survey *model.Survey) error {
-	return r.db.Save(survey).Error
+func (r *surveyRepository) Update(ctx context.Context, survey *model.Survey) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Save(survey).Error
 }
 
 // Delete deletes a survey by ID (cascade delete handled by database)
-func (r *surveyRepository) Delete(id uint) error {
-	return r.db.Delete(&model.Survey{}, id).Error
+func (r *surveyRepository) Delete(ctx context.Context, id uint) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Delete(&model.Survey{}, id).Error
 }
 
 // FindByID finds a survey by ID without preloading questions
-func (r *surveyRepository) FindByID(id uint) (*model.Survey, error) {
+func (r *surveyRepository) FindByID(ctx context.Context, id uint) (*model.Survey, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
 	var survey model.Survey
-	err := r.db.First(&survey, id).Error
+	err := r.db.WithContext(ctx).First(&survey, id).Error
 	if err != nil {
 		return nil, err
 	}
@@ -53,9 +99,12 @@ func (r *surveyRepository) FindByID(id uint) (*model.Survey, error) {
 }
 
 // FindByIDWithQuestions finds a survey by ID with preloaded questions
-func (r *surveyRepository) FindByIDWithQuestions(id uint) (*model.Survey, error) {
+func (r *surveyRepository) FindByIDWithQuestions(ctx context.Context, id uint) (*model.Survey, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
 	var survey model.Survey
-	err := r.db.Preload("Questions", func(db *gorm.DB) *gorm.DB {
+	err := r.db.WithContext(ctx).Preload("Questions", func(db *gorm.DB) *gorm.DB {
 		return db.Order("questions.order ASC")
 	}).First(&survey, id).Error
 	if err != nil {
@@ -64,13 +113,16 @@ func (r *surveyRepository) FindByIDWithQuestions(id uint) (*model.Survey, error)
 	return &survey, nil
 }
 
-// FindByUserID finds surveys by user ID with pagination
-func (r *surveyRepository) FindByUserID(userID uint, page, pageSize int) ([]model.Survey, int64, error) {
+// FindByUserID finds surveys by user ID matching the filter with pagination
+func (r *surveyRepository) FindByUserID(ctx context.Context, userID uint, filter SurveyFilter, page, pageSize int) ([]model.Survey, int64, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
 	var surveys []model.Survey
 	var total int64
 
 	// Count total records
-	if err := r.db.Model(&model.Survey{}).Where("user_id = ?", userID).Count(&total).Error; err != nil {
+	if err := r.db.WithContext(ctx).Model(&model.Survey{}).Scopes(filter.scope(userID)).Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
@@ -78,7 +130,7 @@ func (r *surveyRepository) FindByUserID(userID uint, page, pageSize int) ([]mode
 	offset := (page - 1) * pageSize
 
 	// Query with pagination
-	err := r.db.Where("user_id = ?", userID).
+	err := r.db.WithContext(ctx).Scopes(filter.scope(userID)).
 		Order("created_at DESC").
 		Limit(pageSize).
 		Offset(offset).
@@ -91,7 +143,139 @@ func (r *surveyRepository) FindByUserID(userID uint, page, pageSize int) ([]mode
 	return surveys, total, nil
 }
 
+// FindIDsByUserID returns the IDs of all surveys owned by a user
+func (r *surveyRepository) FindIDsByUserID(ctx context.Context, userID uint) ([]uint, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var ids []uint
+	err := r.db.WithContext(ctx).Model(&model.Survey{}).
+		Where("user_id = ?", userID).
+		Order("id").
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
 // UpdateStatus updates the status of a survey
-func (r *surveyRepository) UpdateStatus(id uint, status string) error {
-	return r.db.Model(&model.Survey{}).Where("id = ?", id).Update("status", status).Error
+func (r *surveyRepository) UpdateStatus(ctx context.Context, id uint, status string) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Model(&model.Survey{}).Where("id = ?", id).Update("status", status).Error
+}
+
+// MarkPublished publishes a survey and starts a new publication, so its owner can be
+// reminded of a lack of responses again
+func (r *surveyRepository) MarkPublished(ctx context.Context, id uint, at time.Time) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Model(&model.Survey{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":       model.SurveyStatusPublished,
+		"published_at": at,
+		"reminded_at":  nil,
+	}).Error
+}
+
+// FindUnanswered finds published surveys without real responses whose no-response reminder
+// is due and has not been sent during the current publication, with their owners preloaded
+func (r *surveyRepository) FindUnanswered(ctx context.Context, now time.Time, limit int) ([]model.Survey, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var surveys []model.Survey
+	err := r.db.WithContext(ctx).Preload("User").
+		Where("status = ? AND no_response_reminder_days > 0", model.SurveyStatusPublished).
+		Where("published_at IS NOT NULL AND reminded_at IS NULL").
+		Where("published_at <= DATE_SUB(?, INTERVAL no_response_reminder_days DAY)", now).
+		Where("NOT EXISTS (SELECT 1 FROM responses WHERE responses.survey_id = surveys.id AND responses.is_test = ?)", false).
+		Order("id").
+		Limit(limit).
+		Find(&surveys).Error
+	return surveys, err
+}
+
+// MarkReminded records that the owners of surveys were reminded
+func (r *surveyRepository) MarkReminded(ctx context.Context, ids []uint, at time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Model(&model.Survey{}).Where("id IN ?", ids).Update("reminded_at", at).Error
+}
+
+// IncrementHoneypotCatches counts a submission caught by the survey's honeypot
+// The update time is left alone so cached survey payloads stay valid
+func (r *surveyRepository) IncrementHoneypotCatches(ctx context.Context, id uint) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Model(&model.Survey{}).Where("id = ?", id).
+		UpdateColumn("honeypot_catches", gorm.Expr("honeypot_catches + 1")).Error
+}
+
+// TransferOwnership makes another user the owner of a survey in one transaction
+// Questions, links and responses belong to the survey and move with it; the report
+// subscriptions and export jobs of the survey are handed over to the new owner too
+func (r *surveyRepository) TransferOwnership(ctx context.Context, id, userID uint) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// The previous owner's team loses access along with the previous owner
+		if err := tx.Model(&model.Survey{}).Where("id = ?", id).Updates(map[string]interface{}{"user_id": userID, "team_id": nil}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&model.ReportSubscription{}).Where("survey_id = ?", id).Update("user_id", userID).Error; err != nil {
+			return err
+		}
+		return tx.Model(&model.ExportJob{}).Where("survey_id = ?", id).Update("user_id", userID).Error
+	})
+}
+
+// SetTeam shares a survey with a team, or stops sharing it when teamID is nil
+func (r *surveyRepository) SetTeam(ctx context.Context, id uint, teamID *uint) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Model(&model.Survey{}).Where("id = ?", id).Update("team_id", teamID).Error
+}
+
+// FindByTeamID finds the surveys shared with a team, newest first
+func (r *surveyRepository) FindByTeamID(ctx context.Context, teamID uint) ([]model.Survey, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var surveys []model.Survey
+	err := r.db.WithContext(ctx).
+		Where("team_id = ?", teamID).
+		Order("created_at DESC").
+		Find(&surveys).Error
+	if err != nil {
+		return nil, err
+	}
+	return surveys, nil
+}
+
+// Clone creates a copy of a survey together with its questions in one transaction
+// The survey and its questions must not have IDs yet
+func (r *surveyRepository) Clone(ctx context.Context, survey *model.Survey) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(survey).Error; err != nil {
+			return err
+		}
+		if len(survey.Questions) == 0 {
+			return nil
+		}
+		for i := range survey.Questions {
+			survey.Questions[i].SurveyID = survey.ID
+		}
+		return tx.Omit(clause.Associations).Create(&survey.Questions).Error
+	})
 }
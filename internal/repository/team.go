@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+
+	"survey-system/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// TeamRepository defines the interface for team data operations
+type TeamRepository interface {
+	Create(ctx context.Context, team *model.Team) error
+	FindByID(ctx context.Context, id uint) (*model.Team, error)
+	FindByIDWithMembers(ctx context.Context, id uint) (*model.Team, error)
+	FindByUserID(ctx context.Context, userID uint) ([]model.Team, error)
+	Rename(ctx context.Context, id uint, name string) error
+	Delete(ctx context.Context, id uint) error
+	AddMember(ctx context.Context, member *model.TeamMember) error
+	RemoveMember(ctx context.Context, teamID, userID uint) (bool, error)
+	IsMember(ctx context.Context, teamID, userID uint) (bool, error)
+}
+
+// teamRepository implements TeamRepository interface
+type teamRepository struct {
+	db       *gorm.DB
+	timeouts Timeouts
+}
+
+// NewTeamRepository creates a new team repository instance
+func NewTeamRepository(db *gorm.DB, timeouts Timeouts) TeamRepository {
+	return &teamRepository{db: db, timeouts: timeouts}
+}
+
+// Create creates a team together with its owner's membership
+func (r *teamRepository) Create(ctx context.Context, team *model.Team) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Members").Create(team).Error; err != nil {
+			return err
+		}
+		owner := model.TeamMember{TeamID: team.ID, UserID: team.OwnerID, Role: model.TeamRoleOwner}
+		if err := tx.Omit("User").Create(&owner).Error; err != nil {
+			return err
+		}
+		team.Members = []model.TeamMember{owner}
+		return nil
+	})
+}
+
+// FindByID finds a team by ID without its members
+func (r *teamRepository) FindByID(ctx context.Context, id uint) (*model.Team, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var team model.Team
+	err := r.db.WithContext(ctx).First(&team, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &team, nil
+}
+
+// FindByIDWithMembers finds a team by ID with its members and their users, oldest member first
+func (r *teamRepository) FindByIDWithMembers(ctx context.Context, id uint) (*model.Team, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var team model.Team
+	err := r.db.WithContext(ctx).Preload("Members", func(db *gorm.DB) *gorm.DB {
+		return db.Order("team_members.id ASC")
+	}).Preload("Members.User").First(&team, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &team, nil
+}
+
+// FindByUserID finds the teams a user is a member of, ordered by name
+func (r *teamRepository) FindByUserID(ctx context.Context, userID uint) ([]model.Team, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var teams []model.Team
+	err := r.db.WithContext(ctx).
+		Joins("JOIN team_members ON team_members.team_id = teams.id").
+		Where("team_members.user_id = ?", userID).
+		Order("teams.name ASC").
+		Find(&teams).Error
+	if err != nil {
+		return nil, err
+	}
+	return teams, nil
+}
+
+// Rename changes the name of a team
+func (r *teamRepository) Rename(ctx context.Context, id uint, name string) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Model(&model.Team{}).Where("id = ?", id).Update("name", name).Error
+}
+
+// Delete deletes a team and its memberships and stops sharing its surveys with it
+func (r *teamRepository) Delete(ctx context.Context, id uint) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.Survey{}).Where("team_id = ?", id).Update("team_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("team_id = ?", id).Delete(&model.TeamMember{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&model.Team{}, id).Error
+	})
+}
+
+// AddMember adds a user to a team
+func (r *teamRepository) AddMember(ctx context.Context, member *model.TeamMember) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Omit("User").Create(member).Error
+}
+
+// RemoveMember removes a user from a team and reports whether they were a member
+func (r *teamRepository) RemoveMember(ctx context.Context, teamID, userID uint) (bool, error) {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	result := r.db.WithContext(ctx).
+		Where("team_id = ? AND user_id = ?", teamID, userID).
+		Delete(&model.TeamMember{})
+	return result.RowsAffected > 0, result.Error
+}
+
+// IsMember reports whether a user is a member of a team
+func (r *teamRepository) IsMember(ctx context.Context, teamID, userID uint) (bool, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var count int64
+	err := r.db.WithContext(ctx).Model(&model.TeamMember{}).
+		Where("team_id = ? AND user_id = ?", teamID, userID).
+		Count(&count).Error
+	return count > 0, err
+}
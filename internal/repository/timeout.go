@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// Timeouts bounds how long a single repository statement may run
+// Zero values leave the caller's context untouched
+type Timeouts struct {
+	Read  time.Duration // queries that only read data
+	Write time.Duration // inserts, updates, deletes and transactions
+}
+
+// read derives the context for a read statement
+func (t Timeouts) read(ctx context.Context) (context.Context, context.CancelFunc) {
+	return withTimeout(ctx, t.Read)
+}
+
+// write derives the context for a write statement
+func (t Timeouts) write(ctx context.Context) (context.Context, context.CancelFunc) {
+	return withTimeout(ctx, t.Write)
+}
+
+// withTimeout applies d to ctx when d is positive
+func withTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	if d <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, d)
+}
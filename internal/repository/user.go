@@ -1,6 +1,9 @@
 package repository
 
 import (
+	"context"
+	"time"
+
 	"survey-system/internal/model"
 
 	"golang.org/x/crypto/bcrypt"
@@ -9,27 +12,35 @@ import (
 
 // UserRepository defines the interface for user data operations
 type UserRepository interface {
-	Create(user *model.User) error
-	FindByID(id uint) (*model.User, error)
-	FindByUsername(username string) (*model.User, error)
-	Update(user *model.User) error
-	UpdatePassword(userID uint, newPassword string) error
+	Create(ctx context.Context, user *model.User) error
+	FindByID(ctx context.Context, id uint) (*model.User, error)
+	FindByUsername(ctx context.Context, username string) (*model.User, error)
+	FindByEmail(ctx context.Context, email string) (*model.User, error)
+	Update(ctx context.Context, user *model.User) error
+	UpdatePassword(ctx context.Context, userID uint, newPassword string) error
+	SetDeactivatedAt(ctx context.Context, userID uint, deactivatedAt *time.Time) error
+	MarkEmailVerified(ctx context.Context, userID uint) error
+	RecordLogin(ctx context.Context, userID uint, at time.Time, ipAddress string) error
 	HashPassword(password string) (string, error)
 	ComparePassword(hashedPassword, password string) error
 }
 
 // userRepository implements UserRepository interface
 type userRepository struct {
-	db *gorm.DB
+	db       *gorm.DB
+	timeouts Timeouts
 }
 
 // NewUserRepository creates a new user repository instance
-func NewUserRepository(db *gorm.DB) UserRepository {
-	return &userRepository{db: db}
+func NewUserRepository(db *gorm.DB, timeouts Timeouts) UserRepository {
+	return &userRepository{db: db, timeouts: timeouts}
 }
 
 // Create creates a new user with hashed password
-func (r *userRepository) Create(user *model.User) error {
+func (r *userRepository) Create(ctx context.Context, user *model.User) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
 	// Hash the password before storing
 	hashedPassword, err := r.HashPassword(user.Password)
 	if err != nil {
@@ -37,13 +48,16 @@ func (r *userRepository) Create(user *model.User) error {
 	}
 	user.Password = hashedPassword
 
-	return r.db.Create(user).Error
+	return r.db.WithContext(ctx).Create(user).Error
 }
 
 // FindByID finds a user by ID
-func (r *userRepository) FindByID(id uint) (*model.User, error) {
+func (r *userRepository) FindByID(ctx context.Context, id uint) (*model.User, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
 	var user model.User
-	err := r.db.First(&user, id).Error
+	err := r.db.WithContext(ctx).First(&user, id).Error
 	if err != nil {
 		return nil, err
 	}
@@ -51,9 +65,25 @@ func (r *userRepository) FindByID(id uint) (*model.User, error) {
 }
 
 // FindByUsername finds a user by username
-func (r *userRepository) FindByUsername(username string) (*model.User, error) {
+func (r *userRepository) FindByUsername(ctx context.Context, username string) (*model.User, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var user model.User
+	err := r.db.WithContext(ctx).Where("username = ?", username).First(&user).Error
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// FindByEmail finds a user by email address
+func (r *userRepository) FindByEmail(ctx context.Context, email string) (*model.User, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
 	var user model.User
-	err := r.db.Where("username = ?", username).First(&user).Error
+	err := r.db.WithContext(ctx).Where("email = ?", email).First(&user).Error
 	if err != nil {
 		return nil, err
 	}
@@ -74,20 +104,61 @@ func (r *userRepository) ComparePassword(hashedPassword, password string) error
 	return bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
 }
 
-// Update updates user information (excluding password)
-func (r *userRepository) Update(user *model.User) error {
-	return r.db.Model(user).Updates(map[string]interface{}{
-		"username": user.Username,
-		"email":    user.Email,
+// Update updates user information (excluding password), including the verification
+// state of the email address
+func (r *userRepository) Update(ctx context.Context, user *model.User) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Model(user).Updates(map[string]interface{}{
+		"username":          user.Username,
+		"email":             user.Email,
+		"email_verified_at": user.EmailVerifiedAt,
+		"email_changed_at":  user.EmailChangedAt,
 	}).Error
 }
 
 // UpdatePassword updates user password with hashing
-func (r *userRepository) UpdatePassword(userID uint, newPassword string) error {
+func (r *userRepository) UpdatePassword(ctx context.Context, userID uint, newPassword string) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
 	hashedPassword, err := r.HashPassword(newPassword)
 	if err != nil {
 		return err
 	}
 
-	return r.db.Model(&model.User{}).Where("id = ?", userID).Update("password", hashedPassword).Error
+	return r.db.WithContext(ctx).Model(&model.User{}).Where("id = ?", userID).Update("password", hashedPassword).Error
+}
+
+// SetDeactivatedAt deactivates a user, or reactivates them when deactivatedAt is nil
+func (r *userRepository) SetDeactivatedAt(ctx context.Context, userID uint, deactivatedAt *time.Time) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Model(&model.User{}).Where("id = ?", userID).Update("deactivated_at", deactivatedAt).Error
+}
+
+// MarkEmailVerified records that a user verified their email address; verifying it again
+// keeps the first verification time
+func (r *userRepository) MarkEmailVerified(ctx context.Context, userID uint) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Model(&model.User{}).
+		Where("id = ? AND email_verified_at IS NULL", userID).
+		Update("email_verified_at", time.Now()).Error
+}
+
+// RecordLogin updates the time and IP address of a user's last successful login
+func (r *userRepository) RecordLogin(ctx context.Context, userID uint, at time.Time, ipAddress string) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Model(&model.User{}).
+		Where("id = ?", userID).
+		Updates(map[string]interface{}{
+			"last_login_at": at,
+			"last_login_ip": ipAddress,
+		}).Error
 }
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"survey-system/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// UserTokenRepository defines the interface for email verification and password reset token data operations
+type UserTokenRepository interface {
+	Create(ctx context.Context, token *model.UserToken) error
+	FindByHash(ctx context.Context, purpose, tokenHash string) (*model.UserToken, error)
+	Use(ctx context.Context, id uint) (bool, error)
+	InvalidateByUser(ctx context.Context, userID uint, purpose string) error
+}
+
+// userTokenRepository implements UserTokenRepository interface
+type userTokenRepository struct {
+	db       *gorm.DB
+	timeouts Timeouts
+}
+
+// NewUserTokenRepository creates a new user token repository instance
+func NewUserTokenRepository(db *gorm.DB, timeouts Timeouts) UserTokenRepository {
+	return &userTokenRepository{db: db, timeouts: timeouts}
+}
+
+// Create creates a new user token
+func (r *userTokenRepository) Create(ctx context.Context, token *model.UserToken) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Create(token).Error
+}
+
+// FindByHash finds a token for a purpose by the hash of its value
+func (r *userTokenRepository) FindByHash(ctx context.Context, purpose, tokenHash string) (*model.UserToken, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var token model.UserToken
+	err := r.db.WithContext(ctx).
+		Where("purpose = ? AND token_hash = ?", purpose, tokenHash).
+		First(&token).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// Use marks an unused token as used and reports whether it was still unused,
+// so concurrent requests cannot use the same token twice
+func (r *userTokenRepository) Use(ctx context.Context, id uint) (bool, error) {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	result := r.db.WithContext(ctx).Model(&model.UserToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// InvalidateByUser marks the unused tokens of a user for a purpose as used, e.g. when a
+// new one is sent so only the latest email's link works
+func (r *userTokenRepository) InvalidateByUser(ctx context.Context, userID uint, purpose string) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Model(&model.UserToken{}).
+		Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID, purpose).
+		Update("used_at", time.Now()).Error
+}
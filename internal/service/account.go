@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	stderrors "errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"survey-system/internal/model"
+	"survey-system/pkg/email"
+	"survey-system/pkg/errors"
+
+	"gorm.io/gorm"
+)
+
+// accountEmailInterval is the minimum interval between verification or reset emails
+// to the same account
+const accountEmailInterval = time.Minute
+
+// AccountSettings configures self-service registration, email verification, password
+// reset and login alerts
+type AccountSettings struct {
+	Registration      bool
+	VerificationTTL   time.Duration
+	VerificationGrace time.Duration // 0 never locks unverified accounts out
+	ResetTTL          time.Duration
+	LoginAlerts       bool   // Email users about logins from a new device or network
+	BaseURL           string // Frontend the emailed links point to
+}
+
+// accountEmailData is the data of the verification and reset email templates
+type accountEmailData struct {
+	Username  string
+	URL       string
+	ExpiresAt string
+}
+
+var (
+	verificationEmail = email.MustTemplate("verify_email",
+		"请验证您的邮箱",
+		"{{.Username}}，您好：\n\n请在 {{.ExpiresAt}} 前通过以下链接验证您的邮箱：\n\n{{.URL}}\n\n如果您没有注册账号，请忽略此邮件。\n")
+	resetPasswordEmail = email.MustTemplate("reset_password",
+		"重置您的密码",
+		"{{.Username}}，您好：\n\n我们收到了重置您账号密码的请求。请在 {{.ExpiresAt}} 前通过以下链接设置新密码：\n\n{{.URL}}\n\n如果这不是您本人的操作，请忽略此邮件，您的密码不会改变。\n")
+)
+
+// checkVerified locks out accounts whose email is still unverified after the grace period,
+// counted from registration or from the last change of the email address
+func (s *authService) checkVerified(user *model.User) error {
+	if user.IsEmailVerified() || s.account.VerificationGrace <= 0 {
+		return nil
+	}
+	since := user.CreatedAt
+	if user.EmailChangedAt != nil && user.EmailChangedAt.After(since) {
+		since = *user.EmailChangedAt
+	}
+	if time.Since(since) > s.account.VerificationGrace {
+		return errors.ErrEmailNotVerified
+	}
+	return nil
+}
+
+// sendAccountEmail emails a user a new verification or password reset link. Links sent
+// before stop working. Emails to the same account are throttled; throttled requests
+// send nothing
+func (s *authService) sendAccountEmail(ctx context.Context, user *model.User, purpose string) error {
+	lockKey := fmt.Sprintf("account:email:%s:%d", purpose, user.ID)
+	acquired, err := s.cache.AcquireLock(ctx, lockKey, accountEmailInterval)
+	if err != nil || !acquired {
+		return err
+	}
+
+	tmpl, path, ttl := verificationEmail, "verify-email", s.account.VerificationTTL
+	if purpose == model.UserTokenResetPassword {
+		tmpl, path, ttl = resetPasswordEmail, "reset-password", s.account.ResetTTL
+	}
+
+	token, err := generateAccountToken()
+	if err != nil {
+		return errors.WrapError(err, "failed to generate token")
+	}
+	if err := s.tokenRepo.InvalidateByUser(ctx, user.ID, purpose); err != nil {
+		return errors.WrapError(err, "failed to invalidate tokens")
+	}
+	userToken := &model.UserToken{
+		UserID:    user.ID,
+		Purpose:   purpose,
+		TokenHash: hashAccountToken(token),
+		ExpiresAt: time.Now().Add(ttl),
+	}
+	if err := s.tokenRepo.Create(ctx, userToken); err != nil {
+		return errors.WrapError(err, "failed to create token")
+	}
+
+	msg, err := tmpl.Render([]string{user.Email}, accountEmailData{
+		Username:  user.Username,
+		URL:       fmt.Sprintf("%s/%s?token=%s", s.account.BaseURL, path, url.QueryEscape(token)),
+		ExpiresAt: userToken.ExpiresAt.Format("2006-01-02 15:04"),
+	})
+	if err != nil {
+		return err
+	}
+	return s.mailer.Send(ctx, msg)
+}
+
+// useAccountToken consumes a verification or reset token and returns its user
+func (s *authService) useAccountToken(ctx context.Context, purpose, token string) (*model.User, error) {
+	userToken, err := s.tokenRepo.FindByHash(ctx, purpose, hashAccountToken(token))
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.ErrInvalidAccountToken
+		}
+		return nil, errors.WrapError(err, "failed to find token")
+	}
+	if !userToken.IsUsable() {
+		return nil, errors.ErrInvalidAccountToken
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userToken.UserID)
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.ErrInvalidAccountToken
+		}
+		return nil, errors.WrapError(err, "failed to find user")
+	}
+	if !user.IsActive() {
+		return nil, errors.ErrAccountDeactivated
+	}
+
+	// Only one of concurrent requests with the same token gets to use it
+	used, err := s.tokenRepo.Use(ctx, userToken.ID)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to use token")
+	}
+	if !used {
+		return nil, errors.ErrInvalidAccountToken
+	}
+	return user, nil
+}
+
+// VerifyEmail verifies the email address of the user a verification link was sent to
+func (s *authService) VerifyEmail(ctx context.Context, token string) error {
+	user, err := s.useAccountToken(ctx, model.UserTokenVerifyEmail, token)
+	if err != nil {
+		return err
+	}
+	if err := s.userRepo.MarkEmailVerified(ctx, user.ID); err != nil {
+		return errors.WrapError(err, "failed to verify email")
+	}
+	return nil
+}
+
+// ResendVerification emails a new verification link to the unverified account with the
+// email address. Whether such an account exists is not revealed
+func (s *authService) ResendVerification(ctx context.Context, address string) error {
+	user, err := s.userRepo.FindByEmail(ctx, address)
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return errors.WrapError(err, "failed to find user")
+	}
+	if user.IsEmailVerified() || !user.IsActive() {
+		return nil
+	}
+
+	if err := s.sendAccountEmail(ctx, user, model.UserTokenVerifyEmail); err != nil {
+		fmt.Printf("failed to send verification email to user %d: %v\n", user.ID, err)
+	}
+	return nil
+}
+
+// ForgotPassword emails a password reset link to the account with the email address
+// Whether such an account exists is not revealed
+func (s *authService) ForgotPassword(ctx context.Context, address string) error {
+	user, err := s.userRepo.FindByEmail(ctx, address)
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return errors.WrapError(err, "failed to find user")
+	}
+	if !user.IsActive() {
+		return nil
+	}
+
+	if err := s.sendAccountEmail(ctx, user, model.UserTokenResetPassword); err != nil {
+		fmt.Printf("failed to send password reset email to user %d: %v\n", user.ID, err)
+	}
+	return nil
+}
+
+// ResetPassword sets a new password for the user a reset link was sent to and logs out
+// all their sessions. Following the link also proves the email address is theirs
+func (s *authService) ResetPassword(ctx context.Context, token, newPassword string) error {
+	user, err := s.useAccountToken(ctx, model.UserTokenResetPassword, token)
+	if err != nil {
+		return err
+	}
+
+	if err := s.userRepo.UpdatePassword(ctx, user.ID, newPassword); err != nil {
+		return errors.WrapError(err, "failed to update password")
+	}
+	if err := s.userRepo.MarkEmailVerified(ctx, user.ID); err != nil {
+		return errors.WrapError(err, "failed to verify email")
+	}
+	if err := s.sessionRepo.RevokeByUser(ctx, user.ID); err != nil {
+		return errors.WrapError(err, "failed to revoke sessions")
+	}
+	return nil
+}
+
+// generateAccountToken returns a random verification or reset token with 256 bits of entropy
+func generateAccountToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// hashAccountToken returns the hex SHA-256 of a verification or reset token as stored in the database
+func hashAccountToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"survey-system/internal/dto/response"
+	"survey-system/internal/model"
+	"survey-system/internal/repository"
+	"survey-system/pkg/errors"
+
+	"gorm.io/gorm"
+)
+
+// EventResponsesReceived is the activity feed type summarizing a day's responses
+const EventResponsesReceived = "responses.received"
+
+const (
+	defaultActivityDays  = 7
+	maxActivityDays      = 90
+	defaultActivityLimit = 50
+	maxActivityLimit     = 200
+
+	// maxActivityEvents bounds the raw events read before merging
+	maxActivityEvents = 1000
+
+	// activityMergeWindow is the largest gap between two events merged into one feed item
+	activityMergeWindow = 10 * time.Minute
+)
+
+// ActivityService defines the interface for the survey activity feed
+type ActivityService interface {
+	GetActivity(ctx context.Context, userID, surveyID uint, days, limit int) ([]response.ActivityItem, error)
+}
+
+// activityService implements ActivityService interface
+type activityService struct {
+	eventRepo    repository.EventRepository
+	responseRepo repository.ResponseRepository
+	surveyRepo   repository.SurveyRepository
+}
+
+// NewActivityService creates a new activity service instance
+func NewActivityService(
+	eventRepo repository.EventRepository,
+	responseRepo repository.ResponseRepository,
+	surveyRepo repository.SurveyRepository,
+) ActivityService {
+	return &activityService{
+		eventRepo:    eventRepo,
+		responseRepo: responseRepo,
+		surveyRepo:   surveyRepo,
+	}
+}
+
+// GetActivity returns the survey's activity of the last days, newest first
+// Responses are summarized per day rather than listed one by one
+func (s *activityService) GetActivity(ctx context.Context, userID, surveyID uint, days, limit int) ([]response.ActivityItem, error) {
+	survey, err := s.surveyRepo.FindByID(ctx, surveyID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.WrapError(err, "failed to find survey")
+	}
+
+	if survey.UserID != userID {
+		return nil, errors.ErrForbidden
+	}
+
+	if days < 1 || days > maxActivityDays {
+		days = defaultActivityDays
+	}
+	if limit < 1 || limit > maxActivityLimit {
+		limit = defaultActivityLimit
+	}
+
+	now := time.Now()
+	since := time.Date(now.Year(), now.Month(), now.Day()-(days-1), 0, 0, 0, 0, now.Location())
+
+	events, err := s.eventRepo.FindBySurveyIDSince(ctx, surveyID, since, maxActivityEvents)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to find survey events")
+	}
+
+	dailyCounts, err := s.responseRepo.CountByDay(ctx, surveyID, since)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to count responses")
+	}
+
+	items := mergeEvents(events)
+	for _, daily := range dailyCounts {
+		items = append(items, response.ActivityItem{
+			Type:       EventResponsesReceived,
+			OccurredAt: daily.Latest,
+			Count:      daily.Count,
+			Date:       daily.Day.Format("2006-01-02"),
+		})
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].OccurredAt.After(items[j].OccurredAt)
+	})
+	if len(items) > limit {
+		items = items[:limit]
+	}
+	return items, nil
+}
+
+// mergeEvents converts events ordered newest first into feed items, merging
+// bursts of link generations per campaign and of edits to the same question
+func mergeEvents(events []model.SurveyEvent) []response.ActivityItem {
+	items := make([]response.ActivityItem, 0, len(events))
+	var oldest time.Time // earliest event merged into the last item
+
+	for _, event := range events {
+		if n := len(items); n > 0 && mergeable(&items[n-1], &event) && oldest.Sub(event.CreatedAt) <= activityMergeWindow {
+			items[n-1].Count++
+			oldest = event.CreatedAt
+			continue
+		}
+
+		items = append(items, response.ActivityItem{
+			Type:       event.Type,
+			OccurredAt: event.CreatedAt,
+			Count:      1,
+			ActorID:    event.UserID,
+			QuestionID: event.QuestionID,
+			Title:      event.Title,
+			Campaign:   event.Campaign,
+			Subnet:     event.Subnet,
+		})
+		oldest = event.CreatedAt
+	}
+	return items
+}
+
+// mergeable reports whether an event continues the burst summarized by a feed item
+func mergeable(item *response.ActivityItem, event *model.SurveyEvent) bool {
+	if item.Type != event.Type || item.ActorID != event.UserID {
+		return false
+	}
+
+	switch event.Type {
+	case model.EventLinkGenerated:
+		return item.Campaign == event.Campaign
+	case model.EventQuestionUpdated:
+		return item.QuestionID != nil && event.QuestionID != nil && *item.QuestionID == *event.QuestionID
+	default:
+		return false
+	}
+}
+
+// recordEvent adds an entry to the survey's event log
+// The change it describes has already been made, so failures are only logged
+func recordEvent(ctx context.Context, eventRepo repository.EventRepository, event *model.SurveyEvent) {
+	if err := eventRepo.Create(ctx, event); err != nil {
+		fmt.Printf("failed to record survey event %s: %v\n", event.Type, err)
+	}
+}
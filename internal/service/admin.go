@@ -0,0 +1,348 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"survey-system/internal/cache"
+	"survey-system/internal/dto/request"
+	"survey-system/internal/dto/response"
+	"survey-system/internal/model"
+	"survey-system/internal/repository"
+	"survey-system/pkg/errors"
+	"survey-system/pkg/utils"
+
+	"gorm.io/gorm"
+)
+
+// AdminService defines the interface for administrative operations across users
+type AdminService interface {
+	TransferSurvey(ctx context.Context, adminID, surveyID uint, req *request.TransferSurveyRequest) (*response.SurveyResponse, error)
+	CloneSurvey(ctx context.Context, adminID, surveyID uint, req *request.CloneSurveyRequest) (*response.SurveyDetailResponse, error)
+	DeactivateUser(ctx context.Context, adminID, userID uint, req *request.DeactivateUserRequest) (*response.DeactivateUserResponse, error)
+	ReactivateUser(ctx context.Context, userID uint) (*response.UserResponse, error)
+	ImpersonateUser(ctx context.Context, adminID, userID uint, req *request.ImpersonateUserRequest, ipAddress string) (*response.ImpersonationResponse, error)
+	RecordImpersonatedRequest(ctx context.Context, entry *model.AuditLog)
+	ListAuditLogs(ctx context.Context, query *request.AuditLogQuery) (*response.PaginatedAuditLogResponse, error)
+}
+
+// adminService implements AdminService interface
+type adminService struct {
+	surveyRepo       repository.SurveyRepository
+	userRepo         repository.UserRepository
+	eventRepo        repository.EventRepository
+	auditRepo        repository.AuditLogRepository
+	cache            cache.Cache
+	jwtUtil          *utils.JWTUtil
+	impersonationTTL time.Duration
+}
+
+// NewAdminService creates a new admin service instance
+func NewAdminService(
+	surveyRepo repository.SurveyRepository,
+	userRepo repository.UserRepository,
+	eventRepo repository.EventRepository,
+	auditRepo repository.AuditLogRepository,
+	cache cache.Cache,
+	jwtUtil *utils.JWTUtil,
+	impersonationTTL time.Duration,
+) AdminService {
+	return &adminService{
+		surveyRepo:       surveyRepo,
+		userRepo:         userRepo,
+		eventRepo:        eventRepo,
+		auditRepo:        auditRepo,
+		cache:            cache,
+		jwtUtil:          jwtUtil,
+		impersonationTTL: impersonationTTL,
+	}
+}
+
+// TransferSurvey makes another user the owner of a survey
+// Its questions, links and responses move with it, so existing links keep working
+func (s *adminService) TransferSurvey(ctx context.Context, adminID, surveyID uint, req *request.TransferSurveyRequest) (*response.SurveyResponse, error) {
+	survey, err := s.findSurvey(ctx, surveyID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.findActiveUser(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+	if survey.UserID == req.UserID {
+		return nil, errors.NewValidationError("user_id", "survey already belongs to this user")
+	}
+
+	if err := s.transfer(ctx, adminID, surveyID, req.UserID); err != nil {
+		return nil, err
+	}
+	survey.UserID = req.UserID
+
+	return response.ToSurveyResponse(survey), nil
+}
+
+// CloneSurvey copies a survey and its questions into another user's account as a draft
+// Links, responses and other survey data are not copied
+func (s *adminService) CloneSurvey(ctx context.Context, adminID, surveyID uint, req *request.CloneSurveyRequest) (*response.SurveyDetailResponse, error) {
+	original, err := s.findSurvey(ctx, surveyID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.findActiveUser(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+
+	clone := *original
+	clone.ID = 0
+	clone.UserID = req.UserID
+	clone.Status = model.SurveyStatusDraft
+	clone.CreatedAt, clone.UpdatedAt = time.Time{}, time.Time{}
+	clone.PublishedAt, clone.RemindedAt = nil, nil
+	clone.HoneypotCatches = 0
+	clone.TeamID = nil
+	clone.User = model.User{}
+	clone.OneLinks = nil
+	clone.Responses = nil
+	if req.Title != "" {
+		clone.Title = req.Title
+	}
+
+	clone.Questions = make([]model.Question, len(original.Questions))
+	for i, q := range original.Questions {
+		q.ID = 0
+		q.SurveyID = 0
+		q.CreatedAt, q.UpdatedAt = time.Time{}, time.Time{}
+		q.Survey = model.Survey{}
+		clone.Questions[i] = q
+	}
+
+	if err := s.surveyRepo.Clone(ctx, &clone); err != nil {
+		return nil, errors.WrapError(err, "failed to clone survey")
+	}
+
+	return response.ToSurveyDetailResponse(&clone), nil
+}
+
+// findSurvey loads a survey regardless of its owner
+func (s *adminService) findSurvey(ctx context.Context, surveyID uint, withQuestions bool) (*model.Survey, error) {
+	var survey *model.Survey
+	var err error
+	if withQuestions {
+		survey, err = s.surveyRepo.FindByIDWithQuestions(ctx, surveyID)
+	} else {
+		survey, err = s.surveyRepo.FindByID(ctx, surveyID)
+	}
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.WrapError(err, "failed to find survey")
+	}
+	return survey, nil
+}
+
+// DeactivateUser blocks a user from logging in and from using tokens already issued to them
+// When req.TransferTo is set, all of the user's surveys are first reassigned to that user,
+// each transfer recorded in the survey's activity feed
+func (s *adminService) DeactivateUser(ctx context.Context, adminID, userID uint, req *request.DeactivateUserRequest) (*response.DeactivateUserResponse, error) {
+	if userID == adminID {
+		return nil, errors.NewValidationError("id", "cannot deactivate your own account")
+	}
+
+	user, err := s.findUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	transferred := []uint{}
+	if req.TransferTo != 0 {
+		if req.TransferTo == userID {
+			return nil, errors.NewValidationError("transfer_to", "cannot transfer surveys to the deactivated user")
+		}
+		if _, err := s.findActiveUser(ctx, req.TransferTo); err != nil {
+			return nil, err
+		}
+
+		surveyIDs, err := s.surveyRepo.FindIDsByUserID(ctx, userID)
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to find surveys")
+		}
+		// Transferred surveys stay with the new owner if a later one fails,
+		// so retrying only moves what is left
+		for _, surveyID := range surveyIDs {
+			if err := s.transfer(ctx, adminID, surveyID, req.TransferTo); err != nil {
+				return nil, err
+			}
+			transferred = append(transferred, surveyID)
+		}
+	}
+
+	// Deactivating an already deactivated user keeps the original timestamp
+	if user.IsActive() {
+		now := time.Now()
+		if err := s.userRepo.SetDeactivatedAt(ctx, userID, &now); err != nil {
+			return nil, errors.WrapError(err, "failed to deactivate user")
+		}
+		user.DeactivatedAt = &now
+	}
+
+	return &response.DeactivateUserResponse{
+		User:               response.ToUserResponse(user),
+		TransferredSurveys: transferred,
+	}, nil
+}
+
+// ReactivateUser lets a deactivated user log in again
+// Surveys reassigned on deactivation stay with their new owner
+func (s *adminService) ReactivateUser(ctx context.Context, userID uint) (*response.UserResponse, error) {
+	user, err := s.findUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsActive() {
+		if err := s.userRepo.SetDeactivatedAt(ctx, userID, nil); err != nil {
+			return nil, errors.WrapError(err, "failed to reactivate user")
+		}
+		user.DeactivatedAt = nil
+	}
+
+	resp := response.ToUserResponse(user)
+	return &resp, nil
+}
+
+// ImpersonateUser issues a short-lived token with which the admin acts as another user,
+// e.g. to reproduce an issue the user reported. The session is recorded in the audit
+// log before the token is issued, and the token refers to that entry, so the changes
+// made with it can be traced back to the admin.
+func (s *adminService) ImpersonateUser(ctx context.Context, adminID, userID uint, req *request.ImpersonateUserRequest, ipAddress string) (*response.ImpersonationResponse, error) {
+	if userID == adminID {
+		return nil, errors.NewValidationError("id", "cannot impersonate your own account")
+	}
+
+	user, err := s.findActiveUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(s.impersonationTTL)
+	entry := &model.AuditLog{
+		ActorID:      adminID,
+		Action:       model.AuditUserImpersonated,
+		TargetUserID: &user.ID,
+		Reason:       req.Reason,
+		IPAddress:    ipAddress,
+		ExpiresAt:    &expiresAt,
+	}
+	if err := s.auditRepo.Create(ctx, entry); err != nil {
+		return nil, errors.WrapError(err, "failed to record impersonation")
+	}
+
+	token, err := s.jwtUtil.GenerateImpersonationToken(user.ID, user.Role, adminID, entry.ID, expiresAt)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to generate impersonation token")
+	}
+
+	return &response.ImpersonationResponse{
+		Token:           token,
+		ExpiresAt:       expiresAt,
+		User:            response.ToUserResponse(user),
+		ImpersonationID: entry.ID,
+	}, nil
+}
+
+// RecordImpersonatedRequest adds a request made with an impersonation token to the audit log
+// The request has already been handled, so failures are only logged
+func (s *adminService) RecordImpersonatedRequest(ctx context.Context, entry *model.AuditLog) {
+	if err := s.auditRepo.Create(ctx, entry); err != nil {
+		fmt.Printf("failed to record impersonated request %s %s: %v\n", entry.Method, entry.Path, err)
+	}
+}
+
+// ListAuditLogs lists audit log entries newest first
+func (s *adminService) ListAuditLogs(ctx context.Context, query *request.AuditLogQuery) (*response.PaginatedAuditLogResponse, error) {
+	page, pageSize := query.Page, query.PageSize
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 20
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	filter := repository.AuditLogFilter{
+		ActorID:         query.ActorID,
+		TargetUserID:    query.TargetUserID,
+		ImpersonationID: query.ImpersonationID,
+	}
+	entries, total, err := s.auditRepo.FindAll(ctx, filter, page, pageSize)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to list audit log")
+	}
+
+	data := make([]response.AuditLogResponse, len(entries))
+	for i := range entries {
+		data[i] = response.ToAuditLogResponse(&entries[i])
+	}
+
+	totalPage := int(total) / pageSize
+	if int(total)%pageSize > 0 {
+		totalPage++
+	}
+
+	return &response.PaginatedAuditLogResponse{
+		Data: data,
+		Meta: response.PaginationMeta{
+			Page:      page,
+			PageSize:  pageSize,
+			Total:     total,
+			TotalPage: totalPage,
+		},
+	}, nil
+}
+
+// transfer hands a survey over to another user and records it in the activity feed
+func (s *adminService) transfer(ctx context.Context, adminID, surveyID, userID uint) error {
+	if err := s.surveyRepo.TransferOwnership(ctx, surveyID, userID); err != nil {
+		return errors.WrapError(err, "failed to transfer survey")
+	}
+
+	recordEvent(ctx, s.eventRepo, &model.SurveyEvent{
+		SurveyID: surveyID,
+		UserID:   adminID,
+		Type:     model.EventSurveyTransferred,
+	})
+
+	if err := s.cache.InvalidateSurvey(ctx, surveyID); err != nil {
+		fmt.Printf("failed to invalidate survey cache: %v\n", err)
+	}
+	return nil
+}
+
+// findUser loads a user by ID
+func (s *adminService) findUser(ctx context.Context, userID uint) (*model.User, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrUserNotFound
+		}
+		return nil, errors.WrapError(err, "failed to find user")
+	}
+	return user, nil
+}
+
+// findActiveUser loads the user receiving a survey, who must not be deactivated
+func (s *adminService) findActiveUser(ctx context.Context, userID uint) (*model.User, error) {
+	user, err := s.findUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsActive() {
+		return nil, errors.ErrAccountDeactivated
+	}
+	return user, nil
+}
@@ -0,0 +1,334 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"survey-system/internal/dto/response"
+	"survey-system/internal/model"
+	"survey-system/internal/repository"
+	"survey-system/pkg/errors"
+)
+
+// scoreBands is the number of percentage bands of the score distribution
+const scoreBands = 10
+
+// validateCorrectAnswers checks the correct answer and points of a question: both or
+// neither are set, only text and choice questions are scored, and choice questions
+// reference their own options
+func validateCorrectAnswers(questionType string, config *model.QuestionConfig) error {
+	if len(config.Correct) == 0 {
+		if config.Points != 0 {
+			return errors.NewLocalizedValidationError("config.correct", "question.correct_required")
+		}
+		return nil
+	}
+	if config.Points <= 0 {
+		return errors.NewLocalizedValidationError("config.points", "question.points_required")
+	}
+
+	switch questionType {
+	case model.QuestionTypeText:
+		return nil
+	case model.QuestionTypeSingle, model.QuestionTypeMultiple:
+		for i, id := range config.Correct {
+			if _, ok := config.FindOption(id); !ok {
+				return errors.NewLocalizedValidationError(fmt.Sprintf("config.correct[%d]", i), "question.correct_option_unknown", id)
+			}
+		}
+		return nil
+	}
+	return errors.NewLocalizedValidationError("config.correct", "question.correct_not_supported", questionType)
+}
+
+// answerCorrect reports whether an answer value is a correct answer to a question
+func answerCorrect(question *model.Question, value interface{}) bool {
+	correct := question.Config.Correct
+	switch question.Type {
+	case model.QuestionTypeText:
+		text, ok := value.(string)
+		return ok && slices.ContainsFunc(correct, func(c string) bool {
+			return strings.EqualFold(strings.TrimSpace(c), strings.TrimSpace(text))
+		})
+	case model.QuestionTypeSingle:
+		id, ok := value.(string)
+		return ok && slices.Contains(correct, id)
+	case model.QuestionTypeMultiple:
+		items, ok := value.([]interface{})
+		if !ok || len(items) != len(correct) {
+			return false
+		}
+		for _, item := range items {
+			if id, ok := item.(string); !ok || !slices.Contains(correct, id) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// scoreAnswers scores the answers of a response to an assessment. The points available
+// are those of the scored questions shown to the respondent; unanswered questions earn
+// nothing. Both results are nil when the survey is not an assessment
+func scoreAnswers(survey *model.Survey, shown []model.Question, withheld map[uint]bool, answers []model.Answer) (*float64, *float64) {
+	if !survey.Assessment {
+		return nil, nil
+	}
+
+	values := make(map[uint]interface{}, len(answers))
+	for _, answer := range answers {
+		values[answer.QuestionID] = answer.Value
+	}
+
+	score, maxScore := 0.0, 0.0
+	for i := range shown {
+		question := &shown[i]
+		if len(question.Config.Correct) == 0 || withheld[question.ID] {
+			continue
+		}
+		maxScore += question.Config.Points
+		if value, ok := values[question.ID]; ok && answerCorrect(question, value) {
+			score += question.Config.Points
+		}
+	}
+	return &score, &maxScore
+}
+
+// scoreStatistics describes the scores of an assessment's responses, computed from the
+// database like the campaign statistics
+func (s *ResponseService) scoreStatistics(ctx context.Context, survey *model.Survey, includeTest bool) (*response.ScoreStatistics, error) {
+	if !survey.Assessment {
+		return nil, nil
+	}
+
+	scores, err := s.responseRepo.FindScores(ctx, survey.ID, repository.ResponseFilter{IncludeTest: includeTest})
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to load scores")
+	}
+
+	stats := &response.ScoreStatistics{
+		Distribution: make([]response.ScoreBand, scoreBands),
+	}
+	for i := range stats.Distribution {
+		stats.Distribution[i] = response.ScoreBand{From: i * 100 / scoreBands, To: (i + 1) * 100 / scoreBands}
+	}
+
+	points := make([]float64, 0, len(scores))
+	percents := make([]float64, 0, len(scores))
+	for _, score := range scores {
+		points = append(points, score.Score)
+		if score.MaxScore <= 0 {
+			continue
+		}
+		percent := score.Score / score.MaxScore * 100
+		percents = append(percents, math.Round(percent*100)/100)
+		band := min(int(percent)*scoreBands/100, scoreBands-1)
+		stats.Distribution[band].Count++
+	}
+	stats.Points = calculateNumericStatistics(points, "")
+	stats.Percent = calculateNumericStatistics(percents, "%")
+	return stats, nil
+}
+
+// scorePercent returns a score as a percentage of the maximum score
+func scorePercent(score, maxScore float64) float64 {
+	return math.Round(score/maxScore*10000) / 100
+}
+
+// responsePassed grades a scored response against the survey's pass threshold. Responses
+// are not graded when the survey sets no threshold or they were worth no points
+func responsePassed(survey *model.Survey, resp *model.Response) (passed, graded bool) {
+	if survey.PassPercent <= 0 || resp.Score == nil || resp.MaxScore == nil || *resp.MaxScore <= 0 {
+		return false, false
+	}
+	return scorePercent(*resp.Score, *resp.MaxScore) >= survey.PassPercent, true
+}
+
+// scoredQuestions returns the questions with a correct answer, in survey order
+func scoredQuestions(questions []model.Question) []model.Question {
+	var scored []model.Question
+	for _, question := range questions {
+		if len(question.Config.Correct) > 0 {
+			scored = append(scored, question)
+		}
+	}
+	return scored
+}
+
+// GetAssessmentStatistics computes the pass rate of an assessment and the difficulty of
+// each scored question, the percent of the responses shown a question answering it
+// correctly. Only responses saved with a score are included, test responses only when
+// includeTest is set
+func (s *ResponseService) GetAssessmentStatistics(ctx context.Context, userID, surveyID uint, includeTest bool) (*response.AssessmentStatisticsResponse, error) {
+	// Verify the user owns the survey or shares it through its team
+	survey, err := findTeamSurvey(ctx, s.authz, userID, surveyID)
+	if err != nil {
+		return nil, err
+	}
+	if !survey.Assessment {
+		return nil, errors.NewLocalizedError("VALIDATION_FAILED", 400, "statistics.not_assessment")
+	}
+
+	questions, err := s.questionRepo.FindBySurveyID(ctx, surveyID)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to find questions")
+	}
+
+	grader := newAssessmentGrader(survey, scoredQuestions(questions))
+	if err := s.eachResponse(ctx, surveyID, repository.ResponseFilter{IncludeTest: includeTest}, grader.add); err != nil {
+		return nil, err
+	}
+
+	result := grader.result()
+	result.SurveyID = surveyID
+	return result, nil
+}
+
+// assessmentGrader grades the scored responses among those added to it
+type assessmentGrader struct {
+	survey       *model.Survey
+	scored       []model.Question
+	stats        *response.AssessmentStatisticsResponse
+	passed       int
+	graded       int
+	percentSum   float64
+	percentCount int
+}
+
+// newAssessmentGrader creates a grader for the scored questions of an assessment
+func newAssessmentGrader(survey *model.Survey, scored []model.Question) *assessmentGrader {
+	result := &response.AssessmentStatisticsResponse{
+		PassPercent: survey.PassPercent,
+		Items:       make([]response.ItemDifficulty, len(scored)),
+	}
+	for i, question := range scored {
+		result.Items[i] = response.ItemDifficulty{
+			QuestionID: question.ID,
+			Title:      question.Title,
+			Points:     question.Config.Points,
+		}
+	}
+	return &assessmentGrader{survey: survey, scored: scored, stats: result}
+}
+
+// add grades a response; unscored responses are skipped
+func (g *assessmentGrader) add(resp *model.Response) {
+	if resp.Score == nil || resp.MaxScore == nil {
+		return
+	}
+	g.stats.Responses++
+	if *resp.MaxScore > 0 {
+		g.percentSum += *resp.Score / *resp.MaxScore * 100
+		g.percentCount++
+	}
+	if ok, isGraded := responsePassed(g.survey, resp); isGraded {
+		g.graded++
+		if ok {
+			g.passed++
+		}
+	}
+
+	values := make(map[uint]interface{}, len(resp.Data.Answers))
+	for _, answer := range resp.Data.Answers {
+		values[answer.QuestionID] = answer.Value
+	}
+	for j := range g.scored {
+		question := &g.scored[j]
+		if slices.Contains(resp.Data.Withheld, question.ID) {
+			continue
+		}
+		item := &g.stats.Items[j]
+		item.Exposed++
+		value, ok := values[question.ID]
+		if !ok {
+			continue
+		}
+		item.Answered++
+		if answerCorrect(question, value) {
+			item.Correct++
+		}
+	}
+}
+
+// result returns the statistics of the responses graded so far
+func (g *assessmentGrader) result() *response.AssessmentStatisticsResponse {
+	percent := func(part, whole int) float64 {
+		if whole == 0 {
+			return 0
+		}
+		return math.Round(float64(part)/float64(whole)*10000) / 100
+	}
+
+	result := g.stats
+	for i := range result.Items {
+		result.Items[i].Difficulty = percent(result.Items[i].Correct, result.Items[i].Exposed)
+	}
+	if g.percentCount > 0 {
+		result.AveragePercent = math.Round(g.percentSum/float64(g.percentCount)*100) / 100
+	}
+	if g.survey.PassPercent > 0 {
+		passed := g.passed
+		rate := percent(passed, g.graded)
+		result.Passed = &passed
+		result.PassRate = &rate
+	}
+	return result
+}
+
+// gradingHeaders returns the headers of the grading columns of an assessment export: the
+// correctness of each scored question, the score and, with a pass threshold, the result
+func gradingHeaders(survey *model.Survey, questions []model.Question) []string {
+	if !survey.Assessment {
+		return nil
+	}
+	var headers []string
+	for _, question := range scoredQuestions(questions) {
+		headers = append(headers, fmt.Sprintf("%s - Correct", question.Title))
+	}
+	headers = append(headers, "Score", "Max Score")
+	if survey.PassPercent > 0 {
+		headers = append(headers, "Result")
+	}
+	return headers
+}
+
+// gradingCells returns the grading columns of a response. Correctness is 1 or 0 and
+// empty for questions the respondent was not shown; unscored responses stay empty
+func gradingCells(survey *model.Survey, questions []model.Question, resp *model.Response) []string {
+	cells := make([]string, 0, len(questions)+3)
+	values := make(map[uint]interface{}, len(resp.Data.Answers))
+	for _, answer := range resp.Data.Answers {
+		values[answer.QuestionID] = answer.Value
+	}
+	for _, question := range scoredQuestions(questions) {
+		switch {
+		case resp.Score == nil || slices.Contains(resp.Data.Withheld, question.ID):
+			cells = append(cells, "")
+		case answerCorrect(&question, values[question.ID]):
+			cells = append(cells, "1")
+		default:
+			cells = append(cells, "0")
+		}
+	}
+
+	if resp.Score == nil || resp.MaxScore == nil {
+		cells = append(cells, "", "")
+	} else {
+		cells = append(cells, strconv.FormatFloat(*resp.Score, 'f', -1, 64), strconv.FormatFloat(*resp.MaxScore, 'f', -1, 64))
+	}
+	if survey.PassPercent > 0 {
+		result := ""
+		if passed, graded := responsePassed(survey, resp); graded && passed {
+			result = "pass"
+		} else if graded {
+			result = "fail"
+		}
+		cells = append(cells, result)
+	}
+	return cells
+}
@@ -1,20 +1,38 @@
 package service
 
 import (
-	"errors"
+	"context"
+	stderrors "errors"
+	"fmt"
+	"survey-system/internal/dto/request"
+	"survey-system/internal/dto/response"
 	"survey-system/internal/model"
 	"survey-system/internal/repository"
+	"survey-system/pkg/email"
+	"survey-system/pkg/errors"
 	"survey-system/pkg/utils"
+	"time"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
 // AuthService defines the interface for authentication operations
 type AuthService interface {
-	Login(username, password string) (*LoginResponse, error)
-	Register(username, password, email string) error
+	Login(ctx context.Context, username, password, userAgent, ipAddress string) (*LoginResponse, error)
+	Register(ctx context.Context, username, password, email string) error
+	VerifyEmail(ctx context.Context, token string) error
+	ResendVerification(ctx context.Context, email string) error
+	ForgotPassword(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token, newPassword string) error
 	ValidateToken(token string) (*utils.JWTClaims, error)
-	UpdateProfile(userID uint, username, email, oldPassword, newPassword string) (*model.User, error)
+	JWKS() utils.JWKSet
+	CheckActive(ctx context.Context, userID uint) error
+	CheckSession(ctx context.Context, userID uint, tokenID string) error
+	ListSessions(ctx context.Context, userID uint, currentTokenID string) ([]response.SessionResponse, error)
+	RevokeSession(ctx context.Context, userID, sessionID uint) error
+	ListLoginHistory(ctx context.Context, userID uint, query *request.LoginHistoryQuery) (*response.PaginatedLoginHistoryResponse, error)
+	UpdateProfile(ctx context.Context, userID uint, tokenID, username, email, oldPassword, newPassword string) (*model.User, error)
 }
 
 // LoginResponse represents the response after successful login
@@ -25,39 +43,85 @@ type LoginResponse struct {
 
 // authService implements AuthService interface
 type authService struct {
-	userRepo repository.UserRepository
-	jwtUtil  *utils.JWTUtil
+	userRepo    repository.UserRepository
+	sessionRepo repository.SessionRepository
+	tokenRepo   repository.UserTokenRepository
+	loginRepo   repository.LoginRecordRepository
+	jwtUtil     *utils.JWTUtil
+	cache       Cache
+	mailer      email.Sender
+	account     AccountSettings
 }
 
 // NewAuthService creates a new auth service instance
-func NewAuthService(userRepo repository.UserRepository, jwtUtil *utils.JWTUtil) AuthService {
+func NewAuthService(
+	userRepo repository.UserRepository,
+	sessionRepo repository.SessionRepository,
+	tokenRepo repository.UserTokenRepository,
+	loginRepo repository.LoginRecordRepository,
+	jwtUtil *utils.JWTUtil,
+	cache Cache,
+	mailer email.Sender,
+	account AccountSettings,
+) AuthService {
 	return &authService{
-		userRepo: userRepo,
-		jwtUtil:  jwtUtil,
+		userRepo:    userRepo,
+		sessionRepo: sessionRepo,
+		tokenRepo:   tokenRepo,
+		loginRepo:   loginRepo,
+		jwtUtil:     jwtUtil,
+		cache:       cache,
+		mailer:      mailer,
+		account:     account,
 	}
 }
 
 // Login authenticates a user and returns a JWT token
-func (s *authService) Login(username, password string) (*LoginResponse, error) {
+// The token's session is recorded with the client's user agent and IP address, and
+// attempts to log in to existing accounts are added to their login history
+func (s *authService) Login(ctx context.Context, username, password, userAgent, ipAddress string) (*LoginResponse, error) {
 	// Find user by username
-	user, err := s.userRepo.FindByUsername(username)
+	user, err := s.userRepo.FindByUsername(ctx, username)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("invalid username or password")
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.ErrInvalidCredentials
 		}
-		return nil, err
+		return nil, errors.WrapError(err, "failed to find user")
 	}
 
 	// Verify password
 	if err := s.userRepo.ComparePassword(user.Password, password); err != nil {
-		return nil, errors.New("invalid username or password")
+		s.recordLogin(ctx, user, userAgent, ipAddress, model.LoginFailedPassword)
+		return nil, errors.ErrInvalidCredentials
+	}
+
+	// Deactivated accounts cannot log in
+	if !user.IsActive() {
+		s.recordLogin(ctx, user, userAgent, ipAddress, model.LoginFailedDeactivated)
+		return nil, errors.ErrAccountDeactivated
+	}
+	if err := s.checkVerified(user); err != nil {
+		s.recordLogin(ctx, user, userAgent, ipAddress, model.LoginFailedUnverified)
+		return nil, err
 	}
 
 	// Generate JWT token
-	token, err := s.jwtUtil.GenerateToken(user.ID, user.Role)
+	tokenID := uuid.New().String()
+	token, expiresAt, err := s.jwtUtil.GenerateToken(user.ID, user.Role, tokenID)
 	if err != nil {
+		return nil, errors.WrapError(err, "failed to generate token")
+	}
+
+	if err := s.recordSession(ctx, &model.UserSession{
+		UserID:    user.ID,
+		TokenID:   tokenID,
+		UserAgent: userAgent,
+		IPAddress: ipAddress,
+		ExpiresAt: expiresAt,
+	}); err != nil {
 		return nil, err
 	}
+	s.recordLogin(ctx, user, userAgent, ipAddress, "")
 
 	return &LoginResponse{
 		Token: token,
@@ -65,15 +129,25 @@ func (s *authService) Login(username, password string) (*LoginResponse, error) {
 	}, nil
 }
 
-// Register creates a new user account
-func (s *authService) Register(username, password, email string) error {
+// Register creates a new user account and emails it a verification link
+// A failed verification email does not fail the registration; it can be sent again
+func (s *authService) Register(ctx context.Context, username, password, email string) error {
+	if !s.account.Registration {
+		return errors.ErrRegistrationDisabled
+	}
+
 	// Check if username already exists
-	existingUser, err := s.userRepo.FindByUsername(username)
-	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-		return err
+	existingUser, err := s.userRepo.FindByUsername(ctx, username)
+	if err != nil && !stderrors.Is(err, gorm.ErrRecordNotFound) {
+		return errors.WrapError(err, "failed to find user")
 	}
 	if existingUser != nil {
-		return errors.New("username already exists")
+		return errors.ErrUsernameExists
+	}
+	if _, err := s.userRepo.FindByEmail(ctx, email); err == nil {
+		return errors.ErrEmailExists
+	} else if !stderrors.Is(err, gorm.ErrRecordNotFound) {
+		return errors.WrapError(err, "failed to find user")
 	}
 
 	// Create new user
@@ -81,10 +155,17 @@ func (s *authService) Register(username, password, email string) error {
 		Username: username,
 		Password: password, // Will be hashed by repository
 		Email:    email,
-		Role:     "admin", // Default role
+		Role:     model.UserRoleUser,
+	}
+
+	if err := s.userRepo.Create(ctx, user); err != nil {
+		return errors.WrapError(err, "failed to create user")
 	}
 
-	return s.userRepo.Create(user)
+	if err := s.sendAccountEmail(ctx, user, model.UserTokenVerifyEmail); err != nil {
+		fmt.Printf("failed to send verification email to user %d: %v\n", user.ID, err)
+	}
+	return nil
 }
 
 // ValidateToken validates a JWT token and returns the claims
@@ -92,51 +173,114 @@ func (s *authService) ValidateToken(token string) (*utils.JWTClaims, error) {
 	return s.jwtUtil.ValidateToken(token)
 }
 
+// JWKS returns the public keys other services verify tokens with
+func (s *authService) JWKS() utils.JWKSet {
+	return s.jwtUtil.JWKS()
+}
+
+// CheckActive verifies that the user a token was issued to still exists and has not been deactivated
+func (s *authService) CheckActive(ctx context.Context, userID uint) error {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.ErrInvalidAuthToken
+		}
+		return errors.WrapError(err, "failed to find user")
+	}
+	if !user.IsActive() {
+		return errors.ErrAccountDeactivated
+	}
+	return s.checkVerified(user)
+}
+
 // UpdateProfile updates user profile (username, email, and/or password)
-func (s *authService) UpdateProfile(userID uint, username, email, oldPassword, newPassword string) (*model.User, error) {
+// A password change signs out every other session; tokenID is the caller's own token
+func (s *authService) UpdateProfile(ctx context.Context, userID uint, tokenID, username, email, oldPassword, newPassword string) (*model.User, error) {
 	// Get current user
-	user, err := s.userRepo.FindByID(userID)
+	user, err := s.userRepo.FindByID(ctx, userID)
 	if err != nil {
-		return nil, errors.New("user not found")
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.ErrUserNotFound
+		}
+		return nil, errors.WrapError(err, "failed to find user")
 	}
 
 	// Check if username is being changed and if it's already taken
 	if username != "" && username != user.Username {
-		existingUser, err := s.userRepo.FindByUsername(username)
-		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, err
+		existingUser, err := s.userRepo.FindByUsername(ctx, username)
+		if err != nil && !stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.WrapError(err, "failed to find user")
 		}
 		if existingUser != nil {
-			return nil, errors.New("username already exists")
+			return nil, errors.ErrUsernameExists
 		}
 		user.Username = username
 	}
 
-	// Update email if provided
-	if email != "" {
+	// A new email address has to be verified again. Links sent to the old address stop
+	// working, as following one would verify the new address
+	emailChanged := email != "" && email != user.Email
+	if emailChanged {
+		if existing, err := s.userRepo.FindByEmail(ctx, email); err == nil {
+			if existing.ID != userID {
+				return nil, errors.ErrEmailExists
+			}
+		} else if !stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.WrapError(err, "failed to find user")
+		}
+		for _, purpose := range []string{model.UserTokenVerifyEmail, model.UserTokenResetPassword} {
+			if err := s.tokenRepo.InvalidateByUser(ctx, userID, purpose); err != nil {
+				return nil, errors.WrapError(err, "failed to invalidate tokens")
+			}
+		}
+		now := time.Now()
 		user.Email = email
+		user.EmailVerifiedAt = nil
+		user.EmailChangedAt = &now
 	}
 
 	// Update password if both old and new passwords are provided
 	if oldPassword != "" && newPassword != "" {
 		// Verify old password
 		if err := s.userRepo.ComparePassword(user.Password, oldPassword); err != nil {
-			return nil, errors.New("old password is incorrect")
+			return nil, errors.ErrInvalidPassword
 		}
 
 		// Update to new password
-		if err := s.userRepo.UpdatePassword(userID, newPassword); err != nil {
-			return nil, err
+		if err := s.userRepo.UpdatePassword(ctx, userID, newPassword); err != nil {
+			return nil, errors.WrapError(err, "failed to update password")
+		}
+		if err := s.sessionRepo.RevokeOthersByUser(ctx, userID, tokenID); err != nil {
+			return nil, errors.WrapError(err, "failed to revoke sessions")
 		}
 	}
 
 	// Update user profile (username and email)
 	if username != "" || email != "" {
-		if err := s.userRepo.Update(user); err != nil {
-			return nil, err
+		if err := s.userRepo.Update(ctx, user); err != nil {
+			// Lost a race with another account taking the same username or email
+			if stderrors.Is(err, gorm.ErrDuplicatedKey) {
+				if emailChanged {
+					if existing, err := s.userRepo.FindByEmail(ctx, email); err == nil && existing.ID != userID {
+						return nil, errors.ErrEmailExists
+					}
+				}
+				return nil, errors.ErrUsernameExists
+			}
+			return nil, errors.WrapError(err, "failed to update user")
+		}
+	}
+
+	if emailChanged {
+		if err := s.sendAccountEmail(ctx, user, model.UserTokenVerifyEmail); err != nil {
+			fmt.Printf("failed to send verification email to user %d: %v\n", user.ID, err)
 		}
 	}
 
 	// Return updated user
-	return s.userRepo.FindByID(userID)
+	updated, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to find user")
+	}
+	return updated, nil
 }
@@ -13,10 +13,14 @@ type Cache interface {
 	GetSurvey(ctx context.Context, surveyID uint) (*model.Survey, error)
 	SetSurvey(ctx context.Context, survey *model.Survey, expiration time.Duration) error
 	DeleteSurvey(ctx context.Context, surveyID uint) error
+	InvalidateSurvey(ctx context.Context, surveyID uint) error
 
 	// OneLink status cache operations
 	GetOneLinkStatus(ctx context.Context, token string) (bool, error)
 	SetOneLinkStatus(ctx context.Context, token string, used bool, expiration time.Duration) error
+	IsTokenInvalid(ctx context.Context, token string) (bool, error)
+	MarkTokenInvalid(ctx context.Context, token string, expiration time.Duration) error
+	DeleteOneLinks(ctx context.Context, tokens []string) error
 
 	// Distributed lock operations
 	AcquireLock(ctx context.Context, key string, expiration time.Duration) (bool, error)
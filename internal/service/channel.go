@@ -0,0 +1,274 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"survey-system/internal/dto/request"
+	"survey-system/internal/dto/response"
+	"survey-system/internal/model"
+	"survey-system/internal/repository"
+	"survey-system/pkg/chat"
+	"survey-system/pkg/errors"
+
+	"gorm.io/gorm"
+)
+
+// channelNotifyTimeout bounds the notifications sent after a single submission
+const channelNotifyTimeout = 30 * time.Second
+
+// ChannelService defines the interface for survey notification channels
+type ChannelService interface {
+	ListChannels(ctx context.Context, userID, surveyID uint) ([]response.ChannelResponse, error)
+	CreateChannel(ctx context.Context, userID, surveyID uint, req *request.ChannelRequest) (*response.ChannelResponse, error)
+	UpdateChannel(ctx context.Context, userID, surveyID, channelID uint, req *request.ChannelRequest) (*response.ChannelResponse, error)
+	DeleteChannel(ctx context.Context, userID, surveyID, channelID uint) error
+	SendTestMessage(ctx context.Context, userID, surveyID, channelID uint) error
+	ResponseNotifier
+}
+
+// ResponseNotifier is told about every saved response
+type ResponseNotifier interface {
+	NotifyResponse(ctx context.Context, survey *model.Survey, resp *model.Response)
+}
+
+// ResponseNotifiers tells each of several notifiers about a response, one after another
+type ResponseNotifiers []ResponseNotifier
+
+// NotifyResponse calls NotifyResponse of every notifier
+func (n ResponseNotifiers) NotifyResponse(ctx context.Context, survey *model.Survey, resp *model.Response) {
+	for _, notifier := range n {
+		notifier.NotifyResponse(ctx, survey, resp)
+	}
+}
+
+// channelService implements ChannelService interface
+type channelService struct {
+	channelRepo  repository.ChannelRepository
+	responseRepo repository.ResponseRepository
+	surveyRepo   repository.SurveyRepository
+	sender       chat.Sender
+}
+
+// NewChannelService creates a new channel service instance
+func NewChannelService(
+	channelRepo repository.ChannelRepository,
+	responseRepo repository.ResponseRepository,
+	surveyRepo repository.SurveyRepository,
+	sender chat.Sender,
+) ChannelService {
+	return &channelService{
+		channelRepo:  channelRepo,
+		responseRepo: responseRepo,
+		surveyRepo:   surveyRepo,
+		sender:       sender,
+	}
+}
+
+// ListChannels returns the notification channels of a survey
+func (s *channelService) ListChannels(ctx context.Context, userID, surveyID uint) ([]response.ChannelResponse, error) {
+	if _, err := s.findOwnSurvey(ctx, userID, surveyID); err != nil {
+		return nil, err
+	}
+
+	channels, err := s.channelRepo.FindBySurveyID(ctx, surveyID)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to find notification channels")
+	}
+
+	result := make([]response.ChannelResponse, len(channels))
+	for i := range channels {
+		result[i] = response.ToChannelResponse(&channels[i])
+	}
+	return result, nil
+}
+
+// CreateChannel adds a notification channel to a survey
+func (s *channelService) CreateChannel(ctx context.Context, userID, surveyID uint, req *request.ChannelRequest) (*response.ChannelResponse, error) {
+	if _, err := s.findOwnSurvey(ctx, userID, surveyID); err != nil {
+		return nil, err
+	}
+
+	if err := chat.ValidateWebhookURL(req.Type, req.WebhookURL); err != nil {
+		return nil, errors.NewValidationError("webhook_url", err.Error())
+	}
+
+	channel := &model.NotificationChannel{SurveyID: surveyID}
+	applyChannelRequest(channel, req)
+
+	if err := s.channelRepo.Create(ctx, channel); err != nil {
+		return nil, errors.WrapError(err, "failed to create notification channel")
+	}
+
+	result := response.ToChannelResponse(channel)
+	return &result, nil
+}
+
+// UpdateChannel replaces the settings of a notification channel
+// An empty secret keeps the current one unless the platform changes
+func (s *channelService) UpdateChannel(ctx context.Context, userID, surveyID, channelID uint, req *request.ChannelRequest) (*response.ChannelResponse, error) {
+	channel, err := s.findChannel(ctx, userID, surveyID, channelID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := chat.ValidateWebhookURL(req.Type, req.WebhookURL); err != nil {
+		return nil, errors.NewValidationError("webhook_url", err.Error())
+	}
+
+	secret := channel.Secret
+	sameType := channel.Type == req.Type
+	applyChannelRequest(channel, req)
+	if req.Secret == "" && sameType {
+		channel.Secret = secret
+	}
+
+	if err := s.channelRepo.Update(ctx, channel); err != nil {
+		return nil, errors.WrapError(err, "failed to update notification channel")
+	}
+
+	result := response.ToChannelResponse(channel)
+	return &result, nil
+}
+
+// DeleteChannel removes a notification channel
+func (s *channelService) DeleteChannel(ctx context.Context, userID, surveyID, channelID uint) error {
+	channel, err := s.findChannel(ctx, userID, surveyID, channelID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.channelRepo.Delete(ctx, channel.ID); err != nil {
+		return errors.WrapError(err, "failed to delete notification channel")
+	}
+	return nil
+}
+
+// SendTestMessage posts a test message so owners can verify a channel, even when it is disabled
+func (s *channelService) SendTestMessage(ctx context.Context, userID, surveyID, channelID uint) error {
+	survey, err := s.findOwnSurvey(ctx, userID, surveyID)
+	if err != nil {
+		return err
+	}
+
+	channel, err := s.channelOf(ctx, surveyID, channelID)
+	if err != nil {
+		return err
+	}
+
+	text := fmt.Sprintf("这是一条来自问卷「%s」的测试消息，通知渠道配置成功。", survey.Title)
+	if err := s.sender.Send(ctx, channel.Type, channel.WebhookURL, channel.Secret, text); err != nil {
+		log.Printf("channel notifier: test message for channel %d failed: %v", channel.ID, err)
+		return errors.ErrChannelUnreachable
+	}
+	return nil
+}
+
+// NotifyResponse posts the new response and, once a day, a reached threshold
+// to the survey's enabled channels. Failures are logged and not retried
+func (s *channelService) NotifyResponse(ctx context.Context, survey *model.Survey, resp *model.Response) {
+	ctx, cancel := context.WithTimeout(ctx, channelNotifyTimeout)
+	defer cancel()
+
+	channels, err := s.channelRepo.FindEnabledBySurveyID(ctx, survey.ID)
+	if err != nil {
+		log.Printf("channel notifier: failed to find channels of survey %d: %v", survey.ID, err)
+		return
+	}
+	if len(channels) == 0 {
+		return
+	}
+
+	now := time.Now()
+	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	dailyCounts, err := s.responseRepo.CountByDay(ctx, survey.ID, startOfDay)
+	if err != nil {
+		log.Printf("channel notifier: failed to count responses of survey %d: %v", survey.ID, err)
+		return
+	}
+	var today int64
+	for _, daily := range dailyCounts {
+		today += daily.Count
+	}
+	day := startOfDay.Format("2006-01-02")
+
+	for i := range channels {
+		channel := &channels[i]
+
+		if channel.OnResponse {
+			text := fmt.Sprintf("问卷「%s」收到一份新填答，今日累计 %d 份。", survey.Title, today)
+			s.send(ctx, channel, text)
+		}
+
+		if channel.DailyThreshold > 0 && today >= int64(channel.DailyThreshold) && channel.ThresholdNotifiedOn != day {
+			first, err := s.channelRepo.MarkThresholdNotified(ctx, channel.ID, day)
+			if err != nil {
+				log.Printf("channel notifier: failed to record threshold of channel %d: %v", channel.ID, err)
+				continue
+			}
+			if first {
+				text := fmt.Sprintf("问卷「%s」今日填答已达到 %d 份。", survey.Title, channel.DailyThreshold)
+				s.send(ctx, channel, text)
+			}
+		}
+	}
+}
+
+// send posts a message to a channel, logging failures
+func (s *channelService) send(ctx context.Context, channel *model.NotificationChannel, text string) {
+	if err := s.sender.Send(ctx, channel.Type, channel.WebhookURL, channel.Secret, text); err != nil {
+		log.Printf("channel notifier: message to channel %d failed: %v", channel.ID, err)
+	}
+}
+
+// findOwnSurvey loads a survey owned by the user
+func (s *channelService) findOwnSurvey(ctx context.Context, userID, surveyID uint) (*model.Survey, error) {
+	survey, err := s.surveyRepo.FindByID(ctx, surveyID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.WrapError(err, "failed to find survey")
+	}
+
+	if survey.UserID != userID {
+		return nil, errors.ErrForbidden
+	}
+	return survey, nil
+}
+
+// findChannel loads a channel of a survey owned by the user
+func (s *channelService) findChannel(ctx context.Context, userID, surveyID, channelID uint) (*model.NotificationChannel, error) {
+	if _, err := s.findOwnSurvey(ctx, userID, surveyID); err != nil {
+		return nil, err
+	}
+	return s.channelOf(ctx, surveyID, channelID)
+}
+
+// channelOf loads a channel and checks that it belongs to the survey
+func (s *channelService) channelOf(ctx context.Context, surveyID, channelID uint) (*model.NotificationChannel, error) {
+	channel, err := s.channelRepo.FindByID(ctx, channelID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.WrapError(err, "failed to find notification channel")
+	}
+
+	if channel.SurveyID != surveyID {
+		return nil, errors.ErrNotFound
+	}
+	return channel, nil
+}
+
+// applyChannelRequest copies the request settings onto a channel
+func applyChannelRequest(channel *model.NotificationChannel, req *request.ChannelRequest) {
+	channel.Type = req.Type
+	channel.WebhookURL = req.WebhookURL
+	channel.Secret = req.Secret
+	channel.OnResponse = req.OnResponse
+	channel.DailyThreshold = req.DailyThreshold
+	channel.Enabled = req.Enabled == nil || *req.Enabled
+}
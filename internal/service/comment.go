@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+
+	"survey-system/internal/dto/request"
+	"survey-system/internal/dto/response"
+	"survey-system/internal/model"
+	"survey-system/internal/repository"
+	"survey-system/pkg/errors"
+	"survey-system/pkg/utils"
+
+	"gorm.io/gorm"
+)
+
+// CommentService defines the interface for response comment business logic
+type CommentService interface {
+	ListComments(ctx context.Context, userID, surveyID, responseID uint) ([]response.CommentResponse, error)
+	CreateComment(ctx context.Context, userID, surveyID, responseID uint, req *request.CommentRequest) (*response.CommentResponse, error)
+	UpdateComment(ctx context.Context, userID, surveyID, responseID, commentID uint, req *request.CommentRequest) (*response.CommentResponse, error)
+	DeleteComment(ctx context.Context, userID, surveyID, responseID, commentID uint) error
+}
+
+// commentService implements CommentService interface
+type commentService struct {
+	commentRepo  repository.CommentRepository
+	responseRepo repository.ResponseRepository
+	authz        *utils.AuthorizationUtil
+}
+
+// NewCommentService creates a new comment service instance
+func NewCommentService(
+	commentRepo repository.CommentRepository,
+	responseRepo repository.ResponseRepository,
+	authz *utils.AuthorizationUtil,
+) CommentService {
+	return &commentService{
+		commentRepo:  commentRepo,
+		responseRepo: responseRepo,
+		authz:        authz,
+	}
+}
+
+// ListComments returns the comments on a response, oldest first
+func (s *commentService) ListComments(ctx context.Context, userID, surveyID, responseID uint) ([]response.CommentResponse, error) {
+	if err := s.checkResponseAccess(ctx, userID, surveyID, responseID); err != nil {
+		return nil, err
+	}
+
+	comments, err := s.commentRepo.FindByResponseID(ctx, responseID)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to find comments")
+	}
+
+	result := make([]response.CommentResponse, len(comments))
+	for i := range comments {
+		result[i] = response.ToCommentResponse(&comments[i])
+	}
+	return result, nil
+}
+
+// CreateComment adds a comment by the user to a response
+func (s *commentService) CreateComment(ctx context.Context, userID, surveyID, responseID uint, req *request.CommentRequest) (*response.CommentResponse, error) {
+	if err := s.checkResponseAccess(ctx, userID, surveyID, responseID); err != nil {
+		return nil, err
+	}
+
+	comment := &model.ResponseComment{
+		ResponseID: responseID,
+		UserID:     userID,
+		Content:    req.Content,
+	}
+	if err := s.commentRepo.Create(ctx, comment); err != nil {
+		return nil, errors.WrapError(err, "failed to create comment")
+	}
+
+	// Reload to include the author
+	return s.reload(ctx, comment.ID)
+}
+
+// UpdateComment edits a comment; only its author may do so
+func (s *commentService) UpdateComment(ctx context.Context, userID, surveyID, responseID, commentID uint, req *request.CommentRequest) (*response.CommentResponse, error) {
+	comment, err := s.findOwnComment(ctx, userID, surveyID, responseID, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	comment.Content = req.Content
+	if err := s.commentRepo.Update(ctx, comment); err != nil {
+		return nil, errors.WrapError(err, "failed to update comment")
+	}
+
+	return s.reload(ctx, comment.ID)
+}
+
+// DeleteComment removes a comment; only its author may do so
+func (s *commentService) DeleteComment(ctx context.Context, userID, surveyID, responseID, commentID uint) error {
+	comment, err := s.findOwnComment(ctx, userID, surveyID, responseID, commentID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.commentRepo.Delete(ctx, comment.ID); err != nil {
+		return errors.WrapError(err, "failed to delete comment")
+	}
+	return nil
+}
+
+// checkResponseAccess verifies that the user can access the survey and that the response belongs to it
+func (s *commentService) checkResponseAccess(ctx context.Context, userID, surveyID, responseID uint) error {
+	// Verify the user owns the survey or shares it through its team
+	if _, err := findTeamSurvey(ctx, s.authz, userID, surveyID); err != nil {
+		return err
+	}
+
+	resp, err := s.responseRepo.FindByID(ctx, responseID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return errors.ErrNotFound
+		}
+		return errors.WrapError(err, "failed to find response")
+	}
+
+	if resp.SurveyID != surveyID {
+		return errors.ErrNotFound
+	}
+	return nil
+}
+
+// findOwnComment loads a comment on the response that was written by the user
+func (s *commentService) findOwnComment(ctx context.Context, userID, surveyID, responseID, commentID uint) (*model.ResponseComment, error) {
+	if err := s.checkResponseAccess(ctx, userID, surveyID, responseID); err != nil {
+		return nil, err
+	}
+
+	comment, err := s.commentRepo.FindByID(ctx, commentID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.WrapError(err, "failed to find comment")
+	}
+
+	if comment.ResponseID != responseID {
+		return nil, errors.ErrNotFound
+	}
+	if comment.UserID != userID {
+		return nil, errors.ErrForbidden
+	}
+	return comment, nil
+}
+
+// reload fetches a comment with its author for the response body
+func (s *commentService) reload(ctx context.Context, commentID uint) (*response.CommentResponse, error) {
+	comment, err := s.commentRepo.FindByID(ctx, commentID)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to find comment")
+	}
+
+	result := response.ToCommentResponse(comment)
+	return &result, nil
+}
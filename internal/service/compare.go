@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"math"
+
+	"survey-system/internal/dto/request"
+	"survey-system/internal/dto/response"
+	"survey-system/internal/model"
+	"survey-system/internal/repository"
+	"survey-system/pkg/errors"
+)
+
+// CompareStatistics compares the per-question statistics of two date ranges of a
+// survey, or of two surveys such as repeated runs of the same questionnaire
+// Questions of another base survey are matched by type and title
+func (s *ResponseService) CompareStatistics(ctx context.Context, userID, surveyID uint, req *request.CompareStatisticsRequest) (*response.ComparisonResponse, error) {
+	baseSurveyID := req.BaseSurveyID
+	if baseSurveyID == 0 {
+		baseSurveyID = surveyID
+	}
+	if baseSurveyID == surveyID && req.BaseFrom == "" && req.BaseTo == "" {
+		return nil, errors.NewLocalizedValidationError("base_survey_id", "statistics.compare_base_required")
+	}
+
+	currentFilter, err := dateRangeFilter(req.From, req.To)
+	if err != nil {
+		return nil, err
+	}
+	baseFilter, err := dateRangeFilter(req.BaseFrom, req.BaseTo)
+	if err != nil {
+		return nil, err
+	}
+	currentFilter.IncludeTest = req.IncludeTest
+	baseFilter.IncludeTest = req.IncludeTest
+
+	currentQuestions, currentStats, err := s.loadStatisticsInput(ctx, userID, surveyID, currentFilter)
+	if err != nil {
+		return nil, err
+	}
+	baseQuestions, baseStats, err := s.loadStatisticsInput(ctx, userID, baseSurveyID, baseFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &response.ComparisonResponse{
+		Current: response.ComparisonPeriod{
+			SurveyID:       surveyID,
+			From:           req.From,
+			To:             req.To,
+			TotalResponses: currentStats.TotalResponses,
+		},
+		Base: response.ComparisonPeriod{
+			SurveyID:       baseSurveyID,
+			From:           req.BaseFrom,
+			To:             req.BaseTo,
+			TotalResponses: baseStats.TotalResponses,
+		},
+		TotalResponsesDelta: currentStats.TotalResponses - baseStats.TotalResponses,
+		Questions:           make([]response.QuestionComparison, len(currentQuestions)),
+	}
+
+	for i, question := range currentQuestions {
+		var base *response.QuestionStatistics
+		for j := range baseQuestions {
+			if baseQuestions[j].ID == question.ID ||
+				(baseSurveyID != surveyID && baseQuestions[j].Type == question.Type && baseQuestions[j].Title == question.Title) {
+				base = &baseStats.Questions[j]
+				break
+			}
+		}
+		result.Questions[i] = compareQuestion(question, currentStats.Questions[i], base)
+	}
+	return result, nil
+}
+
+// loadStatisticsInput loads the questions of a survey the user can access and the statistics
+// of its responses matching filter
+func (s *ResponseService) loadStatisticsInput(ctx context.Context, userID, surveyID uint, filter repository.ResponseFilter) ([]model.Question, *response.StatisticsResponse, error) {
+	// Verify the user owns the survey or shares it through its team
+	if _, err := findTeamSurvey(ctx, s.authz, userID, surveyID); err != nil {
+		return nil, nil, err
+	}
+
+	questions, err := s.questionRepo.FindBySurveyID(ctx, surveyID)
+	if err != nil {
+		return nil, nil, errors.WrapError(err, "failed to find questions")
+	}
+
+	counters, err := s.aggregateStatsCounters(ctx, surveyID, questions, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+	return questions, statisticsFromCounters(surveyID, questions, counters), nil
+}
+
+// compareQuestion computes the deltas between the current and base statistics of a question
+func compareQuestion(question model.Question, current response.QuestionStatistics, base *response.QuestionStatistics) response.QuestionComparison {
+	comparison := response.QuestionComparison{
+		QuestionID: question.ID,
+		Title:      question.Title,
+		Type:       question.Type,
+		Current:    current,
+		Base:       base,
+	}
+	if base == nil {
+		return comparison
+	}
+	comparison.BaseQuestionID = &base.QuestionID
+
+	answerRateDelta := roundDelta(current.AnswerRate - base.AnswerRate)
+	comparison.AnswerRateDelta = &answerRateDelta
+	comparison.AverageScoreDelta = pointerDelta(current.AverageScore, base.AverageScore)
+	comparison.AverageLengthDelta = pointerDelta(current.AverageLength, base.AverageLength)
+	comparison.AverageRowsDelta = pointerDelta(current.AverageRows, base.AverageRows)
+	if current.NPS != nil && base.NPS != nil {
+		delta := roundDelta(current.NPS.Score - base.NPS.Score)
+		comparison.NPSDelta = &delta
+	}
+	if current.Numeric != nil && base.Numeric != nil {
+		delta := roundDelta(current.Numeric.Mean - base.Numeric.Mean)
+		comparison.MeanDelta = &delta
+	}
+
+	// Options are matched by ID; shares are relative to the answers of each side
+	if len(current.Options) > 0 {
+		baseCounts := make(map[string]int, len(base.Options))
+		for _, option := range base.Options {
+			baseCounts[option.ID] = option.Count
+		}
+		comparison.Options = make([]response.OptionComparison, len(current.Options))
+		for i, option := range current.Options {
+			currentPercent := optionShare(option.Count, current.Answered)
+			basePercent := optionShare(baseCounts[option.ID], base.Answered)
+			comparison.Options[i] = response.OptionComparison{
+				ID:             option.ID,
+				Label:          option.Label,
+				CurrentPercent: currentPercent,
+				BasePercent:    basePercent,
+				Delta:          roundDelta(currentPercent - basePercent),
+			}
+		}
+	}
+	return comparison
+}
+
+// pointerDelta returns current minus base when both values are present
+func pointerDelta(current, base *float64) *float64 {
+	if current == nil || base == nil {
+		return nil
+	}
+	delta := roundDelta(*current - *base)
+	return &delta
+}
+
+// optionShare returns how many answers chose an option, in percent
+func optionShare(count, answered int) float64 {
+	if answered == 0 {
+		return 0
+	}
+	return math.Round(float64(count)/float64(answered)*10000) / 100
+}
+
+// roundDelta rounds a delta to two decimals
+func roundDelta(delta float64) float64 {
+	return math.Round(delta*100) / 100
+}
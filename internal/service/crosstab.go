@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"math"
+
+	"survey-system/internal/dto/response"
+	"survey-system/internal/model"
+	"survey-system/internal/repository"
+	"survey-system/pkg/errors"
+)
+
+// GetCrosstab cross-tabulates the answers of two choice questions of a survey
+// Test responses are left out unless includeTest is set
+func (s *ResponseService) GetCrosstab(ctx context.Context, userID, surveyID, rowQuestionID, colQuestionID uint, includeTest bool) (*response.CrosstabResponse, error) {
+	// Verify the user owns the survey or shares it through its team
+	if _, err := findTeamSurvey(ctx, s.authz, userID, surveyID); err != nil {
+		return nil, err
+	}
+
+	questions, err := s.questionRepo.FindBySurveyID(ctx, surveyID)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to find questions")
+	}
+
+	rowQuestion, err := choiceQuestion(questions, rowQuestionID)
+	if err != nil {
+		return nil, err
+	}
+	colQuestion, err := choiceQuestion(questions, colQuestionID)
+	if err != nil {
+		return nil, err
+	}
+
+	counter := newCrosstabCounter(rowQuestion, colQuestion)
+	if err := s.eachResponse(ctx, surveyID, repository.ResponseFilter{IncludeTest: includeTest}, counter.add); err != nil {
+		return nil, err
+	}
+
+	result := counter.result()
+	result.SurveyID = surveyID
+	return result, nil
+}
+
+// choiceQuestion finds a choice question of the survey by ID
+func choiceQuestion(questions []model.Question, questionID uint) (*model.Question, error) {
+	for i := range questions {
+		if questions[i].ID != questionID {
+			continue
+		}
+		if questions[i].Type != model.QuestionTypeSingle && questions[i].Type != model.QuestionTypeMultiple {
+			return nil, errors.NewLocalizedError("VALIDATION_FAILED", 400, "statistics.not_choice_question", questions[i].Title)
+		}
+		return &questions[i], nil
+	}
+	return nil, errors.ErrNotFound
+}
+
+// crosstabCounter counts option pairs over the responses that answered both questions
+// A multiple choice answer contributes once for every selected option, so totals
+// may exceed the number of responses
+type crosstabCounter struct {
+	rowQuestion, colQuestion *model.Question
+	rowIndex, colIndex       map[string]int
+	counts                   [][]int
+	total                    int
+}
+
+// newCrosstabCounter creates a counter for the option pairs of two choice questions
+func newCrosstabCounter(rowQuestion, colQuestion *model.Question) *crosstabCounter {
+	counts := make([][]int, len(rowQuestion.Config.Options))
+	for i := range counts {
+		counts[i] = make([]int, len(colQuestion.Config.Options))
+	}
+	return &crosstabCounter{
+		rowQuestion: rowQuestion,
+		colQuestion: colQuestion,
+		rowIndex:    optionIndex(rowQuestion),
+		colIndex:    optionIndex(colQuestion),
+		counts:      counts,
+	}
+}
+
+// add counts the option pairs of a response
+func (c *crosstabCounter) add(resp *model.Response) {
+	var rowIDs, colIDs []string
+	for _, answer := range resp.Data.Answers {
+		switch answer.QuestionID {
+		case c.rowQuestion.ID:
+			rowIDs = choiceIDs(answer.Value)
+		case c.colQuestion.ID:
+			colIDs = choiceIDs(answer.Value)
+		}
+	}
+	if len(rowIDs) == 0 || len(colIDs) == 0 {
+		return
+	}
+
+	c.total++
+	for _, rowID := range rowIDs {
+		i, ok := c.rowIndex[rowID]
+		if !ok {
+			continue
+		}
+		for _, colID := range colIDs {
+			if j, ok := c.colIndex[colID]; ok {
+				c.counts[i][j]++
+			}
+		}
+	}
+}
+
+// result builds the cross tabulation of the responses counted so far
+func (c *crosstabCounter) result() *response.CrosstabResponse {
+	rowQuestion, colQuestion, counts, total := c.rowQuestion, c.colQuestion, c.counts, c.total
+
+	rowTotals := make([]int, len(counts))
+	colTotals := make([]int, len(colQuestion.Config.Options))
+	for i, row := range counts {
+		for j, count := range row {
+			rowTotals[i] += count
+			colTotals[j] += count
+		}
+	}
+
+	percent := func(part, whole int) float64 {
+		if whole == 0 {
+			return 0
+		}
+		return math.Round(float64(part)/float64(whole)*10000) / 100
+	}
+
+	result := &response.CrosstabResponse{
+		Row:     response.CrosstabQuestion{QuestionID: rowQuestion.ID, Title: rowQuestion.Title, Type: rowQuestion.Type},
+		Column:  response.CrosstabQuestion{QuestionID: colQuestion.ID, Title: colQuestion.Title, Type: colQuestion.Type},
+		Rows:    make([]response.CrosstabRow, len(counts)),
+		Columns: make([]response.CrosstabTotal, len(colTotals)),
+		Total:   total,
+	}
+	for j, option := range colQuestion.Config.Options {
+		result.Columns[j] = response.CrosstabTotal{OptionID: option.ID, Label: option.Label, Total: colTotals[j]}
+	}
+	for i, option := range rowQuestion.Config.Options {
+		cells := make([]response.CrosstabCell, len(colTotals))
+		for j, colOption := range colQuestion.Config.Options {
+			cells[j] = response.CrosstabCell{
+				OptionID:      colOption.ID,
+				Count:         counts[i][j],
+				RowPercent:    percent(counts[i][j], rowTotals[i]),
+				ColumnPercent: percent(counts[i][j], colTotals[j]),
+			}
+		}
+		result.Rows[i] = response.CrosstabRow{OptionID: option.ID, Label: option.Label, Total: rowTotals[i], Cells: cells}
+	}
+	return result
+}
+
+// optionIndex maps the option IDs of a choice question to their position
+func optionIndex(question *model.Question) map[string]int {
+	index := make(map[string]int, len(question.Config.Options))
+	for i, option := range question.Config.Options {
+		index[option.ID] = i
+	}
+	return index
+}
+
+// choiceIDs returns the option IDs selected by a single or multiple choice answer
+func choiceIDs(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		ids := make([]string, 0, len(v))
+		for _, item := range v {
+			if str, ok := item.(string); ok {
+				ids = append(ids, str)
+			}
+		}
+		return ids
+	}
+	return nil
+}
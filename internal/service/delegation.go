@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"time"
+
+	"survey-system/internal/dto/request"
+	"survey-system/internal/dto/response"
+	"survey-system/internal/model"
+	"survey-system/pkg/errors"
+
+	"gorm.io/gorm"
+)
+
+// ListDelegations returns the delegation tokens issued for a survey
+func (s *shareService) ListDelegations(ctx context.Context, userID, surveyID uint) ([]response.DelegationResponse, error) {
+	if _, err := s.findOwnSurvey(ctx, userID, surveyID); err != nil {
+		return nil, err
+	}
+
+	delegations, err := s.delegationRepo.FindBySurveyID(ctx, surveyID)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to find delegations")
+	}
+
+	result := make([]response.DelegationResponse, len(delegations))
+	for i := range delegations {
+		result[i] = response.ToDelegationResponse(&delegations[i])
+	}
+	return result, nil
+}
+
+// CreateDelegation issues a delegation token for a survey
+// Only the hash of the token is stored, so it is returned once in the response
+func (s *shareService) CreateDelegation(ctx context.Context, userID, surveyID uint, req *request.CreateDelegationRequest) (*response.CreateDelegationResponse, error) {
+	if _, err := s.findOwnSurvey(ctx, userID, surveyID); err != nil {
+		return nil, err
+	}
+
+	questions, err := s.questionRepo.FindBySurveyID(ctx, surveyID)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to find questions")
+	}
+	if err := validatePrefillData(questions, req.PrefillData); err != nil {
+		return nil, err
+	}
+
+	// Allowed keys must be prefill keys as well, each listed once and not locked
+	validPrefillKeys := make(map[string]bool)
+	for _, q := range questions {
+		if q.PrefillKey != "" {
+			validPrefillKeys[q.PrefillKey] = true
+		}
+	}
+	seen := make(map[string]bool, len(req.AllowedKeys))
+	for _, key := range req.AllowedKeys {
+		if !validPrefillKeys[key] {
+			return nil, errors.NewValidationError("allowed_keys", fmt.Sprintf("invalid prefill key '%s' - no matching question found", key))
+		}
+		if seen[key] {
+			return nil, errors.NewValidationError("allowed_keys", fmt.Sprintf("duplicate key '%s'", key))
+		}
+		if _, ok := req.PrefillData[key]; ok {
+			return nil, errors.NewValidationError("allowed_keys", fmt.Sprintf("'%s' is already locked in prefill_data", key))
+		}
+		seen[key] = true
+	}
+
+	if req.ExpiresAt != nil && req.ExpiresAt.Before(time.Now()) {
+		return nil, errors.NewValidationError("expires_at", "expiration time must be in the future")
+	}
+
+	token, err := generateDelegationToken()
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to generate delegation token")
+	}
+
+	delegation := &model.Delegation{
+		SurveyID:    surveyID,
+		Name:        req.Name,
+		TokenHash:   hashDelegationToken(token),
+		PrefillData: model.PrefillDataType(req.PrefillData),
+		AllowedKeys: model.StringList(req.AllowedKeys),
+		ExpiresAt:   req.ExpiresAt,
+	}
+	if err := s.delegationRepo.Create(ctx, delegation); err != nil {
+		return nil, errors.WrapError(err, "failed to create delegation")
+	}
+
+	return &response.CreateDelegationResponse{
+		DelegationResponse: response.ToDelegationResponse(delegation),
+		Token:              token,
+	}, nil
+}
+
+// RevokeDelegation revokes a delegation token; links generated with it stay valid
+func (s *shareService) RevokeDelegation(ctx context.Context, userID, surveyID, delegationID uint) error {
+	if _, err := s.findOwnSurvey(ctx, userID, surveyID); err != nil {
+		return err
+	}
+
+	delegation, err := s.delegationRepo.FindByID(ctx, delegationID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return errors.ErrNotFound
+		}
+		return errors.WrapError(err, "failed to find delegation")
+	}
+
+	// Delegations of other surveys are reported as missing
+	if delegation.SurveyID != surveyID {
+		return errors.ErrNotFound
+	}
+
+	if err := s.delegationRepo.Revoke(ctx, delegation.ID); err != nil {
+		return errors.WrapError(err, "failed to revoke delegation")
+	}
+	return nil
+}
+
+// GenerateDelegatedShareLink generates a share link on behalf of the survey owner
+// using a delegation token. The locked prefill values of the delegation are merged
+// into the request; other prefill keys must be among its allowed keys
+func (s *shareService) GenerateDelegatedShareLink(ctx context.Context, delegationToken string, surveyID uint, req *request.GenerateShareLinkRequest) (*response.ShareLinkResponse, error) {
+	delegation, err := s.delegationRepo.FindByTokenHash(ctx, hashDelegationToken(delegationToken))
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrInvalidAuthToken
+		}
+		return nil, errors.WrapError(err, "failed to find delegation")
+	}
+
+	if !delegation.IsActive() {
+		return nil, errors.ErrInvalidAuthToken
+	}
+	if delegation.SurveyID != surveyID {
+		return nil, errors.ErrForbidden
+	}
+
+	// Link templates and test links are owner settings
+	if req.TemplateID != 0 {
+		return nil, errors.NewValidationError("template_id", "link templates cannot be used with a delegation token")
+	}
+	if req.Test {
+		return nil, errors.NewValidationError("test", "test links cannot be generated with a delegation token")
+	}
+
+	allowed := make(map[string]bool, len(delegation.AllowedKeys))
+	for _, key := range delegation.AllowedKeys {
+		allowed[key] = true
+	}
+	prefillData := make(map[string]interface{}, len(delegation.PrefillData)+len(req.PrefillData))
+	for key, value := range req.PrefillData {
+		if locked, ok := delegation.PrefillData[key]; ok {
+			if !reflect.DeepEqual(locked, value) {
+				return nil, errors.NewValidationError("prefill_data", fmt.Sprintf("'%s' is locked by the delegation", key))
+			}
+			continue
+		}
+		if !allowed[key] {
+			return nil, errors.NewValidationError("prefill_data", fmt.Sprintf("'%s' is not allowed by the delegation", key))
+		}
+		prefillData[key] = value
+	}
+	for key, value := range delegation.PrefillData {
+		prefillData[key] = value
+	}
+	req.PrefillData = prefillData
+
+	survey, err := s.surveyRepo.FindByID(ctx, surveyID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.WrapError(err, "failed to find survey")
+	}
+
+	// The link is recorded as generated by, and counted against the quota of, the owner
+	// who issued the delegation
+	release, err := s.usage.ReserveUsage(ctx, survey.UserID, UsageShareLinks)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.generateShareLink(ctx, survey.UserID, survey, req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+
+	if err := s.delegationRepo.RecordUse(ctx, delegation.ID); err != nil {
+		fmt.Printf("failed to record delegation use: %v\n", err)
+	}
+	return result, nil
+}
+
+// generateDelegationToken returns a random delegation token with 256 bits of entropy
+func generateDelegationToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return model.DelegationTokenPrefix + base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// hashDelegationToken returns the hex SHA-256 of a delegation token as stored in the database
+func hashDelegationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
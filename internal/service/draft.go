@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"survey-system/internal/dto/request"
+	"survey-system/internal/dto/response"
+	"survey-system/internal/model"
+	"survey-system/internal/repository"
+	"survey-system/pkg/email"
+	"survey-system/pkg/errors"
+
+	"gorm.io/gorm"
+)
+
+// draftEmailInterval is the minimum interval between resume emails for the same link
+const draftEmailInterval = time.Minute
+
+// DraftService defines the interface for response draft business logic
+type DraftService interface {
+	SaveDraft(ctx context.Context, req *request.SaveDraftRequest) (*response.SaveDraftResponse, error)
+	ResumeDraft(ctx context.Context, draftToken string) (*response.DraftResponse, error)
+}
+
+// draftService implements DraftService interface
+type draftService struct {
+	draftRepo     repository.DraftRepository
+	surveyRepo    repository.SurveyRepository
+	oneLinkRepo   repository.OneLinkRepository
+	encryptionSvc EncryptionService
+	cache         Cache
+	mailer        email.Sender
+	baseURL       string
+}
+
+// NewDraftService creates a new draft service instance
+func NewDraftService(
+	draftRepo repository.DraftRepository,
+	surveyRepo repository.SurveyRepository,
+	oneLinkRepo repository.OneLinkRepository,
+	encryptionSvc EncryptionService,
+	cache Cache,
+	mailer email.Sender,
+	baseURL string,
+) DraftService {
+	return &draftService{
+		draftRepo:     draftRepo,
+		surveyRepo:    surveyRepo,
+		oneLinkRepo:   oneLinkRepo,
+		encryptionSvc: encryptionSvc,
+		cache:         cache,
+		mailer:        mailer,
+		baseURL:       baseURL,
+	}
+}
+
+// SaveDraft stores the respondent's partial answers and emails them a resume link
+func (s *draftService) SaveDraft(ctx context.Context, req *request.SaveDraftRequest) (*response.SaveDraftResponse, error) {
+	// Decrypt and validate the survey token
+	tokenData, err := s.encryptionSvc.DecryptToken(req.Token)
+	if err != nil {
+		return nil, errors.ErrInvalidToken
+	}
+
+	if time.Now().Unix() > tokenData.ExpiresAt {
+		return nil, errors.ErrTokenExpired
+	}
+
+	oneLink, err := findOneLinkByToken(ctx, s.oneLinkRepo, s.cache, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	if oneLink.Used {
+		return nil, errors.ErrLinkUsed
+	}
+
+	if oneLink.IsRevoked() {
+		return nil, errors.ErrLinkRevoked
+	}
+
+	survey, err := s.surveyRepo.FindByID(ctx, tokenData.SurveyID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.WrapError(err, "failed to find survey")
+	}
+
+	if survey.Status != model.SurveyStatusPublished {
+		return nil, errors.ErrSurveyNotPublished
+	}
+
+	// Create or update the draft bound to this link
+	draft, err := s.draftRepo.FindByOneLinkID(ctx, oneLink.ID)
+	if err != nil {
+		if err != gorm.ErrRecordNotFound {
+			return nil, errors.WrapError(err, "failed to find draft")
+		}
+		draft = &model.ResponseDraft{
+			SurveyID:  survey.ID,
+			OneLinkID: oneLink.ID,
+		}
+	}
+
+	answers := make([]model.Answer, len(req.Answers))
+	for i, ans := range req.Answers {
+		answers[i] = model.Answer{
+			QuestionID: ans.QuestionID,
+			Value:      ans.Value,
+		}
+	}
+
+	draft.Email = req.Email
+	draft.Data = model.ResponseData{Answers: answers}
+	draft.ExpiresAt = oneLink.ExpiresAt
+
+	if err := s.draftRepo.Save(ctx, draft); err != nil {
+		return nil, errors.WrapError(err, "failed to save draft")
+	}
+
+	resp := &response.SaveDraftResponse{
+		DraftID:   draft.ID,
+		ExpiresAt: draft.ExpiresAt,
+		Message:   "草稿已保存",
+	}
+
+	// Throttle resume emails per link to avoid mailbox flooding
+	lockKey := fmt.Sprintf("draft:email:%d", oneLink.ID)
+	acquired, err := s.cache.AcquireLock(ctx, lockKey, draftEmailInterval)
+	if err != nil || !acquired {
+		resp.Message = "草稿已保存，恢复链接邮件发送过于频繁，请稍后再试"
+		return resp, nil
+	}
+
+	draftToken, err := s.encryptionSvc.EncryptDraftToken(&DraftTokenData{
+		DraftID:   draft.ID,
+		SurveyID:  survey.ID,
+		ExpiresAt: draft.ExpiresAt.Unix(),
+	})
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to encrypt draft token")
+	}
+
+	resumeURL := fmt.Sprintf("%s/survey/%d?draft_token=%s", s.baseURL, survey.ID, url.QueryEscape(draftToken))
+	msg := &email.Message{
+		To:      []string{req.Email},
+		Subject: fmt.Sprintf("继续填写问卷：%s", survey.Title),
+		Body: fmt.Sprintf("您好，\n\n您在问卷「%s」中的填写进度已保存。请在 %s 前通过以下链接继续填写：\n\n%s\n\n如果这不是您本人的操作，请忽略此邮件。\n",
+			survey.Title, draft.ExpiresAt.Format("2006-01-02 15:04"), resumeURL),
+	}
+
+	if err := s.mailer.Send(ctx, msg); err != nil {
+		return nil, &errors.AppError{
+			Code:    "EMAIL_SEND_FAILED",
+			Message: "恢复链接邮件发送失败",
+			Status:  500,
+		}
+	}
+
+	resp.EmailSent = true
+	resp.Message = "草稿已保存，恢复链接已发送至您的邮箱"
+	return resp, nil
+}
+
+// ResumeDraft validates a draft token and returns the saved answers with the original link token
+func (s *draftService) ResumeDraft(ctx context.Context, draftToken string) (*response.DraftResponse, error) {
+	tokenData, err := s.encryptionSvc.DecryptDraftToken(draftToken)
+	if err != nil {
+		return nil, errors.ErrInvalidToken
+	}
+
+	if time.Now().Unix() > tokenData.ExpiresAt {
+		return nil, errors.ErrTokenExpired
+	}
+
+	draft, err := s.draftRepo.FindByID(ctx, tokenData.DraftID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.WrapError(err, "failed to find draft")
+	}
+
+	if draft.SurveyID != tokenData.SurveyID {
+		return nil, errors.ErrInvalidToken
+	}
+
+	if draft.IsExpired() {
+		return nil, errors.ErrTokenExpired
+	}
+
+	oneLink, err := s.oneLinkRepo.FindByID(ctx, draft.OneLinkID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrInvalidToken
+		}
+		return nil, errors.WrapError(err, "failed to find one-time link")
+	}
+
+	if oneLink.Used {
+		return nil, errors.ErrLinkUsed
+	}
+
+	if oneLink.IsRevoked() {
+		return nil, errors.ErrLinkRevoked
+	}
+
+	answers := draft.Data.Answers
+	if answers == nil {
+		answers = []model.Answer{}
+	}
+
+	return &response.DraftResponse{
+		SurveyID:  draft.SurveyID,
+		Token:     oneLink.Token,
+		Answers:   answers,
+		ExpiresAt: draft.ExpiresAt,
+	}, nil
+}
@@ -1,6 +1,8 @@
 package service
 
 import (
+	"bytes"
+	"compress/flate"
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/rand"
@@ -18,10 +20,42 @@ type TokenData struct {
 	UniqueID    string                 `json:"unique_id"`
 }
 
+// DraftTokenData represents the data encrypted in a draft resume token
+type DraftTokenData struct {
+	DraftID   uint  `json:"draft_id"`
+	SurveyID  uint  `json:"survey_id"`
+	ExpiresAt int64 `json:"expires_at"`
+}
+
+// PreviewTokenData represents the data encrypted in a survey preview token
+// Preview tokens have no one-time link record and can be used any number of times
+type PreviewTokenData struct {
+	SurveyID    uint                   `json:"survey_id"`
+	UserID      uint                   `json:"user_id"` // Owner who generated the preview
+	PrefillData map[string]interface{} `json:"prefill_data"`
+	ExpiresAt   int64                  `json:"expires_at"`
+	Variant     string                 `json:"variant,omitempty"` // Survey variant previewed
+}
+
+// draftTokenAAD is the additional authenticated data bound to draft tokens,
+// so a draft token can never be decrypted as a survey token and vice versa
+var draftTokenAAD = []byte("draft")
+
+// previewTokenAAD binds preview tokens in the same way
+var previewTokenAAD = []byte("preview")
+
+// tokenFormatDeflate marks a token plaintext holding deflate-compressed JSON
+// Tokens from before compression hold plain JSON, which always starts with '{'
+const tokenFormatDeflate byte = 1
+
 // EncryptionService defines the interface for encryption operations
 type EncryptionService interface {
 	EncryptToken(data *TokenData) (string, error)
 	DecryptToken(token string) (*TokenData, error)
+	EncryptDraftToken(data *DraftTokenData) (string, error)
+	DecryptDraftToken(token string) (*DraftTokenData, error)
+	EncryptPreviewToken(data *PreviewTokenData) (string, error)
+	DecryptPreviewToken(token string) (*PreviewTokenData, error)
 }
 
 // encryptionService implements EncryptionService using AES-256-GCM
@@ -33,12 +67,12 @@ type encryptionService struct {
 // key must be exactly 32 bytes for AES-256
 func NewEncryptionService(key string) (EncryptionService, error) {
 	keyBytes := []byte(key)
-	
+
 	// Validate key length
 	if len(keyBytes) != 32 {
 		return nil, fmt.Errorf("encryption key must be exactly 32 bytes, got %d bytes", len(keyBytes))
 	}
-	
+
 	return &encryptionService{
 		key: keyBytes,
 	}, nil
@@ -51,75 +85,200 @@ func (s *encryptionService) EncryptToken(data *TokenData) (string, error) {
 	if err != nil {
 		return "", fmt.Errorf("failed to marshal token data: %w", err)
 	}
-	
+
+	plaintext, err = compressPayload(plaintext)
+	if err != nil {
+		return "", err
+	}
+
+	return s.encrypt(plaintext, nil)
+}
+
+// DecryptToken decrypts a base64 URL-safe encoded token and returns TokenData
+func (s *encryptionService) DecryptToken(token string) (*TokenData, error) {
+	plaintext, err := s.decrypt(token, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err = decompressPayload(plaintext)
+	if err != nil {
+		return nil, err
+	}
+
+	// Deserialize JSON to TokenData
+	var data TokenData
+	if err := json.Unmarshal(plaintext, &data); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal token data: %w", err)
+	}
+
+	return &data, nil
+}
+
+// EncryptDraftToken encrypts DraftTokenData and returns a base64 URL-safe encoded string
+func (s *encryptionService) EncryptDraftToken(data *DraftTokenData) (string, error) {
+	plaintext, err := json.Marshal(data)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal draft token data: %w", err)
+	}
+
+	return s.encrypt(plaintext, draftTokenAAD)
+}
+
+// DecryptDraftToken decrypts a draft resume token and returns DraftTokenData
+func (s *encryptionService) DecryptDraftToken(token string) (*DraftTokenData, error) {
+	plaintext, err := s.decrypt(token, draftTokenAAD)
+	if err != nil {
+		return nil, err
+	}
+
+	var data DraftTokenData
+	if err := json.Unmarshal(plaintext, &data); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal draft token data: %w", err)
+	}
+
+	return &data, nil
+}
+
+// EncryptPreviewToken encrypts PreviewTokenData and returns a base64 URL-safe encoded string
+func (s *encryptionService) EncryptPreviewToken(data *PreviewTokenData) (string, error) {
+	plaintext, err := json.Marshal(data)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal preview token data: %w", err)
+	}
+
+	plaintext, err = compressPayload(plaintext)
+	if err != nil {
+		return "", err
+	}
+
+	return s.encrypt(plaintext, previewTokenAAD)
+}
+
+// DecryptPreviewToken decrypts a survey preview token and returns PreviewTokenData
+func (s *encryptionService) DecryptPreviewToken(token string) (*PreviewTokenData, error) {
+	plaintext, err := s.decrypt(token, previewTokenAAD)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err = decompressPayload(plaintext)
+	if err != nil {
+		return nil, err
+	}
+
+	var data PreviewTokenData
+	if err := json.Unmarshal(plaintext, &data); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal preview token data: %w", err)
+	}
+
+	return &data, nil
+}
+
+// compressPayload deflates a JSON payload behind a format byte, shortening
+// prefill-heavy tokens; payloads that do not shrink are kept as plain JSON
+func compressPayload(payload []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte(tokenFormatDeflate)
+
+	w, err := flate.NewWriter(&buf, flate.BestCompression)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create compressor: %w", err)
+	}
+	if _, err := w.Write(payload); err != nil {
+		return nil, fmt.Errorf("failed to compress token data: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress token data: %w", err)
+	}
+
+	if buf.Len() >= len(payload) {
+		return payload, nil
+	}
+	return buf.Bytes(), nil
+}
+
+// decompressPayload returns the JSON inside a token plaintext, compressed or not
+// The plaintext has already been authenticated, so it was produced by compressPayload
+func decompressPayload(plaintext []byte) ([]byte, error) {
+	if len(plaintext) == 0 || plaintext[0] != tokenFormatDeflate {
+		return plaintext, nil
+	}
+
+	r := flate.NewReader(bytes.NewReader(plaintext[1:]))
+	defer r.Close()
+
+	payload, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress token data: %w", err)
+	}
+	return payload, nil
+}
+
+// encrypt seals the plaintext with AES-256-GCM and encodes it as base64 URL-safe
+func (s *encryptionService) encrypt(plaintext, additionalData []byte) (string, error) {
 	// Create AES cipher block
 	block, err := aes.NewCipher(s.key)
 	if err != nil {
 		return "", fmt.Errorf("failed to create cipher block: %w", err)
 	}
-	
+
 	// Create GCM mode
 	gcm, err := cipher.NewGCM(block)
 	if err != nil {
 		return "", fmt.Errorf("failed to create GCM: %w", err)
 	}
-	
+
 	// Generate random nonce (IV)
 	nonce := make([]byte, gcm.NonceSize())
 	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
 		return "", fmt.Errorf("failed to generate nonce: %w", err)
 	}
-	
+
 	// Encrypt the plaintext
 	// The nonce is prepended to the ciphertext
-	ciphertext := gcm.Seal(nonce, nonce, plaintext, nil)
-	
+	ciphertext := gcm.Seal(nonce, nonce, plaintext, additionalData)
+
 	// Encode to base64 URL-safe format
 	encoded := base64.URLEncoding.EncodeToString(ciphertext)
-	
+
 	return encoded, nil
 }
 
-// DecryptToken decrypts a base64 URL-safe encoded token and returns TokenData
-func (s *encryptionService) DecryptToken(token string) (*TokenData, error) {
+// decrypt decodes a base64 URL-safe token and opens it with AES-256-GCM
+func (s *encryptionService) decrypt(token string, additionalData []byte) ([]byte, error) {
 	// Decode from base64 URL-safe format
 	ciphertext, err := base64.URLEncoding.DecodeString(token)
 	if err != nil {
 		return nil, fmt.Errorf("failed to decode token: %w", err)
 	}
-	
+
 	// Create AES cipher block
 	block, err := aes.NewCipher(s.key)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create cipher block: %w", err)
 	}
-	
+
 	// Create GCM mode
 	gcm, err := cipher.NewGCM(block)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create GCM: %w", err)
 	}
-	
+
 	// Validate ciphertext length
 	nonceSize := gcm.NonceSize()
 	if len(ciphertext) < nonceSize {
 		return nil, fmt.Errorf("ciphertext too short")
 	}
-	
+
 	// Extract nonce and ciphertext
 	nonce, ciphertext := ciphertext[:nonceSize], ciphertext[nonceSize:]
-	
+
 	// Decrypt the ciphertext
-	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, additionalData)
 	if err != nil {
 		return nil, fmt.Errorf("failed to decrypt token: %w", err)
 	}
-	
-	// Deserialize JSON to TokenData
-	var data TokenData
-	if err := json.Unmarshal(plaintext, &data); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal token data: %w", err)
-	}
-	
-	return &data, nil
+
+	return plaintext, nil
 }
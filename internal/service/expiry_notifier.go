@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"survey-system/internal/model"
+	"survey-system/internal/repository"
+	"survey-system/pkg/email"
+	"survey-system/pkg/webhook"
+)
+
+// EventLinksExpiring is the webhook event emitted for links about to expire
+const EventLinksExpiring = "links.expiring"
+
+// expiryNotifierBatchSize caps how many links are processed per scan
+const expiryNotifierBatchSize = 500
+
+// ExpiringLinksEvent is the payload sent to a survey's expiration webhook
+type ExpiringLinksEvent struct {
+	Event          string         `json:"event"`
+	SurveyID       uint           `json:"survey_id"`
+	SurveyTitle    string         `json:"survey_title"`
+	ThresholdHours int            `json:"threshold_hours"`
+	Links          []ExpiringLink `json:"links"`
+	SentAt         time.Time      `json:"sent_at"`
+}
+
+// ExpiringLink describes an unused one-time link close to expiration
+type ExpiringLink struct {
+	ID          uint                   `json:"id"`
+	ExpiresAt   time.Time              `json:"expires_at"`
+	Accessed    bool                   `json:"accessed"`
+	PrefillData map[string]interface{} `json:"prefill_data,omitempty"`
+}
+
+// ExpiryNotifier periodically notifies survey owners about unused links that are about to expire
+type ExpiryNotifier struct {
+	oneLinkRepo repository.OneLinkRepository
+	cache       Cache
+	mailer      email.Sender
+	webhooks    webhook.Sender
+	interval    time.Duration
+}
+
+// NewExpiryNotifier creates a new ExpiryNotifier
+func NewExpiryNotifier(
+	oneLinkRepo repository.OneLinkRepository,
+	cache Cache,
+	mailer email.Sender,
+	webhooks webhook.Sender,
+	interval time.Duration,
+) *ExpiryNotifier {
+	return &ExpiryNotifier{
+		oneLinkRepo: oneLinkRepo,
+		cache:       cache,
+		mailer:      mailer,
+		webhooks:    webhooks,
+		interval:    interval,
+	}
+}
+
+// Run scans for expiring links every interval until ctx is cancelled
+// A non-positive interval disables the notifier
+func (n *ExpiryNotifier) Run(ctx context.Context) {
+	if n.interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(n.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := n.NotifyExpiring(ctx); err != nil {
+			log.Printf("expiry notifier: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// NotifyExpiring performs a single scan and sends one event per survey
+// Links are only marked as notified once every configured channel succeeded,
+// so failed deliveries are retried on the next scan
+func (n *ExpiryNotifier) NotifyExpiring(ctx context.Context) error {
+	// Only one instance should scan at a time
+	lockKey := "notifier:expiry"
+	acquired, err := n.cache.AcquireLock(ctx, lockKey, n.interval)
+	if err != nil {
+		return fmt.Errorf("failed to acquire lock: %w", err)
+	}
+	if !acquired {
+		return nil
+	}
+	defer n.cache.ReleaseLock(ctx, lockKey)
+
+	links, err := n.oneLinkRepo.FindExpiringUnnotified(ctx, time.Now(), expiryNotifierBatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to find expiring links: %w", err)
+	}
+
+	// Links are ordered by survey, group consecutive runs
+	for start := 0; start < len(links); {
+		end := start
+		for end < len(links) && links[end].SurveyID == links[start].SurveyID {
+			end++
+		}
+		n.notifySurvey(ctx, &links[start].Survey, links[start:end])
+		start = end
+	}
+
+	return nil
+}
+
+// notifySurvey delivers the expiring links of a single survey
+func (n *ExpiryNotifier) notifySurvey(ctx context.Context, survey *model.Survey, links []model.OneLink) {
+	event := &ExpiringLinksEvent{
+		Event:          EventLinksExpiring,
+		SurveyID:       survey.ID,
+		SurveyTitle:    survey.Title,
+		ThresholdHours: survey.ExpiryNotifyHours,
+		Links:          make([]ExpiringLink, len(links)),
+		SentAt:         time.Now(),
+	}
+	ids := make([]uint, len(links))
+	for i, link := range links {
+		ids[i] = link.ID
+		event.Links[i] = ExpiringLink{
+			ID:          link.ID,
+			ExpiresAt:   link.ExpiresAt,
+			Accessed:    link.AccessedAt != nil,
+			PrefillData: link.PrefillData,
+		}
+	}
+
+	delivered := true
+	if survey.ExpiryWebhookURL != "" {
+		if err := n.webhooks.Send(ctx, survey.ExpiryWebhookURL, EventLinksExpiring, event); err != nil {
+			log.Printf("expiry notifier: webhook for survey %d failed: %v", survey.ID, err)
+			delivered = false
+		}
+	}
+	if survey.ExpiryNotifyEmail != "" {
+		if err := n.mailer.Send(ctx, buildExpiringLinksEmail(survey, event)); err != nil {
+			log.Printf("expiry notifier: email for survey %d failed: %v", survey.ID, err)
+			delivered = false
+		}
+	}
+
+	if !delivered {
+		return
+	}
+	if err := n.oneLinkRepo.MarkAsNotified(ctx, ids); err != nil {
+		log.Printf("expiry notifier: failed to mark links of survey %d as notified: %v", survey.ID, err)
+	}
+}
+
+// buildExpiringLinksEmail builds the summary email sent to the survey's notification address
+func buildExpiringLinksEmail(survey *model.Survey, event *ExpiringLinksEvent) *email.Message {
+	var b strings.Builder
+	fmt.Fprintf(&b, "您好，\n\n问卷「%s」有 %d 个尚未填答的一次性链接将在 %d 小时内过期：\n\n",
+		survey.Title, len(event.Links), survey.ExpiryNotifyHours)
+	for _, link := range event.Links {
+		status := "未打开"
+		if link.Accessed {
+			status = "已打开未提交"
+		}
+		fmt.Fprintf(&b, "- 链接 #%d，%s 过期，%s", link.ID, link.ExpiresAt.Format("2006-01-02 15:04"), status)
+		for key, value := range link.PrefillData {
+			fmt.Fprintf(&b, "，%s=%v", key, value)
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\n如需继续收集，请及时联系上述填答者或重新生成分享链接。\n")
+
+	return &email.Message{
+		To:      []string{survey.ExpiryNotifyEmail},
+		Subject: fmt.Sprintf("问卷链接即将过期：%s", survey.Title),
+		Body:    b.String(),
+	}
+}
@@ -2,13 +2,21 @@ package service
 
 import (
 	"bytes"
+	"context"
 	"encoding/csv"
+	"encoding/json"
 	"fmt"
+	"math"
+	"sort"
 	"strconv"
+	"time"
 
+	"survey-system/internal/dto/request"
+	"survey-system/internal/dto/response"
 	"survey-system/internal/model"
 	"survey-system/internal/repository"
 	"survey-system/pkg/errors"
+	"survey-system/pkg/utils"
 
 	"github.com/xuri/excelize/v2"
 )
@@ -18,6 +26,8 @@ type ExportService struct {
 	surveyRepo   repository.SurveyRepository
 	questionRepo repository.QuestionRepository
 	responseRepo repository.ResponseRepository
+	numbering    RespondentNumbering
+	authz        *utils.AuthorizationUtil
 }
 
 // NewExportService creates a new ExportService
@@ -25,105 +35,212 @@ func NewExportService(
 	surveyRepo repository.SurveyRepository,
 	questionRepo repository.QuestionRepository,
 	responseRepo repository.ResponseRepository,
+	numbering RespondentNumbering,
+	authz *utils.AuthorizationUtil,
 ) *ExportService {
 	return &ExportService{
 		surveyRepo:   surveyRepo,
 		questionRepo: questionRepo,
 		responseRepo: responseRepo,
+		numbering:    numbering,
+		authz:        authz,
 	}
 }
 
-// ExportResponses exports survey responses in the specified format
-func (s *ExportService) ExportResponses(userID, surveyID uint, format string) ([]byte, string, error) {
-	// Verify survey ownership
-	survey, err := s.surveyRepo.FindByID(surveyID)
+// utf8BOM is the byte order mark that lets Excel detect UTF-8 encoded CSV files
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// csvDelimiters maps delimiter option names to their field separator runes
+var csvDelimiters = map[string]rune{
+	"comma":     ',',
+	"semicolon": ';',
+	"tab":       '\t',
+}
+
+// ExportResponses exports survey responses in the format specified by the request
+func (s *ExportService) ExportResponses(ctx context.Context, userID, surveyID uint, req *request.ExportResponsesRequest) ([]byte, string, error) {
+	// Verify the user owns the survey or shares it through its team
+	survey, err := findTeamSurvey(ctx, s.authz, userID, surveyID)
 	if err != nil {
-		return nil, "", errors.ErrNotFound
+		return nil, "", err
 	}
 
-	if survey.UserID != userID {
-		return nil, "", errors.ErrForbidden
+	// Get all questions for the survey
+	questions, err := s.questionRepo.FindBySurveyID(ctx, surveyID)
+	if err != nil {
+		return nil, "", errors.WrapError(err, "failed to find questions")
 	}
 
-	// Get all questions for the survey
-	questions, err := s.questionRepo.FindBySurveyID(surveyID)
+	filter, err := exportFilter(req)
 	if err != nil {
-		return nil, "", &errors.AppError{
-			Code:    "INTERNAL_ERROR",
-			Message: "获取问卷题目失败",
-			Status:  500,
-		}
+		return nil, "", err
 	}
 
-	// Get all responses (no pagination for export)
-	responses, _, err := s.responseRepo.FindBySurveyID(surveyID, 1, 999999)
+	// Get all matching responses (no pagination for export)
+	responses, _, err := s.responseRepo.FindBySurveyID(ctx, surveyID, filter, 1, 999999)
 	if err != nil {
-		return nil, "", &errors.AppError{
-			Code:    "INTERNAL_ERROR",
-			Message: "获取填答记录失败",
-			Status:  500,
-		}
+		return nil, "", errors.WrapError(err, "failed to find responses")
 	}
 
-	switch format {
+	switch req.Format {
 	case "csv":
-		return s.exportCSV(survey, questions, responses)
+		return s.exportCSV(survey, questions, responses, req)
 	case "excel":
-		return s.exportExcel(survey, questions, responses)
+		return s.exportExcel(survey, questions, responses, req)
 	default:
-		return nil, "", &errors.AppError{
-			Code:    "INVALID_FORMAT",
-			Message: "不支持的导出格式",
-			Status:  400,
+		return nil, "", errors.ErrInvalidExportFormat
+	}
+}
+
+// exportFilter builds the response filter from the export options
+func exportFilter(req *request.ExportResponsesRequest) (repository.ResponseFilter, error) {
+	filter, err := dateRangeFilter(req.From, req.To)
+	filter.Campaign = req.Campaign
+	filter.IncludeTest = req.IncludeTest
+	return filter, err
+}
+
+// dateRangeFilter builds a response filter for submissions between two days
+// Dates are whole days in server local time and the to day is inclusive
+func dateRangeFilter(from, to string) (repository.ResponseFilter, error) {
+	var filter repository.ResponseFilter
+	if from != "" {
+		day, err := time.ParseInLocation("2006-01-02", from, time.Local)
+		if err != nil {
+			return filter, errors.ErrInvalidDateRange
+		}
+		filter.From = day
+	}
+	if to != "" {
+		day, err := time.ParseInLocation("2006-01-02", to, time.Local)
+		if err != nil {
+			return filter, errors.ErrInvalidDateRange
 		}
+		filter.To = day.AddDate(0, 0, 1)
 	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return filter, errors.ErrInvalidDateRange
+	}
+	return filter, nil
 }
 
 // exportCSV exports responses as CSV format
-func (s *ExportService) exportCSV(survey *model.Survey, questions []model.Question, responses []model.Response) ([]byte, string, error) {
+// BOM, delimiter and line ending are taken from the request options
+func (s *ExportService) exportCSV(survey *model.Survey, questions []model.Question, responses []model.Response, req *request.ExportResponsesRequest) ([]byte, string, error) {
 	var buf bytes.Buffer
+
+	// Excel needs a BOM to recognize UTF-8 (e.g. Chinese) content correctly
+	if req.BOM {
+		buf.Write(utf8BOM)
+	}
+
 	writer := csv.NewWriter(&buf)
+	if delimiter, ok := csvDelimiters[req.Delimiter]; ok {
+		writer.Comma = delimiter
+	}
+	writer.UseCRLF = req.LineEnding == "crlf"
+
+	// Build header and data rows according to the requested layout
+	header, rows := s.buildExportRows(survey, questions, responses, req)
 
-	// Build header row
-	header := s.buildCSVHeader(questions)
 	if err := writer.Write(header); err != nil {
-		return nil, "", &errors.AppError{
-			Code:    "EXPORT_ERROR",
-			Message: "生成 CSV 表头失败",
-			Status:  500,
-		}
+		return nil, "", errors.WrapError(err, "failed to write CSV header")
 	}
 
 	// Write data rows
-	for _, response := range responses {
-		rows := s.buildCSVRows(questions, response)
-		for _, row := range rows {
-			if err := writer.Write(row); err != nil {
-				return nil, "", &errors.AppError{
-					Code:    "EXPORT_ERROR",
-					Message: "写入 CSV 数据失败",
-					Status:  500,
-				}
-			}
+	for _, row := range rows {
+		if err := writer.Write(row); err != nil {
+			return nil, "", errors.WrapError(err, "failed to write CSV row")
 		}
 	}
 
 	writer.Flush()
 	if err := writer.Error(); err != nil {
-		return nil, "", &errors.AppError{
-			Code:    "EXPORT_ERROR",
-			Message: "生成 CSV 文件失败",
-			Status:  500,
-		}
+		return nil, "", errors.WrapError(err, "failed to flush CSV")
 	}
 
 	filename := fmt.Sprintf("%s_responses.csv", survey.Title)
 	return buf.Bytes(), filename, nil
 }
 
+// buildExportRows builds the header row and all data rows for the requested layout
+// The long layout (default) emits one row per table row, the wide layout one row per response
+// Assessments end each response's first row with its grading columns
+func (s *ExportService) buildExportRows(survey *model.Survey, questions []model.Question, responses []model.Response, req *request.ExportResponsesRequest) ([]string, [][]string) {
+	grading := gradingHeaders(survey, questions)
+
+	if req.Layout == "wide" {
+		rowCounts := s.tableRowCounts(questions, responses)
+		header := append(s.buildWideHeader(questions, rowCounts, req.TableFormat), grading...)
+
+		rows := make([][]string, 0, len(responses))
+		for _, response := range responses {
+			row := s.buildWideRow(questions, response, rowCounts, req.TableFormat)
+			if grading != nil {
+				row = append(row, gradingCells(survey, questions, &response)...)
+			}
+			rows = append(rows, row)
+		}
+		return header, rows
+	}
+
+	header := append(s.buildCSVHeader(questions), grading...)
+	rows := [][]string{}
+	for _, response := range responses {
+		responseRows := s.buildCSVRows(questions, response)
+		if grading != nil {
+			responseRows[0] = append(responseRows[0], gradingCells(survey, questions, &response)...)
+			for i := 1; i < len(responseRows); i++ {
+				responseRows[i] = append(responseRows[i], make([]string, len(grading))...)
+			}
+		}
+		rows = append(rows, responseRows...)
+	}
+	return header, rows
+}
+
+// numericColumns reports which export columns hold numeric answers, aligned with the header
+func (s *ExportService) numericColumns(survey *model.Survey, questions []model.Question, responses []model.Response, req *request.ExportResponsesRequest) []bool {
+	var rowCounts map[uint]int
+	if req.Layout == "wide" {
+		rowCounts = s.tableRowCounts(questions, responses)
+	}
+
+	// Response ID, Respondent No., Submitted At, IP Address and Respondent ID
+	flags := []bool{false, false, false, false, false}
+	for _, question := range questions {
+		switch question.Type {
+		case model.QuestionTypeTable:
+			width := len(question.Config.Columns)
+			if req.Layout == "wide" {
+				if req.TableFormat == "json" {
+					width = 1
+				} else {
+					width *= rowCounts[question.ID]
+				}
+			}
+			flags = append(flags, make([]bool, width)...)
+			for range totalHeaders(question) {
+				flags = append(flags, true)
+			}
+		case model.QuestionTypeNPS:
+			flags = append(flags, true, false)
+		case model.QuestionTypeSlider:
+			flags = append(flags, true)
+		default:
+			flags = append(flags, false)
+		}
+	}
+	// Grading columns; the pass/fail result is not a number and stays text
+	for range gradingHeaders(survey, questions) {
+		flags = append(flags, true)
+	}
+	return flags
+}
+
 // buildCSVHeader builds the CSV header row from questions
 func (s *ExportService) buildCSVHeader(questions []model.Question) []string {
-	header := []string{"Response ID", "Submitted At", "IP Address"}
+	header := []string{"Response ID", "Respondent No.", "Submitted At", "IP Address", "Respondent ID"}
 
 	for _, question := range questions {
 		if question.Type == model.QuestionTypeTable {
@@ -131,6 +248,9 @@ func (s *ExportService) buildCSVHeader(questions []model.Question) []string {
 			for _, col := range question.Config.Columns {
 				header = append(header, fmt.Sprintf("%s - %s", question.Title, col.Label))
 			}
+			header = append(header, totalHeaders(question)...)
+		} else if question.Type == model.QuestionTypeNPS {
+			header = append(header, question.Title, fmt.Sprintf("%s - Category", question.Title))
 		} else {
 			header = append(header, question.Title)
 		}
@@ -170,10 +290,12 @@ func (s *ExportService) buildCSVRows(questions []model.Question, response model.
 		// Add response metadata only in the first row
 		if rowIdx == 0 {
 			row = append(row, strconv.FormatUint(uint64(response.ID), 10))
+			row = append(row, s.numbering.Format(response.RespondentNumber))
 			row = append(row, response.SubmittedAt.Format("2006-01-02 15:04:05"))
 			row = append(row, response.IPAddress)
+			row = append(row, respondentID(response))
 		} else {
-			row = append(row, "", "", "")
+			row = append(row, "", "", "", "", "")
 		}
 
 		// Add answer values
@@ -185,6 +307,9 @@ func (s *ExportService) buildCSVRows(questions []model.Question, response model.
 					for range question.Config.Columns {
 						row = append(row, "")
 					}
+					row = append(row, make([]string, len(totalHeaders(question)))...)
+				} else if question.Type == model.QuestionTypeNPS {
+					row = append(row, "", "")
 				} else {
 					row = append(row, "")
 				}
@@ -192,7 +317,7 @@ func (s *ExportService) buildCSVRows(questions []model.Question, response model.
 			}
 
 			switch question.Type {
-			case model.QuestionTypeText:
+			case model.QuestionTypeText, model.QuestionTypeSlider:
 				if rowIdx == 0 {
 					row = append(row, s.formatTextValue(value))
 				} else {
@@ -201,20 +326,32 @@ func (s *ExportService) buildCSVRows(questions []model.Question, response model.
 
 			case model.QuestionTypeSingle:
 				if rowIdx == 0 {
-					row = append(row, s.formatTextValue(value))
+					row = append(row, s.formatTextValue(optionLabels(question, value)))
 				} else {
 					row = append(row, "")
 				}
 
 			case model.QuestionTypeMultiple:
 				if rowIdx == 0 {
-					row = append(row, s.formatMultipleChoiceValue(value))
+					row = append(row, s.formatMultipleChoiceValue(optionLabels(question, value)))
 				} else {
 					row = append(row, "")
 				}
 
 			case model.QuestionTypeTable:
 				row = append(row, s.formatTableRow(value, question.Config.Columns, rowIdx)...)
+				if rowIdx == 0 {
+					row = append(row, formatTotals(question, value)...)
+				} else {
+					row = append(row, make([]string, len(totalHeaders(question)))...)
+				}
+
+			case model.QuestionTypeNPS:
+				if rowIdx == 0 {
+					row = append(row, s.formatNPSValue(value)...)
+				} else {
+					row = append(row, "", "")
+				}
 			}
 		}
 
@@ -224,10 +361,175 @@ func (s *ExportService) buildCSVRows(questions []model.Question, response model.
 	return result
 }
 
+// tableRowCounts determines how many column groups each table question needs in the wide layout
+// The largest row count found in the responses, at least the configured max_rows so the
+// columns stay the same across exports; answers saved before max_rows was lowered keep all rows
+func (s *ExportService) tableRowCounts(questions []model.Question, responses []model.Response) map[uint]int {
+	counts := make(map[uint]int)
+	for _, question := range questions {
+		if question.Type != model.QuestionTypeTable {
+			continue
+		}
+
+		maxRows := max(question.Config.MaxRows, 1)
+		for _, response := range responses {
+			for _, answer := range response.Data.Answers {
+				if answer.QuestionID != question.ID {
+					continue
+				}
+				if rows, ok := answer.Value.([]interface{}); ok && len(rows) > maxRows {
+					maxRows = len(rows)
+				}
+			}
+		}
+		counts[question.ID] = maxRows
+	}
+	return counts
+}
+
+// buildWideHeader builds the header row for the wide layout
+// Table questions become a single JSON column or repeated column groups per row
+func (s *ExportService) buildWideHeader(questions []model.Question, rowCounts map[uint]int, tableFormat string) []string {
+	header := []string{"Response ID", "Respondent No.", "Submitted At", "IP Address", "Respondent ID"}
+
+	for _, question := range questions {
+		if question.Type == model.QuestionTypeNPS {
+			header = append(header, question.Title, fmt.Sprintf("%s - Category", question.Title))
+			continue
+		}
+		if question.Type != model.QuestionTypeTable {
+			header = append(header, question.Title)
+			continue
+		}
+
+		if tableFormat == "json" {
+			header = append(header, question.Title)
+		} else {
+			for rowIdx := 0; rowIdx < rowCounts[question.ID]; rowIdx++ {
+				for _, col := range question.Config.Columns {
+					header = append(header, fmt.Sprintf("%s - %s #%d", question.Title, col.Label, rowIdx+1))
+				}
+			}
+		}
+		header = append(header, totalHeaders(question)...)
+	}
+
+	return header
+}
+
+// buildWideRow builds a single data row for a response in the wide layout
+func (s *ExportService) buildWideRow(questions []model.Question, response model.Response, rowCounts map[uint]int, tableFormat string) []string {
+	// Create answer map for quick lookup
+	answerMap := make(map[uint]interface{})
+	for _, answer := range response.Data.Answers {
+		answerMap[answer.QuestionID] = answer.Value
+	}
+
+	row := []string{
+		strconv.FormatUint(uint64(response.ID), 10),
+		s.numbering.Format(response.RespondentNumber),
+		response.SubmittedAt.Format("2006-01-02 15:04:05"),
+		response.IPAddress,
+		respondentID(response),
+	}
+
+	for _, question := range questions {
+		value, exists := answerMap[question.ID]
+
+		switch question.Type {
+		case model.QuestionTypeTable:
+			if tableFormat == "json" {
+				row = append(row, s.formatJSONValue(value, exists))
+			} else {
+				for rowIdx := 0; rowIdx < rowCounts[question.ID]; rowIdx++ {
+					if !exists {
+						row = append(row, make([]string, len(question.Config.Columns))...)
+						continue
+					}
+					row = append(row, s.formatTableRow(value, question.Config.Columns, rowIdx)...)
+				}
+			}
+			if !exists {
+				row = append(row, make([]string, len(totalHeaders(question)))...)
+				continue
+			}
+			row = append(row, formatTotals(question, value)...)
+
+		case model.QuestionTypeMultiple:
+			if !exists {
+				row = append(row, "")
+				continue
+			}
+			row = append(row, s.formatMultipleChoiceValue(optionLabels(question, value)))
+
+		case model.QuestionTypeNPS:
+			if !exists {
+				row = append(row, "", "")
+				continue
+			}
+			row = append(row, s.formatNPSValue(value)...)
+
+		default:
+			if !exists {
+				row = append(row, "")
+				continue
+			}
+			row = append(row, s.formatTextValue(optionLabels(question, value)))
+		}
+	}
+
+	return row
+}
+
+// totalHeaders returns the headers of the computed total columns of a table question
+// Imports ignore these columns since they match no table column
+func totalHeaders(question model.Question) []string {
+	var headers []string
+	for _, col := range question.Config.Columns {
+		if col.Total {
+			headers = append(headers, fmt.Sprintf("%s - %s Total", question.Title, col.Label))
+		}
+	}
+	return headers
+}
+
+// formatTotals returns the computed totals of a table answer, aligned with totalHeaders
+func formatTotals(question model.Question, value interface{}) []string {
+	totals, ok := columnTotals(question.Config.Columns, value)
+	var cells []string
+	for colIdx, col := range question.Config.Columns {
+		if !col.Total {
+			continue
+		}
+		if !ok {
+			cells = append(cells, "")
+			continue
+		}
+		cells = append(cells, strconv.FormatFloat(totals[colIdx], 'f', -1, 64))
+	}
+	return cells
+}
+
+// formatJSONValue serializes an answer value as a JSON string for a single cell
+func (s *ExportService) formatJSONValue(value interface{}, exists bool) string {
+	if !exists {
+		return ""
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(data)
+}
+
 // formatTextValue formats a text value for CSV
 func (s *ExportService) formatTextValue(value interface{}) string {
-	if str, ok := value.(string); ok {
-		return str
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		// Avoid exponent notation for numeric answers
+		return strconv.FormatFloat(v, 'f', -1, 64)
 	}
 	return fmt.Sprintf("%v", value)
 }
@@ -294,7 +596,7 @@ func (s *ExportService) formatTableRow(value interface{}, columns []model.TableC
 }
 
 // exportExcel exports responses as Excel format
-func (s *ExportService) exportExcel(survey *model.Survey, questions []model.Question, responses []model.Response) ([]byte, string, error) {
+func (s *ExportService) exportExcel(survey *model.Survey, questions []model.Question, responses []model.Response, req *request.ExportResponsesRequest) ([]byte, string, error) {
 	// Create a new Excel file
 	f := excelize.NewFile()
 	defer f.Close()
@@ -302,18 +604,16 @@ func (s *ExportService) exportExcel(survey *model.Survey, questions []model.Ques
 	sheetName := "Responses"
 	index, err := f.NewSheet(sheetName)
 	if err != nil {
-		return nil, "", &errors.AppError{
-			Code:    "EXPORT_ERROR",
-			Message: "创建 Excel 工作表失败",
-			Status:  500,
-		}
+		return nil, "", errors.WrapError(err, "failed to create sheet")
 	}
 
 	// Set active sheet
 	f.SetActiveSheet(index)
 
-	// Build and write header row
-	header := s.buildCSVHeader(questions)
+	// Build header and data rows according to the requested layout
+	header, rows := s.buildExportRows(survey, questions, responses, req)
+
+	// Write header row
 	for colIdx, headerValue := range header {
 		cell, _ := excelize.CoordinatesToCellName(colIdx+1, 1)
 		f.SetCellValue(sheetName, cell, headerValue)
@@ -335,17 +635,21 @@ func (s *ExportService) exportExcel(survey *model.Survey, questions []model.Ques
 		f.SetCellStyle(sheetName, "A1", endCol, headerStyle)
 	}
 
-	// Write data rows
+	// Write data rows, numeric answers as number cells so they can be summed and charted
+	numeric := s.numericColumns(survey, questions, responses, req)
 	currentRow := 2
-	for _, response := range responses {
-		rows := s.buildCSVRows(questions, response)
-		for _, row := range rows {
-			for colIdx, cellValue := range row {
-				cell, _ := excelize.CoordinatesToCellName(colIdx+1, currentRow)
-				f.SetCellValue(sheetName, cell, cellValue)
+	for _, row := range rows {
+		for colIdx, cellValue := range row {
+			cell, _ := excelize.CoordinatesToCellName(colIdx+1, currentRow)
+			if colIdx < len(numeric) && numeric[colIdx] {
+				if number, err := strconv.ParseFloat(cellValue, 64); err == nil {
+					f.SetCellValue(sheetName, cell, number)
+					continue
+				}
 			}
-			currentRow++
+			f.SetCellValue(sheetName, cell, cellValue)
 		}
+		currentRow++
 	}
 
 	// Auto-fit column widths
@@ -354,6 +658,11 @@ func (s *ExportService) exportExcel(survey *model.Survey, questions []model.Ques
 		f.SetColWidth(sheetName, colName, colName, 15)
 	}
 
+	// Add summary sheet with per-question aggregates and charts
+	if err := s.addSummarySheet(f, questions, responses); err != nil {
+		return nil, "", errors.WrapError(err, "failed to add summary sheet")
+	}
+
 	// Delete default Sheet1 if it exists and is not our sheet
 	if sheetName != "Sheet1" {
 		f.DeleteSheet("Sheet1")
@@ -362,13 +671,417 @@ func (s *ExportService) exportExcel(survey *model.Survey, questions []model.Ques
 	// Write to buffer
 	var buf bytes.Buffer
 	if err := f.Write(&buf); err != nil {
-		return nil, "", &errors.AppError{
-			Code:    "EXPORT_ERROR",
-			Message: "生成 Excel 文件失败",
-			Status:  500,
-		}
+		return nil, "", errors.WrapError(err, "failed to write Excel file")
 	}
 
 	filename := fmt.Sprintf("%s_responses.xlsx", survey.Title)
 	return buf.Bytes(), filename, nil
 }
+
+// summarySheetName is the name of the per-question aggregate sheet in Excel exports
+const summarySheetName = "Summary"
+
+// summaryChartRows is the number of rows reserved for a chart next to an option table
+const summaryChartRows = 16
+
+// addSummarySheet adds a sheet with per-question aggregates to the workbook
+// Choice questions get an option count table and a bar chart, other types get answer counts
+func (s *ExportService) addSummarySheet(f *excelize.File, questions []model.Question, responses []model.Response) error {
+	if _, err := f.NewSheet(summarySheetName); err != nil {
+		return err
+	}
+
+	titleStyle, _ := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true, Size: 12},
+	})
+	headerStyle, _ := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true},
+		Fill: excelize.Fill{
+			Type:    "pattern",
+			Color:   []string{"#E0E0E0"},
+			Pattern: 1,
+		},
+	})
+
+	f.SetCellValue(summarySheetName, "A1", "Total Responses")
+	f.SetCellValue(summarySheetName, "B1", len(responses))
+	f.SetCellStyle(summarySheetName, "A1", "A1", titleStyle)
+
+	f.SetColWidth(summarySheetName, "A", "A", 30)
+	f.SetColWidth(summarySheetName, "B", "C", 12)
+
+	currentRow := 3
+	for _, question := range questions {
+		titleCell, _ := excelize.CoordinatesToCellName(1, currentRow)
+		f.SetCellValue(summarySheetName, titleCell, question.Title)
+		f.SetCellStyle(summarySheetName, titleCell, titleCell, titleStyle)
+		currentRow++
+
+		answered := s.countAnswered(question.ID, responses)
+
+		switch question.Type {
+		case model.QuestionTypeSingle, model.QuestionTypeMultiple:
+			headerRow := currentRow
+			s.setSummaryRow(f, headerRow, "Option", "Count", "Percentage")
+			f.SetCellStyle(summarySheetName, fmt.Sprintf("A%d", headerRow), fmt.Sprintf("C%d", headerRow), headerStyle)
+
+			counts := s.countOptions(question, responses)
+			for i, option := range question.Config.Options {
+				percentage := 0.0
+				if answered > 0 {
+					percentage = float64(counts[option.ID]) / float64(answered) * 100
+				}
+				s.setSummaryRow(f, headerRow+1+i, option.Label, counts[option.ID], fmt.Sprintf("%.1f%%", percentage))
+			}
+
+			firstRow := headerRow + 1
+			lastRow := headerRow + len(question.Config.Options)
+			if err := s.addOptionChart(f, question.Title, headerRow, firstRow, lastRow); err != nil {
+				return err
+			}
+
+			// Reserve enough rows so the chart does not overlap the next question
+			blockRows := len(question.Config.Options) + 1
+			if avg, ok := s.averageScore(question, responses); ok {
+				s.setSummaryRow(f, lastRow+1, "Average Score", math.Round(avg*100)/100, nil)
+				blockRows++
+			}
+			if blockRows < summaryChartRows {
+				blockRows = summaryChartRows
+			}
+			currentRow += blockRows
+
+		case model.QuestionTypeTable:
+			s.setSummaryRow(f, currentRow, "Answered", answered, nil)
+			s.setSummaryRow(f, currentRow+1, "Total Rows", s.countTableRows(question.ID, responses), nil)
+			currentRow += 2
+			for _, total := range columnTotalStatistics(question, responses) {
+				s.setSummaryRow(f, currentRow, fmt.Sprintf("Sum - %s", total.Label), total.Sum, nil)
+				currentRow++
+			}
+
+		case model.QuestionTypeSlider:
+			stats := calculateNumericStatistics(s.numericValues(question.ID, responses), question.Config.Unit)
+			s.setSummaryRow(f, currentRow, "Answered", answered, nil)
+			currentRow++
+			if stats != nil {
+				s.setSummaryRow(f, currentRow, "Min", stats.Min, nil)
+				s.setSummaryRow(f, currentRow+1, "Max", stats.Max, nil)
+				s.setSummaryRow(f, currentRow+2, "Mean", stats.Mean, nil)
+				s.setSummaryRow(f, currentRow+3, "Median", stats.Median, nil)
+				s.setSummaryRow(f, currentRow+4, "Std Dev", stats.StdDev, nil)
+				currentRow += 5
+			}
+
+		case model.QuestionTypeNPS:
+			s.setSummaryRow(f, currentRow, "Category", "Count", "Percentage")
+			f.SetCellStyle(summarySheetName, fmt.Sprintf("A%d", currentRow), fmt.Sprintf("C%d", currentRow), headerStyle)
+			nps := calculateNPS(s.npsCounts(question.ID, responses))
+			s.setSummaryRow(f, currentRow+1, "Promoters (9-10)", nps.Promoters, fmt.Sprintf("%.1f%%", nps.PromotersPercent))
+			s.setSummaryRow(f, currentRow+2, "Passives (7-8)", nps.Passives, fmt.Sprintf("%.1f%%", nps.PassivesPercent))
+			s.setSummaryRow(f, currentRow+3, "Detractors (0-6)", nps.Detractors, fmt.Sprintf("%.1f%%", nps.DetractorsPercent))
+			s.setSummaryRow(f, currentRow+4, "NPS", nps.Score, nil)
+			currentRow += 5
+
+		default:
+			s.setSummaryRow(f, currentRow, "Answered", answered, nil)
+			currentRow++
+		}
+
+		// Blank separator row between questions
+		currentRow++
+	}
+
+	return nil
+}
+
+// setSummaryRow writes up to three values into columns A-C of the summary sheet
+func (s *ExportService) setSummaryRow(f *excelize.File, row int, label string, value interface{}, extra interface{}) {
+	f.SetCellValue(summarySheetName, fmt.Sprintf("A%d", row), label)
+	f.SetCellValue(summarySheetName, fmt.Sprintf("B%d", row), value)
+	if extra != nil {
+		f.SetCellValue(summarySheetName, fmt.Sprintf("C%d", row), extra)
+	}
+}
+
+// addOptionChart adds a bar chart of option counts next to the option table
+func (s *ExportService) addOptionChart(f *excelize.File, title string, anchorRow, firstRow, lastRow int) error {
+	if lastRow < firstRow {
+		return nil
+	}
+
+	anchor := fmt.Sprintf("E%d", anchorRow)
+	return f.AddChart(summarySheetName, anchor, &excelize.Chart{
+		Type: excelize.Bar,
+		Series: []excelize.ChartSeries{
+			{
+				Name:       fmt.Sprintf("%s!$B$%d", summarySheetName, anchorRow),
+				Categories: fmt.Sprintf("%s!$A$%d:$A$%d", summarySheetName, firstRow, lastRow),
+				Values:     fmt.Sprintf("%s!$B$%d:$B$%d", summarySheetName, firstRow, lastRow),
+			},
+		},
+		Title:  []excelize.RichTextRun{{Text: title}},
+		Legend: excelize.ChartLegend{Position: "none"},
+		Format: excelize.GraphicOptions{
+			OffsetX: 10,
+			OffsetY: 5,
+		},
+	})
+}
+
+// countAnswered counts how many responses contain an answer for the question
+func (s *ExportService) countAnswered(questionID uint, responses []model.Response) int {
+	count := 0
+	for _, response := range responses {
+		for _, answer := range response.Data.Answers {
+			if answer.QuestionID == questionID {
+				count++
+				break
+			}
+		}
+	}
+	return count
+}
+
+// countOptions counts how often each option was selected for a choice question
+func (s *ExportService) countOptions(question model.Question, responses []model.Response) map[string]int {
+	counts := make(map[string]int)
+	for _, response := range responses {
+		for _, answer := range response.Data.Answers {
+			if answer.QuestionID != question.ID {
+				continue
+			}
+
+			switch v := answer.Value.(type) {
+			case string:
+				counts[v]++
+			case []interface{}:
+				for _, item := range v {
+					if str, ok := item.(string); ok {
+						counts[str]++
+					}
+				}
+			}
+		}
+	}
+	return counts
+}
+
+// averageScore returns the average option score of a choice question over the responses
+// that selected at least one scored option. For multiple choice questions the scores of
+// all selected options of a response are summed first.
+func (s *ExportService) averageScore(question model.Question, responses []model.Response) (float64, bool) {
+	total := 0.0
+	scored := 0
+	for _, response := range responses {
+		for _, answer := range response.Data.Answers {
+			if answer.QuestionID != question.ID {
+				continue
+			}
+
+			var ids []string
+			switch v := answer.Value.(type) {
+			case string:
+				ids = []string{v}
+			case []interface{}:
+				for _, item := range v {
+					if str, ok := item.(string); ok {
+						ids = append(ids, str)
+					}
+				}
+			}
+
+			sum, found := 0.0, false
+			for _, id := range ids {
+				if option, ok := question.Config.FindOption(id); ok && option.Score != nil {
+					sum += *option.Score
+					found = true
+				}
+			}
+			if found {
+				total += sum
+				scored++
+			}
+		}
+	}
+
+	if scored == 0 {
+		return 0, false
+	}
+	return total / float64(scored), true
+}
+
+// npsCounts counts promoters, passives and detractors of an NPS question
+func (s *ExportService) npsCounts(questionID uint, responses []model.Response) map[string]int {
+	counts := make(map[string]int)
+	for _, response := range responses {
+		for _, answer := range response.Data.Answers {
+			if answer.QuestionID != questionID {
+				continue
+			}
+			if score, ok := model.NPSScore(answer.Value); ok {
+				counts[model.NPSCategory(score)]++
+			}
+		}
+	}
+	return counts
+}
+
+// calculateNPS computes category percentages and the Net Promoter Score from category counts
+func calculateNPS(counts map[string]int) *response.NPSStatistics {
+	nps := &response.NPSStatistics{
+		Promoters:  counts[model.NPSPromoter],
+		Passives:   counts[model.NPSPassive],
+		Detractors: counts[model.NPSDetractor],
+	}
+	total := nps.Promoters + nps.Passives + nps.Detractors
+	if total == 0 {
+		return nps
+	}
+
+	percent := func(n int) float64 {
+		return math.Round(float64(n)/float64(total)*1000) / 10
+	}
+	nps.PromotersPercent = percent(nps.Promoters)
+	nps.PassivesPercent = percent(nps.Passives)
+	nps.DetractorsPercent = percent(nps.Detractors)
+	nps.Score = math.Round(float64(nps.Promoters-nps.Detractors)/float64(total)*1000) / 10
+	return nps
+}
+
+// numericValues collects the numeric answers of a question
+func (s *ExportService) numericValues(questionID uint, responses []model.Response) []float64 {
+	var values []float64
+	for _, response := range responses {
+		for _, answer := range response.Data.Answers {
+			if answer.QuestionID != questionID {
+				continue
+			}
+			if number, ok := answer.Value.(float64); ok {
+				values = append(values, number)
+			}
+		}
+	}
+	return values
+}
+
+// calculateNumericStatistics computes min, max, mean, median and standard deviation
+// Returns nil when there are no values
+func calculateNumericStatistics(values []float64, unit string) *response.NumericStatistics {
+	if len(values) == 0 {
+		return nil
+	}
+
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	sum := 0.0
+	for _, v := range sorted {
+		sum += v
+	}
+	mean := sum / float64(len(sorted))
+
+	variance := 0.0
+	for _, v := range sorted {
+		variance += (v - mean) * (v - mean)
+	}
+	variance /= float64(len(sorted))
+
+	mid := len(sorted) / 2
+	median := sorted[mid]
+	if len(sorted)%2 == 0 {
+		median = (sorted[mid-1] + sorted[mid]) / 2
+	}
+
+	round := func(v float64) float64 {
+		return math.Round(v*100) / 100
+	}
+	return &response.NumericStatistics{
+		Count:  len(sorted),
+		Min:    sorted[0],
+		Max:    sorted[len(sorted)-1],
+		Mean:   round(mean),
+		Median: round(median),
+		StdDev: round(math.Sqrt(variance)),
+		Unit:   unit,
+	}
+}
+
+// npsCategoryLabels are the export labels of NPS categories
+var npsCategoryLabels = map[string]string{
+	model.NPSPromoter:  "Promoter",
+	model.NPSPassive:   "Passive",
+	model.NPSDetractor: "Detractor",
+}
+
+// formatNPSValue formats an NPS answer as its score and category cells
+func (s *ExportService) formatNPSValue(value interface{}) []string {
+	score, ok := model.NPSScore(value)
+	if !ok {
+		return []string{s.formatTextValue(value), ""}
+	}
+	return []string{strconv.Itoa(score), npsCategoryLabels[model.NPSCategory(score)]}
+}
+
+// optionLabels replaces option IDs in a choice answer with their labels
+func optionLabels(question model.Question, value interface{}) interface{} {
+	if len(question.Config.Options) == 0 {
+		return value
+	}
+
+	switch v := value.(type) {
+	case string:
+		return question.Config.OptionLabel(v)
+	case []interface{}:
+		labels := make([]interface{}, len(v))
+		for i, item := range v {
+			if str, ok := item.(string); ok {
+				labels[i] = question.Config.OptionLabel(str)
+			} else {
+				labels[i] = item
+			}
+		}
+		return labels
+	default:
+		return value
+	}
+}
+
+// countTableRows counts the total number of table rows submitted for a table question
+func (s *ExportService) countTableRows(questionID uint, responses []model.Response) int {
+	total := 0
+	for _, response := range responses {
+		for _, answer := range response.Data.Answers {
+			if answer.QuestionID != questionID {
+				continue
+			}
+			if rows, ok := answer.Value.([]interface{}); ok {
+				total += len(rows)
+			}
+		}
+	}
+	return total
+}
+
+// respondentID returns the respondent identifier of a response, empty for anonymous links
+func respondentID(response model.Response) string {
+	if response.RespondentID == nil {
+		return ""
+	}
+	return *response.RespondentID
+}
+
+// RespondentNumbering is the display format of per-survey respondent numbers
+type RespondentNumbering struct {
+	Prefix string // e.g. R-
+	Width  int    // minimum digits, padded with zeros
+}
+
+// Format returns the display form of a respondent number such as R-0001, empty
+// for responses without a number
+func (n RespondentNumbering) Format(number *uint) string {
+	if number == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s%0*d", n.Prefix, n.Width, *number)
+}
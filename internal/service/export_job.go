@@ -0,0 +1,267 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"path"
+	"time"
+
+	"survey-system/internal/dto/request"
+	"survey-system/internal/dto/response"
+	"survey-system/internal/model"
+	"survey-system/internal/repository"
+	"survey-system/pkg/errors"
+	"survey-system/pkg/storage"
+	"survey-system/pkg/zipcrypt"
+
+	"gorm.io/gorm"
+)
+
+// exportJobTimeout bounds how long a single background export may run
+const exportJobTimeout = 10 * time.Minute
+
+// exportStoragePrefix is the storage key prefix of export job files
+const exportStoragePrefix = "exports/"
+
+// ExportJobService defines the interface for asynchronous exports
+type ExportJobService interface {
+	CreateJob(ctx context.Context, userID, surveyID uint, req *request.CreateExportJobRequest) (*response.ExportJobResponse, error)
+	GetJob(ctx context.Context, userID, surveyID, jobID uint) (*response.ExportJobResponse, error)
+	DownloadJob(ctx context.Context, userID, surveyID, jobID uint) (storage.Object, *model.ExportJob, error)
+}
+
+// exportJobService implements ExportJobService interface
+type exportJobService struct {
+	jobRepo    repository.ExportJobRepository
+	surveyRepo repository.SurveyRepository
+	exportSvc  *ExportService
+	store      storage.Storage
+	ttl        time.Duration
+	linkTTL    time.Duration
+}
+
+// NewExportJobService creates a new export job service instance
+func NewExportJobService(
+	jobRepo repository.ExportJobRepository,
+	surveyRepo repository.SurveyRepository,
+	exportSvc *ExportService,
+	store storage.Storage,
+	ttl time.Duration,
+	linkTTL time.Duration,
+) ExportJobService {
+	return &exportJobService{
+		jobRepo:    jobRepo,
+		surveyRepo: surveyRepo,
+		exportSvc:  exportSvc,
+		store:      store,
+		ttl:        ttl,
+		linkTTL:    linkTTL,
+	}
+}
+
+// CreateJob starts an export in the background and returns the pending job
+// Setting a password implies encryption; encrypting without one generates a
+// password that is returned only in this response and never stored
+func (s *exportJobService) CreateJob(ctx context.Context, userID, surveyID uint, req *request.CreateExportJobRequest) (*response.ExportJobResponse, error) {
+	_, err := findTeamSurvey(ctx, s.exportSvc.authz, userID, surveyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Format == "" {
+		req.Format = "csv"
+	}
+
+	password := req.Password
+	generated := false
+	if req.Encrypt && password == "" {
+		password, err = generateArchivePassword()
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to generate archive password")
+		}
+		generated = true
+	}
+
+	job := &model.ExportJob{
+		SurveyID:  surveyID,
+		UserID:    userID,
+		Status:    model.ExportJobPending,
+		Format:    req.Format,
+		Encrypted: password != "",
+		ExpiresAt: time.Now().Add(s.ttl),
+	}
+	if err := s.jobRepo.Create(ctx, job); err != nil {
+		return nil, errors.WrapError(err, "failed to create export job")
+	}
+
+	go s.run(job.ID, userID, surveyID, req.ExportResponsesRequest, password)
+
+	result := response.ToExportJobResponse(job)
+	if generated {
+		result.Password = password
+	}
+	return result, nil
+}
+
+// run generates the export of a job and stores the file
+func (s *exportJobService) run(jobID, userID, surveyID uint, req request.ExportResponsesRequest, password string) {
+	ctx, cancel := context.WithTimeout(context.Background(), exportJobTimeout)
+	defer cancel()
+
+	if err := s.jobRepo.MarkRunning(ctx, jobID); err != nil {
+		log.Printf("export jobs: failed to start job %d: %v", jobID, err)
+	}
+
+	data, filename, err := s.exportSvc.ExportResponses(ctx, userID, surveyID, &req)
+	if err != nil {
+		s.fail(ctx, jobID, err)
+		return
+	}
+
+	contentType := exportContentType(req.Format)
+	if password != "" {
+		data, err = zipcrypt.Encrypt(filename, data, password, time.Now())
+		if err != nil {
+			s.fail(ctx, jobID, err)
+			return
+		}
+		filename += ".zip"
+		contentType = "application/zip"
+	}
+
+	key, err := exportStorageKey(jobID, filename)
+	if err != nil {
+		s.fail(ctx, jobID, err)
+		return
+	}
+	if err := s.store.Put(ctx, key, data, contentType); err != nil {
+		s.fail(ctx, jobID, err)
+		return
+	}
+
+	completedAt := time.Now()
+	job := &model.ExportJob{
+		ID:          jobID,
+		Filename:    filename,
+		ContentType: contentType,
+		StorageKey:  key,
+		Size:        int64(len(data)),
+		CompletedAt: &completedAt,
+	}
+	if err := s.jobRepo.Complete(ctx, job); err != nil {
+		log.Printf("export jobs: failed to store result of job %d: %v", jobID, err)
+		s.fail(ctx, jobID, err)
+		// The cleaner only finds files through their job, so remove the orphan now
+		if err := s.store.Delete(context.WithoutCancel(ctx), key); err != nil {
+			log.Printf("export jobs: failed to delete file of job %d: %v", jobID, err)
+		}
+	}
+}
+
+// fail records why a job failed
+func (s *exportJobService) fail(ctx context.Context, jobID uint, cause error) {
+	log.Printf("export jobs: job %d failed: %v", jobID, cause)
+
+	message := "export failed"
+	if appErr, ok := cause.(*errors.AppError); ok {
+		message = appErr.Code
+	}
+	if err := s.jobRepo.Fail(context.WithoutCancel(ctx), jobID, message); err != nil {
+		log.Printf("export jobs: failed to record failure of job %d: %v", jobID, err)
+	}
+}
+
+// GetJob returns the state of an export job
+// Completed jobs include a pre-signed download URL valid for the configured
+// period, or until the job expires if that comes first
+func (s *exportJobService) GetJob(ctx context.Context, userID, surveyID, jobID uint) (*response.ExportJobResponse, error) {
+	job, err := s.findJob(ctx, userID, surveyID, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := response.ToExportJobResponse(job)
+	if job.Status == model.ExportJobCompleted && job.StorageKey != "" {
+		expires := s.linkTTL
+		if remaining := time.Until(job.ExpiresAt); remaining < expires {
+			expires = remaining
+		}
+		link, err := s.store.PresignGet(ctx, job.StorageKey, job.Filename, expires)
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to sign download url")
+		}
+		result.DownloadURL = link
+	}
+	return result, nil
+}
+
+// DownloadJob opens the file of a completed export job; the caller must close it
+func (s *exportJobService) DownloadJob(ctx context.Context, userID, surveyID, jobID uint) (storage.Object, *model.ExportJob, error) {
+	job, err := s.findJob(ctx, userID, surveyID, jobID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if job.Status != model.ExportJobCompleted {
+		return nil, nil, errors.ErrExportNotReady
+	}
+
+	file, err := s.store.Open(ctx, job.StorageKey)
+	if err != nil {
+		if err == storage.ErrNotFound {
+			return nil, nil, errors.ErrNotFound
+		}
+		return nil, nil, errors.WrapError(err, "failed to load export file")
+	}
+	return file, job, nil
+}
+
+// findJob loads an unexpired export job of a survey the user owns
+func (s *exportJobService) findJob(ctx context.Context, userID, surveyID, jobID uint) (*model.ExportJob, error) {
+	job, err := s.jobRepo.FindByID(ctx, jobID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.WrapError(err, "failed to find export job")
+	}
+
+	if job.SurveyID != surveyID || time.Now().After(job.ExpiresAt) {
+		return nil, errors.ErrNotFound
+	}
+	if job.UserID != userID {
+		return nil, errors.ErrForbidden
+	}
+	return job, nil
+}
+
+// exportContentType returns the MIME type of an export format
+func exportContentType(format string) string {
+	if format == "excel" {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// exportStorageKey returns a storage key for the file of a job
+// The random part keeps keys unguessable even though job IDs are sequential
+func exportStorageKey(jobID uint, filename string) (string, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s%d-%s%s", exportStoragePrefix, jobID, hex.EncodeToString(buf), path.Ext(filename)), nil
+}
+
+// generateArchivePassword returns a random URL-safe password with 128 bits of entropy
+func generateArchivePassword() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
@@ -0,0 +1,269 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"survey-system/internal/cache"
+	"survey-system/internal/dto/request"
+	"survey-system/internal/dto/response"
+	"survey-system/internal/model"
+	"survey-system/pkg/errors"
+)
+
+// Feature flags gating risky features
+const (
+	FeatureAsyncExports    = "async_exports"
+	FeatureNPSQuestions    = "nps_questions"
+	FeatureSliderQuestions = "slider_questions"
+	FeatureCascadeColumns  = "cascade_columns"
+)
+
+// featureFlags lists the known flags in the order they are reported.
+// Flags missing from the configuration are on, as the features shipped before the flags.
+var featureFlags = []string{FeatureAsyncExports, FeatureNPSQuestions, FeatureSliderQuestions, FeatureCascadeColumns}
+
+// featureUserSeparator separates the flag from the user ID in the field of a user override
+const featureUserSeparator = ":user:"
+
+// FeatureFlag is the configured state of a feature flag
+type FeatureFlag struct {
+	Enabled      bool     // On for everyone
+	Environments []string // On for everyone in these environments
+	Users        []uint   // On for these users when off for everyone
+}
+
+// FeatureSettings are the feature flags configured for a deployment
+type FeatureSettings struct {
+	Environment string                 // Name of this deployment, matched against each flag's environments
+	Flags       map[string]FeatureFlag // Configured flags by name
+}
+
+// FeatureService defines the interface for feature flags
+type FeatureService interface {
+	Enabled(ctx context.Context, flag string, userID uint) bool
+	RequireFeature(ctx context.Context, flag string, userID uint) error
+	UserFeatures(ctx context.Context, userID uint) map[string]bool
+	ListFeatures(ctx context.Context) ([]response.FeatureFlagResponse, error)
+	SetFeature(ctx context.Context, name string, req *request.SetFeatureRequest) (*response.FeatureFlagResponse, error)
+	ClearFeature(ctx context.Context, name string, userID uint) (*response.FeatureFlagResponse, error)
+}
+
+// featureService implements FeatureService interface
+type featureService struct {
+	cache    cache.Cache
+	defaults map[string]FeatureFlag // Configured state of every known flag in this environment
+}
+
+// NewFeatureService creates a new feature service instance
+// Returns an error when the settings configure a flag that does not exist
+func NewFeatureService(cache cache.Cache, settings FeatureSettings) (FeatureService, error) {
+	defaults := make(map[string]FeatureFlag, len(featureFlags))
+	for _, name := range featureFlags {
+		defaults[name] = FeatureFlag{Enabled: true}
+	}
+
+	for name, flag := range settings.Flags {
+		if _, ok := defaults[name]; !ok {
+			return nil, fmt.Errorf("unknown feature flag %q", name)
+		}
+		defaults[name] = FeatureFlag{
+			Enabled: flag.Enabled || slices.Contains(flag.Environments, settings.Environment),
+			Users:   flag.Users,
+		}
+	}
+
+	return &featureService{
+		cache:    cache,
+		defaults: defaults,
+	}, nil
+}
+
+// Enabled reports whether a flag is on for a user. A runtime override of the user wins
+// over one for everyone, which wins over the configuration. When Redis cannot be reached
+// the configuration applies.
+func (s *featureService) Enabled(ctx context.Context, flag string, userID uint) bool {
+	return s.enabled(flag, userID, s.overrides(ctx))
+}
+
+// RequireFeature returns a FEATURE_DISABLED error when a flag is off for a user
+func (s *featureService) RequireFeature(ctx context.Context, flag string, userID uint) error {
+	if !s.Enabled(ctx, flag, userID) {
+		return errors.NewLocalizedError("FEATURE_DISABLED", 403, "feature.disabled", flag)
+	}
+	return nil
+}
+
+// UserFeatures returns the state of every flag for a user, for clients hiding features
+func (s *featureService) UserFeatures(ctx context.Context, userID uint) map[string]bool {
+	overrides := s.overrides(ctx)
+
+	features := make(map[string]bool, len(featureFlags))
+	for _, name := range featureFlags {
+		features[name] = s.enabled(name, userID, overrides)
+	}
+	return features
+}
+
+// ListFeatures returns the configuration and runtime overrides of every flag
+func (s *featureService) ListFeatures(ctx context.Context) ([]response.FeatureFlagResponse, error) {
+	overrides, err := s.cache.GetFeatureOverrides(ctx)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to get feature overrides")
+	}
+
+	result := make([]response.FeatureFlagResponse, len(featureFlags))
+	for i, name := range featureFlags {
+		result[i] = s.featureResponse(name, overrides)
+	}
+	return result, nil
+}
+
+// SetFeature overrides a flag at runtime, for everyone or for one user
+func (s *featureService) SetFeature(ctx context.Context, name string, req *request.SetFeatureRequest) (*response.FeatureFlagResponse, error) {
+	if _, ok := s.defaults[name]; !ok {
+		return nil, errors.ErrNotFound
+	}
+
+	if err := s.cache.SetFeatureOverride(ctx, featureField(name, req.UserID), *req.Enabled); err != nil {
+		return nil, errors.WrapError(err, "failed to set feature override")
+	}
+	return s.reportFeature(ctx, name)
+}
+
+// ClearFeature removes a runtime override of a flag, for everyone or for one user,
+// so the configuration applies again
+func (s *featureService) ClearFeature(ctx context.Context, name string, userID uint) (*response.FeatureFlagResponse, error) {
+	if _, ok := s.defaults[name]; !ok {
+		return nil, errors.ErrNotFound
+	}
+
+	if err := s.cache.DeleteFeatureOverride(ctx, featureField(name, userID)); err != nil {
+		return nil, errors.WrapError(err, "failed to delete feature override")
+	}
+	return s.reportFeature(ctx, name)
+}
+
+// overrides returns the runtime overrides, or none when Redis cannot be reached
+func (s *featureService) overrides(ctx context.Context) map[string]bool {
+	overrides, err := s.cache.GetFeatureOverrides(ctx)
+	if err != nil {
+		fmt.Printf("failed to get feature overrides, using configured flags: %v\n", err)
+		return nil
+	}
+	return overrides
+}
+
+// enabled resolves the state of a flag for a user; unknown flags are off
+func (s *featureService) enabled(flag string, userID uint, overrides map[string]bool) bool {
+	if userID != 0 {
+		if enabled, ok := overrides[featureField(flag, userID)]; ok {
+			return enabled
+		}
+	}
+	if enabled, ok := overrides[flag]; ok {
+		return enabled
+	}
+
+	configured, ok := s.defaults[flag]
+	if !ok {
+		return false
+	}
+	return configured.Enabled || (userID != 0 && slices.Contains(configured.Users, userID))
+}
+
+// reportFeature returns the current state of a flag after a change
+func (s *featureService) reportFeature(ctx context.Context, name string) (*response.FeatureFlagResponse, error) {
+	overrides, err := s.cache.GetFeatureOverrides(ctx)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to get feature overrides")
+	}
+
+	result := s.featureResponse(name, overrides)
+	return &result, nil
+}
+
+// featureResponse reports a flag with its overrides
+func (s *featureService) featureResponse(name string, overrides map[string]bool) response.FeatureFlagResponse {
+	configured := s.defaults[name]
+	result := response.FeatureFlagResponse{
+		Name:            name,
+		Enabled:         s.enabled(name, 0, overrides),
+		Configured:      configured.Enabled,
+		ConfiguredUsers: configured.Users,
+		UserOverrides:   []response.FeatureUserOverride{},
+	}
+	if result.ConfiguredUsers == nil {
+		result.ConfiguredUsers = []uint{}
+	}
+	if enabled, ok := overrides[name]; ok {
+		result.Override = &enabled
+	}
+
+	prefix := name + featureUserSeparator
+	for field, enabled := range overrides {
+		id, ok := strings.CutPrefix(field, prefix)
+		if !ok {
+			continue
+		}
+		userID, err := strconv.ParseUint(id, 10, 32)
+		if err != nil {
+			continue
+		}
+		result.UserOverrides = append(result.UserOverrides, response.FeatureUserOverride{UserID: uint(userID), Enabled: enabled})
+	}
+	sort.Slice(result.UserOverrides, func(i, j int) bool {
+		return result.UserOverrides[i].UserID < result.UserOverrides[j].UserID
+	})
+	return result
+}
+
+// featureField returns the override field of a flag for everyone, or for a user when set
+func featureField(flag string, userID uint) string {
+	if userID == 0 {
+		return flag
+	}
+	return flag + featureUserSeparator + strconv.FormatUint(uint64(userID), 10)
+}
+
+// questionFeatures returns the feature flags a question of the type and configuration needs
+func questionFeatures(questionType string, config *model.QuestionConfig) []string {
+	var flags []string
+	switch questionType {
+	case model.QuestionTypeNPS:
+		flags = append(flags, FeatureNPSQuestions)
+	case model.QuestionTypeSlider:
+		flags = append(flags, FeatureSliderQuestions)
+	case model.QuestionTypeTable:
+		for _, column := range config.Columns {
+			if column.Type == "cascade" {
+				flags = append(flags, FeatureCascadeColumns)
+				break
+			}
+		}
+	}
+	return flags
+}
+
+// requireQuestionFeatures rejects a question needing a flag that is off for the user.
+// Flags the question already needed before the change are not checked again, so
+// existing questions stay editable after their feature is turned off.
+func (s *questionService) requireQuestionFeatures(ctx context.Context, userID uint, before *model.Question, questionType string, config *model.QuestionConfig) error {
+	var had []string
+	if before != nil {
+		had = questionFeatures(before.Type, &before.Config)
+	}
+	for _, flag := range questionFeatures(questionType, config) {
+		if slices.Contains(had, flag) {
+			continue
+		}
+		if err := s.features.RequireFeature(ctx, flag, userID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"time"
+
+	"survey-system/internal/dto/request"
+	"survey-system/internal/dto/response"
+	"survey-system/internal/model"
+)
+
+// honeypotFields are the field names and labels a honeypot is chosen from. They look
+// like ordinary contact fields, which naive bots fill in and people never see
+var honeypotFields = []response.HoneypotField{
+	{Name: "website", Label: "个人网站"},
+	{Name: "homepage", Label: "主页地址"},
+	{Name: "company_url", Label: "公司网址"},
+	{Name: "fax", Label: "传真号码"},
+	{Name: "nickname", Label: "昵称"},
+}
+
+// honeypotField returns the honeypot field shown with a survey opened through a link,
+// or nil when the survey has no honeypot. The field is derived from the token, so
+// each link shows a different but stable field
+func honeypotField(token string, survey *model.Survey) *response.HoneypotField {
+	if survey.HoneypotAction == "" {
+		return nil
+	}
+
+	sum := sha256.Sum256([]byte("honeypot|" + token))
+	field := honeypotFields[int(sum[0])%len(honeypotFields)]
+	return &field
+}
+
+// honeypotCaught reports whether a submission filled in the survey's honeypot and
+// counts it in the survey's statistics; submissions through test links are not counted
+func (s *ResponseService) honeypotCaught(ctx context.Context, survey *model.Survey, oneLink *model.OneLink, req *request.SubmitResponseRequest) bool {
+	if survey.HoneypotAction == "" || req.Honeypot == "" {
+		return false
+	}
+
+	if !oneLink.IsTest {
+		if err := s.surveyRepo.IncrementHoneypotCatches(context.WithoutCancel(ctx), survey.ID); err != nil {
+			fmt.Printf("failed to count honeypot catch: %v\n", err)
+		}
+	}
+	return true
+}
+
+// discardSubmission drops a submission caught by the honeypot. The link is used up and
+// the submitter gets the usual success result, so a bot cannot tell it was caught
+func (s *ResponseService) discardSubmission(ctx context.Context, tokenData *TokenData, token string, oneLink *model.OneLink) *response.SubmitResponseResponse {
+	ctx = context.WithoutCancel(ctx)
+
+	if err := s.oneLinkRepo.MarkAsUsed(ctx, oneLink.ID); err != nil {
+		fmt.Printf("failed to mark link as used: %v\n", err)
+	}
+	s.cache.SetOneLinkStatus(ctx, token, true, time.Until(time.Unix(tokenData.ExpiresAt, 0)))
+
+	return &response.SubmitResponseResponse{
+		SurveyID:    oneLink.SurveyID,
+		Version:     1,
+		SubmittedAt: time.Now(),
+		Message:     "提交成功",
+		RedirectURL: oneLink.RedirectURL,
+	}
+}
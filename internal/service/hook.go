@@ -0,0 +1,408 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+	"unicode/utf8"
+
+	"survey-system/internal/dto/request"
+	"survey-system/internal/dto/response"
+	"survey-system/internal/model"
+	"survey-system/internal/repository"
+	"survey-system/pkg/errors"
+	"survey-system/pkg/netguard"
+	"survey-system/pkg/webhook"
+
+	"gorm.io/gorm"
+)
+
+// hookNotifyTimeout bounds the hook deliveries sent after a single submission
+const hookNotifyTimeout = 30 * time.Second
+
+// hookSampleSize is the number of recent responses returned as sample payloads
+const hookSampleSize = 3
+
+// HookService defines the interface for REST Hooks subscriptions used by no-code tools
+// such as Zapier and Make
+type HookService interface {
+	ListHooks(ctx context.Context, userID uint) ([]response.HookResponse, error)
+	Subscribe(ctx context.Context, userID uint, req *request.SubscribeHookRequest) (*response.HookResponse, error)
+	Unsubscribe(ctx context.Context, userID, hookID uint) error
+	SamplePayloads(ctx context.Context, userID uint, query *request.HookSampleQuery) ([]response.ResponseSubmittedPayload, error)
+	ListDeliveries(ctx context.Context, userID, hookID uint, query *request.HookDeliveryQuery) (*response.PaginatedHookDeliveryResponse, error)
+	RetryDelivery(ctx context.Context, userID, deliveryID uint) (*response.HookDeliveryResponse, error)
+	ResponseNotifier
+}
+
+// hookService implements HookService interface
+type hookService struct {
+	hookRepo     repository.HookRepository
+	surveyRepo   repository.SurveyRepository
+	questionRepo repository.QuestionRepository
+	responseRepo repository.ResponseRepository
+	sender       webhook.Sender
+	numbering    RespondentNumbering
+}
+
+// NewHookService creates a new hook service instance
+func NewHookService(
+	hookRepo repository.HookRepository,
+	surveyRepo repository.SurveyRepository,
+	questionRepo repository.QuestionRepository,
+	responseRepo repository.ResponseRepository,
+	sender webhook.Sender,
+	numbering RespondentNumbering,
+) HookService {
+	return &hookService{
+		hookRepo:     hookRepo,
+		surveyRepo:   surveyRepo,
+		questionRepo: questionRepo,
+		responseRepo: responseRepo,
+		sender:       sender,
+		numbering:    numbering,
+	}
+}
+
+// ListHooks returns the subscriptions created by the user
+func (s *hookService) ListHooks(ctx context.Context, userID uint) ([]response.HookResponse, error) {
+	hooks, err := s.hookRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to find hooks")
+	}
+
+	result := make([]response.HookResponse, len(hooks))
+	for i := range hooks {
+		result[i] = response.ToHookResponse(&hooks[i])
+	}
+	return result, nil
+}
+
+// Subscribe registers a target URL for an event of a survey owned by the user
+func (s *hookService) Subscribe(ctx context.Context, userID uint, req *request.SubscribeHookRequest) (*response.HookResponse, error) {
+	if _, err := s.findOwnSurvey(ctx, userID, req.SurveyID); err != nil {
+		return nil, err
+	}
+
+	if err := netguard.ValidateURL(ctx, req.TargetURL); err != nil {
+		return nil, errors.NewValidationError("target_url", "target URL must be an absolute http or https URL to a public address")
+	}
+
+	hook := &model.Hook{
+		UserID:    userID,
+		SurveyID:  req.SurveyID,
+		Event:     req.Event,
+		TargetURL: req.TargetURL,
+	}
+	if err := s.hookRepo.Create(ctx, hook); err != nil {
+		return nil, errors.WrapError(err, "failed to create hook")
+	}
+
+	result := response.ToHookResponse(hook)
+	return &result, nil
+}
+
+// Unsubscribe removes a subscription created by the user
+func (s *hookService) Unsubscribe(ctx context.Context, userID, hookID uint) error {
+	hook, err := s.hookRepo.FindByID(ctx, hookID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return errors.ErrNotFound
+		}
+		return errors.WrapError(err, "failed to find hook")
+	}
+
+	if hook.UserID != userID {
+		return errors.ErrNotFound
+	}
+
+	if err := s.hookRepo.Delete(ctx, hook.ID); err != nil {
+		return errors.WrapError(err, "failed to delete hook")
+	}
+	return nil
+}
+
+// SamplePayloads returns payloads as they would be delivered for an event, built from
+// the survey's latest responses, or from its questions when it has none yet, so no-code
+// tools can show the available fields while a zap or scenario is set up
+func (s *hookService) SamplePayloads(ctx context.Context, userID uint, query *request.HookSampleQuery) ([]response.ResponseSubmittedPayload, error) {
+	survey, err := s.findOwnSurvey(ctx, userID, query.SurveyID)
+	if err != nil {
+		return nil, err
+	}
+
+	questions, err := s.questionRepo.FindBySurveyID(ctx, survey.ID)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to find questions")
+	}
+
+	responses, _, err := s.responseRepo.FindBySurveyID(ctx, survey.ID, repository.ResponseFilter{}, 1, hookSampleSize)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to find responses")
+	}
+
+	if len(responses) == 0 {
+		return []response.ResponseSubmittedPayload{s.examplePayload(survey, questions)}, nil
+	}
+
+	result := make([]response.ResponseSubmittedPayload, len(responses))
+	for i := range responses {
+		result[i] = s.payload(survey, questions, &responses[i])
+	}
+	return result, nil
+}
+
+// NotifyResponse delivers a submitted response to the survey's response.submitted hooks
+// Every attempt is recorded as a delivery. Subscriptions whose endpoint answers 410 Gone
+// are removed; other failures are not retried automatically but can be retried by hand
+func (s *hookService) NotifyResponse(ctx context.Context, survey *model.Survey, resp *model.Response) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), hookNotifyTimeout)
+	defer cancel()
+
+	hooks, err := s.hookRepo.FindBySurveyAndEvent(ctx, survey.ID, model.HookEventResponseSubmitted)
+	if err != nil {
+		log.Printf("hook notifier: failed to find hooks of survey %d: %v", survey.ID, err)
+		return
+	}
+	if len(hooks) == 0 {
+		return
+	}
+
+	questions, err := s.questionRepo.FindBySurveyID(ctx, survey.ID)
+	if err != nil {
+		log.Printf("hook notifier: failed to find questions of survey %d: %v", survey.ID, err)
+		return
+	}
+	body, err := json.Marshal(s.payload(survey, questions, resp))
+	if err != nil {
+		log.Printf("hook notifier: failed to marshal payload of response %d: %v", resp.ID, err)
+		return
+	}
+
+	for i := range hooks {
+		if delivery := s.deliver(ctx, &hooks[i], string(body), &resp.ID, nil); !delivery.Succeeded {
+			log.Printf("hook notifier: delivery to hook %d failed: %s", hooks[i].ID, delivery.Error)
+		}
+	}
+}
+
+// ListDeliveries lists the delivery attempts of a hook created by the user, newest first
+func (s *hookService) ListDeliveries(ctx context.Context, userID, hookID uint, query *request.HookDeliveryQuery) (*response.PaginatedHookDeliveryResponse, error) {
+	hook, err := s.hookRepo.FindByID(ctx, hookID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.WrapError(err, "failed to find hook")
+	}
+	if hook.UserID != userID {
+		return nil, errors.ErrNotFound
+	}
+
+	page, pageSize := query.Page, query.PageSize
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 20
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	deliveries, total, err := s.hookRepo.FindDeliveries(ctx, hook.ID, query.Failed, page, pageSize)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to list hook deliveries")
+	}
+
+	data := make([]response.HookDeliveryResponse, len(deliveries))
+	for i := range deliveries {
+		data[i] = response.ToHookDeliveryResponse(&deliveries[i])
+	}
+
+	totalPage := int(total) / pageSize
+	if int(total)%pageSize > 0 {
+		totalPage++
+	}
+
+	return &response.PaginatedHookDeliveryResponse{
+		Data: data,
+		Meta: response.PaginationMeta{
+			Page:      page,
+			PageSize:  pageSize,
+			Total:     total,
+			TotalPage: totalPage,
+		},
+	}, nil
+}
+
+// RetryDelivery sends the payload of a failed delivery to its hook again and records the
+// attempt as a retry of the original delivery. An event is delivered successfully at
+// most once: deliveries that succeeded, or whose retry did, cannot be retried
+func (s *hookService) RetryDelivery(ctx context.Context, userID, deliveryID uint) (*response.HookDeliveryResponse, error) {
+	delivery, err := s.hookRepo.FindDeliveryByID(ctx, deliveryID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.WrapError(err, "failed to find hook delivery")
+	}
+	if delivery.Hook.UserID != userID {
+		return nil, errors.ErrNotFound
+	}
+
+	if delivery.Succeeded {
+		return nil, errors.ErrAlreadyDelivered
+	}
+
+	// Retries of a retry belong to the original delivery
+	original := delivery.ID
+	if delivery.RetryOf != nil {
+		original = *delivery.RetryOf
+	}
+	delivered, err := s.hookRepo.HasSucceededRetry(ctx, original)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to find hook deliveries")
+	}
+	if delivered {
+		return nil, errors.ErrAlreadyDelivered
+	}
+
+	retry := s.deliver(ctx, &delivery.Hook, delivery.Payload, delivery.ResponseID, &original)
+	result := response.ToHookDeliveryResponse(retry)
+	return &result, nil
+}
+
+// deliver sends a payload built from a response to a hook and records the attempt. A
+// hook whose endpoint answers 410 Gone is removed together with its deliveries
+func (s *hookService) deliver(ctx context.Context, hook *model.Hook, payload string, responseID, retryOf *uint) *model.HookDelivery {
+	started := time.Now()
+	status, err := s.sender.Deliver(ctx, hook.TargetURL, hook.Event, json.RawMessage(payload))
+
+	delivery := &model.HookDelivery{
+		HookID:     hook.ID,
+		Event:      hook.Event,
+		Payload:    payload,
+		StatusCode: status,
+		Succeeded:  err == nil,
+		RetryOf:    retryOf,
+		ResponseID: responseID,
+		DurationMs: time.Since(started).Milliseconds(),
+	}
+	if err != nil {
+		delivery.Error = truncateRunes(err.Error(), 500)
+	}
+
+	if err == webhook.ErrGone {
+		if err := s.hookRepo.Delete(ctx, hook.ID); err != nil {
+			log.Printf("hook notifier: failed to remove gone hook %d: %v", hook.ID, err)
+		}
+		return delivery
+	}
+	if err := s.hookRepo.CreateDelivery(context.WithoutCancel(ctx), delivery); err != nil {
+		log.Printf("hook notifier: failed to record delivery to hook %d: %v", hook.ID, err)
+	}
+	return delivery
+}
+
+// truncateRunes shortens a string to at most n runes
+func truncateRunes(value string, n int) string {
+	if utf8.RuneCountInString(value) <= n {
+		return value
+	}
+	return string([]rune(value)[:n])
+}
+
+// payload builds the response.submitted payload of a response
+func (s *hookService) payload(survey *model.Survey, questions []model.Question, resp *model.Response) response.ResponseSubmittedPayload {
+	values := make(map[uint]interface{}, len(resp.Data.Answers))
+	for _, answer := range resp.Data.Answers {
+		values[answer.QuestionID] = answer.Value
+	}
+
+	answers := make([]response.HookAnswer, 0, len(questions))
+	for _, question := range questions {
+		value, ok := values[question.ID]
+		if !ok {
+			continue
+		}
+		answers = append(answers, response.HookAnswer{
+			QuestionID: question.ID,
+			Question:   question.Title,
+			Value:      optionLabels(question, value),
+		})
+	}
+
+	return response.ResponseSubmittedPayload{
+		Event:            model.HookEventResponseSubmitted,
+		SurveyID:         survey.ID,
+		SurveyTitle:      survey.Title,
+		ResponseID:       resp.ID,
+		RespondentNumber: s.numbering.Format(resp.RespondentNumber),
+		RespondentID:     respondentID(*resp),
+		Campaign:         resp.Campaign,
+		Variant:          resp.Variant,
+		SubmittedAt:      resp.SubmittedAt,
+		Answers:          answers,
+	}
+}
+
+// examplePayload builds a payload with placeholder answers to every question
+func (s *hookService) examplePayload(survey *model.Survey, questions []model.Question) response.ResponseSubmittedPayload {
+	number := uint(1)
+	resp := &model.Response{
+		SurveyID:         survey.ID,
+		RespondentNumber: &number,
+		SubmittedAt:      time.Now(),
+	}
+	for _, question := range questions {
+		resp.Data.Answers = append(resp.Data.Answers, model.Answer{
+			QuestionID: question.ID,
+			Value:      exampleAnswer(question),
+		})
+	}
+	return s.payload(survey, questions, resp)
+}
+
+// exampleAnswer returns a placeholder answer in the shape a question type submits
+func exampleAnswer(question model.Question) interface{} {
+	options := question.Config.Options
+	switch question.Type {
+	case model.QuestionTypeSingle:
+		if len(options) > 0 {
+			return options[0].ID
+		}
+	case model.QuestionTypeMultiple:
+		if len(options) > 0 {
+			return []interface{}{options[0].ID}
+		}
+		return []interface{}{}
+	case model.QuestionTypeNPS:
+		return float64(10)
+	case model.QuestionTypeSlider:
+		if question.Config.Min != nil {
+			return *question.Config.Min
+		}
+		return float64(0)
+	case model.QuestionTypeTable:
+		return []interface{}{}
+	}
+	return "示例答案"
+}
+
+// findOwnSurvey loads a survey owned by the user
+func (s *hookService) findOwnSurvey(ctx context.Context, userID, surveyID uint) (*model.Survey, error) {
+	survey, err := s.surveyRepo.FindByID(ctx, surveyID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.WrapError(err, "failed to find survey")
+	}
+
+	if survey.UserID != userID {
+		return nil, errors.ErrForbidden
+	}
+	return survey, nil
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"survey-system/internal/repository"
+)
+
+// HookDeliveryCleaner periodically deletes REST hook delivery attempts older than the
+// retention period. Each attempt keeps the payload it delivered, which includes the
+// answers of a response, so they are not kept forever
+type HookDeliveryCleaner struct {
+	hookRepo  repository.HookRepository
+	cache     Cache
+	interval  time.Duration
+	retention time.Duration
+	batchSize int
+}
+
+// NewHookDeliveryCleaner creates a new HookDeliveryCleaner
+func NewHookDeliveryCleaner(
+	hookRepo repository.HookRepository,
+	cache Cache,
+	interval time.Duration,
+	retention time.Duration,
+	batchSize int,
+) *HookDeliveryCleaner {
+	return &HookDeliveryCleaner{
+		hookRepo:  hookRepo,
+		cache:     cache,
+		interval:  interval,
+		retention: retention,
+		batchSize: batchSize,
+	}
+}
+
+// Run deletes old deliveries every interval until ctx is cancelled
+// A non-positive retention or interval disables the cleaner
+func (c *HookDeliveryCleaner) Run(ctx context.Context) {
+	if c.retention <= 0 || c.interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.Clean(ctx); err != nil {
+			log.Printf("hook delivery cleaner: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Clean deletes the delivery attempts created before the retention period, batchSize
+// at a time so no single statement holds locks for long
+func (c *HookDeliveryCleaner) Clean(ctx context.Context) error {
+	// Only one instance should clean at a time
+	lockKey := "hooks:deliveries:cleaner"
+	acquired, err := c.cache.AcquireLock(ctx, lockKey, c.interval)
+	if err != nil {
+		return fmt.Errorf("failed to acquire lock: %w", err)
+	}
+	if !acquired {
+		return nil
+	}
+	defer c.cache.ReleaseLock(ctx, lockKey)
+
+	before := time.Now().Add(-c.retention)
+	var deleted int64
+	for {
+		n, err := c.hookRepo.DeleteDeliveriesBefore(ctx, before, c.batchSize)
+		deleted += n
+		if err != nil {
+			return fmt.Errorf("failed to delete deliveries after deleting %d: %w", deleted, err)
+		}
+		if n < int64(c.batchSize) {
+			break
+		}
+	}
+
+	if deleted > 0 {
+		log.Printf("hook delivery cleaner: deleted %d deliveries created before %s", deleted, before.Format(time.RFC3339))
+	}
+	return nil
+}
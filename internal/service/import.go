@@ -0,0 +1,612 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"survey-system/internal/dto/request"
+	"survey-system/internal/dto/response"
+	"survey-system/internal/model"
+	"survey-system/pkg/errors"
+
+	"github.com/xuri/excelize/v2"
+	"gorm.io/gorm"
+)
+
+// importMaxErrors caps how many row errors an import reports
+const importMaxErrors = 100
+
+// ImportOptions limits response imports. Both values must be positive.
+type ImportOptions struct {
+	MaxRows   int // data rows per file
+	BatchSize int // responses inserted per statement
+}
+
+// Fields an import column can hold
+const (
+	importFieldAnswer       = "answer"
+	importFieldSubmittedAt  = "submitted_at"
+	importFieldIPAddress    = "ip_address"
+	importFieldRespondentID = "respondent_id"
+	importFieldCampaign     = "campaign"
+)
+
+// importMetadataColumns maps the lower-cased metadata headers of the export to their fields
+var importMetadataColumns = map[string]string{
+	"submitted at":  importFieldSubmittedAt,
+	"ip address":    importFieldIPAddress,
+	"respondent id": importFieldRespondentID,
+	"campaign":      importFieldCampaign,
+}
+
+// importTimeLayouts are the accepted formats of the Submitted At column, in server local time
+var importTimeLayouts = []string{
+	"2006-01-02 15:04:05",
+	time.RFC3339,
+	"2006-01-02 15:04",
+	"2006/01/02 15:04:05",
+	"2006/01/02 15:04",
+	"2006-01-02",
+	"2006/01/02",
+}
+
+// wideTableHeader matches the "<title> - <column> #<row>" headers of the wide export layout
+var wideTableHeader = regexp.MustCompile(`^(.+) #(\d+)$`)
+
+// importColumn describes what a column of an import file holds
+type importColumn struct {
+	field    string
+	question *model.Question
+	tableRow int // 1-based table row for per-cell table columns, 0 for a JSON table column
+	tableCol int
+}
+
+// importRow is a data row of an import file with its line number
+type importRow struct {
+	line  int
+	cells []string
+}
+
+// importCandidate is a converted row, either a response to store or the reason it was rejected
+type importCandidate struct {
+	line     int
+	response *model.Response
+	column   string
+	err      *errors.AppError
+}
+
+// ImportResponses imports historical responses from a CSV or XLSX file
+// The header row is matched to questions by title, using the same column names as the
+// export, and every row is validated like a submission. Unless skipInvalid is set,
+// nothing is stored when any row has errors. Row errors are localized into lang.
+func (s *ResponseService) ImportResponses(ctx context.Context, userID, surveyID uint, filename string, data []byte, req *request.ImportResponsesRequest, lang string) (*response.ImportResponsesResult, error) {
+	survey, err := s.surveyRepo.FindByID(ctx, surveyID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.WrapError(err, "failed to find survey")
+	}
+
+	if survey.UserID != userID {
+		return nil, errors.ErrForbidden
+	}
+
+	questions, err := s.questionRepo.FindBySurveyID(ctx, surveyID)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to find questions")
+	}
+
+	header, rows, err := readImportFile(filename, data)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) > s.importOpts.MaxRows {
+		return nil, errors.NewLocalizedError("INVALID_IMPORT_FILE", 400, "import.too_many_rows", s.importOpts.MaxRows)
+	}
+
+	columns, result, err := matchImportColumns(header, questions)
+	if err != nil {
+		return nil, err
+	}
+	result.DryRun = req.DryRun
+	result.TotalRows = len(rows)
+
+	// Convert the rows, rejecting respondents that appear more than once in the file
+	candidates := make([]importCandidate, len(rows))
+	seen := make(map[string]bool)
+	var respondentIDs []string
+	for i, row := range rows {
+		candidates[i] = s.importRow(survey, questions, header, columns, row)
+		candidate := &candidates[i]
+		if candidate.err != nil || candidate.response.RespondentID == nil {
+			continue
+		}
+		respondent := *candidate.response.RespondentID
+		if seen[respondent] {
+			candidate.reject(respondentColumn(header, columns), errors.NewLocalizedError("VALIDATION_FAILED", 400, "import.duplicate_respondent", respondent))
+			continue
+		}
+		seen[respondent] = true
+		respondentIDs = append(respondentIDs, respondent)
+	}
+
+	// A known respondent may only have one response, as for submissions
+	existing, err := s.responseRepo.FindExistingRespondents(ctx, surveyID, respondentIDs)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to check previous responses")
+	}
+	if len(existing) > 0 {
+		responded := make(map[string]bool, len(existing))
+		for _, respondent := range existing {
+			responded[respondent] = true
+		}
+		for i := range candidates {
+			candidate := &candidates[i]
+			if candidate.err == nil && candidate.response.RespondentID != nil && responded[*candidate.response.RespondentID] {
+				candidate.reject(respondentColumn(header, columns), errors.NewLocalizedError("VALIDATION_FAILED", 400, "import.respondent_exists", *candidate.response.RespondentID))
+			}
+		}
+	}
+
+	valid := make([]model.Response, 0, len(candidates))
+	for _, candidate := range candidates {
+		if candidate.err == nil {
+			valid = append(valid, *candidate.response)
+			continue
+		}
+		result.InvalidRows++
+		if len(result.Errors) == importMaxErrors {
+			result.ErrorsTruncated = true
+			continue
+		}
+		result.Errors = append(result.Errors, response.ImportRowError{
+			Row:     candidate.line,
+			Column:  candidate.column,
+			Message: candidate.err.Localize(lang),
+		})
+	}
+	result.ValidRows = len(valid)
+
+	if req.DryRun || len(valid) == 0 || (result.InvalidRows > 0 && !req.SkipInvalid) {
+		return result, nil
+	}
+
+	if err := s.responseRepo.CreateBatch(ctx, valid, s.importOpts.BatchSize); err != nil {
+		// The unique index catches a respondent that responded while the file was validated
+		if err == gorm.ErrDuplicatedKey {
+			return nil, errors.ErrAlreadyResponded
+		}
+		return nil, errors.WrapError(err, "failed to save imported responses")
+	}
+	result.Imported = len(valid)
+
+	// Rebuild the cached statistics counters on the next request
+	if err := s.cache.DeleteStats(context.WithoutCancel(ctx), surveyID); err != nil {
+		fmt.Printf("failed to reset statistics counters: %v\n", err)
+	}
+
+	return result, nil
+}
+
+// reject marks a candidate as invalid
+func (c *importCandidate) reject(column string, err *errors.AppError) {
+	c.response = nil
+	c.column = column
+	c.err = err
+}
+
+// importRow converts a data row into a response, validating it like a submission
+func (s *ResponseService) importRow(survey *model.Survey, questions []model.Question, header []string, columns []importColumn, row importRow) importCandidate {
+	candidate := importCandidate{line: row.line}
+
+	resp := &model.Response{
+		SurveyID:    survey.ID,
+		SubmittedAt: time.Now(),
+	}
+	values := make(map[uint]interface{})
+	tables := make(map[uint][][]interface{})
+
+	for i, column := range columns {
+		if i >= len(row.cells) {
+			break
+		}
+		cell := strings.TrimSpace(row.cells[i])
+		if cell == "" || column.field == "" {
+			continue
+		}
+
+		switch column.field {
+		case importFieldSubmittedAt:
+			submittedAt, ok := parseImportTime(cell)
+			if !ok {
+				candidate.reject(header[i], errors.NewLocalizedError("VALIDATION_FAILED", 400, "import.invalid_submitted_at", cell))
+				return candidate
+			}
+			resp.SubmittedAt = submittedAt
+
+		case importFieldIPAddress:
+			if net.ParseIP(cell) == nil {
+				candidate.reject(header[i], errors.NewLocalizedError("VALIDATION_FAILED", 400, "import.invalid_ip", cell))
+				return candidate
+			}
+			resp.IPAddress = cell
+
+		case importFieldRespondentID:
+			if utf8.RuneCountInString(cell) > 255 {
+				candidate.reject(header[i], errors.NewLocalizedError("VALIDATION_FAILED", 400, "import.value_too_long", header[i], 255))
+				return candidate
+			}
+			respondent := cell
+			resp.RespondentID = &respondent
+
+		case importFieldCampaign:
+			if utf8.RuneCountInString(cell) > 100 {
+				candidate.reject(header[i], errors.NewLocalizedError("VALIDATION_FAILED", 400, "import.value_too_long", header[i], 100))
+				return candidate
+			}
+			resp.Campaign = cell
+
+		case importFieldAnswer:
+			question := column.question
+			if question.Type == model.QuestionTypeTable && column.tableRow > 0 {
+				tables[question.ID] = setTableCell(tables[question.ID], question, column.tableRow-1, column.tableCol, cell)
+				continue
+			}
+			values[question.ID] = importAnswerValue(question, cell)
+		}
+	}
+
+	for questionID, rows := range tables {
+		values[questionID] = nonEmptyTableRows(rows)
+	}
+	if len(values) == 0 {
+		candidate.reject("", errors.NewLocalizedError("VALIDATION_FAILED", 400, "import.no_answers"))
+		return candidate
+	}
+
+	// Keep the answers in question order
+	answers := make([]request.AnswerRequest, 0, len(values))
+	for _, question := range questions {
+		if value, ok := values[question.ID]; ok {
+			answers = append(answers, request.AnswerRequest{QuestionID: question.ID, Value: value})
+		}
+	}
+	if err := s.validateResponseData(questions, answers, nil); err != nil {
+		appErr, ok := err.(*errors.AppError)
+		if !ok {
+			appErr = errors.ErrValidationFailed
+		}
+		candidate.reject("", appErr)
+		return candidate
+	}
+
+	resp.Data.Answers = make([]model.Answer, len(answers))
+	for i, answer := range answers {
+		resp.Data.Answers[i] = model.Answer{QuestionID: answer.QuestionID, Value: answer.Value}
+	}
+	resp.Score, resp.MaxScore = scoreAnswers(survey, questions, nil, resp.Data.Answers)
+
+	// Anonymous surveys never store who responded or from where
+	if survey.Anonymous {
+		resp.IPAddress = ""
+		resp.RespondentID = nil
+	}
+
+	candidate.response = resp
+	return candidate
+}
+
+// importAnswerValue converts a cell into the answer value a submission would carry
+// Values that cannot be converted are passed on as text so validation reports them
+func importAnswerValue(question *model.Question, cell string) interface{} {
+	switch question.Type {
+	case model.QuestionTypeSingle:
+		return importOptionID(question, cell)
+
+	case model.QuestionTypeMultiple:
+		choices := []interface{}{}
+		for _, part := range strings.Split(cell, ";") {
+			if part = strings.TrimSpace(part); part != "" {
+				choices = append(choices, importOptionID(question, part))
+			}
+		}
+		return choices
+
+	case model.QuestionTypeNPS, model.QuestionTypeSlider:
+		if number, err := strconv.ParseFloat(cell, 64); err == nil {
+			return number
+		}
+		return cell
+
+	case model.QuestionTypeTable:
+		// A single column holds the rows as a JSON array of arrays
+		var rows interface{}
+		if err := json.Unmarshal([]byte(cell), &rows); err == nil {
+			return rows
+		}
+		return cell
+
+	default:
+		return cell
+	}
+}
+
+// importOptionID resolves a cell to an option by ID or, as exported, by label
+func importOptionID(question *model.Question, cell string) string {
+	if _, ok := question.Config.FindOption(cell); ok {
+		return cell
+	}
+	for _, option := range question.Config.Options {
+		if strings.EqualFold(option.Label, cell) {
+			return option.ID
+		}
+	}
+	return cell
+}
+
+// setTableCell stores a cell of a table answer, growing the rows as needed
+func setTableCell(rows [][]interface{}, question *model.Question, rowIdx, colIdx int, cell string) [][]interface{} {
+	for len(rows) <= rowIdx {
+		row := make([]interface{}, len(question.Config.Columns))
+		for i := range row {
+			row[i] = ""
+		}
+		rows = append(rows, row)
+	}
+	rows[rowIdx][colIdx] = cell
+	return rows
+}
+
+// nonEmptyTableRows drops the rows without any value, e.g. unused column groups of the wide layout
+func nonEmptyTableRows(rows [][]interface{}) []interface{} {
+	result := []interface{}{}
+	for _, row := range rows {
+		for _, cell := range row {
+			if cell != "" {
+				result = append(result, row)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// parseImportTime parses a Submitted At cell in server local time
+func parseImportTime(cell string) (time.Time, bool) {
+	for _, layout := range importTimeLayouts {
+		if t, err := time.ParseInLocation(layout, cell, time.Local); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// respondentColumn returns the header of the respondent ID column
+func respondentColumn(header []string, columns []importColumn) string {
+	for i, column := range columns {
+		if column.field == importFieldRespondentID {
+			return header[i]
+		}
+	}
+	return ""
+}
+
+// matchImportColumns matches the header row to questions and metadata fields
+// Answer columns use the question title as in the export; table questions take
+// either one JSON column or one column per cell ("<title> - <column>", optionally
+// followed by " #<row>" as in the wide layout)
+func matchImportColumns(header []string, questions []model.Question) ([]importColumn, *response.ImportResponsesResult, error) {
+	result := &response.ImportResponsesResult{
+		Columns:        []response.ImportColumn{},
+		IgnoredColumns: []string{},
+		Errors:         []response.ImportRowError{},
+	}
+
+	// Titles shared by several questions cannot be matched
+	byTitle := make(map[string]*model.Question)
+	ambiguous := make(map[string]bool)
+	for i := range questions {
+		title := strings.ToLower(strings.TrimSpace(questions[i].Title))
+		if _, exists := byTitle[title]; exists {
+			ambiguous[title] = true
+		}
+		byTitle[title] = &questions[i]
+	}
+	lookup := func(title string) (*model.Question, error) {
+		key := strings.ToLower(strings.TrimSpace(title))
+		if ambiguous[key] {
+			return nil, errors.NewLocalizedError("INVALID_IMPORT_FILE", 400, "import.ambiguous_column", strings.TrimSpace(title))
+		}
+		return byTitle[key], nil
+	}
+
+	columns := make([]importColumn, len(header))
+	targets := make(map[string]bool)
+	answerColumns := 0
+	for i, name := range header {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		column, err := matchImportColumn(name, lookup)
+		if err != nil {
+			return nil, nil, err
+		}
+		if column.field == "" {
+			result.IgnoredColumns = append(result.IgnoredColumns, name)
+			continue
+		}
+
+		target := column.field
+		if column.question != nil {
+			target = fmt.Sprintf("%d/%d/%d", column.question.ID, column.tableRow, column.tableCol)
+		}
+		if targets[target] {
+			return nil, nil, errors.NewLocalizedError("INVALID_IMPORT_FILE", 400, "import.duplicate_column", name)
+		}
+		targets[target] = true
+
+		columns[i] = column
+		matched := response.ImportColumn{Column: name, Field: column.field, TableRow: column.tableRow}
+		if column.question != nil {
+			matched.QuestionID = column.question.ID
+			answerColumns++
+		}
+		result.Columns = append(result.Columns, matched)
+	}
+
+	if answerColumns == 0 {
+		return nil, nil, errors.NewLocalizedError("INVALID_IMPORT_FILE", 400, "import.no_question_columns")
+	}
+	return columns, result, nil
+}
+
+// matchImportColumn matches a single header; an empty field means the column is ignored
+func matchImportColumn(name string, lookup func(string) (*model.Question, error)) (importColumn, error) {
+	if field, ok := importMetadataColumns[strings.ToLower(name)]; ok {
+		return importColumn{field: field}, nil
+	}
+
+	question, err := lookup(name)
+	if err != nil || question != nil {
+		return importColumn{field: importFieldAnswer, question: question}, err
+	}
+
+	// Per-cell table columns, with the row number of the wide layout when present
+	title, tableRow := name, 1
+	if match := wideTableHeader.FindStringSubmatch(name); match != nil {
+		if row, err := strconv.Atoi(match[2]); err == nil && row > 0 {
+			title, tableRow = match[1], row
+		}
+	}
+	sep := strings.LastIndex(title, " - ")
+	if sep < 0 {
+		return importColumn{}, nil
+	}
+	question, err = lookup(title[:sep])
+	if err != nil || question == nil || question.Type != model.QuestionTypeTable {
+		// The derived NPS category column is ignored as well
+		return importColumn{}, err
+	}
+
+	label := strings.TrimSpace(title[sep+3:])
+	for colIdx, col := range question.Config.Columns {
+		if strings.EqualFold(col.Label, label) {
+			return importColumn{field: importFieldAnswer, question: question, tableRow: tableRow, tableCol: colIdx}, nil
+		}
+	}
+	return importColumn{}, nil
+}
+
+// readImportFile reads the header and the non-empty data rows of a CSV or XLSX file
+func readImportFile(filename string, data []byte) ([]string, []importRow, error) {
+	var records []importRow
+	var err error
+	switch strings.ToLower(path.Ext(filename)) {
+	case ".csv":
+		records, err = readImportCSV(data)
+	case ".xlsx":
+		records, err = readImportExcel(data)
+	default:
+		return nil, nil, errors.NewLocalizedError("INVALID_IMPORT_FILE", 400, "import.unsupported_file")
+	}
+	if err != nil {
+		return nil, nil, errors.NewLocalizedError("INVALID_IMPORT_FILE", 400, "import.unreadable_file", err.Error())
+	}
+	if len(records) == 0 {
+		return nil, nil, errors.NewLocalizedError("INVALID_IMPORT_FILE", 400, "import.empty_file")
+	}
+
+	rows := make([]importRow, 0, len(records)-1)
+	for _, record := range records[1:] {
+		if !emptyImportRow(record.cells) {
+			rows = append(rows, record)
+		}
+	}
+	return records[0].cells, rows, nil
+}
+
+// readImportCSV reads a CSV file, detecting the delimiter from the header line
+func readImportCSV(data []byte) ([]importRow, error) {
+	data = bytes.TrimPrefix(data, utf8BOM)
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.Comma = detectCSVDelimiter(data)
+
+	var rows []importRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		rows = append(rows, importRow{line: line, cells: record})
+	}
+}
+
+// detectCSVDelimiter picks the delimiter option that occurs most often in the first line
+func detectCSVDelimiter(data []byte) rune {
+	firstLine := data
+	if end := bytes.IndexByte(data, '\n'); end >= 0 {
+		firstLine = data[:end]
+	}
+
+	delimiter, best := ',', 0
+	for _, candidate := range []rune{',', ';', '\t'} {
+		if count := bytes.Count(firstLine, []byte(string(candidate))); count > best {
+			delimiter, best = candidate, count
+		}
+	}
+	return delimiter
+}
+
+// readImportExcel reads the first sheet of an XLSX file
+func readImportExcel(data []byte) ([]importRow, error) {
+	f, err := excelize.OpenReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	sheets := f.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, nil
+	}
+	records, err := f.GetRows(sheets[0])
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]importRow, len(records))
+	for i, record := range records {
+		rows[i] = importRow{line: i + 1, cells: record}
+	}
+	return rows, nil
+}
+
+// emptyImportRow reports whether every cell of a row is blank
+func emptyImportRow(cells []string) bool {
+	for _, cell := range cells {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"survey-system/internal/dto/request"
+	"survey-system/internal/dto/response"
+	"survey-system/internal/model"
+	"survey-system/pkg/errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// GenerateLinkPool pre-generates links without prefill data, e.g. to print them before an
+// event. Each link works as a generic link until it is claimed and bound to prefill data
+func (s *shareService) GenerateLinkPool(ctx context.Context, userID, surveyID uint, req *request.GenerateLinkPoolRequest) (*response.LinkPoolResponse, error) {
+	survey, err := findTeamSurvey(ctx, s.authz, userID, surveyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.RedirectURL != "" {
+		if err := s.validateRedirectURL(req.RedirectURL); err != nil {
+			return nil, err
+		}
+	}
+	expiresAt, err := s.linkExpiresAt(survey, req.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	// Every link counts against the daily quota, not just the request
+	release, err := s.usage.ReserveUsageCount(ctx, userID, UsageShareLinks, req.Count)
+	if err != nil {
+		return nil, err
+	}
+
+	oneLinks := make([]model.OneLink, req.Count)
+	for i := range oneLinks {
+		variant, err := linkVariant(survey, req.Variant)
+		if err != nil {
+			release()
+			return nil, err
+		}
+		token, err := s.encryptionSvc.EncryptToken(&TokenData{
+			SurveyID:  surveyID,
+			ExpiresAt: expiresAt.Unix(),
+			UniqueID:  uuid.New().String(),
+		})
+		if err != nil {
+			release()
+			return nil, errors.WrapError(err, "failed to encrypt token")
+		}
+		oneLinks[i] = model.OneLink{
+			SurveyID:    surveyID,
+			Token:       token,
+			ExpiresAt:   expiresAt,
+			RedirectURL: req.RedirectURL,
+			Campaign:    req.Campaign,
+			IsTest:      req.Test,
+			Pooled:      true,
+			Variant:     variant,
+		}
+	}
+	if err := s.oneLinkRepo.CreateBatch(ctx, oneLinks); err != nil {
+		release()
+		return nil, errors.WrapError(err, "failed to create one-time links")
+	}
+
+	recordEvent(ctx, s.eventRepo, &model.SurveyEvent{
+		SurveyID: surveyID,
+		UserID:   userID,
+		Type:     model.EventLinkGenerated,
+		Campaign: req.Campaign,
+	})
+
+	result := &response.LinkPoolResponse{
+		Count:       len(oneLinks),
+		ExpiresAt:   expiresAt,
+		RedirectURL: req.RedirectURL,
+		Campaign:    req.Campaign,
+		Test:        req.Test,
+		Links:       make([]response.PoolLinkItem, len(oneLinks)),
+	}
+	for i, oneLink := range oneLinks {
+		result.Links[i] = response.PoolLinkItem{
+			ID:      oneLink.ID,
+			Token:   oneLink.Token,
+			URL:     fmt.Sprintf("%s/survey/%d?token=%s", s.baseURL, surveyID, oneLink.Token),
+			Variant: oneLink.Variant,
+		}
+	}
+	return result, nil
+}
+
+// ClaimPoolLink binds prefill data and a respondent to an unclaimed pool link of a survey,
+// the one with the request's token or else the oldest one. The link's token stays the
+// same; its prefill data is read from the link record when it is opened or submitted
+func (s *shareService) ClaimPoolLink(ctx context.Context, userID, surveyID uint, req *request.ClaimPoolLinkRequest) (*response.ClaimedLinkResponse, error) {
+	survey, err := findTeamSurvey(ctx, s.authz, userID, surveyID)
+	if err != nil {
+		return nil, err
+	}
+
+	// A link bound to a known respondent would identify the answers of an anonymous survey
+	if survey.Anonymous && req.RespondentID != "" {
+		return nil, errors.NewValidationError("respondent_id", "anonymous surveys cannot bind links to a respondent")
+	}
+
+	questions, err := s.questionRepo.FindBySurveyID(ctx, surveyID)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to find questions")
+	}
+	if err := validatePrefillData(questions, req.PrefillData); err != nil {
+		return nil, err
+	}
+
+	oneLink, err := s.oneLinkRepo.ClaimPooled(ctx, surveyID, req.Token, model.PrefillDataType(req.PrefillData), req.RespondentID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			if req.Token != "" {
+				return nil, errors.ErrLinkNotClaimable
+			}
+			return nil, errors.ErrLinkPoolEmpty
+		}
+		return nil, errors.WrapError(err, "failed to claim pool link")
+	}
+
+	remaining, err := s.oneLinkRepo.CountClaimable(ctx, surveyID)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to count pool links")
+	}
+
+	prefillData := req.PrefillData
+	if prefillData == nil {
+		prefillData = map[string]interface{}{}
+	}
+	return &response.ClaimedLinkResponse{
+		ID:           oneLink.ID,
+		Token:        oneLink.Token,
+		URL:          fmt.Sprintf("%s/survey/%d?token=%s", s.baseURL, surveyID, oneLink.Token),
+		ExpiresAt:    oneLink.ExpiresAt,
+		Campaign:     oneLink.Campaign,
+		RespondentID: oneLink.RespondentID,
+		Variant:      oneLink.Variant,
+		PrefillData:  prefillData,
+		ClaimedAt:    *oneLink.ClaimedAt,
+		Remaining:    remaining,
+	}, nil
+}
+
+// linkPrefillData returns the prefill data of an opened link. Pool links carry none in
+// their token and are bound to it when claimed, so theirs is read from the link record
+func linkPrefillData(tokenData *TokenData, oneLink *model.OneLink) map[string]interface{} {
+	if oneLink.Pooled {
+		return oneLink.PrefillData
+	}
+	return tokenData.PrefillData
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"survey-system/internal/dto/request"
+	"survey-system/internal/dto/response"
+	"survey-system/internal/model"
+	"survey-system/internal/repository"
+	"survey-system/pkg/errors"
+)
+
+// RevokeLinks revokes the links of a survey matching the request's filters in bulk,
+// e.g. after links were generated with wrong prefill data, and purges their cache entries
+// Revoked links can no longer be opened, resumed or submitted
+func (s *shareService) RevokeLinks(ctx context.Context, userID, surveyID uint, req *request.RevokeLinksRequest) (*response.RevokeLinksResponse, error) {
+	if _, err := s.findOwnSurvey(ctx, userID, surveyID); err != nil {
+		return nil, err
+	}
+
+	// Refuse to revoke every link of the survey by accident
+	if req.CreatedFrom == "" && req.CreatedTo == "" && req.Campaign == "" {
+		return nil, errors.NewValidationError("created_from", "at least one of created_from, created_to or campaign is required")
+	}
+
+	days, err := dateRangeFilter(req.CreatedFrom, req.CreatedTo)
+	if err != nil {
+		return nil, err
+	}
+	filter := repository.LinkFilter{
+		CreatedFrom: days.From,
+		CreatedTo:   days.To,
+		Campaign:    req.Campaign,
+		UnusedOnly:  req.UnusedOnly == nil || *req.UnusedOnly,
+	}
+
+	result := &response.RevokeLinksResponse{DryRun: req.DryRun}
+	if req.DryRun {
+		result.Matched, err = s.oneLinkRepo.CountRevocable(ctx, surveyID, filter)
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to count links")
+		}
+		return result, nil
+	}
+
+	revoked, err := s.oneLinkRepo.Revoke(ctx, surveyID, filter)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to revoke links")
+	}
+	result.Matched = int64(len(revoked))
+	result.Revoked = result.Matched
+	if len(revoked) == 0 {
+		return result, nil
+	}
+
+	tokens := make([]string, len(revoked))
+	for i, oneLink := range revoked {
+		tokens[i] = oneLink.Token
+	}
+	if err := s.cache.DeleteOneLinks(ctx, tokens); err != nil {
+		fmt.Printf("failed to purge revoked links from cache: %v\n", err)
+	}
+
+	recordEvent(ctx, s.eventRepo, &model.SurveyEvent{
+		SurveyID: surveyID,
+		UserID:   userID,
+		Type:     model.EventLinksRevoked,
+		Campaign: req.Campaign,
+	})
+
+	return result, nil
+}
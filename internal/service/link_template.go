@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"survey-system/internal/dto/request"
+	"survey-system/internal/dto/response"
+	"survey-system/internal/model"
+	"survey-system/pkg/errors"
+
+	"gorm.io/gorm"
+)
+
+// ListLinkTemplates returns the link templates of a survey
+func (s *shareService) ListLinkTemplates(ctx context.Context, userID, surveyID uint) ([]response.LinkTemplateResponse, error) {
+	if _, err := s.findOwnSurvey(ctx, userID, surveyID); err != nil {
+		return nil, err
+	}
+
+	templates, err := s.linkTemplateRepo.FindBySurveyID(ctx, surveyID)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to find link templates")
+	}
+
+	result := make([]response.LinkTemplateResponse, len(templates))
+	for i := range templates {
+		result[i] = response.ToLinkTemplateResponse(&templates[i])
+	}
+	return result, nil
+}
+
+// CreateLinkTemplate saves a new link template for a survey
+func (s *shareService) CreateLinkTemplate(ctx context.Context, userID, surveyID uint, req *request.LinkTemplateRequest) (*response.LinkTemplateResponse, error) {
+	if _, err := s.findOwnSurvey(ctx, userID, surveyID); err != nil {
+		return nil, err
+	}
+
+	template := &model.LinkTemplate{SurveyID: surveyID}
+	if err := s.applyLinkTemplateRequest(ctx, template, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.linkTemplateRepo.Create(ctx, template); err != nil {
+		if err == gorm.ErrDuplicatedKey {
+			return nil, errors.ErrTemplateNameExists
+		}
+		return nil, errors.WrapError(err, "failed to create link template")
+	}
+
+	result := response.ToLinkTemplateResponse(template)
+	return &result, nil
+}
+
+// UpdateLinkTemplate replaces the settings of a link template
+// Links generated from it earlier are not changed
+func (s *shareService) UpdateLinkTemplate(ctx context.Context, userID, surveyID, templateID uint, req *request.LinkTemplateRequest) (*response.LinkTemplateResponse, error) {
+	template, err := s.findLinkTemplate(ctx, userID, surveyID, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.applyLinkTemplateRequest(ctx, template, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.linkTemplateRepo.Update(ctx, template); err != nil {
+		if err == gorm.ErrDuplicatedKey {
+			return nil, errors.ErrTemplateNameExists
+		}
+		return nil, errors.WrapError(err, "failed to update link template")
+	}
+
+	result := response.ToLinkTemplateResponse(template)
+	return &result, nil
+}
+
+// DeleteLinkTemplate removes a link template; links generated from it stay valid
+func (s *shareService) DeleteLinkTemplate(ctx context.Context, userID, surveyID, templateID uint) error {
+	template, err := s.findLinkTemplate(ctx, userID, surveyID, templateID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.linkTemplateRepo.Delete(ctx, template.ID); err != nil {
+		return errors.WrapError(err, "failed to delete link template")
+	}
+	return nil
+}
+
+// findOwnSurvey loads a survey and verifies the user owns it
+func (s *shareService) findOwnSurvey(ctx context.Context, userID, surveyID uint) (*model.Survey, error) {
+	survey, err := s.surveyRepo.FindByID(ctx, surveyID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.WrapError(err, "failed to find survey")
+	}
+
+	if survey.UserID != userID {
+		return nil, errors.ErrForbidden
+	}
+	return survey, nil
+}
+
+// findLinkTemplate loads a link template of a survey the user owns
+func (s *shareService) findLinkTemplate(ctx context.Context, userID, surveyID, templateID uint) (*model.LinkTemplate, error) {
+	if _, err := s.findOwnSurvey(ctx, userID, surveyID); err != nil {
+		return nil, err
+	}
+
+	template, err := s.linkTemplateRepo.FindByID(ctx, templateID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.WrapError(err, "failed to find link template")
+	}
+
+	// Templates of other surveys are reported as missing
+	if template.SurveyID != surveyID {
+		return nil, errors.ErrNotFound
+	}
+	return template, nil
+}
+
+// applyLinkTemplateRequest validates a template request against the survey's
+// questions and copies it into the template
+func (s *shareService) applyLinkTemplateRequest(ctx context.Context, template *model.LinkTemplate, req *request.LinkTemplateRequest) error {
+	questions, err := s.questionRepo.FindBySurveyID(ctx, template.SurveyID)
+	if err != nil {
+		return errors.WrapError(err, "failed to find questions")
+	}
+
+	if err := validatePrefillData(questions, req.PrefillData); err != nil {
+		return err
+	}
+
+	// Variables must be prefill keys as well, each listed once and without a fixed value
+	validPrefillKeys := make(map[string]bool)
+	for _, q := range questions {
+		if q.PrefillKey != "" {
+			validPrefillKeys[q.PrefillKey] = true
+		}
+	}
+	seen := make(map[string]bool, len(req.Variables))
+	for _, key := range req.Variables {
+		if !validPrefillKeys[key] {
+			return errors.NewValidationError("variables", fmt.Sprintf("invalid prefill key '%s' - no matching question found", key))
+		}
+		if seen[key] {
+			return errors.NewValidationError("variables", fmt.Sprintf("duplicate variable '%s'", key))
+		}
+		if _, ok := req.PrefillData[key]; ok {
+			return errors.NewValidationError("variables", fmt.Sprintf("'%s' already has a fixed value in prefill_data", key))
+		}
+		seen[key] = true
+	}
+
+	if req.RedirectURL != "" {
+		if err := s.validateRedirectURL(req.RedirectURL); err != nil {
+			return err
+		}
+	}
+
+	template.Name = req.Name
+	template.PrefillData = model.PrefillDataType(req.PrefillData)
+	template.Variables = model.StringList(req.Variables)
+	template.ExpiresInHours = req.ExpiresInHours
+	template.RedirectURL = req.RedirectURL
+	template.Campaign = req.Campaign
+	return nil
+}
+
+// applyLinkTemplate fills a share link request from the template it references
+// The request carries the values of the template variables and may override the
+// template's expiration, redirect URL and campaign
+func (s *shareService) applyLinkTemplate(ctx context.Context, surveyID uint, req *request.GenerateShareLinkRequest) error {
+	template, err := s.linkTemplateRepo.FindByID(ctx, req.TemplateID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return errors.NewValidationError("template_id", "link template not found")
+		}
+		return errors.WrapError(err, "failed to find link template")
+	}
+	if template.SurveyID != surveyID {
+		return errors.NewValidationError("template_id", "link template not found")
+	}
+
+	isVariable := make(map[string]bool, len(template.Variables))
+	for _, key := range template.Variables {
+		isVariable[key] = true
+		if _, ok := req.PrefillData[key]; !ok {
+			return errors.NewValidationError("prefill_data", fmt.Sprintf("missing value for template variable '%s'", key))
+		}
+	}
+
+	prefillData := make(map[string]interface{}, len(template.PrefillData)+len(req.PrefillData))
+	for key, value := range template.PrefillData {
+		prefillData[key] = value
+	}
+	for key, value := range req.PrefillData {
+		if !isVariable[key] {
+			return errors.NewValidationError("prefill_data", fmt.Sprintf("'%s' is not a variable of the link template", key))
+		}
+		prefillData[key] = value
+	}
+	req.PrefillData = prefillData
+
+	if req.ExpiresAt == nil && template.ExpiresInHours > 0 {
+		expiresAt := time.Now().Add(time.Duration(template.ExpiresInHours) * time.Hour)
+		req.ExpiresAt = &expiresAt
+	}
+	if req.RedirectURL == "" {
+		req.RedirectURL = template.RedirectURL
+	}
+	if req.Campaign == "" {
+		req.Campaign = template.Campaign
+	}
+	return nil
+}
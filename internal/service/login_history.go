@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"survey-system/internal/dto/request"
+	"survey-system/internal/dto/response"
+	"survey-system/internal/model"
+	"survey-system/pkg/email"
+	"survey-system/pkg/errors"
+)
+
+// newLoginEmailData is the data of the new login alert template
+type newLoginEmailData struct {
+	Username  string
+	Time      string
+	IPAddress string
+	UserAgent string
+}
+
+var newLoginEmail = email.MustTemplate("new_login",
+	"您的账号在新的设备或网络上登录",
+	"{{.Username}}，您好：\n\n您的账号刚刚在新的设备或网络上登录：\n\n时间：{{.Time}}\nIP 地址：{{.IPAddress}}\n浏览器：{{.UserAgent}}\n\n如果这是您本人的操作，请忽略此邮件。如果不是，请立即在登录会话中注销该会话并重置密码。\n")
+
+// recordLogin adds a login attempt to the user's login history; failure is empty for
+// successful logins, which also update the user's last login and are compared with
+// earlier ones to alert the user about logins from a new device or network.
+// Failures to record are logged and do not affect the login
+func (s *authService) recordLogin(ctx context.Context, user *model.User, userAgent, ipAddress, failure string) {
+	userAgent = truncateRunes(userAgent, 500)
+	network, _ := submissionSubnet(ipAddress)
+	record := &model.LoginRecord{
+		UserID:    user.ID,
+		Succeeded: failure == "",
+		Failure:   failure,
+		IPAddress: ipAddress,
+		Network:   network,
+		UserAgent: userAgent,
+		CreatedAt: time.Now(),
+	}
+
+	var seenBefore bool
+	if record.Succeeded {
+		var err error
+		if seenBefore, err = s.loginRepo.HasSucceeded(ctx, user.ID, "", ""); err != nil {
+			log.Printf("Failed to check login history of user %d: %v", user.ID, err)
+		}
+		// The first login has nothing to compare with
+		if seenBefore {
+			knownDevice, err := s.loginRepo.HasSucceeded(ctx, user.ID, userAgent, "")
+			if err != nil {
+				log.Printf("Failed to check login history of user %d: %v", user.ID, err)
+				knownDevice = true
+			}
+			knownLocation := true
+			if network != "" {
+				if knownLocation, err = s.loginRepo.HasSucceeded(ctx, user.ID, "", network); err != nil {
+					log.Printf("Failed to check login history of user %d: %v", user.ID, err)
+					knownLocation = true
+				}
+			}
+			record.NewDevice, record.NewLocation = !knownDevice, !knownLocation
+		}
+	}
+
+	if err := s.loginRepo.Create(ctx, record); err != nil {
+		log.Printf("Failed to record login of user %d: %v", user.ID, err)
+	}
+	if !record.Succeeded {
+		return
+	}
+
+	if err := s.userRepo.RecordLogin(ctx, user.ID, record.CreatedAt, ipAddress); err != nil {
+		log.Printf("Failed to update last login of user %d: %v", user.ID, err)
+	}
+	if s.account.LoginAlerts && user.Email != "" && (record.NewDevice || record.NewLocation) {
+		// Sent without delaying the login
+		go s.notifyNewLogin(context.WithoutCancel(ctx), user, record)
+	}
+}
+
+// notifyNewLogin emails a user about a login from a new device or network
+func (s *authService) notifyNewLogin(ctx context.Context, user *model.User, record *model.LoginRecord) {
+	userAgent := record.UserAgent
+	if userAgent == "" {
+		userAgent = "未知"
+	}
+	msg, err := newLoginEmail.Render([]string{user.Email}, newLoginEmailData{
+		Username:  user.Username,
+		Time:      record.CreatedAt.Format("2006-01-02 15:04"),
+		IPAddress: record.IPAddress,
+		UserAgent: userAgent,
+	})
+	if err == nil {
+		err = s.mailer.Send(ctx, msg)
+	}
+	if err != nil {
+		log.Printf("Failed to send new login alert to user %d: %v", user.ID, err)
+	}
+}
+
+// ListLoginHistory returns a page of the login attempts to a user's account, newest first
+func (s *authService) ListLoginHistory(ctx context.Context, userID uint, query *request.LoginHistoryQuery) (*response.PaginatedLoginHistoryResponse, error) {
+	page, pageSize := query.Page, query.PageSize
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 20
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	records, total, err := s.loginRepo.FindByUserID(ctx, userID, page, pageSize)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to list login history")
+	}
+
+	totalPage := int(total) / pageSize
+	if int(total)%pageSize > 0 {
+		totalPage++
+	}
+
+	data := make([]response.LoginRecordResponse, len(records))
+	for i := range records {
+		data[i] = response.ToLoginRecordResponse(&records[i])
+	}
+
+	return &response.PaginatedLoginHistoryResponse{
+		Data: data,
+		Meta: response.PaginationMeta{
+			Page:      page,
+			PageSize:  pageSize,
+			Total:     total,
+			TotalPage: totalPage,
+		},
+	}, nil
+}
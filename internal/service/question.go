@@ -3,6 +3,10 @@ package service
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
 
 	"survey-system/internal/cache"
 	"survey-system/internal/dto/request"
@@ -10,6 +14,7 @@ import (
 	"survey-system/internal/model"
 	"survey-system/internal/repository"
 	"survey-system/pkg/errors"
+	"survey-system/pkg/optionset"
 
 	"gorm.io/gorm"
 )
@@ -18,34 +23,70 @@ import (
 type QuestionService interface {
 	CreateQuestion(ctx context.Context, userID uint, req *request.CreateQuestionRequest) (*response.QuestionResponse, error)
 	UpdateQuestion(ctx context.Context, userID, questionID uint, req *request.UpdateQuestionRequest) (*response.QuestionResponse, error)
+	PreviewQuestionUpdate(ctx context.Context, userID, questionID uint, req *request.UpdateQuestionRequest) (*response.QuestionUpdateImpact, error)
 	DeleteQuestion(ctx context.Context, userID, questionID uint) error
 	ReorderQuestions(ctx context.Context, userID, surveyID uint, questionIDs []uint) error
+	GetQuestionHistory(ctx context.Context, userID, questionID uint) ([]response.QuestionChangeResponse, error)
+	RestoreQuestion(ctx context.Context, userID, questionID, versionID uint, req *request.RestoreQuestionRequest) (*response.QuestionResponse, error)
+	ImportQuestionOptions(ctx context.Context, userID, questionID uint, req *request.ImportQuestionOptionsRequest) (*response.ImportQuestionOptionsResponse, error)
+	ListOptionSets() []response.OptionSetResponse
+	SizeReport(ctx context.Context, userID, surveyID uint) (*response.SurveySizeReport, error)
 }
 
 // questionService implements QuestionService interface
 type questionService struct {
 	questionRepo repository.QuestionRepository
 	surveyRepo   repository.SurveyRepository
+	eventRepo    repository.EventRepository
+	changeRepo   repository.QuestionChangeRepository
 	cache        cache.Cache
+	features     FeatureService
+	optionSets   *optionset.Library
+	maxOptions   int // Options a question can have after an import
+	limits       QuestionLimits
+	answers      QuestionAnswers
+}
+
+// QuestionLimits cap the size of surveys and questions, keeping exports and the public
+// survey payload manageable. A zero MaxRows leaves table rows unlimited
+type QuestionLimits struct {
+	MaxQuestions int // questions per survey
+	MaxOptions   int // options per choice question, select column or cascade parent value
+	MaxColumns   int // columns per table question
+	MaxRows      int // rows a table question may require or allow
 }
 
 // NewQuestionService creates a new question service instance
 func NewQuestionService(
 	questionRepo repository.QuestionRepository,
 	surveyRepo repository.SurveyRepository,
+	eventRepo repository.EventRepository,
+	changeRepo repository.QuestionChangeRepository,
 	cache cache.Cache,
+	features FeatureService,
+	optionSets *optionset.Library,
+	maxOptions int,
+	limits QuestionLimits,
+	answers QuestionAnswers,
 ) QuestionService {
 	return &questionService{
 		questionRepo: questionRepo,
 		surveyRepo:   surveyRepo,
+		eventRepo:    eventRepo,
+		changeRepo:   changeRepo,
 		cache:        cache,
+		features:     features,
+		optionSets:   optionSets,
+		maxOptions:   maxOptions,
+		limits:       limits,
+		answers:      answers,
 	}
 }
 
 // CreateQuestion creates a new question after verifying survey ownership and validating configuration
 func (s *questionService) CreateQuestion(ctx context.Context, userID uint, req *request.CreateQuestionRequest) (*response.QuestionResponse, error) {
 	// Verify survey exists and user owns it
-	survey, err := s.surveyRepo.FindByID(req.SurveyID)
+	survey, err := s.surveyRepo.FindByID(ctx, req.SurveyID)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, errors.ErrNotFound
@@ -57,10 +98,30 @@ func (s *questionService) CreateQuestion(ctx context.Context, userID uint, req *
 		return nil, errors.ErrForbidden
 	}
 
+	existing, err := s.questionRepo.FindBySurveyID(ctx, req.SurveyID)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to find questions")
+	}
+	if len(existing) >= s.limits.MaxQuestions {
+		return nil, errors.NewLocalizedValidationError("survey_id", "question.questions_too_many", s.limits.MaxQuestions)
+	}
+
 	// Validate question configuration based on type
 	if err := s.validateQuestionConfig(req.Type, &req.Config); err != nil {
 		return nil, err
 	}
+	if err := validatePrefillType(req.Type, req.PrefillKey, req.PrefillType, req.LockPrefill, req.Hidden); err != nil {
+		return nil, err
+	}
+	if err := validateQuestionPool(survey, req.Pool, req.Hidden); err != nil {
+		return nil, err
+	}
+	if err := validateQuestionVariant(survey, req.Variant); err != nil {
+		return nil, err
+	}
+	if err := s.requireQuestionFeatures(ctx, userID, nil, req.Type, &req.Config); err != nil {
+		return nil, err
+	}
 
 	// Create the question
 	question := &model.Question{
@@ -72,14 +133,23 @@ func (s *questionService) CreateQuestion(ctx context.Context, userID uint, req *
 		Order:       *req.Order,
 		Config:      req.Config,
 		PrefillKey:  req.PrefillKey,
+		PrefillType: req.PrefillType,
+		LockPrefill: req.LockPrefill,
+		Hidden:      req.Hidden,
+		Sensitive:   req.Sensitive,
+		Pool:        req.Pool,
+		Variant:     req.Variant,
 	}
 
-	if err := s.questionRepo.Create(question); err != nil {
+	if err := s.questionRepo.Create(ctx, question); err != nil {
 		return nil, errors.WrapError(err, "failed to create question")
 	}
 
-	// Invalidate survey cache since questions changed
-	if err := s.cache.DeleteSurvey(ctx, req.SurveyID); err != nil {
+	recordEvent(ctx, s.eventRepo, questionEvent(question, userID, model.EventQuestionCreated))
+	s.recordQuestionChanges(ctx, questionChange(nil, question, userID, model.QuestionChangeCreated))
+
+	// Invalidate survey cache and statistics since questions changed
+	if err := s.cache.InvalidateSurvey(ctx, req.SurveyID); err != nil {
 		fmt.Printf("failed to invalidate survey cache: %v\n", err)
 	}
 
@@ -88,34 +158,89 @@ func (s *questionService) CreateQuestion(ctx context.Context, userID uint, req *
 
 // UpdateQuestion updates an existing question after verifying ownership and validating configuration
 func (s *questionService) UpdateQuestion(ctx context.Context, userID, questionID uint, req *request.UpdateQuestionRequest) (*response.QuestionResponse, error) {
+	before, question, err := s.prepareQuestionUpdate(ctx, userID, questionID, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkRemovedOptions(ctx, before, question, &req.OptionRemoval); err != nil {
+		return nil, err
+	}
+	if err := s.remapRemovedOptions(ctx, before, &req.OptionRemoval); err != nil {
+		return nil, err
+	}
+
+	if err := s.questionRepo.Update(ctx, question); err != nil {
+		return nil, errors.WrapError(err, "failed to update question")
+	}
+
+	recordEvent(ctx, s.eventRepo, questionEvent(question, userID, model.EventQuestionUpdated))
+	if change := questionChange(before, question, userID, model.QuestionChangeUpdated); len(change.Fields) > 0 {
+		s.recordQuestionChanges(ctx, change)
+	}
+
+	// Options or the type may have changed, so the statistics counters are rebuilt too
+	if err := s.cache.InvalidateSurvey(ctx, question.SurveyID); err != nil {
+		fmt.Printf("failed to invalidate survey cache: %v\n", err)
+	}
+
+	return response.ToQuestionResponse(question), nil
+}
+
+// PreviewQuestionUpdate validates a question update like UpdateQuestion and reports which
+// collected answers and outstanding links it would invalidate, without applying it
+func (s *questionService) PreviewQuestionUpdate(ctx context.Context, userID, questionID uint, req *request.UpdateQuestionRequest) (*response.QuestionUpdateImpact, error) {
+	before, question, err := s.prepareQuestionUpdate(ctx, userID, questionID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.answers.QuestionUpdateImpact(ctx, before, question)
+}
+
+// prepareQuestionUpdate verifies ownership, validates an update request and returns the
+// question as stored and as it would be after the update
+func (s *questionService) prepareQuestionUpdate(ctx context.Context, userID, questionID uint, req *request.UpdateQuestionRequest) (*model.Question, *model.Question, error) {
 	// Find the question
-	question, err := s.questionRepo.FindByID(questionID)
+	question, err := s.questionRepo.FindByID(ctx, questionID)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, errors.ErrNotFound
+			return nil, nil, errors.ErrNotFound
 		}
-		return nil, errors.WrapError(err, "failed to find question")
+		return nil, nil, errors.WrapError(err, "failed to find question")
 	}
 
 	// Verify survey ownership
-	survey, err := s.surveyRepo.FindByID(question.SurveyID)
+	survey, err := s.surveyRepo.FindByID(ctx, question.SurveyID)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, errors.ErrNotFound
+			return nil, nil, errors.ErrNotFound
 		}
-		return nil, errors.WrapError(err, "failed to find survey")
+		return nil, nil, errors.WrapError(err, "failed to find survey")
 	}
 
 	if survey.UserID != userID {
-		return nil, errors.ErrForbidden
+		return nil, nil, errors.ErrForbidden
 	}
 
 	// Validate question configuration based on type
 	if err := s.validateQuestionConfig(req.Type, &req.Config); err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	if err := validatePrefillType(req.Type, req.PrefillKey, req.PrefillType, req.LockPrefill, req.Hidden); err != nil {
+		return nil, nil, err
+	}
+	if err := validateQuestionPool(survey, req.Pool, req.Hidden); err != nil {
+		return nil, nil, err
+	}
+	if err := validateQuestionVariant(survey, req.Variant); err != nil {
+		return nil, nil, err
+	}
+	if err := s.requireQuestionFeatures(ctx, userID, question, req.Type, &req.Config); err != nil {
+		return nil, nil, err
 	}
 
-	// Update fields
+	// Update fields, keeping the old values for the change history
+	before := *question
 	question.Type = req.Type
 	question.Title = req.Title
 	question.Description = req.Description
@@ -123,23 +248,20 @@ func (s *questionService) UpdateQuestion(ctx context.Context, userID, questionID
 	question.Order = *req.Order
 	question.Config = req.Config
 	question.PrefillKey = req.PrefillKey
-
-	if err := s.questionRepo.Update(question); err != nil {
-		return nil, errors.WrapError(err, "failed to update question")
-	}
-
-	// Invalidate survey cache
-	if err := s.cache.DeleteSurvey(ctx, question.SurveyID); err != nil {
-		fmt.Printf("failed to invalidate survey cache: %v\n", err)
-	}
-
-	return response.ToQuestionResponse(question), nil
+	question.PrefillType = req.PrefillType
+	question.LockPrefill = req.LockPrefill
+	question.Hidden = req.Hidden
+	question.Sensitive = req.Sensitive
+	question.Pool = req.Pool
+	question.Variant = req.Variant
+
+	return &before, question, nil
 }
 
 // DeleteQuestion deletes a question after verifying ownership
 func (s *questionService) DeleteQuestion(ctx context.Context, userID, questionID uint) error {
 	// Find the question
-	question, err := s.questionRepo.FindByID(questionID)
+	question, err := s.questionRepo.FindByID(ctx, questionID)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return errors.ErrNotFound
@@ -148,7 +270,7 @@ func (s *questionService) DeleteQuestion(ctx context.Context, userID, questionID
 	}
 
 	// Verify survey ownership
-	survey, err := s.surveyRepo.FindByID(question.SurveyID)
+	survey, err := s.surveyRepo.FindByID(ctx, question.SurveyID)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return errors.ErrNotFound
@@ -161,12 +283,15 @@ func (s *questionService) DeleteQuestion(ctx context.Context, userID, questionID
 	}
 
 	// Delete the question
-	if err := s.questionRepo.Delete(questionID); err != nil {
+	if err := s.questionRepo.Delete(ctx, questionID); err != nil {
 		return errors.WrapError(err, "failed to delete question")
 	}
 
-	// Invalidate survey cache
-	if err := s.cache.DeleteSurvey(ctx, question.SurveyID); err != nil {
+	recordEvent(ctx, s.eventRepo, questionEvent(question, userID, model.EventQuestionDeleted))
+	s.recordQuestionChanges(ctx, questionChange(question, nil, userID, model.QuestionChangeDeleted))
+
+	// Invalidate survey cache and statistics of the removed question
+	if err := s.cache.InvalidateSurvey(ctx, question.SurveyID); err != nil {
 		fmt.Printf("failed to invalidate survey cache: %v\n", err)
 	}
 
@@ -176,7 +301,7 @@ func (s *questionService) DeleteQuestion(ctx context.Context, userID, questionID
 // ReorderQuestions updates the order of questions in a survey
 func (s *questionService) ReorderQuestions(ctx context.Context, userID, surveyID uint, questionIDs []uint) error {
 	// Verify survey ownership
-	survey, err := s.surveyRepo.FindByID(surveyID)
+	survey, err := s.surveyRepo.FindByID(ctx, surveyID)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return errors.ErrNotFound
@@ -189,7 +314,7 @@ func (s *questionService) ReorderQuestions(ctx context.Context, userID, surveyID
 	}
 
 	// Get all questions for this survey
-	questions, err := s.questionRepo.FindBySurveyID(surveyID)
+	questions, err := s.questionRepo.FindBySurveyID(ctx, surveyID)
 	if err != nil {
 		return errors.WrapError(err, "failed to find questions")
 	}
@@ -215,10 +340,19 @@ func (s *questionService) ReorderQuestions(ctx context.Context, userID, surveyID
 	}
 
 	// Batch update the order
-	if err := s.questionRepo.BatchUpdateOrder(questionsToUpdate); err != nil {
+	if err := s.questionRepo.BatchUpdateOrder(ctx, questionsToUpdate); err != nil {
 		return errors.WrapError(err, "failed to reorder questions")
 	}
 
+	// Record the questions that actually moved
+	changes := make([]model.QuestionChange, 0, len(questionsToUpdate))
+	for i := range questionsToUpdate {
+		if change := questionChange(questionMap[questionsToUpdate[i].ID], &questionsToUpdate[i], userID, model.QuestionChangeReordered); len(change.Fields) > 0 {
+			changes = append(changes, change)
+		}
+	}
+	s.recordQuestionChanges(ctx, changes...)
+
 	// Invalidate survey cache
 	if err := s.cache.DeleteSurvey(ctx, surveyID); err != nil {
 		fmt.Printf("failed to invalidate survey cache: %v\n", err)
@@ -227,60 +361,280 @@ func (s *questionService) ReorderQuestions(ctx context.Context, userID, surveyID
 	return nil
 }
 
+// questionEvent builds the survey event describing a change to a question
+func questionEvent(question *model.Question, userID uint, eventType string) *model.SurveyEvent {
+	questionID := question.ID
+	return &model.SurveyEvent{
+		SurveyID:   question.SurveyID,
+		UserID:     userID,
+		Type:       eventType,
+		QuestionID: &questionID,
+		Title:      question.Title,
+	}
+}
+
 // validateQuestionConfig validates the question configuration based on question type
+// validatePrefillType checks that a prefill type, lock or hidden flag is declared together
+// with a prefill key and that option values are only expected by choice questions
+func validatePrefillType(questionType, prefillKey, prefillType string, lockPrefill, hidden bool) error {
+	if lockPrefill && prefillKey == "" {
+		return errors.NewLocalizedValidationError("lock_prefill", "question.lock_prefill_requires_key")
+	}
+	if hidden && prefillKey == "" {
+		return errors.NewLocalizedValidationError("hidden", "question.hidden_requires_key")
+	}
+	if prefillType == "" {
+		return nil
+	}
+	if prefillKey == "" {
+		return errors.NewLocalizedValidationError("prefill_type", "question.prefill_type_requires_key")
+	}
+	if prefillType == model.PrefillTypeOption && questionType != model.QuestionTypeSingle && questionType != model.QuestionTypeMultiple {
+		return errors.NewLocalizedValidationError("prefill_type", "question.prefill_option_not_choice")
+	}
+	return nil
+}
+
+// validateQuestionPool checks that a pooled question names a pool of its survey and is
+// shown to respondents
+func validateQuestionPool(survey *model.Survey, pool string, hidden bool) error {
+	if pool == "" {
+		return nil
+	}
+	if _, ok := survey.QuestionPools.Find(pool); !ok {
+		return errors.NewLocalizedValidationError("pool", "question.pool_unknown", pool)
+	}
+	if hidden {
+		return errors.NewLocalizedValidationError("pool", "question.pool_hidden")
+	}
+	return nil
+}
+
+// validateQuestionVariant checks that a question restricted to a variant names a variant
+// of its survey
+func validateQuestionVariant(survey *model.Survey, variant string) error {
+	if variant != "" && !slices.Contains(survey.Variants, variant) {
+		return errors.NewLocalizedValidationError("variant", "question.variant_unknown", variant)
+	}
+	return nil
+}
+
 func (s *questionService) validateQuestionConfig(questionType string, config *model.QuestionConfig) error {
+	if err := validateCorrectAnswers(questionType, config); err != nil {
+		return err
+	}
+
 	switch questionType {
 	case model.QuestionTypeText:
-		// Text questions don't need special configuration
+		if config.MaxLength < 0 {
+			return errors.NewLocalizedValidationError("config.max_length", "question.max_length_negative")
+		}
 		return nil
 
 	case model.QuestionTypeSingle, model.QuestionTypeMultiple:
 		// Single and multiple choice questions must have options
 		if len(config.Options) == 0 {
-			return errors.NewValidationError("config.options", "single and multiple choice questions must have at least one option")
+			return errors.NewLocalizedValidationError("config.options", "question.options_required")
+		}
+		if len(config.Options) > s.limits.MaxOptions {
+			return errors.NewLocalizedValidationError("config.options", "question.options_too_many", s.limits.MaxOptions)
+		}
+
+		// Validate each option, IDs must be unique since answers reference them
+		optionIDs := make(map[string]bool)
+		for i, option := range config.Options {
+			if option.Label == "" {
+				return errors.NewLocalizedValidationError(fmt.Sprintf("config.options[%d].label", i), "question.option_label_required")
+			}
+			if optionIDs[option.ID] {
+				return errors.NewLocalizedValidationError(fmt.Sprintf("config.options[%d].id", i), "question.option_id_duplicate", option.ID)
+			}
+			optionIDs[option.ID] = true
+			if option.ImageURL != "" {
+				u, err := url.Parse(option.ImageURL)
+				if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+					return errors.NewLocalizedValidationError(fmt.Sprintf("config.options[%d].image_url", i), "question.option_image_invalid")
+				}
+			}
+		}
+		return nil
+
+	case model.QuestionTypeNPS:
+		// NPS questions always use the fixed 0-10 scale
+		return nil
+
+	case model.QuestionTypeSlider:
+		// Slider questions need a numeric range
+		if config.Min == nil {
+			return errors.NewLocalizedValidationError("config.min", "question.slider_min_required")
+		}
+		if config.Max == nil {
+			return errors.NewLocalizedValidationError("config.max", "question.slider_max_required")
+		}
+		if *config.Min >= *config.Max {
+			return errors.NewLocalizedValidationError("config.min", "question.slider_min_exceeds_max")
+		}
+		if config.Step < 0 || config.Step > *config.Max-*config.Min {
+			return errors.NewLocalizedValidationError("config.step", "question.slider_step_invalid")
 		}
 		return nil
 
 	case model.QuestionTypeTable:
 		// Table questions must have column definitions
 		if len(config.Columns) == 0 {
-			return errors.NewValidationError("config.columns", "table questions must have at least one column")
+			return errors.NewLocalizedValidationError("config.columns", "question.columns_required")
+		}
+		if len(config.Columns) > s.limits.MaxColumns {
+			return errors.NewLocalizedValidationError("config.columns", "question.columns_too_many", s.limits.MaxColumns)
 		}
 
 		// Validate each column
 		for i, col := range config.Columns {
 			if col.ID == "" {
-				return errors.NewValidationError(fmt.Sprintf("config.columns[%d].id", i), "column ID is required")
+				return errors.NewLocalizedValidationError(fmt.Sprintf("config.columns[%d].id", i), "question.column_id_required")
 			}
 			if col.Type == "" {
-				return errors.NewValidationError(fmt.Sprintf("config.columns[%d].type", i), "column type is required")
+				return errors.NewLocalizedValidationError(fmt.Sprintf("config.columns[%d].type", i), "question.column_type_required")
 			}
-			if col.Type != "text" && col.Type != "number" && col.Type != "select" {
-				return errors.NewValidationError(fmt.Sprintf("config.columns[%d].type", i), "column type must be text, number, or select")
+			if col.Type != "text" && col.Type != "number" && col.Type != "select" && col.Type != "cascade" {
+				return errors.NewLocalizedValidationError(fmt.Sprintf("config.columns[%d].type", i), "question.column_type_invalid")
 			}
 			if col.Label == "" {
-				return errors.NewValidationError(fmt.Sprintf("config.columns[%d].label", i), "column label is required")
+				return errors.NewLocalizedValidationError(fmt.Sprintf("config.columns[%d].label", i), "question.column_label_required")
 			}
 			// If column type is select, it must have options
 			if col.Type == "select" && len(col.Options) == 0 {
-				return errors.NewValidationError(fmt.Sprintf("config.columns[%d].options", i), "select columns must have at least one option")
+				return errors.NewLocalizedValidationError(fmt.Sprintf("config.columns[%d].options", i), "question.select_options_required")
+			}
+			if len(col.Options) > s.limits.MaxOptions {
+				return errors.NewLocalizedValidationError(fmt.Sprintf("config.columns[%d].options", i), "question.column_options_too_many", s.limits.MaxOptions)
+			}
+			for value, options := range col.Cascade {
+				if len(options) > s.limits.MaxOptions {
+					return errors.NewLocalizedValidationError(fmt.Sprintf("config.columns[%d].cascade.%s", i, value), "question.column_options_too_many", s.limits.MaxOptions)
+				}
+			}
+			if col.Type == "cascade" {
+				if err := validateCascadeColumn(config.Columns, i); err != nil {
+					return err
+				}
+			}
+			if err := validateColumnDisplay(&col, i); err != nil {
+				return err
+			}
+			if col.Total && col.Type != "number" {
+				return errors.NewLocalizedValidationError(fmt.Sprintf("config.columns[%d].total", i), "question.column_total_not_number")
 			}
 		}
 
 		// Validate row constraints
 		if config.MinRows < 0 {
-			return errors.NewValidationError("config.min_rows", "min_rows cannot be negative")
+			return errors.NewLocalizedValidationError("config.min_rows", "question.min_rows_negative")
 		}
 		if config.MaxRows < 0 {
-			return errors.NewValidationError("config.max_rows", "max_rows cannot be negative")
+			return errors.NewLocalizedValidationError("config.max_rows", "question.max_rows_negative")
 		}
 		if config.MinRows > 0 && config.MaxRows > 0 && config.MinRows > config.MaxRows {
-			return errors.NewValidationError("config.min_rows", "min_rows cannot be greater than max_rows")
+			return errors.NewLocalizedValidationError("config.min_rows", "question.min_rows_exceeds_max")
+		}
+		if s.limits.MaxRows > 0 {
+			if config.MaxRows > s.limits.MaxRows {
+				return errors.NewLocalizedValidationError("config.max_rows", "question.rows_too_many", s.limits.MaxRows)
+			}
+			if config.MinRows > s.limits.MaxRows {
+				return errors.NewLocalizedValidationError("config.min_rows", "question.rows_too_many", s.limits.MaxRows)
+			}
+		}
+		if config.TotalTolerance < 0 {
+			return errors.NewLocalizedValidationError("config.total_tolerance", "question.total_tolerance_negative")
 		}
 
 		return nil
 
 	default:
-		return errors.NewValidationError("type", fmt.Sprintf("invalid question type: %s", questionType))
+		return errors.NewLocalizedValidationError("type", "question.invalid_type", questionType)
+	}
+}
+
+// validateCascadeColumn checks that the cascade column at index i depends on an earlier
+// select or cascade column and only maps values that column can hold
+func validateCascadeColumn(columns []model.TableColumn, i int) error {
+	col := columns[i]
+	if col.DependsOn == "" {
+		return errors.NewLocalizedValidationError(fmt.Sprintf("config.columns[%d].depends_on", i), "question.cascade_depends_on_required")
+	}
+
+	var parent *model.TableColumn
+	for j := 0; j < i; j++ {
+		if columns[j].ID == col.DependsOn {
+			parent = &columns[j]
+			break
+		}
+	}
+	if parent == nil || (parent.Type != "select" && parent.Type != "cascade") {
+		return errors.NewLocalizedValidationError(fmt.Sprintf("config.columns[%d].depends_on", i), "question.cascade_parent_invalid", col.DependsOn)
+	}
+	if len(col.Cascade) == 0 {
+		return errors.NewLocalizedValidationError(fmt.Sprintf("config.columns[%d].cascade", i), "question.cascade_options_required")
+	}
+
+	// The values a parent can hold are its options, or all options it maps to
+	parentValues := make(map[string]bool)
+	if parent.Type == "select" {
+		for _, option := range parent.Options {
+			parentValues[option] = true
+		}
+	} else {
+		for _, options := range parent.Cascade {
+			for _, option := range options {
+				parentValues[option] = true
+			}
+		}
 	}
+
+	values := make([]string, 0, len(col.Cascade))
+	for value := range col.Cascade {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+	for _, value := range values {
+		if !parentValues[value] {
+			return errors.NewLocalizedValidationError(fmt.Sprintf("config.columns[%d].cascade", i), "question.cascade_unknown_value", value, parent.Label)
+		}
+	}
+	return nil
+}
+
+// validateColumnDisplay checks the display hints and the default value of the column at index i
+func validateColumnDisplay(col *model.TableColumn, i int) error {
+	if col.Width < 0 {
+		return errors.NewLocalizedValidationError(fmt.Sprintf("config.columns[%d].width", i), "question.column_width_negative")
+	}
+	if col.Default == "" {
+		if col.ApplyDefault {
+			return errors.NewLocalizedValidationError(fmt.Sprintf("config.columns[%d].default", i), "question.column_default_required")
+		}
+		return nil
+	}
+
+	valid := true
+	switch col.Type {
+	case "number":
+		_, err := strconv.ParseFloat(col.Default, 64)
+		valid = err == nil
+	case "select":
+		valid = slices.Contains(col.Options, col.Default)
+	case "cascade":
+		valid = false
+		for _, options := range col.Cascade {
+			if slices.Contains(options, col.Default) {
+				valid = true
+				break
+			}
+		}
+	}
+	if !valid {
+		return errors.NewLocalizedValidationError(fmt.Sprintf("config.columns[%d].default", i), "question.column_default_invalid", col.Default)
+	}
+	return nil
 }
@@ -0,0 +1,310 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"survey-system/internal/dto/request"
+	"survey-system/internal/dto/response"
+	"survey-system/internal/model"
+	"survey-system/pkg/errors"
+
+	"gorm.io/gorm"
+)
+
+// questionFieldValues are the editable fields of a question compared by its change history
+type questionFieldValues struct {
+	Type        string `json:"type"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Required    bool   `json:"required"`
+	Order       int    `json:"order"`
+	PrefillKey  string `json:"prefill_key"`
+	PrefillType string `json:"prefill_type"`
+	LockPrefill bool   `json:"lock_prefill"`
+	Hidden      bool   `json:"hidden"`
+	Sensitive   bool   `json:"sensitive"`
+	Pool        string `json:"pool"`
+	Variant     string `json:"variant"`
+}
+
+// GetQuestionHistory returns the change history of a question, newest first
+// The history stays available after the question is deleted
+func (s *questionService) GetQuestionHistory(ctx context.Context, userID, questionID uint) ([]response.QuestionChangeResponse, error) {
+	changes, err := s.changeRepo.FindByQuestionID(ctx, questionID)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to find question history")
+	}
+
+	var surveyID uint
+	question, err := s.questionRepo.FindByID(ctx, questionID)
+	switch {
+	case err == nil:
+		surveyID = question.SurveyID
+	case err != gorm.ErrRecordNotFound:
+		return nil, errors.WrapError(err, "failed to find question")
+	case len(changes) == 0:
+		return nil, errors.ErrNotFound
+	default:
+		surveyID = changes[0].SurveyID
+	}
+
+	// Verify survey ownership
+	survey, err := s.surveyRepo.FindByID(ctx, surveyID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.WrapError(err, "failed to find survey")
+	}
+
+	if survey.UserID != userID {
+		return nil, errors.ErrForbidden
+	}
+
+	result := make([]response.QuestionChangeResponse, len(changes))
+	for i := range changes {
+		result[i] = response.ToQuestionChangeResponse(&changes[i])
+	}
+	return result, nil
+}
+
+// RestoreQuestion reverts a question to its version right after the given history entry
+// Changes made since then are undone except for the position, which is kept so the
+// survey's order stays intact. Restoring a version without options chosen in responses is
+// guarded like an update. The update and its history entry are saved together
+func (s *questionService) RestoreQuestion(ctx context.Context, userID, questionID, versionID uint, req *request.RestoreQuestionRequest) (*response.QuestionResponse, error) {
+	// Find the question
+	question, err := s.questionRepo.FindByID(ctx, questionID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.WrapError(err, "failed to find question")
+	}
+
+	// Verify survey ownership
+	survey, err := s.surveyRepo.FindByID(ctx, question.SurveyID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.WrapError(err, "failed to find survey")
+	}
+
+	if survey.UserID != userID {
+		return nil, errors.ErrForbidden
+	}
+
+	changes, err := s.changeRepo.FindByQuestionID(ctx, questionID)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to find question history")
+	}
+
+	// Undo the changes newer than the version, newest first
+	fields := questionFields(question)
+	found := false
+	for _, change := range changes {
+		if change.ID == versionID {
+			found = change.Action != model.QuestionChangeDeleted
+			break
+		}
+		for _, field := range change.Fields {
+			if field.Old == nil {
+				delete(fields, field.Field)
+			} else {
+				fields[field.Field] = field.Old
+			}
+		}
+	}
+	if !found {
+		return nil, errors.ErrNotFound
+	}
+
+	before := *question
+	if err := applyQuestionFields(question, fields); err != nil {
+		return nil, errors.WrapError(err, "failed to restore question fields")
+	}
+	question.Order = before.Order
+
+	// Validation rules may have changed since the version was saved
+	if err := s.validateQuestionConfig(question.Type, &question.Config); err != nil {
+		return nil, err
+	}
+	if err := validatePrefillType(question.Type, question.PrefillKey, question.PrefillType, question.LockPrefill, question.Hidden); err != nil {
+		return nil, err
+	}
+	if err := validateQuestionPool(survey, question.Pool, question.Hidden); err != nil {
+		return nil, err
+	}
+	if err := validateQuestionVariant(survey, question.Variant); err != nil {
+		return nil, err
+	}
+	if err := s.requireQuestionFeatures(ctx, userID, &before, question.Type, &question.Config); err != nil {
+		return nil, err
+	}
+
+	change := questionChange(&before, question, userID, model.QuestionChangeRestored)
+	if len(change.Fields) == 0 {
+		return response.ToQuestionResponse(question), nil
+	}
+	change.RestoredFromID = &versionID
+
+	if err := s.checkRemovedOptions(ctx, &before, question, &req.OptionRemoval); err != nil {
+		return nil, err
+	}
+	if err := s.remapRemovedOptions(ctx, &before, &req.OptionRemoval); err != nil {
+		return nil, err
+	}
+
+	if err := s.questionRepo.UpdateWithChange(ctx, question, &change); err != nil {
+		return nil, errors.WrapError(err, "failed to restore question")
+	}
+
+	recordEvent(ctx, s.eventRepo, questionEvent(question, userID, model.EventQuestionUpdated))
+
+	// Options or the type may have changed, so the statistics counters are rebuilt too
+	if err := s.cache.InvalidateSurvey(ctx, question.SurveyID); err != nil {
+		fmt.Printf("failed to invalidate survey cache: %v\n", err)
+	}
+
+	return response.ToQuestionResponse(question), nil
+}
+
+// recordQuestionChanges stores question changes, logging failures since the edit itself succeeded
+func (s *questionService) recordQuestionChanges(ctx context.Context, changes ...model.QuestionChange) {
+	if err := s.changeRepo.Create(ctx, changes); err != nil {
+		fmt.Printf("failed to record question history: %v\n", err)
+	}
+}
+
+// questionChange builds the history entry of an edit from the question before and after it;
+// before is nil for created questions and after is nil for deleted ones
+// The entry has no fields when nothing changed
+func questionChange(before, after *model.Question, userID uint, action string) model.QuestionChange {
+	question := after
+	if question == nil {
+		question = before
+	}
+	return model.QuestionChange{
+		QuestionID: question.ID,
+		SurveyID:   question.SurveyID,
+		UserID:     userID,
+		Action:     action,
+		Fields:     diffQuestionFields(questionFields(before), questionFields(after)),
+	}
+}
+
+// questionFields flattens the editable fields of a question into JSON values keyed by
+// field name, with each config setting as a separate "config.<name>" field
+func questionFields(question *model.Question) map[string]interface{} {
+	fields := make(map[string]interface{})
+	if question == nil {
+		return fields
+	}
+
+	decodeInto(fields, "", questionFieldValues{
+		Type:        question.Type,
+		Title:       question.Title,
+		Description: question.Description,
+		Required:    question.Required,
+		Order:       question.Order,
+		PrefillKey:  question.PrefillKey,
+		PrefillType: question.PrefillType,
+		LockPrefill: question.LockPrefill,
+		Hidden:      question.Hidden,
+		Sensitive:   question.Sensitive,
+		Pool:        question.Pool,
+		Variant:     question.Variant,
+	})
+	decodeInto(fields, "config.", question.Config)
+	return fields
+}
+
+// decodeInto adds the JSON object fields of value to fields, prefixing their names
+func decodeInto(fields map[string]interface{}, prefix string, value interface{}) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return
+	}
+	for name, v := range decoded {
+		fields[prefix+name] = v
+	}
+}
+
+// applyQuestionFields sets the editable fields of a question from values flattened
+// by questionFields; config settings missing from fields are cleared
+func applyQuestionFields(question *model.Question, fields map[string]interface{}) error {
+	values := make(map[string]interface{})
+	config := make(map[string]interface{})
+	for name, value := range fields {
+		if setting, ok := strings.CutPrefix(name, "config."); ok {
+			config[setting] = value
+		} else {
+			values[name] = value
+		}
+	}
+
+	var restored questionFieldValues
+	if err := remarshal(values, &restored); err != nil {
+		return err
+	}
+	var restoredConfig model.QuestionConfig
+	if err := remarshal(config, &restoredConfig); err != nil {
+		return err
+	}
+
+	question.Type = restored.Type
+	question.Title = restored.Title
+	question.Description = restored.Description
+	question.Required = restored.Required
+	question.Order = restored.Order
+	question.Config = restoredConfig
+	question.PrefillKey = restored.PrefillKey
+	question.PrefillType = restored.PrefillType
+	question.LockPrefill = restored.LockPrefill
+	question.Hidden = restored.Hidden
+	question.Sensitive = restored.Sensitive
+	question.Pool = restored.Pool
+	question.Variant = restored.Variant
+	return nil
+}
+
+// remarshal converts decoded JSON values into target through JSON
+func remarshal(value interface{}, target interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, target)
+}
+
+// diffQuestionFields lists the fields whose values differ, sorted by field name
+func diffQuestionFields(before, after map[string]interface{}) model.FieldChanges {
+	names := make([]string, 0, len(before)+len(after))
+	for name := range before {
+		names = append(names, name)
+	}
+	for name := range after {
+		if _, ok := before[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	changes := model.FieldChanges{}
+	for _, name := range names {
+		if !reflect.DeepEqual(before[name], after[name]) {
+			changes = append(changes, model.FieldChange{Field: name, Old: before[name], New: after[name]})
+		}
+	}
+	return changes
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+
+	"survey-system/internal/dto/response"
+	"survey-system/internal/model"
+	"survey-system/internal/repository"
+	"survey-system/pkg/errors"
+)
+
+// Batch size of the responses and links read for an impact report, and how many
+// rejected answers and links it lists
+const (
+	impactBatchSize = 500
+	impactSamples   = 20
+)
+
+// UpdateImpactAnalyzer reports how a question update would affect the responses
+// collected so far and the links that can still be opened
+type UpdateImpactAnalyzer interface {
+	QuestionUpdateImpact(ctx context.Context, before, after *model.Question) (*response.QuestionUpdateImpact, error)
+}
+
+// QuestionUpdateImpact checks the answers given to a question and the prefill data of the
+// survey's outstanding links against the updated question with the rules applied on
+// submission. Only answers and links accepted now and rejected after the update count
+func (s *ResponseService) QuestionUpdateImpact(ctx context.Context, before, after *model.Question) (*response.QuestionUpdateImpact, error) {
+	impact := &response.QuestionUpdateImpact{
+		QuestionID:    after.ID,
+		Changes:       questionChange(before, after, 0, model.QuestionChangeUpdated).Fields,
+		AnswerSamples: []response.InvalidAnswerSample{},
+		LinkSamples:   []response.BrokenLinkSample{},
+	}
+	if impact.Changes == nil {
+		impact.Changes = []model.FieldChange{}
+	}
+
+	if err := s.answerImpact(ctx, before, after, impact); err != nil {
+		return nil, err
+	}
+	if err := s.linkImpact(ctx, after, impact); err != nil {
+		return nil, err
+	}
+	return impact, nil
+}
+
+// answerImpact counts the stored answers of a question the updated question would reject
+func (s *ResponseService) answerImpact(ctx context.Context, before, after *model.Question, impact *response.QuestionUpdateImpact) error {
+	filter := repository.ResponseFilter{QuestionID: after.ID, IncludeTest: true}
+	var cursor *repository.ResponseCursor
+	for {
+		responses, err := s.responseRepo.FindBySurveyIDAfter(ctx, after.SurveyID, filter, cursor, impactBatchSize)
+		if err != nil {
+			return errors.WrapError(err, "failed to find responses")
+		}
+
+		for _, resp := range responses {
+			for _, answer := range resp.Data.Answers {
+				if answer.QuestionID != after.ID {
+					continue
+				}
+				impact.CheckedResponses++
+				if s.validateAnswer(before, answer.Value) != nil {
+					break
+				}
+				if err := s.validateAnswer(after, answer.Value); err != nil {
+					impact.InvalidAnswers++
+					if len(impact.AnswerSamples) < impactSamples {
+						impact.AnswerSamples = append(impact.AnswerSamples, response.InvalidAnswerSample{
+							ResponseID: resp.ID,
+							Value:      answer.Value,
+							Reason:     err.Error(),
+						})
+					}
+				}
+				break
+			}
+		}
+
+		if len(responses) < impactBatchSize {
+			return nil
+		}
+		last := responses[len(responses)-1]
+		cursor = &repository.ResponseCursor{SubmittedAt: last.SubmittedAt, ID: last.ID}
+	}
+}
+
+// linkImpact counts the outstanding links of a survey whose prefill data the updated
+// question would reject, e.g. because its prefill key was renamed or an option removed
+func (s *ResponseService) linkImpact(ctx context.Context, after *model.Question, impact *response.QuestionUpdateImpact) error {
+	current, err := s.questionRepo.FindBySurveyID(ctx, after.SurveyID)
+	if err != nil {
+		return errors.WrapError(err, "failed to find questions")
+	}
+	updated := make([]model.Question, len(current))
+	copy(updated, current)
+	for i := range updated {
+		if updated[i].ID == after.ID {
+			updated[i] = *after
+		}
+	}
+
+	var afterID uint
+	for {
+		links, err := s.oneLinkRepo.FindOutstandingWithPrefill(ctx, after.SurveyID, afterID, impactBatchSize)
+		if err != nil {
+			return errors.WrapError(err, "failed to find links")
+		}
+
+		for _, link := range links {
+			impact.CheckedLinks++
+			prefillData := map[string]interface{}(link.PrefillData)
+			if validatePrefillData(current, prefillData) != nil {
+				continue
+			}
+			if err := validatePrefillData(updated, prefillData); err != nil {
+				impact.BrokenLinks++
+				if len(impact.LinkSamples) < impactSamples {
+					impact.LinkSamples = append(impact.LinkSamples, response.BrokenLinkSample{
+						LinkID:    link.ID,
+						Campaign:  link.Campaign,
+						ExpiresAt: link.ExpiresAt,
+						Reason:    err.Error(),
+					})
+				}
+			}
+		}
+
+		if len(links) < impactBatchSize {
+			return nil
+		}
+		afterID = links[len(links)-1].ID
+	}
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"survey-system/internal/dto/request"
+	"survey-system/internal/model"
+	"survey-system/internal/repository"
+	"survey-system/pkg/errors"
+)
+
+// QuestionAnswers inspects and migrates the answers collected for a question when the
+// question is updated
+type QuestionAnswers interface {
+	UpdateImpactAnalyzer
+	CountOptionAnswers(ctx context.Context, question *model.Question, optionIDs []string) (int64, error)
+	RemapOptionAnswers(ctx context.Context, question *model.Question, remap map[string]string) (int64, error)
+}
+
+// checkRemovedOptions guards the options an update, option import or restore removes from
+// a choice question. Remapped options must be removed ones and map to options of the
+// updated question. Removed options without a remap that non-test responses chose block
+// the edit unless the request forces their removal
+func (s *questionService) checkRemovedOptions(ctx context.Context, before, after *model.Question, req *request.OptionRemoval) error {
+	removed := removedOptions(before, after)
+	for from, to := range req.OptionRemap {
+		if !slices.Contains(removed, from) {
+			return errors.NewLocalizedValidationError("option_remap", "question.remap_option_not_removed", from)
+		}
+		if !isChoiceQuestion(after) || !slices.ContainsFunc(after.Config.Options, func(o model.QuestionOption) bool { return o.ID == to }) {
+			return errors.NewLocalizedValidationError("option_remap", "question.remap_option_unknown", to)
+		}
+	}
+	if req.ForceOptionRemoval {
+		return nil
+	}
+
+	var unmapped []string
+	for _, id := range removed {
+		if _, ok := req.OptionRemap[id]; !ok {
+			unmapped = append(unmapped, id)
+		}
+	}
+	if len(unmapped) == 0 {
+		return nil
+	}
+	count, err := s.answers.CountOptionAnswers(ctx, before, unmapped)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.NewLocalizedError("OPTIONS_IN_USE", 409, "question.options_in_use", strings.Join(unmapped, ", "), count)
+	}
+	return nil
+}
+
+// remapRemovedOptions rewrites the stored answers choosing remapped options, before the
+// edit removing them is saved: should saving fail, retrying it finds nothing left to remap
+func (s *questionService) remapRemovedOptions(ctx context.Context, before *model.Question, req *request.OptionRemoval) error {
+	if len(req.OptionRemap) == 0 {
+		return nil
+	}
+	_, err := s.answers.RemapOptionAnswers(ctx, before, req.OptionRemap)
+	return err
+}
+
+// removedOptions returns the IDs of the options of a choice question an update drops,
+// all of them when the question stops being a choice question
+func removedOptions(before, after *model.Question) []string {
+	if !isChoiceQuestion(before) {
+		return nil
+	}
+
+	kept := make(map[string]bool)
+	if isChoiceQuestion(after) {
+		for _, option := range after.Config.Options {
+			kept[option.ID] = true
+		}
+	}
+	var removed []string
+	for _, option := range before.Config.Options {
+		if !kept[option.ID] {
+			removed = append(removed, option.ID)
+		}
+	}
+	return removed
+}
+
+func isChoiceQuestion(question *model.Question) bool {
+	return question.Type == model.QuestionTypeSingle || question.Type == model.QuestionTypeMultiple
+}
+
+// CountOptionAnswers counts the non-test responses that chose any of the given options
+// of a question
+func (s *ResponseService) CountOptionAnswers(ctx context.Context, question *model.Question, optionIDs []string) (int64, error) {
+	var total int64
+	for _, id := range optionIDs {
+		count, err := s.responseRepo.CountByFilter(ctx, question.SurveyID, repository.ResponseFilter{QuestionID: question.ID, OptionID: id})
+		if err != nil {
+			return 0, errors.WrapError(err, "failed to count responses")
+		}
+		total += count
+	}
+	return total, nil
+}
+
+// RemapOptionAnswers replaces options in the stored answers to a question, test responses
+// included, and returns the number of responses changed. A multiple choice answer that
+// already holds the new option keeps it once
+func (s *ResponseService) RemapOptionAnswers(ctx context.Context, question *model.Question, remap map[string]string) (int64, error) {
+	var changed int64
+	for from, to := range remap {
+		filter := repository.ResponseFilter{QuestionID: question.ID, OptionID: from, IncludeTest: true}
+		// Remapped responses stop matching the filter, so the first batch is read until none is left
+		for {
+			responses, err := s.responseRepo.FindBySurveyIDAfter(ctx, question.SurveyID, filter, nil, impactBatchSize)
+			if err != nil {
+				return changed, errors.WrapError(err, "failed to find responses")
+			}
+			if len(responses) == 0 {
+				break
+			}
+
+			for i := range responses {
+				for j, answer := range responses[i].Data.Answers {
+					if answer.QuestionID == question.ID {
+						responses[i].Data.Answers[j].Value = remapOptionValue(answer.Value, from, to)
+					}
+				}
+			}
+			if err := s.responseRepo.ReplaceData(ctx, responses); err != nil {
+				return changed, errors.WrapError(err, "failed to remap answers")
+			}
+			changed += int64(len(responses))
+
+			if len(responses) < impactBatchSize {
+				break
+			}
+		}
+	}
+	return changed, nil
+}
+
+// remapOptionValue replaces an option in a single or multiple choice answer value
+func remapOptionValue(value interface{}, from, to string) interface{} {
+	switch v := value.(type) {
+	case string:
+		if v == from {
+			return to
+		}
+	case []interface{}:
+		remapped := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			if item == from {
+				item = to
+			}
+			if !slices.Contains(remapped, item) {
+				remapped = append(remapped, item)
+			}
+		}
+		return remapped
+	}
+	return value
+}
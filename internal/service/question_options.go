@@ -0,0 +1,251 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"survey-system/internal/dto/request"
+	"survey-system/internal/dto/response"
+	"survey-system/internal/model"
+	"survey-system/pkg/errors"
+
+	"gorm.io/gorm"
+)
+
+// Columns of an option CSV
+const (
+	optionColumnID    = "id"
+	optionColumnLabel = "label"
+	optionColumnScore = "score"
+)
+
+// optionLayouts are the columns of headerless option CSV rows by their number of cells
+var optionLayouts = map[int][]string{
+	1: {optionColumnLabel},
+	2: {optionColumnID, optionColumnLabel},
+	3: {optionColumnID, optionColumnLabel, optionColumnScore},
+}
+
+// ImportQuestionOptions adds choice options to a question from CSV text or a built-in option set
+// Options keep their order; rows whose ID is already present, in the question or earlier in
+// the import, are skipped. In replace mode the question's existing options are dropped first.
+func (s *questionService) ImportQuestionOptions(ctx context.Context, userID, questionID uint, req *request.ImportQuestionOptionsRequest) (*response.ImportQuestionOptionsResponse, error) {
+	// Find the question
+	question, err := s.questionRepo.FindByID(ctx, questionID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.WrapError(err, "failed to find question")
+	}
+
+	// Verify survey ownership
+	survey, err := s.surveyRepo.FindByID(ctx, question.SurveyID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.WrapError(err, "failed to find survey")
+	}
+
+	if survey.UserID != userID {
+		return nil, errors.ErrForbidden
+	}
+
+	if question.Type != model.QuestionTypeSingle && question.Type != model.QuestionTypeMultiple {
+		return nil, errors.NewLocalizedValidationError("type", "question.option_import_not_choice")
+	}
+
+	var imported []model.QuestionOption
+	if req.Set != "" {
+		set, ok := s.optionSets.Get(req.Set)
+		if !ok {
+			return nil, errors.NewLocalizedValidationError("set", "question.option_set_unknown", req.Set)
+		}
+		imported = make([]model.QuestionOption, len(set.Options))
+		for i, option := range set.Options {
+			imported[i] = model.QuestionOption{ID: option.ID, Label: option.Label}
+		}
+	} else {
+		imported, err = parseOptionCSV(req.CSV)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var options []model.QuestionOption
+	if req.Mode != "replace" {
+		options = append(options, question.Config.Options...)
+	}
+	seen := make(map[string]bool, len(options)+len(imported))
+	for _, option := range options {
+		seen[option.ID] = true
+	}
+
+	result := &response.ImportQuestionOptionsResponse{}
+	for _, option := range imported {
+		if seen[option.ID] {
+			result.Duplicates++
+			continue
+		}
+		seen[option.ID] = true
+		options = append(options, option)
+		result.Imported++
+	}
+
+	if len(options) > s.maxOptions {
+		return nil, errors.NewLocalizedValidationError("config.options", "question.options_too_many", s.maxOptions)
+	}
+
+	before := *question
+	question.Config.Options = options
+	if err := s.validateQuestionConfig(question.Type, &question.Config); err != nil {
+		return nil, err
+	}
+
+	// Replacing options may drop options chosen in responses
+	if err := s.checkRemovedOptions(ctx, &before, question, &req.OptionRemoval); err != nil {
+		return nil, err
+	}
+	if err := s.remapRemovedOptions(ctx, &before, &req.OptionRemoval); err != nil {
+		return nil, err
+	}
+
+	if err := s.questionRepo.Update(ctx, question); err != nil {
+		return nil, errors.WrapError(err, "failed to update question")
+	}
+
+	recordEvent(ctx, s.eventRepo, questionEvent(question, userID, model.EventQuestionUpdated))
+	if change := questionChange(&before, question, userID, model.QuestionChangeUpdated); len(change.Fields) > 0 {
+		s.recordQuestionChanges(ctx, change)
+	}
+
+	// Replaced options change the statistics counters, so they are rebuilt too
+	if err := s.cache.InvalidateSurvey(ctx, question.SurveyID); err != nil {
+		fmt.Printf("failed to invalidate survey cache: %v\n", err)
+	}
+
+	result.Question = response.ToQuestionResponse(question)
+	return result, nil
+}
+
+// ListOptionSets returns the built-in option sets offered for import
+func (s *questionService) ListOptionSets() []response.OptionSetResponse {
+	sets := s.optionSets.Sets()
+	result := make([]response.OptionSetResponse, len(sets))
+	for i, set := range sets {
+		result[i] = response.OptionSetResponse{Name: set.Name, Options: len(set.Options)}
+	}
+	return result
+}
+
+// parseOptionCSV reads choice options from CSV text in row order, skipping empty rows
+// Without a header, rows hold "label", "id,label" or "id,label,score" and a missing
+// ID is taken from the label. A first row made up of these column names is a header.
+func parseOptionCSV(text string) ([]model.QuestionOption, error) {
+	data := bytes.TrimPrefix([]byte(text), utf8BOM)
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.Comma = detectCSVDelimiter(data)
+
+	var header []string
+	var options []model.QuestionOption
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.NewLocalizedValidationError("csv", "question.option_csv_unreadable", err.Error())
+		}
+		line, _ := reader.FieldPos(0)
+
+		for i := range record {
+			record[i] = strings.TrimSpace(record[i])
+		}
+		if emptyImportRow(record) {
+			continue
+		}
+		if header == nil && options == nil && isOptionHeader(record) {
+			header = record
+			for i := range header {
+				header[i] = strings.ToLower(header[i])
+			}
+			continue
+		}
+
+		columns := header
+		if columns == nil {
+			columns = optionLayouts[len(record)]
+			if columns == nil {
+				return nil, errors.NewLocalizedValidationError("csv", "question.option_csv_too_many_columns", line)
+			}
+		}
+
+		option, err := optionFromRow(columns, record, line)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, option)
+	}
+
+	if len(options) == 0 {
+		return nil, errors.NewLocalizedValidationError("csv", "question.option_csv_empty")
+	}
+	return options, nil
+}
+
+// isOptionHeader reports whether a row names option columns and includes the label
+func isOptionHeader(record []string) bool {
+	hasLabel := false
+	for _, cell := range record {
+		switch strings.ToLower(cell) {
+		case optionColumnLabel:
+			hasLabel = true
+		case optionColumnID, optionColumnScore:
+		default:
+			return false
+		}
+	}
+	return hasLabel
+}
+
+// optionFromRow builds an option from the cells of a CSV row laid out as columns
+func optionFromRow(columns, record []string, line int) (model.QuestionOption, error) {
+	var option model.QuestionOption
+	for i, column := range columns {
+		if i >= len(record) {
+			break
+		}
+		switch column {
+		case optionColumnID:
+			option.ID = record[i]
+		case optionColumnLabel:
+			option.Label = record[i]
+		case optionColumnScore:
+			if record[i] == "" {
+				continue
+			}
+			score, err := strconv.ParseFloat(record[i], 64)
+			if err != nil || math.IsNaN(score) || math.IsInf(score, 0) {
+				return option, errors.NewLocalizedValidationError("csv", "question.option_csv_score_invalid", line, record[i])
+			}
+			option.Score = &score
+		}
+	}
+
+	if option.Label == "" {
+		return option, errors.NewLocalizedValidationError("csv", "question.option_csv_label_required", line)
+	}
+	if option.ID == "" {
+		option.ID = option.Label
+	}
+	return option, nil
+}
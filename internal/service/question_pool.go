@@ -0,0 +1,46 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math/rand/v2"
+	"slices"
+
+	"survey-system/internal/model"
+)
+
+// withheldQuestions returns the pool questions not drawn for the respondent of a token.
+// Each pool shuffles its questions with a seed derived from the token and keeps the
+// first ones, so reopening a link shows the same questions. Questions naming a pool the
+// survey no longer defines are shown to everyone, and without a token nothing is withheld
+func withheldQuestions(pools model.QuestionPools, questions []model.Question, token string) map[uint]bool {
+	if len(pools) == 0 || token == "" {
+		return nil
+	}
+
+	members := make(map[string][]uint)
+	for _, question := range questions {
+		if question.Pool != "" {
+			members[question.Pool] = append(members[question.Pool], question.ID)
+		}
+	}
+
+	withheld := make(map[uint]bool)
+	for _, pool := range pools {
+		ids := members[pool.Key]
+		if len(ids) <= pool.Draw {
+			continue
+		}
+		// Sorted by ID so reordering the questions does not change the draw
+		slices.Sort(ids)
+		sum := sha256.Sum256([]byte("pool|" + token + "|" + pool.Key))
+		rng := rand.New(rand.NewPCG(binary.BigEndian.Uint64(sum[:8]), binary.BigEndian.Uint64(sum[8:16])))
+		rng.Shuffle(len(ids), func(i, j int) {
+			ids[i], ids[j] = ids[j], ids[i]
+		})
+		for _, id := range ids[pool.Draw:] {
+			withheld[id] = true
+		}
+	}
+	return withheld
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+
+	"survey-system/internal/dto/request"
+	"survey-system/internal/dto/response"
+	"survey-system/internal/model"
+	"survey-system/internal/repository"
+	"survey-system/pkg/errors"
+
+	"gorm.io/gorm"
+)
+
+// ReportService defines the interface for managing summary report subscriptions
+type ReportService interface {
+	ListSubscriptions(ctx context.Context, userID, surveyID uint) ([]response.ReportSubscriptionResponse, error)
+	Subscribe(ctx context.Context, userID, surveyID uint, req *request.SubscribeReportRequest) (*response.ReportSubscriptionResponse, error)
+	Unsubscribe(ctx context.Context, userID, surveyID, subscriptionID uint) error
+}
+
+// reportService implements ReportService interface
+type reportService struct {
+	reportRepo repository.ReportRepository
+	surveyRepo repository.SurveyRepository
+	userRepo   repository.UserRepository
+}
+
+// NewReportService creates a new report service instance
+func NewReportService(
+	reportRepo repository.ReportRepository,
+	surveyRepo repository.SurveyRepository,
+	userRepo repository.UserRepository,
+) ReportService {
+	return &reportService{
+		reportRepo: reportRepo,
+		surveyRepo: surveyRepo,
+		userRepo:   userRepo,
+	}
+}
+
+// ListSubscriptions returns the report subscriptions of a survey
+func (s *reportService) ListSubscriptions(ctx context.Context, userID, surveyID uint) ([]response.ReportSubscriptionResponse, error) {
+	if err := s.checkOwnership(ctx, userID, surveyID); err != nil {
+		return nil, err
+	}
+
+	subscriptions, err := s.reportRepo.FindBySurveyID(ctx, surveyID)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to find report subscriptions")
+	}
+
+	result := make([]response.ReportSubscriptionResponse, len(subscriptions))
+	for i := range subscriptions {
+		result[i] = response.ToReportSubscriptionResponse(&subscriptions[i])
+	}
+	return result, nil
+}
+
+// Subscribe subscribes an email address, by default the owner's, to daily or weekly reports
+func (s *reportService) Subscribe(ctx context.Context, userID, surveyID uint, req *request.SubscribeReportRequest) (*response.ReportSubscriptionResponse, error) {
+	if err := s.checkOwnership(ctx, userID, surveyID); err != nil {
+		return nil, err
+	}
+
+	address := req.Email
+	if address == "" {
+		user, err := s.userRepo.FindByID(ctx, userID)
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to find user")
+		}
+		if user.Email == "" {
+			return nil, errors.NewValidationError("email", "email is required when the account has no email address")
+		}
+		address = user.Email
+	}
+
+	subscription := &model.ReportSubscription{
+		SurveyID:  surveyID,
+		UserID:    userID,
+		Frequency: req.Frequency,
+		Email:     address,
+	}
+	if err := s.reportRepo.Create(ctx, subscription); err != nil {
+		if err == gorm.ErrDuplicatedKey {
+			return nil, errors.ErrAlreadySubscribed
+		}
+		return nil, errors.WrapError(err, "failed to create report subscription")
+	}
+
+	result := response.ToReportSubscriptionResponse(subscription)
+	return &result, nil
+}
+
+// Unsubscribe removes a report subscription of a survey
+func (s *reportService) Unsubscribe(ctx context.Context, userID, surveyID, subscriptionID uint) error {
+	if err := s.checkOwnership(ctx, userID, surveyID); err != nil {
+		return err
+	}
+
+	subscription, err := s.reportRepo.FindByID(ctx, subscriptionID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return errors.ErrNotFound
+		}
+		return errors.WrapError(err, "failed to find report subscription")
+	}
+
+	if subscription.SurveyID != surveyID {
+		return errors.ErrNotFound
+	}
+
+	if err := s.reportRepo.Delete(ctx, subscription.ID); err != nil {
+		return errors.WrapError(err, "failed to delete report subscription")
+	}
+	return nil
+}
+
+// checkOwnership verifies that the survey exists and belongs to the user
+func (s *reportService) checkOwnership(ctx context.Context, userID, surveyID uint) error {
+	survey, err := s.surveyRepo.FindByID(ctx, surveyID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return errors.ErrNotFound
+		}
+		return errors.WrapError(err, "failed to find survey")
+	}
+
+	if survey.UserID != userID {
+		return errors.ErrForbidden
+	}
+	return nil
+}
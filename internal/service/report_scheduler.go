@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"survey-system/internal/dto/response"
+	"survey-system/internal/model"
+	"survey-system/internal/repository"
+	"survey-system/pkg/email"
+)
+
+// reportSchedulerBatchSize caps how many subscriptions of a frequency are processed per run
+const reportSchedulerBatchSize = 200
+
+// ReportScheduler periodically emails daily and weekly survey summaries to subscribers
+type ReportScheduler struct {
+	reportRepo   repository.ReportRepository
+	responseRepo repository.ResponseRepository
+	statistics   *ResponseService
+	cache        Cache
+	mailer       email.Sender
+	interval     time.Duration
+	sendHour     int
+	topOptions   int
+}
+
+// NewReportScheduler creates a new ReportScheduler
+func NewReportScheduler(
+	reportRepo repository.ReportRepository,
+	responseRepo repository.ResponseRepository,
+	statistics *ResponseService,
+	cache Cache,
+	mailer email.Sender,
+	interval time.Duration,
+	sendHour int,
+	topOptions int,
+) *ReportScheduler {
+	return &ReportScheduler{
+		reportRepo:   reportRepo,
+		responseRepo: responseRepo,
+		statistics:   statistics,
+		cache:        cache,
+		mailer:       mailer,
+		interval:     interval,
+		sendHour:     sendHour,
+		topOptions:   topOptions,
+	}
+}
+
+// Run sends due reports every interval until ctx is cancelled
+// A non-positive interval disables the scheduler
+func (r *ReportScheduler) Run(ctx context.Context) {
+	if r.interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.SendDue(ctx); err != nil {
+			log.Printf("report scheduler: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// SendDue sends every report whose period has started and that was not sent yet
+// Subscriptions are only marked as sent once the email was delivered, so
+// failures are retried on the next run
+func (r *ReportScheduler) SendDue(ctx context.Context) error {
+	// Only one instance should send at a time
+	lockKey := "reports:scheduler"
+	acquired, err := r.cache.AcquireLock(ctx, lockKey, r.interval)
+	if err != nil {
+		return fmt.Errorf("failed to acquire lock: %w", err)
+	}
+	if !acquired {
+		return nil
+	}
+	defer r.cache.ReleaseLock(ctx, lockKey)
+
+	now := time.Now()
+	for _, frequency := range []string{model.ReportFrequencyDaily, model.ReportFrequencyWeekly} {
+		periodStart := reportPeriodStart(frequency, now, r.sendHour)
+
+		subscriptions, err := r.reportRepo.FindDue(ctx, frequency, periodStart, reportSchedulerBatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to find due %s reports: %w", frequency, err)
+		}
+
+		for i := range subscriptions {
+			r.send(ctx, &subscriptions[i], periodStart, now)
+		}
+	}
+
+	return nil
+}
+
+// send delivers a single report
+func (r *ReportScheduler) send(ctx context.Context, subscription *model.ReportSubscription, periodStart, now time.Time) {
+	survey := &subscription.Survey
+
+	// Archived surveys no longer collect responses; skip the period without sending
+	if survey.Status != model.SurveyStatusArchived {
+		stats, err := r.statistics.GetStatistics(ctx, survey.UserID, survey.ID, false)
+		if err != nil {
+			log.Printf("report scheduler: statistics for survey %d failed: %v", survey.ID, err)
+			return
+		}
+
+		since := previousPeriodStart(subscription.Frequency, periodStart)
+		dailyCounts, err := r.responseRepo.CountByDay(ctx, survey.ID, since)
+		if err != nil {
+			log.Printf("report scheduler: response counts for survey %d failed: %v", survey.ID, err)
+			return
+		}
+		var newResponses int64
+		for _, daily := range dailyCounts {
+			newResponses += daily.Count
+		}
+
+		msg := buildSummaryReportEmail(subscription, stats, newResponses, since, now, r.topOptions)
+		if err := r.mailer.Send(ctx, msg); err != nil {
+			log.Printf("report scheduler: email for subscription %d failed: %v", subscription.ID, err)
+			return
+		}
+	}
+
+	if err := r.reportRepo.MarkAsSent(ctx, subscription.ID, now); err != nil {
+		log.Printf("report scheduler: failed to mark subscription %d as sent: %v", subscription.ID, err)
+	}
+}
+
+// reportPeriodStart returns when the current report period began: today's send
+// hour for daily reports and this week's Monday send hour for weekly reports
+func reportPeriodStart(frequency string, now time.Time, sendHour int) time.Time {
+	start := time.Date(now.Year(), now.Month(), now.Day(), sendHour, 0, 0, 0, now.Location())
+	if frequency == model.ReportFrequencyWeekly {
+		daysSinceMonday := (int(start.Weekday()) + 6) % 7
+		start = start.AddDate(0, 0, -daysSinceMonday)
+	}
+
+	if start.After(now) {
+		start = previousPeriodStart(frequency, start)
+	}
+	return start
+}
+
+// previousPeriodStart returns the start of the period before the one starting at start
+func previousPeriodStart(frequency string, start time.Time) time.Time {
+	if frequency == model.ReportFrequencyWeekly {
+		return start.AddDate(0, 0, -7)
+	}
+	return start.AddDate(0, 0, -1)
+}
+
+// buildSummaryReportEmail builds the summary email of a survey
+func buildSummaryReportEmail(subscription *model.ReportSubscription, stats *response.StatisticsResponse, newResponses int64, since, now time.Time, topOptions int) *email.Message {
+	survey := &subscription.Survey
+	period := "每日"
+	if subscription.Frequency == model.ReportFrequencyWeekly {
+		period = "每周"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "您好，\n\n以下是问卷「%s」的%s汇总（统计截至 %s）：\n\n", survey.Title, period, now.Format("2006-01-02 15:04"))
+	fmt.Fprintf(&b, "填答总数：%d\n", stats.TotalResponses)
+	fmt.Fprintf(&b, "新增填答：%d（自 %s 起）\n", newResponses, since.Format("2006-01-02 15:04"))
+	fmt.Fprintf(&b, "完成率：%.1f%%\n", stats.CompletionRate)
+
+	if len(stats.Questions) > 0 {
+		b.WriteString("\n各题作答分布：\n")
+	}
+	for i, question := range stats.Questions {
+		fmt.Fprintf(&b, "\n%d. %s（已作答 %d）\n", i+1, question.Title, question.Answered)
+		writeQuestionSummary(&b, &question, topOptions)
+	}
+
+	b.WriteString("\n如不再需要此报告，可在问卷设置中取消订阅。\n")
+
+	return &email.Message{
+		To:      []string{subscription.Email},
+		Subject: fmt.Sprintf("问卷%s汇总：%s", period, survey.Title),
+		Body:    b.String(),
+	}
+}
+
+// writeQuestionSummary writes the most selected options, NPS breakdown or numeric summary of a question
+func writeQuestionSummary(b *strings.Builder, question *response.QuestionStatistics, topOptions int) {
+	switch {
+	case question.NPS != nil:
+		nps := question.NPS
+		fmt.Fprintf(b, "   NPS：%.1f（推荐者 %.1f%%，中立者 %.1f%%，贬损者 %.1f%%）\n",
+			nps.Score, nps.PromotersPercent, nps.PassivesPercent, nps.DetractorsPercent)
+	case question.Numeric != nil:
+		numeric := question.Numeric
+		fmt.Fprintf(b, "   平均值：%.2f%s，中位数：%.2f%s\n", numeric.Mean, numeric.Unit, numeric.Median, numeric.Unit)
+	case len(question.Options) > 0:
+		options := make([]response.OptionStatistics, len(question.Options))
+		copy(options, question.Options)
+		sort.SliceStable(options, func(i, j int) bool {
+			return options[i].Count > options[j].Count
+		})
+		if topOptions > 0 && len(options) > topOptions {
+			options = options[:topOptions]
+		}
+
+		for _, option := range options {
+			percent := 0.0
+			if question.Answered > 0 {
+				percent = float64(option.Count) / float64(question.Answered) * 100
+			}
+			fmt.Fprintf(b, "   - %s：%d（%.1f%%）\n", option.Label, option.Count, percent)
+		}
+		if question.AverageScore != nil {
+			fmt.Fprintf(b, "   平均分：%.2f\n", *question.AverageScore)
+		}
+	}
+}
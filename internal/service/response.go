@@ -2,9 +2,16 @@ package service
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"survey-system/internal/cache"
 	"survey-system/internal/dto/request"
@@ -12,6 +19,9 @@ import (
 	"survey-system/internal/model"
 	"survey-system/internal/repository"
 	"survey-system/pkg/errors"
+	"survey-system/pkg/utils"
+
+	"gorm.io/gorm"
 )
 
 // ResponseService handles response-related business logic
@@ -20,9 +30,27 @@ type ResponseService struct {
 	surveyRepo    repository.SurveyRepository
 	questionRepo  repository.QuestionRepository
 	oneLinkRepo   repository.OneLinkRepository
+	draftRepo     repository.DraftRepository
+	commentRepo   repository.CommentRepository
 	encryptionSvc EncryptionService
 	cache         cache.Cache
 	exportSvc     *ExportService
+	notifier      ResponseNotifier
+	limits        SubmissionLimits
+	statsOpts     StatisticsOptions
+	importOpts    ImportOptions
+	numbering     RespondentNumbering
+	bursts        *BurstDetector
+	authz         *utils.AuthorizationUtil
+}
+
+// SubmissionLimits caps the size of submitted answers and sets the re-submit
+// window. Zero values disable the corresponding limit.
+type SubmissionLimits struct {
+	MaxTextLength  int           // characters per text answer or table cell
+	MaxTableRows   int           // rows per table answer
+	ResubmitWindow time.Duration // how long a re-submit through a used link returns the original result
+	MinAnswerTime  time.Duration // average time per answer below which a fully timed response is flagged
 }
 
 // NewResponseService creates a new ResponseService
@@ -31,43 +59,62 @@ func NewResponseService(
 	surveyRepo repository.SurveyRepository,
 	questionRepo repository.QuestionRepository,
 	oneLinkRepo repository.OneLinkRepository,
+	draftRepo repository.DraftRepository,
+	commentRepo repository.CommentRepository,
 	encryptionSvc EncryptionService,
 	cache cache.Cache,
 	exportSvc *ExportService,
+	notifier ResponseNotifier,
+	limits SubmissionLimits,
+	statsOpts StatisticsOptions,
+	importOpts ImportOptions,
+	numbering RespondentNumbering,
+	bursts *BurstDetector,
+	authz *utils.AuthorizationUtil,
 ) *ResponseService {
 	return &ResponseService{
 		responseRepo:  responseRepo,
 		surveyRepo:    surveyRepo,
 		questionRepo:  questionRepo,
 		oneLinkRepo:   oneLinkRepo,
+		draftRepo:     draftRepo,
+		commentRepo:   commentRepo,
 		encryptionSvc: encryptionSvc,
 		cache:         cache,
 		exportSvc:     exportSvc,
+		notifier:      notifier,
+		limits:        limits,
+		statsOpts:     statsOpts,
+		importOpts:    importOpts,
+		numbering:     numbering,
+		bursts:        bursts,
+		authz:         authz,
 	}
 }
 
 // validateResponseData validates the response data against question configurations
-func (s *ResponseService) validateResponseData(questions []model.Question, answers []request.AnswerRequest) error {
+// Withheld pool questions need no answer, but answering them is accepted
+func (s *ResponseService) validateResponseData(questions []model.Question, answers []request.AnswerRequest, withheld map[uint]bool) error {
 	// Create a map of question ID to question for easy lookup
 	questionMap := make(map[uint]*model.Question)
 	for i := range questions {
 		questionMap[questions[i].ID] = &questions[i]
 	}
 
-	// Create a map of answered question IDs
+	// Create a map of answered question IDs, rejecting duplicates so a
+	// payload cannot repeat the same answer arbitrarily often
 	answeredQuestions := make(map[uint]bool)
 	for _, answer := range answers {
+		if answeredQuestions[answer.QuestionID] {
+			return errors.NewLocalizedError("VALIDATION_FAILED", 400, "validation.duplicate_answer", answer.QuestionID)
+		}
 		answeredQuestions[answer.QuestionID] = true
 	}
 
 	// Check all required questions are answered
 	for _, question := range questions {
-		if question.Required && !answeredQuestions[question.ID] {
-			return &errors.AppError{
-				Code:    "VALIDATION_FAILED",
-				Message: fmt.Sprintf("必填题目 '%s' 未回答", question.Title),
-				Status:  400,
-			}
+		if question.Required && !withheld[question.ID] && !answeredQuestions[question.ID] {
+			return errors.NewLocalizedError("VALIDATION_FAILED", 400, "validation.required_unanswered", question.Title)
 		}
 	}
 
@@ -75,11 +122,7 @@ func (s *ResponseService) validateResponseData(questions []model.Question, answe
 	for _, answer := range answers {
 		question, exists := questionMap[answer.QuestionID]
 		if !exists {
-			return &errors.AppError{
-				Code:    "VALIDATION_FAILED",
-				Message: fmt.Sprintf("题目 ID %d 不存在", answer.QuestionID),
-				Status:  400,
-			}
+			return errors.NewLocalizedError("VALIDATION_FAILED", 400, "validation.question_not_found", answer.QuestionID)
 		}
 
 		if err := s.validateAnswer(question, answer.Value); err != nil {
@@ -90,6 +133,148 @@ func (s *ResponseService) validateResponseData(questions []model.Question, answe
 	return nil
 }
 
+// withHiddenAnswers adds the answers of hidden questions from the prefill data of the
+// token. Hidden questions are not shown to respondents, so answers submitted for them
+// are rejected
+func withHiddenAnswers(questions []model.Question, prefillData map[string]interface{}, answers []request.AnswerRequest) ([]request.AnswerRequest, error) {
+	hidden := make(map[uint]bool)
+	for _, question := range questions {
+		if question.Hidden {
+			hidden[question.ID] = true
+		}
+	}
+	if len(hidden) == 0 {
+		return answers, nil
+	}
+
+	for _, answer := range answers {
+		if hidden[answer.QuestionID] {
+			return nil, errors.NewLocalizedError("VALIDATION_FAILED", 400, "validation.hidden_question_answered", answer.QuestionID)
+		}
+	}
+
+	result := append([]request.AnswerRequest(nil), answers...)
+	for _, question := range questions {
+		if !question.Hidden {
+			continue
+		}
+		if value, ok := prefillData[question.PrefillKey]; ok {
+			result = append(result, request.AnswerRequest{QuestionID: question.ID, Value: value})
+		}
+	}
+	return result, nil
+}
+
+// withTableDefaults returns the answers with the empty cells of table columns that apply
+// their default filled in. The submitted answers are left unchanged
+func withTableDefaults(questions []model.Question, answers []request.AnswerRequest) []request.AnswerRequest {
+	byID := make(map[uint]*model.Question, len(questions))
+	for i := range questions {
+		byID[questions[i].ID] = &questions[i]
+	}
+
+	var result []request.AnswerRequest
+	for i, answer := range answers {
+		question, ok := byID[answer.QuestionID]
+		if !ok || question.Type != model.QuestionTypeTable {
+			continue
+		}
+		if filled := applyTableDefaults(question, answer.Value); filled != nil {
+			if result == nil {
+				result = append([]request.AnswerRequest(nil), answers...)
+			}
+			result[i].Value = filled
+		}
+	}
+	if result == nil {
+		return answers
+	}
+	return result
+}
+
+// applyTableDefaults returns a copy of a table answer with the empty cells of columns that
+// apply their default filled in, or nil when nothing was filled. A cascade default is only
+// filled in when it is an option of the row's parent value. Malformed answers are left to
+// the validation
+func applyTableDefaults(question *model.Question, value interface{}) []interface{} {
+	columns := question.Config.Columns
+	rows, ok := value.([]interface{})
+	if !ok {
+		return nil
+	}
+	parents := cascadeParents(columns)
+
+	// The totals row ends the rows and is not filled in
+	dataRows := rows
+	if hasTotals(question) && len(rows) > 0 {
+		dataRows = rows[:len(rows)-1]
+	}
+
+	var result []interface{}
+	for rowIdx, rowValue := range dataRows {
+		row, ok := rowValue.([]interface{})
+		if !ok || len(row) != len(columns) {
+			continue
+		}
+
+		var filled []interface{}
+		for colIdx, column := range columns {
+			if !column.ApplyDefault || row[colIdx] != "" {
+				continue
+			}
+			if parentIdx, ok := parents[colIdx]; ok {
+				cells := row
+				if filled != nil {
+					cells = filled
+				}
+				parentValue, _ := cells[parentIdx].(string)
+				if !slices.Contains(column.Cascade[parentValue], column.Default) {
+					continue
+				}
+			}
+			if filled == nil {
+				filled = append([]interface{}(nil), row...)
+			}
+			filled[colIdx] = column.Default
+		}
+
+		if filled != nil {
+			if result == nil {
+				result = append([]interface{}(nil), rows...)
+			}
+			result[rowIdx] = filled
+		}
+	}
+	return result
+}
+
+// validateLockedPrefill rejects submissions that change or omit the prefilled answer of
+// a question with lock_prefill, comparing against the prefill data of the token
+func validateLockedPrefill(questions []model.Question, prefillData map[string]interface{}, answers []request.AnswerRequest) error {
+	if len(prefillData) == 0 {
+		return nil
+	}
+
+	submitted := make(map[uint]interface{}, len(answers))
+	for _, answer := range answers {
+		submitted[answer.QuestionID] = answer.Value
+	}
+
+	for _, question := range questions {
+		if !question.LockPrefill || question.PrefillKey == "" {
+			continue
+		}
+		prefilled, ok := prefillData[question.PrefillKey]
+		if !ok {
+			continue
+		}
+		if value, answered := submitted[question.ID]; !answered || !reflect.DeepEqual(value, prefilled) {
+			return errors.NewLocalizedError("VALIDATION_FAILED", 400, "validation.prefill_locked", question.Title)
+		}
+	}
+	return nil
+}
+
 // validateAnswer validates a single answer based on question type and configuration
 func (s *ResponseService) validateAnswer(question *model.Question, value interface{}) error {
 	switch question.Type {
@@ -101,24 +286,28 @@ func (s *ResponseService) validateAnswer(question *model.Question, value interfa
 		return s.validateMultipleChoiceAnswer(question, value)
 	case model.QuestionTypeTable:
 		return s.validateTableAnswer(question, value)
+	case model.QuestionTypeNPS:
+		return s.validateNPSAnswer(question, value)
+	case model.QuestionTypeSlider:
+		return s.validateSliderAnswer(question, value)
 	default:
-		return &errors.AppError{
-			Code:    "VALIDATION_FAILED",
-			Message: fmt.Sprintf("不支持的题目类型: %s", question.Type),
-			Status:  400,
-		}
+		return errors.NewLocalizedError("VALIDATION_FAILED", 400, "validation.unsupported_question_type", question.Type)
 	}
 }
 
 // validateTextAnswer validates text question answer
 func (s *ResponseService) validateTextAnswer(question *model.Question, value interface{}) error {
-	_, ok := value.(string)
+	answer, ok := value.(string)
 	if !ok {
-		return &errors.AppError{
-			Code:    "VALIDATION_FAILED",
-			Message: fmt.Sprintf("题目 '%s' 的答案必须是字符串", question.Title),
-			Status:  400,
-		}
+		return errors.NewLocalizedError("VALIDATION_FAILED", 400, "validation.answer_must_be_string", question.Title)
+	}
+
+	maxLength := s.limits.MaxTextLength
+	if question.Config.MaxLength > 0 && (maxLength == 0 || question.Config.MaxLength < maxLength) {
+		maxLength = question.Config.MaxLength
+	}
+	if maxLength > 0 && utf8.RuneCountInString(answer) > maxLength {
+		return errors.NewLocalizedError("VALIDATION_FAILED", 400, "validation.text_too_long", question.Title, maxLength)
 	}
 	return nil
 }
@@ -127,28 +316,12 @@ func (s *ResponseService) validateTextAnswer(question *model.Question, value int
 func (s *ResponseService) validateSingleChoiceAnswer(question *model.Question, value interface{}) error {
 	answer, ok := value.(string)
 	if !ok {
-		return &errors.AppError{
-			Code:    "VALIDATION_FAILED",
-			Message: fmt.Sprintf("题目 '%s' 的答案必须是字符串", question.Title),
-			Status:  400,
-		}
+		return errors.NewLocalizedError("VALIDATION_FAILED", 400, "validation.answer_must_be_string", question.Title)
 	}
 
-	// Check if the answer is in the options
-	validOption := false
-	for _, option := range question.Config.Options {
-		if option == answer {
-			validOption = true
-			break
-		}
-	}
-
-	if !validOption {
-		return &errors.AppError{
-			Code:    "VALIDATION_FAILED",
-			Message: fmt.Sprintf("题目 '%s' 的答案 '%s' 不在选项中", question.Title, answer),
-			Status:  400,
-		}
+	// Check if the answer references one of the options
+	if _, ok := question.Config.FindOption(answer); !ok {
+		return errors.NewLocalizedError("VALIDATION_FAILED", 400, "validation.answer_not_in_options", question.Title, answer)
 	}
 
 	return nil
@@ -165,100 +338,116 @@ func (s *ResponseService) validateMultipleChoiceAnswer(question *model.Question,
 		for i, item := range v {
 			str, ok := item.(string)
 			if !ok {
-				return &errors.AppError{
-					Code:    "VALIDATION_FAILED",
-					Message: fmt.Sprintf("题目 '%s' 的答案必须是字符串数组", question.Title),
-					Status:  400,
-				}
+				return errors.NewLocalizedError("VALIDATION_FAILED", 400, "validation.answer_must_be_string_array", question.Title)
 			}
 			answers[i] = str
 		}
 	case []string:
 		answers = v
 	default:
-		return &errors.AppError{
-			Code:    "VALIDATION_FAILED",
-			Message: fmt.Sprintf("题目 '%s' 的答案必须是字符串数组", question.Title),
-			Status:  400,
-		}
+		return errors.NewLocalizedError("VALIDATION_FAILED", 400, "validation.answer_must_be_string_array", question.Title)
 	}
 
 	// Check if all answers are in the options
 	optionMap := make(map[string]bool)
 	for _, option := range question.Config.Options {
-		optionMap[option] = true
+		optionMap[option.ID] = true
 	}
 
 	for _, answer := range answers {
 		if !optionMap[answer] {
-			return &errors.AppError{
-				Code:    "VALIDATION_FAILED",
-				Message: fmt.Sprintf("题目 '%s' 的答案 '%s' 不在选项中", question.Title, answer),
-				Status:  400,
-			}
+			return errors.NewLocalizedError("VALIDATION_FAILED", 400, "validation.answer_not_in_options", question.Title, answer)
+		}
+	}
+
+	return nil
+}
+
+// validateNPSAnswer validates NPS question answer
+func (s *ResponseService) validateNPSAnswer(question *model.Question, value interface{}) error {
+	if _, ok := model.NPSScore(value); !ok {
+		return errors.NewLocalizedError("VALIDATION_FAILED", 400, "validation.nps_out_of_range", question.Title)
+	}
+	return nil
+}
+
+// validateSliderAnswer validates slider question answer
+func (s *ResponseService) validateSliderAnswer(question *model.Question, value interface{}) error {
+	number, ok := value.(float64)
+	if !ok {
+		return errors.NewLocalizedError("VALIDATION_FAILED", 400, "validation.answer_must_be_number", question.Title)
+	}
+
+	config := &question.Config
+	if config.Min != nil && number < *config.Min || config.Max != nil && number > *config.Max {
+		return errors.NewLocalizedError("VALIDATION_FAILED", 400, "validation.slider_out_of_range", question.Title, formatNumber(config.Min), formatNumber(config.Max))
+	}
+
+	// The value must land on a step, allowing for floating point error
+	if config.Step > 0 && config.Min != nil {
+		steps := (number - *config.Min) / config.Step
+		if math.Abs(steps-math.Round(steps)) > 1e-9 {
+			return errors.NewLocalizedError("VALIDATION_FAILED", 400, "validation.slider_step_mismatch", question.Title, strconv.FormatFloat(config.Step, 'f', -1, 64))
 		}
 	}
 
 	return nil
 }
 
+// formatNumber formats an optional slider bound for error messages
+func formatNumber(value *float64) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*value, 'f', -1, 64)
+}
+
 // validateTableAnswer validates table question answer
 func (s *ResponseService) validateTableAnswer(question *model.Question, value interface{}) error {
 	// Value should be []interface{} where each item is []interface{} (2D array)
 	rows, ok := value.([]interface{})
 	if !ok {
-		return &errors.AppError{
-			Code:    "VALIDATION_FAILED",
-			Message: fmt.Sprintf("题目 '%s' 的答案必须是数组", question.Title),
-			Status:  400,
-		}
+		return errors.NewLocalizedError("VALIDATION_FAILED", 400, "validation.answer_must_be_array", question.Title)
 	}
 
 	// Check row count constraints
 	rowCount := len(rows)
 	if question.Config.MinRows > 0 && rowCount < question.Config.MinRows {
-		return &errors.AppError{
-			Code:    "VALIDATION_FAILED",
-			Message: fmt.Sprintf("题目 '%s' 至少需要 %d 行，当前只有 %d 行", question.Title, question.Config.MinRows, rowCount),
-			Status:  400,
-		}
+		return errors.NewLocalizedError("VALIDATION_FAILED", 400, "validation.table_min_rows", question.Title, question.Config.MinRows, rowCount)
 	}
-	if question.Config.MaxRows > 0 && rowCount > question.Config.MaxRows {
-		return &errors.AppError{
-			Code:    "VALIDATION_FAILED",
-			Message: fmt.Sprintf("题目 '%s' 最多允许 %d 行，当前有 %d 行", question.Title, question.Config.MaxRows, rowCount),
-			Status:  400,
-		}
+	maxRows := s.limits.MaxTableRows
+	if question.Config.MaxRows > 0 && (maxRows == 0 || question.Config.MaxRows < maxRows) {
+		maxRows = question.Config.MaxRows
+	}
+	if maxRows > 0 && rowCount > maxRows {
+		return errors.NewLocalizedError("VALIDATION_FAILED", 400, "validation.table_max_rows", question.Title, maxRows, rowCount)
 	}
 
 	// Get expected column count
 	expectedColCount := len(question.Config.Columns)
+	parents := cascadeParents(question.Config.Columns)
 
 	// Validate each row
 	for rowIdx, rowInterface := range rows {
 		// Each row should be an array
 		row, ok := rowInterface.([]interface{})
 		if !ok {
-			return &errors.AppError{
-				Code:    "VALIDATION_FAILED",
-				Message: fmt.Sprintf("题目 '%s' 第 %d 行格式错误，应为数组", question.Title, rowIdx+1),
-				Status:  400,
-			}
+			return errors.NewLocalizedError("VALIDATION_FAILED", 400, "validation.table_row_not_array", question.Title, rowIdx+1)
 		}
 
 		// Check column count
 		if len(row) != expectedColCount {
-			return &errors.AppError{
-				Code:    "VALIDATION_FAILED",
-				Message: fmt.Sprintf("题目 '%s' 第 %d 行列数错误，期望 %d 列，实际 %d 列", question.Title, rowIdx+1, expectedColCount, len(row)),
-				Status:  400,
-			}
+			return errors.NewLocalizedError("VALIDATION_FAILED", 400, "validation.table_column_count", question.Title, rowIdx+1, expectedColCount, len(row))
 		}
 
-		// Validate each cell
+		// Validate each cell, cascade cells against the value of their earlier parent cell
 		for colIdx, cellValue := range row {
 			column := &question.Config.Columns[colIdx]
-			if err := s.validateTableCell(question.Title, rowIdx+1, column, cellValue); err != nil {
+			parentValue := ""
+			if parentIdx, ok := parents[colIdx]; ok {
+				parentValue, _ = row[parentIdx].(string)
+			}
+			if err := s.validateTableCell(question.Title, rowIdx+1, column, cellValue, parentValue); err != nil {
 				return err
 			}
 		}
@@ -267,18 +456,35 @@ func (s *ResponseService) validateTableAnswer(question *model.Question, value in
 	return nil
 }
 
+// cascadeParents maps the index of each cascade column to the index of the column it depends on
+func cascadeParents(columns []model.TableColumn) map[int]int {
+	parents := make(map[int]int)
+	for i, column := range columns {
+		if column.Type != "cascade" {
+			continue
+		}
+		for j := 0; j < i; j++ {
+			if columns[j].ID == column.DependsOn {
+				parents[i] = j
+				break
+			}
+		}
+	}
+	return parents
+}
+
 // validateTableCell validates a single cell in a table question
-func (s *ResponseService) validateTableCell(questionTitle string, rowNum int, column *model.TableColumn, value interface{}) error {
+// parentValue is the row's value of the column a cascade column depends on
+func (s *ResponseService) validateTableCell(questionTitle string, rowNum int, column *model.TableColumn, value interface{}, parentValue string) error {
 	// For table questions, all values come as strings (from 2D string array)
 	// We validate the string format based on column type
 
 	strValue, ok := value.(string)
 	if !ok {
-		return &errors.AppError{
-			Code:    "VALIDATION_FAILED",
-			Message: fmt.Sprintf("题目 '%s' 第 %d 行列 '%s' 必须是字符串", questionTitle, rowNum, column.Label),
-			Status:  400,
-		}
+		return errors.NewLocalizedError("VALIDATION_FAILED", 400, "validation.cell_must_be_string", questionTitle, rowNum, column.Label)
+	}
+	if s.limits.MaxTextLength > 0 && utf8.RuneCountInString(strValue) > s.limits.MaxTextLength {
+		return errors.NewLocalizedError("VALIDATION_FAILED", 400, "validation.cell_too_long", questionTitle, rowNum, column.Label, s.limits.MaxTextLength)
 	}
 
 	switch column.Type {
@@ -294,11 +500,7 @@ func (s *ResponseService) validateTableCell(questionTitle string, rowNum int, co
 		}
 		// Try to parse as float to validate it's a number
 		if _, err := strconv.ParseFloat(strValue, 64); err != nil {
-			return &errors.AppError{
-				Code:    "VALIDATION_FAILED",
-				Message: fmt.Sprintf("题目 '%s' 第 %d 行列 '%s' 必须是有效的数字", questionTitle, rowNum, column.Label),
-				Status:  400,
-			}
+			return errors.NewLocalizedError("VALIDATION_FAILED", 400, "validation.cell_must_be_number", questionTitle, rowNum, column.Label)
 		}
 
 	case "select":
@@ -312,22 +514,26 @@ func (s *ResponseService) validateTableCell(questionTitle string, rowNum int, co
 		}
 
 		if !validOption && strValue != "" {
-			return &errors.AppError{
-				Code:    "VALIDATION_FAILED",
-				Message: fmt.Sprintf("题目 '%s' 第 %d 行列 '%s' 的值 '%s' 不在选项中", questionTitle, rowNum, column.Label, strValue),
-				Status:  400,
-			}
+			return errors.NewLocalizedError("VALIDATION_FAILED", 400, "validation.cell_not_in_options", questionTitle, rowNum, column.Label, strValue)
+		}
+
+	case "cascade":
+		// The options depend on the value chosen in the parent column
+		if strValue != "" && !slices.Contains(column.Cascade[parentValue], strValue) {
+			return errors.NewLocalizedError("VALIDATION_FAILED", 400, "validation.cell_not_in_cascade", questionTitle, rowNum, column.Label, strValue, parentValue)
 		}
 	}
 
 	return nil
 } // SubmitResponse handles the submission of a survey response
-func (s *ResponseService) SubmitResponse(req *request.SubmitResponseRequest, ipAddress, userAgent string) (*response.SubmitResponseResponse, error) {
-	ctx := context.Background()
-
+func (s *ResponseService) SubmitResponse(ctx context.Context, req *request.SubmitResponseRequest, ipAddress, userAgent string) (*response.SubmitResponseResponse, error) {
 	// Decrypt and validate token
 	tokenData, err := s.encryptionSvc.DecryptToken(req.Token)
 	if err != nil {
+		// Preview tokens submit into a sandbox that never stores anything
+		if previewData, previewErr := s.encryptionSvc.DecryptPreviewToken(req.Token); previewErr == nil {
+			return s.submitPreview(ctx, previewData, req)
+		}
 		return nil, errors.ErrInvalidToken
 	}
 
@@ -339,37 +545,45 @@ func (s *ResponseService) SubmitResponse(req *request.SubmitResponseRequest, ipA
 	// Check one-time link status in cache first
 	used, err := s.cache.GetOneLinkStatus(ctx, req.Token)
 	if err == nil && used {
-		return nil, errors.ErrLinkUsed
+		return s.usedLinkSubmission(ctx, tokenData, req, ipAddress)
 	}
 
 	// Acquire distributed lock to prevent concurrent submissions
-	lockKey := fmt.Sprintf("response:%s", req.Token)
+	lockKey := fmt.Sprintf("response:%s", model.HashLinkToken(req.Token))
 	acquired, err := s.cache.AcquireLock(ctx, lockKey, 10*time.Second)
 	if err != nil || !acquired {
-		return nil, &errors.AppError{
-			Code:    "CONCURRENT_SUBMISSION",
-			Message: "请勿重复提交",
-			Status:  409,
-		}
+		return nil, errors.ErrConcurrentSubmission
 	}
 	defer s.cache.ReleaseLock(ctx, lockKey)
 
 	// Verify one-time link in database
-	oneLink, err := s.oneLinkRepo.FindByToken(req.Token)
+	oneLink, err := findOneLinkByToken(ctx, s.oneLinkRepo, s.cache, req.Token)
 	if err != nil {
-		return nil, errors.ErrInvalidToken
+		return nil, err
 	}
 
 	if oneLink.Used {
 		// Update cache
 		s.cache.SetOneLinkStatus(ctx, req.Token, true, time.Until(time.Unix(tokenData.ExpiresAt, 0)))
-		return nil, errors.ErrLinkUsed
+		return s.usedLinkSubmission(ctx, tokenData, req, ipAddress)
+	}
+
+	if oneLink.IsRevoked() {
+		return nil, errors.ErrLinkRevoked
+	}
+
+	// Links can be closed early, e.g. when their quota segment is full
+	if oneLink.IsExpired() {
+		return nil, errors.ErrTokenExpired
 	}
 
 	// Get survey with questions
-	survey, err := s.surveyRepo.FindByID(tokenData.SurveyID)
+	survey, err := s.surveyRepo.FindByID(ctx, tokenData.SurveyID)
 	if err != nil {
-		return nil, errors.ErrNotFound
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.WrapError(err, "failed to find survey")
 	}
 
 	// Check if survey is published
@@ -377,90 +591,486 @@ func (s *ResponseService) SubmitResponse(req *request.SubmitResponseRequest, ipA
 		return nil, errors.ErrSurveyNotPublished
 	}
 
+	// Check the respondent network allowlist
+	if !IPAllowed(ipAddress, survey.AllowedIPs) {
+		return nil, errors.ErrIPNotAllowed
+	}
+
+	// Timed surveys must be submitted within the time limit of opening the link
+	if err := checkTimeLimit(survey, oneLink); err != nil {
+		return nil, err
+	}
+
+	// A known respondent may respond only once, whichever of their links is used;
+	// anonymous surveys do not record respondents and rely on the one-time link alone.
+	// Test links do not bind their response to the respondent either
+	if oneLink.RespondentID != "" && !survey.Anonymous && !oneLink.IsTest {
+		responded, err := s.responseRepo.ExistsByRespondent(ctx, survey.ID, oneLink.RespondentID)
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to check previous responses")
+		}
+		if responded {
+			return nil, errors.ErrAlreadyResponded
+		}
+	}
+
 	// Get all questions for the survey
-	questions, err := s.questionRepo.FindBySurveyID(survey.ID)
+	questions, err := s.questionRepo.FindBySurveyID(ctx, survey.ID)
 	if err != nil {
-		return nil, &errors.AppError{
-			Code:    "INTERNAL_ERROR",
-			Message: "获取问卷题目失败",
-			Status:  500,
-		}
+		return nil, errors.WrapError(err, "failed to find questions")
 	}
 
-	// Validate response data
-	if err := s.validateResponseData(questions, req.Answers); err != nil {
+	// Complete and validate the answers to the questions of the link's variant; pool
+	// questions not drawn for the link need none
+	shown := variantQuestions(survey, questions, oneLink.Variant)
+	withheld := withheldQuestions(survey.QuestionPools, shown, req.Token)
+	answers, err := s.checkedAnswers(shown, linkPrefillData(tokenData, oneLink), req.Answers, withheld)
+	if err != nil {
 		return nil, err
 	}
 
-	// Convert request answers to model answers
-	answers := make([]model.Answer, len(req.Answers))
-	for i, ans := range req.Answers {
-		answers[i] = model.Answer{
-			QuestionID: ans.QuestionID,
-			Value:      ans.Value,
-		}
+	// Bots filling in the honeypot field are discarded or flagged as the survey chooses
+	caught := s.honeypotCaught(ctx, survey, oneLink, req)
+	if caught && survey.HoneypotAction == model.HoneypotDiscard {
+		return s.discardSubmission(ctx, tokenData, req.Token, oneLink), nil
 	}
 
+	// Flag submissions arriving in a burst from one network; test links are exempt
+	flagged := !oneLink.IsTest && s.bursts.Flag(ctx, survey, ipAddress)
+	tooFast := !oneLink.IsTest && s.answeredTooFast(req.Answers)
+
 	// Create response record
 	responseModel := &model.Response{
 		SurveyID:  survey.ID,
-		OneLinkID: oneLink.ID,
+		OneLinkID: &oneLink.ID,
 		Data: model.ResponseData{
-			Answers: answers,
+			Answers:  answers,
+			Withheld: unseenQuestions(questions, shown, withheld),
 		},
 		IPAddress:   ipAddress,
 		UserAgent:   userAgent,
+		Campaign:    oneLink.Campaign,
+		Variant:     oneLink.Variant,
+		IsTest:      oneLink.IsTest,
+		Flagged:     flagged || caught || tooFast,
 		SubmittedAt: time.Now(),
 	}
+	responseModel.Score, responseModel.MaxScore = scoreAnswers(survey, shown, withheld, answers)
+	switch {
+	case caught:
+		responseModel.FlagReason = model.FlagReasonHoneypot
+	case flagged:
+		responseModel.FlagReason = model.FlagReasonBurst
+	case tooFast:
+		responseModel.FlagReason = model.FlagReasonTooFast
+	}
+	if survey.Anonymous {
+		// The network details are only used for the checks above and never persisted
+		responseModel.IPAddress = ""
+		responseModel.UserAgent = ""
+	} else if oneLink.RespondentID != "" && !oneLink.IsTest {
+		responseModel.RespondentID = &oneLink.RespondentID
+	}
 
-	if err := s.responseRepo.Create(responseModel); err != nil {
-		return nil, &errors.AppError{
-			Code:    "INTERNAL_ERROR",
-			Message: "保存填答记录失败",
-			Status:  500,
+	// Take a slot in every quota segment this link belongs to; test responses
+	// do not count towards quotas
+	var reserved []reservedQuota
+	if !oneLink.IsTest {
+		reserved, err = s.reserveQuotas(ctx, survey, oneLink)
+		if err != nil {
+			return nil, err
 		}
 	}
 
+	if err := s.responseRepo.Create(ctx, responseModel); err != nil {
+		s.releaseQuotas(context.WithoutCancel(ctx), survey.ID, reserved)
+		// The unique index catches a concurrent submission through another link of the respondent
+		if err == gorm.ErrDuplicatedKey {
+			return nil, errors.ErrAlreadyResponded
+		}
+		return nil, errors.WrapError(err, "failed to save response")
+	}
+
+	// The response is saved, finish the bookkeeping even if the client goes away
+	ctx = context.WithoutCancel(ctx)
+
 	// Mark one-time link as used
-	if err := s.oneLinkRepo.MarkAsUsed(oneLink.ID); err != nil {
+	if err := s.oneLinkRepo.MarkAsUsed(ctx, oneLink.ID); err != nil {
 		// Log error but don't fail the request since response is already saved
 		// In production, this should be logged properly
 	}
 
+	// Close the outstanding links of segments this response filled up
+	s.closeFilledQuotas(ctx, survey.ID, reserved)
+
+	// Keep the cached statistics counters in step with the new response; they
+	// never include test data
+	if !oneLink.IsTest {
+		if err := s.cache.IncrementStats(ctx, survey.ID, responseStatsDelta(questions, responseModel)); err != nil {
+			fmt.Printf("failed to update statistics counters: %v\n", err)
+		}
+	}
+
+	// Remove any saved draft for this link now that the response is complete
+	if err := s.draftRepo.DeleteByOneLinkID(ctx, oneLink.ID); err != nil {
+		fmt.Printf("failed to delete response draft: %v\n", err)
+	}
+
+	// Remember the response so a refresh that re-submits gets the same result
+	if s.limits.ResubmitWindow > 0 {
+		if err := s.cache.SetSubmittedResponse(ctx, req.Token, responseModel.ID, s.limits.ResubmitWindow); err != nil {
+			fmt.Printf("failed to record submitted response: %v\n", err)
+		}
+	}
+
 	// Update cache
 	s.cache.SetOneLinkStatus(ctx, req.Token, true, time.Until(time.Unix(tokenData.ExpiresAt, 0)))
 
+	// Post to the survey's chat channels and hooks without delaying the respondent
+	if !oneLink.IsTest {
+		go s.notifier.NotifyResponse(ctx, survey, responseModel)
+	}
+
+	return s.submitResult(responseModel, oneLink, survey.ShowScore), nil
+}
+
+// checkedAnswers completes submitted answers with hidden prefilled answers, table
+// defaults and totals, validates them and returns them as stored in a response
+func (s *ResponseService) checkedAnswers(questions []model.Question, prefillData map[string]interface{}, reqAnswers []request.AnswerRequest, withheld map[uint]bool) ([]model.Answer, error) {
+	// Hidden questions are answered from the link's prefill data
+	reqAnswers, err := withHiddenAnswers(questions, prefillData, reqAnswers)
+	if err != nil {
+		return nil, err
+	}
+	reqAnswers = withTableDefaults(questions, reqAnswers)
+	reqAnswers, err = withTableTotals(questions, reqAnswers)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate response data
+	if err := s.validateResponseData(questions, reqAnswers, withheld); err != nil {
+		return nil, err
+	}
+
+	// Locked prefilled answers must come back unchanged
+	if err := validateLockedPrefill(questions, prefillData, reqAnswers); err != nil {
+		return nil, err
+	}
+
+	// Convert request answers to model answers
+	answers := make([]model.Answer, len(reqAnswers))
+	for i, ans := range reqAnswers {
+		answers[i] = model.Answer{
+			QuestionID: ans.QuestionID,
+			Value:      ans.Value,
+			DurationMs: ans.DurationMs,
+		}
+	}
+	return answers, nil
+}
+
+// answeredTooFast reports whether the client timed every submitted answer and the
+// respondent spent less than the minimum average time on them. Responses without
+// complete timings are never flagged, as older clients send none
+func (s *ResponseService) answeredTooFast(answers []request.AnswerRequest) bool {
+	if s.limits.MinAnswerTime <= 0 || len(answers) == 0 {
+		return false
+	}
+
+	var total int64
+	for _, answer := range answers {
+		if answer.DurationMs <= 0 {
+			return false
+		}
+		total += answer.DurationMs
+	}
+	return time.Duration(total)*time.Millisecond < s.limits.MinAnswerTime*time.Duration(len(answers))
+}
+
+// submitPreview validates a submission through a preview token like a real one and
+// returns the result without saving the response, reserving quotas or notifying anyone
+func (s *ResponseService) submitPreview(ctx context.Context, previewData *PreviewTokenData, req *request.SubmitResponseRequest) (*response.SubmitResponseResponse, error) {
+	if time.Now().Unix() > previewData.ExpiresAt {
+		return nil, errors.ErrTokenExpired
+	}
+
+	survey, err := s.surveyRepo.FindByID(ctx, previewData.SurveyID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.WrapError(err, "failed to find survey")
+	}
+	if survey.UserID != previewData.UserID {
+		return nil, errors.ErrInvalidToken
+	}
+
+	questions, err := s.questionRepo.FindBySurveyID(ctx, survey.ID)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to find questions")
+	}
+	shown := variantQuestions(survey, questions, previewData.Variant)
+	withheld := withheldQuestions(survey.QuestionPools, shown, req.Token)
+	answers, err := s.checkedAnswers(shown, previewData.PrefillData, req.Answers, withheld)
+	if err != nil {
+		return nil, err
+	}
+
+	// Previews always show the score so the survey's owner can check the correct answers
+	score, maxScore := scoreAnswers(survey, shown, withheld, answers)
 	return &response.SubmitResponseResponse{
-		ID:          responseModel.ID,
-		SurveyID:    responseModel.SurveyID,
-		SubmittedAt: responseModel.SubmittedAt,
-		Message:     "提交成功",
+		SurveyID:    survey.ID,
+		SubmittedAt: time.Now(),
+		Message:     "预览提交成功，数据未保存",
+		Preview:     true,
+		Score:       score,
+		MaxScore:    maxScore,
 	}, nil
 }
 
-// GetResponses retrieves paginated responses for a survey
-func (s *ResponseService) GetResponses(userID, surveyID uint, page, pageSize int) ([]response.ResponseListItem, *response.PaginatedResponseMeta, error) {
-	// Verify survey ownership
-	survey, err := s.surveyRepo.FindByID(surveyID)
+// resubmittedResponse answers a submission through an already used link. Within the
+// re-submit window the original result is returned, e.g. when the respondent refreshed
+// the page after submitting; afterwards the link is reported as used
+func (s *ResponseService) resubmittedResponse(ctx context.Context, token string) (*response.SubmitResponseResponse, error) {
+	if s.limits.ResubmitWindow <= 0 {
+		return nil, errors.ErrLinkUsed
+	}
+
+	responseID, err := s.cache.GetSubmittedResponse(ctx, token)
+	if err != nil {
+		fmt.Printf("failed to get submitted response: %v\n", err)
+	}
+	if responseID == 0 {
+		return nil, errors.ErrLinkUsed
+	}
+
+	resp, err := s.responseRepo.FindByID(ctx, responseID)
+	if err != nil {
+		return nil, errors.ErrLinkUsed
+	}
+	oneLink, err := s.oneLinkRepo.FindByToken(ctx, token)
+	if err != nil {
+		return nil, errors.ErrLinkUsed
+	}
+	survey, err := s.surveyRepo.FindByID(ctx, resp.SurveyID)
 	if err != nil {
-		return nil, nil, errors.ErrNotFound
+		return nil, errors.ErrLinkUsed
 	}
+	return s.submitResult(resp, oneLink, survey.ShowScore), nil
+}
+
+// submitResult builds the success result of a submission, with the score of an
+// assessment when the survey shows it to respondents
+func (s *ResponseService) submitResult(resp *model.Response, oneLink *model.OneLink, showScore bool) *response.SubmitResponseResponse {
+	result := &response.SubmitResponseResponse{
+		ID:               resp.ID,
+		SurveyID:         resp.SurveyID,
+		RespondentNumber: s.numbering.Format(resp.RespondentNumber),
+		Version:          resp.Version,
+		SubmittedAt:      resp.SubmittedAt,
+		Message:          "提交成功",
+		RedirectURL:      oneLink.RedirectURL,
+	}
+	if showScore {
+		result.Score, result.MaxScore = resp.Score, resp.MaxScore
+	}
+	return result
+}
+
+// quotaSegment identifies a quota rule's segment in the quota counters
+func quotaSegment(rule *model.QuotaRule) string {
+	return rule.Field + "=" + rule.Value
+}
+
+// reservedQuota is a quota slot taken for a submission together with the resulting count
+type reservedQuota struct {
+	rule  model.QuotaRule
+	count int64
+}
+
+// reserveQuotas reserves one slot in each quota matching the link's prefill data
+// Either all matching quotas are reserved or none are
+func (s *ResponseService) reserveQuotas(ctx context.Context, survey *model.Survey, oneLink *model.OneLink) ([]reservedQuota, error) {
+	var reserved []reservedQuota
+	for i := range survey.Quotas {
+		rule := &survey.Quotas[i]
+		if !rule.Matches(oneLink.PrefillData) {
+			continue
+		}
+
+		count, err := s.reserveQuota(ctx, survey.ID, rule)
+		if err != nil {
+			s.releaseQuotas(context.WithoutCancel(ctx), survey.ID, reserved)
+			if err == cache.ErrQuotaExceeded {
+				if rule.CloseLinks {
+					s.closeQuotaSegment(ctx, survey.ID, rule)
+				}
+				return nil, errors.ErrQuotaFull
+			}
+			return nil, errors.WrapError(err, "failed to reserve quota")
+		}
+		reserved = append(reserved, reservedQuota{rule: *rule, count: count})
+	}
+	return reserved, nil
+}
 
-	if survey.UserID != userID {
-		return nil, nil, errors.ErrForbidden
+// reserveQuota reserves a slot in a single quota, seeding its counter from the database on first use
+func (s *ResponseService) reserveQuota(ctx context.Context, surveyID uint, rule *model.QuotaRule) (int64, error) {
+	segment := quotaSegment(rule)
+	count, err := s.cache.ReserveQuota(ctx, surveyID, segment, rule.Limit)
+	if err != cache.ErrQuotaNotSeeded {
+		return count, err
+	}
+
+	existing, err := s.responseRepo.CountBySurveyAndPrefill(ctx, surveyID, rule.Field, rule.Value)
+	if err != nil {
+		return 0, err
+	}
+	// Counters expire so they are periodically re-synced with the database
+	if err := s.cache.SeedQuota(ctx, surveyID, segment, existing, 24*time.Hour); err != nil {
+		return 0, err
+	}
+	return s.cache.ReserveQuota(ctx, surveyID, segment, rule.Limit)
+}
+
+// releaseQuotas gives back reserved quota slots when the submission could not be saved
+func (s *ResponseService) releaseQuotas(ctx context.Context, surveyID uint, reserved []reservedQuota) {
+	for i := range reserved {
+		if err := s.cache.ReleaseQuota(ctx, surveyID, quotaSegment(&reserved[i].rule)); err != nil {
+			fmt.Printf("failed to release quota: %v\n", err)
+		}
+	}
+}
+
+// closeFilledQuotas closes the segments whose quota was filled by a saved response
+func (s *ResponseService) closeFilledQuotas(ctx context.Context, surveyID uint, reserved []reservedQuota) {
+	for i := range reserved {
+		rule := &reserved[i].rule
+		if rule.CloseLinks && reserved[i].count >= int64(rule.Limit) {
+			s.closeQuotaSegment(ctx, surveyID, rule)
+		}
+	}
+}
+
+// closeQuotaSegment expires the outstanding links of a full quota segment
+func (s *ResponseService) closeQuotaSegment(ctx context.Context, surveyID uint, rule *model.QuotaRule) {
+	if _, err := s.oneLinkRepo.ExpireUnusedByPrefill(ctx, surveyID, rule.Field, rule.Value); err != nil {
+		fmt.Printf("failed to close links of quota segment %s: %v\n", quotaSegment(rule), err)
+	}
+}
+
+// GetResponses retrieves paginated responses for a survey
+func (s *ResponseService) GetResponses(ctx context.Context, userID, surveyID uint, filter repository.ResponseFilter, page, pageSize int) ([]response.ResponseListItem, *response.PaginatedResponseMeta, error) {
+	// Verify the user owns the survey or shares it through its team
+	if _, err := findTeamSurvey(ctx, s.authz, userID, surveyID); err != nil {
+		return nil, nil, err
 	}
 
 	// Get responses with pagination
-	responses, total, err := s.responseRepo.FindBySurveyID(surveyID, page, pageSize)
+	responses, total, err := s.responseRepo.FindBySurveyID(ctx, surveyID, filter, page, pageSize)
 	if err != nil {
-		return nil, nil, &errors.AppError{
-			Code:    "INTERNAL_ERROR",
-			Message: "获取填答记录失败",
-			Status:  500,
+		return nil, nil, errors.WrapError(err, "failed to find responses")
+	}
+
+	meta := &response.PaginatedResponseMeta{
+		Page:     page,
+		PageSize: pageSize,
+		Total:    total,
+	}
+
+	items, err := s.toResponseListItems(ctx, responses)
+	if err != nil {
+		return nil, nil, err
+	}
+	return items, meta, nil
+}
+
+// GetResponsesByCursor retrieves responses using keyset pagination on submitted_at and id
+// An empty cursor starts from the most recent response
+func (s *ResponseService) GetResponsesByCursor(ctx context.Context, userID, surveyID uint, filter repository.ResponseFilter, cursor string, pageSize int) ([]response.ResponseListItem, *response.CursorResponseMeta, error) {
+	// Verify the user owns the survey or shares it through its team
+	_, err := findTeamSurvey(ctx, s.authz, userID, surveyID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	var after *repository.ResponseCursor
+	if cursor != "" {
+		after, err = decodeResponseCursor(cursor)
+		if err != nil {
+			return nil, nil, errors.ErrInvalidCursor
 		}
 	}
 
-	// Convert to response DTOs
+	// Fetch one extra record to know whether another page follows
+	responses, err := s.responseRepo.FindBySurveyIDAfter(ctx, surveyID, filter, after, pageSize+1)
+	if err != nil {
+		return nil, nil, errors.WrapError(err, "failed to find responses")
+	}
+
+	meta := &response.CursorResponseMeta{PageSize: pageSize}
+	if len(responses) > pageSize {
+		responses = responses[:pageSize]
+		last := responses[len(responses)-1]
+		meta.HasMore = true
+		meta.NextCursor = encodeResponseCursor(&repository.ResponseCursor{SubmittedAt: last.SubmittedAt, ID: last.ID})
+	}
+
+	items, err := s.toResponseListItems(ctx, responses)
+	if err != nil {
+		return nil, nil, err
+	}
+	return items, meta, nil
+}
+
+// encodeResponseCursor serializes a cursor into an opaque URL-safe string
+func encodeResponseCursor(cursor *repository.ResponseCursor) string {
+	raw := fmt.Sprintf("%d:%d", cursor.SubmittedAt.UnixNano(), cursor.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeResponseCursor parses a cursor produced by encodeResponseCursor
+func decodeResponseCursor(cursor string) (*repository.ResponseCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	nanos, id, found := strings.Cut(string(raw), ":")
+	if !found {
+		return nil, fmt.Errorf("malformed cursor")
+	}
+	unixNano, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	responseID, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &repository.ResponseCursor{
+		SubmittedAt: time.Unix(0, unixNano),
+		ID:          uint(responseID),
+	}, nil
+}
+
+// toResponseListItems converts response models to list DTOs, including their comment counts
+func (s *ResponseService) toResponseListItems(ctx context.Context, responses []model.Response) ([]response.ResponseListItem, error) {
+	ids := make([]uint, len(responses))
+	for i, resp := range responses {
+		ids[i] = resp.ID
+	}
+	commentCounts, err := s.commentRepo.CountByResponseIDs(ctx, ids)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to count comments")
+	}
+
 	responseList := make([]response.ResponseListItem, len(responses))
 	for i, resp := range responses {
 		// Convert ResponseData to map for JSON serialization
@@ -469,61 +1079,153 @@ func (s *ResponseService) GetResponses(userID, surveyID uint, page, pageSize int
 		}
 
 		responseList[i] = response.ResponseListItem{
-			ID:          resp.ID,
-			SurveyID:    resp.SurveyID,
-			Data:        dataMap,
-			IPAddress:   resp.IPAddress,
-			UserAgent:   resp.UserAgent,
-			SubmittedAt: resp.SubmittedAt,
-			CreatedAt:   resp.CreatedAt,
+			ID:               resp.ID,
+			SurveyID:         resp.SurveyID,
+			Data:             dataMap,
+			IPAddress:        resp.IPAddress,
+			UserAgent:        resp.UserAgent,
+			Campaign:         resp.Campaign,
+			Variant:          resp.Variant,
+			Score:            resp.Score,
+			MaxScore:         resp.MaxScore,
+			RespondentID:     respondentID(resp),
+			RespondentNumber: s.numbering.Format(resp.RespondentNumber),
+			IsTest:           resp.IsTest,
+			CommentCount:     commentCounts[resp.ID],
+			Version:          resp.Version,
+			EditedAt:         resp.EditedAt,
+			Flagged:          resp.Flagged,
+			FlagReason:       resp.FlagReason,
+			ReviewStatus:     resp.ReviewStatus,
+			ReviewedAt:       resp.ReviewedAt,
+			SubmittedAt:      resp.SubmittedAt,
+			CreatedAt:        resp.CreatedAt,
 		}
 	}
+	return responseList, nil
+}
 
-	meta := &response.PaginatedResponseMeta{
-		Page:     page,
-		PageSize: pageSize,
-		Total:    total,
+// GetStatistics retrieves statistics for a survey
+// Test responses are left out unless includeTest is set
+func (s *ResponseService) GetStatistics(ctx context.Context, userID, surveyID uint, includeTest bool) (*response.StatisticsResponse, error) {
+	// Verify the user owns the survey or shares it through its team
+	survey, err := findTeamSurvey(ctx, s.authz, userID, surveyID)
+	if err != nil {
+		return nil, err
+	}
+
+	questions, err := s.questionRepo.FindBySurveyID(ctx, surveyID)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to find questions")
 	}
 
-	return responseList, meta, nil
+	counters, cached, err := s.statisticsCounters(ctx, surveyID, questions, includeTest)
+	if err != nil {
+		return nil, err
+	}
+	stats := statisticsFromCounters(surveyID, questions, counters)
+	if !cached {
+		stats.CachedAt = nil
+	}
+
+	stats.Campaigns, err = s.campaignStatistics(ctx, surveyID, includeTest)
+	if err != nil {
+		return nil, err
+	}
+	stats.Variants, err = s.variantStatistics(ctx, survey, questions, counters, includeTest)
+	if err != nil {
+		return nil, err
+	}
+	stats.Scores, err = s.scoreStatistics(ctx, survey, includeTest)
+	if err != nil {
+		return nil, err
+	}
+	stats.HoneypotCatches = survey.HoneypotCatches
+	return stats, nil
 }
 
-// GetStatistics retrieves statistics for a survey
-func (s *ResponseService) GetStatistics(userID, surveyID uint) (*response.StatisticsResponse, error) {
-	// Verify survey ownership
-	survey, err := s.surveyRepo.FindByID(surveyID)
+// statisticsCounters returns the statistics counters of a survey: the cached counters
+// for large surveys, otherwise aggregated from the stored responses. cached reports
+// whether the counters are the cached ones. The cache never includes test responses,
+// so includeTest always aggregates the responses
+func (s *ResponseService) statisticsCounters(ctx context.Context, surveyID uint, questions []model.Question, includeTest bool) (map[string]float64, bool, error) {
+	// Large surveys are served from the counters maintained at submission time
+	if s.statsOpts.CacheThreshold > 0 && !includeTest {
+		counters, err := s.cache.GetStats(ctx, surveyID)
+		if err != nil {
+			fmt.Printf("failed to get statistics counters: %v\n", err)
+		} else if counters != nil && counters[statsVersionField] == statsVersion {
+			return counters, true, nil
+		}
+	}
+
+	counters, err := s.aggregateStatsCounters(ctx, surveyID, questions, repository.ResponseFilter{IncludeTest: includeTest})
 	if err != nil {
-		return nil, errors.ErrNotFound
+		return nil, false, err
 	}
 
-	if survey.UserID != userID {
-		return nil, errors.ErrForbidden
+	// Cache the counters once the survey is large enough; they expire after the
+	// reconcile interval so any drift is corrected by the next rebuild
+	if s.statsOpts.CacheThreshold > 0 && !includeTest && int64(counters[statsTotalField]) >= s.statsOpts.CacheThreshold {
+		s.storeStatsCounters(ctx, surveyID, counters)
+		return counters, true, nil
 	}
+	return counters, false, nil
+}
 
-	// Count total responses
-	count, err := s.responseRepo.CountBySurveyID(surveyID)
+// campaignStatistics compares generated links and received responses per campaign label
+func (s *ResponseService) campaignStatistics(ctx context.Context, surveyID uint, includeTest bool) ([]response.CampaignStatistics, error) {
+	links, err := s.oneLinkRepo.CountByCampaign(ctx, surveyID, includeTest)
 	if err != nil {
-		return nil, &errors.AppError{
-			Code:    "INTERNAL_ERROR",
-			Message: "获取统计信息失败",
-			Status:  500,
+		return nil, errors.WrapError(err, "failed to count links by campaign")
+	}
+	responses, err := s.responseRepo.CountByCampaign(ctx, surveyID, includeTest)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to count responses by campaign")
+	}
+
+	byCampaign := make(map[string]*response.CampaignStatistics)
+	entry := func(campaign string) *response.CampaignStatistics {
+		if _, ok := byCampaign[campaign]; !ok {
+			byCampaign[campaign] = &response.CampaignStatistics{Campaign: campaign}
 		}
+		return byCampaign[campaign]
+	}
+	for _, count := range links {
+		entry(count.Campaign).Links = count.Count
+	}
+	for _, count := range responses {
+		entry(count.Campaign).Responses = count.Count
 	}
 
-	// Calculate completion rate (assuming all submitted responses are complete)
-	completionRate := 100.0
-	if count == 0 {
-		completionRate = 0.0
+	stats := make([]response.CampaignStatistics, 0, len(byCampaign))
+	for _, campaign := range byCampaign {
+		if campaign.Links > 0 {
+			campaign.ResponseRate = math.Round(float64(campaign.Responses)/float64(campaign.Links)*10000) / 100
+		}
+		stats = append(stats, *campaign)
 	}
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Campaign < stats[j].Campaign
+	})
+	return stats, nil
+}
 
-	return &response.StatisticsResponse{
-		SurveyID:       surveyID,
-		TotalResponses: count,
-		CompletionRate: completionRate,
-	}, nil
+// storeStatsCounters caches rebuilt counters unless another request is already rebuilding them
+func (s *ResponseService) storeStatsCounters(ctx context.Context, surveyID uint, counters map[string]float64) {
+	lockKey := fmt.Sprintf("stats:rebuild:%d", surveyID)
+	acquired, err := s.cache.AcquireLock(ctx, lockKey, 30*time.Second)
+	if err != nil || !acquired {
+		return
+	}
+	defer s.cache.ReleaseLock(ctx, lockKey)
+
+	if err := s.cache.SetStats(ctx, surveyID, counters, s.statsOpts.ReconcileInterval); err != nil {
+		fmt.Printf("failed to cache statistics counters: %v\n", err)
+	}
 }
 
-// ExportResponses exports survey responses in the specified format
-func (s *ResponseService) ExportResponses(userID, surveyID uint, format string) ([]byte, string, error) {
-	return s.exportSvc.ExportResponses(userID, surveyID, format)
+// ExportResponses exports survey responses in the format specified by the request
+func (s *ResponseService) ExportResponses(ctx context.Context, userID, surveyID uint, req *request.ExportResponsesRequest) ([]byte, string, error) {
+	return s.exportSvc.ExportResponses(ctx, userID, surveyID, req)
 }
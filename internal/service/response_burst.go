@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"time"
+
+	"survey-system/internal/cache"
+	"survey-system/internal/model"
+	"survey-system/internal/repository"
+)
+
+// Prefix lengths of the subnets submissions are counted per
+const (
+	burstIPv4Bits = 24
+	burstIPv6Bits = 64
+)
+
+// BurstLimits configure the detection of submission bursts from one subnet.
+// A zero Submissions or Window disables detection.
+type BurstLimits struct {
+	Submissions int           // submissions to a survey allowed per subnet and window
+	Window      time.Duration // length of the fixed counting window
+}
+
+// BurstDetector flags submissions that arrive in bursts from one subnet, such as
+// automated form stuffing. Submissions are counted per survey and subnet in Redis,
+// so bursts spread over several server instances are caught too
+type BurstDetector struct {
+	cache     cache.Cache
+	eventRepo repository.EventRepository
+	limits    BurstLimits
+}
+
+// NewBurstDetector creates a new BurstDetector
+func NewBurstDetector(cache cache.Cache, eventRepo repository.EventRepository, limits BurstLimits) *BurstDetector {
+	return &BurstDetector{
+		cache:     cache,
+		eventRepo: eventRepo,
+		limits:    limits,
+	}
+}
+
+// Flag counts a submission to a survey from an IP address and reports whether it
+// exceeds the subnet's limit of the current window. The first submission over the
+// limit records a responses.burst event in the survey's activity. For anonymous
+// surveys the subnet is only used for counting and is neither recorded nor logged.
+// When Redis cannot be reached submissions are not flagged
+func (d *BurstDetector) Flag(ctx context.Context, survey *model.Survey, ipAddress string) bool {
+	if d.limits.Submissions <= 0 || d.limits.Window <= 0 {
+		return false
+	}
+	subnet, ok := submissionSubnet(ipAddress)
+	if !ok {
+		return false
+	}
+
+	slot := time.Now().UnixNano() / int64(d.limits.Window)
+	count, err := d.cache.CountSubmission(ctx, survey.ID, subnet, slot, d.limits.Window)
+	if err != nil {
+		fmt.Printf("failed to count submission to survey %d: %v\n", survey.ID, err)
+		return false
+	}
+	if count <= int64(d.limits.Submissions) {
+		return false
+	}
+
+	if count == int64(d.limits.Submissions)+1 {
+		event := &model.SurveyEvent{
+			SurveyID: survey.ID,
+			Type:     model.EventResponsesBurst,
+			Subnet:   subnet,
+		}
+		if survey.Anonymous {
+			event.Subnet = ""
+		}
+		recordEvent(context.WithoutCancel(ctx), d.eventRepo, event)
+	}
+	return true
+}
+
+// submissionSubnet returns the /24 (IPv4) or /64 (IPv6) subnet of an IP address in CIDR
+// notation, e.g. "203.0.113.0/24"
+func submissionSubnet(ipAddress string) (string, bool) {
+	addr, err := netip.ParseAddr(ipAddress)
+	if err != nil {
+		return "", false
+	}
+	addr = addr.Unmap()
+
+	bits := burstIPv6Bits
+	if addr.Is4() {
+		bits = burstIPv4Bits
+	}
+	prefix, err := addr.Prefix(bits)
+	if err != nil {
+		return "", false
+	}
+	return prefix.String(), true
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+
+	"survey-system/internal/dto/request"
+	"survey-system/internal/dto/response"
+	"survey-system/internal/repository"
+	"survey-system/pkg/errors"
+)
+
+// UpdateReviewStatus sets the review status of the survey's responses selected by an
+// ID list or a filter in a single bulk update. IDs of other surveys' responses are ignored
+func (s *ResponseService) UpdateReviewStatus(ctx context.Context, userID, surveyID uint, req *request.UpdateReviewStatusRequest) (*response.UpdateReviewStatusResponse, error) {
+	if _, err := findTeamSurvey(ctx, s.authz, userID, surveyID); err != nil {
+		return nil, err
+	}
+
+	filter, err := reviewStatusFilter(req)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.responseRepo.UpdateReviewStatus(ctx, surveyID, filter, req.Status, userID)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to update review status")
+	}
+
+	return &response.UpdateReviewStatusResponse{Status: req.Status, Updated: updated}, nil
+}
+
+// reviewStatusFilter builds the response filter of a review status update. Listed IDs
+// match test responses too; an empty filter object selects all non-test responses
+func reviewStatusFilter(req *request.UpdateReviewStatusRequest) (repository.ResponseFilter, error) {
+	if (len(req.IDs) > 0) == (req.Filter != nil) {
+		return repository.ResponseFilter{}, errors.NewLocalizedValidationError("ids", "response.review_selection_required")
+	}
+	if len(req.IDs) > 0 {
+		return repository.ResponseFilter{IDs: req.IDs, IncludeTest: true}, nil
+	}
+
+	filter, err := dateRangeFilter(req.Filter.From, req.Filter.To)
+	filter.Campaign = req.Filter.Campaign
+	filter.FlaggedOnly = req.Filter.Flagged
+	filter.IncludeTest = req.Filter.IncludeTest
+	filter.ReviewStatus = req.Filter.ReviewStatus
+	return filter, err
+}
@@ -0,0 +1,293 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"survey-system/internal/dto/request"
+	"survey-system/internal/dto/response"
+	"survey-system/internal/model"
+	"survey-system/pkg/errors"
+
+	"gorm.io/gorm"
+)
+
+// Kinds of answer changes between two versions of a response
+const (
+	AnswerAdded   = "added"
+	AnswerRemoved = "removed"
+	AnswerChanged = "changed"
+)
+
+// usedLinkSubmission answers a submission through an already used link. Within the
+// survey's edit window the respondent's response is corrected with the new answers;
+// otherwise the re-submit window applies
+func (s *ResponseService) usedLinkSubmission(ctx context.Context, tokenData *TokenData, req *request.SubmitResponseRequest, ipAddress string) (*response.SubmitResponseResponse, error) {
+	survey, err := s.surveyRepo.FindByID(ctx, tokenData.SurveyID)
+	if err != nil || survey.EditWindowMinutes <= 0 || survey.Status != model.SurveyStatusPublished {
+		return s.resubmittedResponse(ctx, req.Token)
+	}
+
+	oneLink, err := findOneLinkByToken(ctx, s.oneLinkRepo, s.cache, req.Token)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.responseRepo.FindByOneLinkID(ctx, oneLink.ID)
+	if err != nil {
+		return s.resubmittedResponse(ctx, req.Token)
+	}
+
+	window := time.Duration(survey.EditWindowMinutes) * time.Minute
+	if time.Since(resp.SubmittedAt) > window {
+		return s.resubmittedResponse(ctx, req.Token)
+	}
+
+	return s.correctResponse(ctx, survey, oneLink, resp, tokenData, req, ipAddress)
+}
+
+// correctResponse replaces the answers of a response with corrected ones and keeps the
+// replaced answers as a version. Re-submitting the same answers changes nothing
+func (s *ResponseService) correctResponse(ctx context.Context, survey *model.Survey, oneLink *model.OneLink, resp *model.Response, tokenData *TokenData, req *request.SubmitResponseRequest, ipAddress string) (*response.SubmitResponseResponse, error) {
+	if oneLink.IsRevoked() {
+		return nil, errors.ErrLinkRevoked
+	}
+	if !IPAllowed(ipAddress, survey.AllowedIPs) {
+		return nil, errors.ErrIPNotAllowed
+	}
+	// Corrections of timed responses are bound by the same time limit
+	if err := checkTimeLimit(survey, oneLink); err != nil {
+		return nil, err
+	}
+
+	questions, err := s.questionRepo.FindBySurveyID(ctx, survey.ID)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to find questions")
+	}
+	shown := variantQuestions(survey, questions, oneLink.Variant)
+	withheld := withheldQuestions(survey.QuestionPools, shown, req.Token)
+	answers, err := s.checkedAnswers(shown, linkPrefillData(tokenData, oneLink), req.Answers, withheld)
+	if err != nil {
+		return nil, err
+	}
+
+	// A refresh that re-submits the same answers gets the same result
+	if len(diffAnswers(questions, resp.Data.Answers, answers)) == 0 {
+		return s.submitResult(resp, oneLink, survey.ShowScore), nil
+	}
+
+	previous := &model.ResponseVersion{
+		ResponseID:  resp.ID,
+		Version:     resp.Version,
+		Data:        resp.Data,
+		SubmittedAt: versionSubmittedAt(resp),
+	}
+
+	now := time.Now()
+	resp.Data = model.ResponseData{Answers: answers, Withheld: unseenQuestions(questions, shown, withheld)}
+	resp.Score, resp.MaxScore = scoreAnswers(survey, shown, withheld, answers)
+	resp.Version++
+	resp.EditedAt = &now
+
+	if err := s.responseRepo.UpdateAnswers(ctx, resp, previous); err != nil {
+		// Another correction was saved since the response was loaded
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrConcurrentSubmission
+		}
+		return nil, errors.WrapError(err, "failed to save corrected response")
+	}
+
+	// Minimum, maximum and median counters cannot take an answer back, so the
+	// counters are rebuilt from the database
+	if !resp.IsTest {
+		if err := s.cache.DeleteStats(context.WithoutCancel(ctx), survey.ID); err != nil {
+			fmt.Printf("failed to invalidate statistics counters: %v\n", err)
+		}
+	}
+
+	return s.submitResult(resp, oneLink, survey.ShowScore), nil
+}
+
+// versionSubmittedAt returns when the current answers of a response were submitted
+func versionSubmittedAt(resp *model.Response) time.Time {
+	if resp.EditedAt != nil {
+		return *resp.EditedAt
+	}
+	return resp.SubmittedAt
+}
+
+// ListResponseVersions returns every version of a response, oldest first and ending
+// with the current answers
+func (s *ResponseService) ListResponseVersions(ctx context.Context, userID, surveyID, responseID uint) ([]response.ResponseVersionResponse, error) {
+	resp, versions, err := s.loadResponseVersions(ctx, userID, surveyID, responseID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]response.ResponseVersionResponse, len(versions))
+	for i, version := range versions {
+		result[i] = response.ResponseVersionResponse{
+			Version:     version.Version,
+			Current:     version.Version == resp.Version,
+			Answers:     version.Data.Answers,
+			SubmittedAt: version.SubmittedAt,
+		}
+	}
+	return result, nil
+}
+
+// DiffResponseVersions lists the answers that differ between two versions of a response,
+// in the order of the survey's questions
+func (s *ResponseService) DiffResponseVersions(ctx context.Context, userID, surveyID, responseID uint, req *request.ResponseDiffRequest) (*response.ResponseDiffResponse, error) {
+	resp, versions, err := s.loadResponseVersions(ctx, userID, surveyID, responseID)
+	if err != nil {
+		return nil, err
+	}
+
+	to := req.To
+	if to == 0 {
+		to = resp.Version
+	}
+	from := req.From
+	if from == 0 {
+		from = to - 1
+	}
+	if from < 1 || from >= to || to > resp.Version {
+		return nil, errors.NewLocalizedValidationError("from", "response.version_range_invalid", resp.Version)
+	}
+
+	byVersion := make(map[int]*model.ResponseVersion, len(versions))
+	for i := range versions {
+		byVersion[versions[i].Version] = &versions[i]
+	}
+	fromVersion, toVersion := byVersion[from], byVersion[to]
+	if fromVersion == nil || toVersion == nil {
+		return nil, errors.ErrNotFound
+	}
+
+	questions, err := s.questionRepo.FindBySurveyID(ctx, surveyID)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to find questions")
+	}
+
+	changes := diffAnswers(questions, fromVersion.Data.Answers, toVersion.Data.Answers)
+	answered := make(map[uint]bool)
+	for _, answers := range [][]model.Answer{fromVersion.Data.Answers, toVersion.Data.Answers} {
+		for _, answer := range answers {
+			answered[answer.QuestionID] = true
+		}
+	}
+
+	return &response.ResponseDiffResponse{
+		ResponseID:      resp.ID,
+		From:            from,
+		To:              to,
+		FromSubmittedAt: fromVersion.SubmittedAt,
+		ToSubmittedAt:   toVersion.SubmittedAt,
+		Changes:         changes,
+		Unchanged:       len(answered) - len(changes),
+	}, nil
+}
+
+// loadResponseVersions verifies that the user can access the survey and the response belongs to
+// it, and returns the response with all its versions, the current one included last
+func (s *ResponseService) loadResponseVersions(ctx context.Context, userID, surveyID, responseID uint) (*model.Response, []model.ResponseVersion, error) {
+	// Verify the user owns the survey or shares it through its team
+	if _, err := findTeamSurvey(ctx, s.authz, userID, surveyID); err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := s.responseRepo.FindByID(ctx, responseID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil, errors.ErrNotFound
+		}
+		return nil, nil, errors.WrapError(err, "failed to find response")
+	}
+	if resp.SurveyID != surveyID {
+		return nil, nil, errors.ErrNotFound
+	}
+
+	versions, err := s.responseRepo.FindVersions(ctx, responseID)
+	if err != nil {
+		return nil, nil, errors.WrapError(err, "failed to find response versions")
+	}
+	versions = append(versions, model.ResponseVersion{
+		ResponseID:  resp.ID,
+		Version:     resp.Version,
+		Data:        resp.Data,
+		SubmittedAt: versionSubmittedAt(resp),
+	})
+	return resp, versions, nil
+}
+
+// diffAnswers compares two answer sets and returns the changes in the order of the
+// questions; answers to questions that no longer exist come last
+func diffAnswers(questions []model.Question, before, after []model.Answer) []response.AnswerChange {
+	beforeByID := make(map[uint]interface{}, len(before))
+	for _, answer := range before {
+		beforeByID[answer.QuestionID] = answer.Value
+	}
+	afterByID := make(map[uint]interface{}, len(after))
+	for _, answer := range after {
+		afterByID[answer.QuestionID] = answer.Value
+	}
+
+	changes := []response.AnswerChange{}
+	compare := func(questionID uint, question *model.Question) {
+		oldValue, hadOld := beforeByID[questionID]
+		newValue, hasNew := afterByID[questionID]
+
+		change := response.AnswerChange{QuestionID: questionID, Before: oldValue, After: newValue}
+		switch {
+		case hadOld && hasNew:
+			if sameAnswerValue(oldValue, newValue) {
+				return
+			}
+			change.Change = AnswerChanged
+		case hasNew:
+			change.Change = AnswerAdded
+		case hadOld:
+			change.Change = AnswerRemoved
+		default:
+			return
+		}
+		if question != nil {
+			change.QuestionTitle = question.Title
+			change.QuestionType = question.Type
+		}
+		changes = append(changes, change)
+	}
+
+	known := make(map[uint]bool, len(questions))
+	for i := range questions {
+		known[questions[i].ID] = true
+		compare(questions[i].ID, &questions[i])
+	}
+
+	// Answers of deleted questions, in the order they were given
+	seen := make(map[uint]bool)
+	for _, answers := range [][]model.Answer{before, after} {
+		for _, answer := range answers {
+			if known[answer.QuestionID] || seen[answer.QuestionID] {
+				continue
+			}
+			seen[answer.QuestionID] = true
+			compare(answer.QuestionID, nil)
+		}
+	}
+	return changes
+}
+
+// sameAnswerValue reports whether two answer values are equal once encoded as JSON,
+// so values decoded from the database compare equal to freshly submitted ones
+func sameAnswerValue(a, b interface{}) bool {
+	encodedA, errA := json.Marshal(a)
+	encodedB, errB := json.Marshal(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+	return bytes.Equal(encodedA, encodedB)
+}
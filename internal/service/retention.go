@@ -0,0 +1,274 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"time"
+
+	"survey-system/internal/cache"
+	"survey-system/internal/dto/request"
+	"survey-system/internal/dto/response"
+	"survey-system/internal/model"
+	"survey-system/internal/repository"
+	"survey-system/pkg/errors"
+	"survey-system/pkg/storage"
+	"survey-system/pkg/zipcrypt"
+
+	"gorm.io/gorm"
+)
+
+// archiveStoragePrefix is the storage key prefix of response archives,
+// which the storage cleaner leaves alone
+const archiveStoragePrefix = "archives/"
+
+// ResponsePurger periodically deletes responses older than the retention period.
+// Each survey's responses are first exported to a password-protected ZIP in storage
+// and recorded as a ResponseArchive; responses are only deleted once that succeeded.
+// Hook deliveries built from deleted responses are deleted with them.
+type ResponsePurger struct {
+	responseRepo repository.ResponseRepository
+	hookRepo     repository.HookRepository
+	surveyRepo   repository.SurveyRepository
+	archiveRepo  repository.ResponseArchiveRepository
+	exportSvc    *ExportService
+	store        storage.Storage
+	cache        cache.Cache
+	retention    time.Duration
+	interval     time.Duration
+	password     string
+	batchSize    int
+}
+
+// NewResponsePurger creates a new ResponsePurger
+func NewResponsePurger(
+	responseRepo repository.ResponseRepository,
+	hookRepo repository.HookRepository,
+	surveyRepo repository.SurveyRepository,
+	archiveRepo repository.ResponseArchiveRepository,
+	exportSvc *ExportService,
+	store storage.Storage,
+	cache cache.Cache,
+	retention time.Duration,
+	interval time.Duration,
+	password string,
+	batchSize int,
+) *ResponsePurger {
+	return &ResponsePurger{
+		responseRepo: responseRepo,
+		hookRepo:     hookRepo,
+		surveyRepo:   surveyRepo,
+		archiveRepo:  archiveRepo,
+		exportSvc:    exportSvc,
+		store:        store,
+		cache:        cache,
+		retention:    retention,
+		interval:     interval,
+		password:     password,
+		batchSize:    batchSize,
+	}
+}
+
+// Run purges old responses every interval until ctx is cancelled
+// A non-positive retention or interval disables the purge
+func (p *ResponsePurger) Run(ctx context.Context) {
+	if p.retention <= 0 || p.interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := p.Purge(ctx); err != nil {
+			log.Printf("response purge: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Purge archives and deletes the responses submitted before the first whole day
+// inside the retention period. A survey whose archive fails keeps its responses
+// until the next run.
+func (p *ResponsePurger) Purge(ctx context.Context) error {
+	// Only one instance should purge at a time
+	lockKey := "responses:purge"
+	acquired, err := p.cache.AcquireLock(ctx, lockKey, p.interval)
+	if err != nil {
+		return fmt.Errorf("failed to acquire lock: %w", err)
+	}
+	if !acquired {
+		return nil
+	}
+	defer p.cache.ReleaseLock(ctx, lockKey)
+
+	year, month, day := time.Now().Add(-p.retention).Date()
+	cutoff := time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+
+	surveyIDs, err := p.responseRepo.FindSurveyIDsSubmittedBefore(ctx, cutoff)
+	if err != nil {
+		return fmt.Errorf("failed to find surveys with expired responses: %w", err)
+	}
+
+	for _, surveyID := range surveyIDs {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err := p.purgeSurvey(ctx, surveyID, cutoff); err != nil {
+			log.Printf("response purge: survey %d: %v", surveyID, err)
+		}
+	}
+	return nil
+}
+
+// purgeSurvey archives and deletes the responses of a survey submitted before cutoff
+func (p *ResponsePurger) purgeSurvey(ctx context.Context, surveyID uint, cutoff time.Time) error {
+	survey, err := p.surveyRepo.FindByID(ctx, surveyID)
+	if err != nil {
+		return fmt.Errorf("failed to find survey: %w", err)
+	}
+
+	// The export's to day is inclusive, so it ends on the day before cutoff
+	exportReq := &request.ExportResponsesRequest{
+		Format:      "csv",
+		BOM:         true,
+		To:          cutoff.AddDate(0, 0, -1).Format("2006-01-02"),
+		IncludeTest: true,
+	}
+	filter, err := exportFilter(exportReq)
+	if err != nil {
+		return err
+	}
+
+	count, err := p.responseRepo.CountByFilter(ctx, surveyID, filter)
+	if err != nil {
+		return fmt.Errorf("failed to count responses: %w", err)
+	}
+	if count == 0 {
+		return nil
+	}
+
+	data, filename, err := p.exportSvc.ExportResponses(ctx, survey.UserID, surveyID, exportReq)
+	if err != nil {
+		return fmt.Errorf("failed to export responses: %w", err)
+	}
+	data, err = zipcrypt.Encrypt(filename, data, p.password, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to encrypt archive: %w", err)
+	}
+	filename += ".zip"
+
+	key, err := archiveStorageKey(surveyID)
+	if err != nil {
+		return fmt.Errorf("failed to generate archive key: %w", err)
+	}
+	if err := p.store.Put(ctx, key, data, "application/zip"); err != nil {
+		return fmt.Errorf("failed to store archive: %w", err)
+	}
+
+	archive := &model.ResponseArchive{
+		SurveyID:        surveyID,
+		SurveyTitle:     survey.Title,
+		OwnerID:         survey.UserID,
+		ResponseCount:   count,
+		SubmittedBefore: cutoff,
+		Filename:        filename,
+		StorageKey:      key,
+		Size:            int64(len(data)),
+	}
+	if err := p.archiveRepo.Create(ctx, archive); err != nil {
+		// Without a record the file could not be found, so nothing is deleted
+		if delErr := p.store.Delete(ctx, key); delErr != nil {
+			log.Printf("response purge: failed to delete unrecorded archive %s: %v", key, delErr)
+		}
+		return fmt.Errorf("failed to record archive: %w", err)
+	}
+
+	deleted, err := p.responseRepo.DeleteByFilter(ctx, surveyID, filter, p.batchSize)
+	if deleted > 0 {
+		if cacheErr := p.cache.DeleteStats(ctx, surveyID); cacheErr != nil {
+			fmt.Printf("failed to invalidate statistics counters: %v\n", cacheErr)
+		}
+		// Delivery payloads carry the answers, so they must not outlive the responses
+		if _, hookErr := p.hookRepo.DeleteDeliveriesOfDeletedResponses(ctx, surveyID); hookErr != nil {
+			log.Printf("response purge: survey %d: failed to delete hook deliveries: %v", surveyID, hookErr)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete responses after archiving %d: %w", deleted, err)
+	}
+
+	log.Printf("response purge: archived and deleted %d responses of survey %d submitted before %s (archive %d)",
+		deleted, surveyID, cutoff.Format("2006-01-02"), archive.ID)
+	return nil
+}
+
+// archiveStorageKey returns a new unguessable storage key for an archive of a survey
+func archiveStorageKey(surveyID uint) (string, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s%d/%s-%s.zip", archiveStoragePrefix, surveyID, time.Now().Format("20060102"), hex.EncodeToString(buf)), nil
+}
+
+// ArchiveService defines the interface for retrieving response archives
+type ArchiveService interface {
+	ListArchives(ctx context.Context, surveyID uint) ([]response.ResponseArchiveResponse, error)
+	OpenArchive(ctx context.Context, archiveID uint) (storage.Object, *model.ResponseArchive, error)
+}
+
+// archiveService implements ArchiveService interface
+type archiveService struct {
+	archiveRepo repository.ResponseArchiveRepository
+	store       storage.Storage
+}
+
+// NewArchiveService creates a new archive service instance
+func NewArchiveService(archiveRepo repository.ResponseArchiveRepository, store storage.Storage) ArchiveService {
+	return &archiveService{
+		archiveRepo: archiveRepo,
+		store:       store,
+	}
+}
+
+// ListArchives lists response archives newest first, only those of surveyID when it is not 0
+func (s *archiveService) ListArchives(ctx context.Context, surveyID uint) ([]response.ResponseArchiveResponse, error) {
+	archives, err := s.archiveRepo.FindAll(ctx, surveyID)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to find response archives")
+	}
+
+	result := make([]response.ResponseArchiveResponse, len(archives))
+	for i := range archives {
+		result[i] = response.ToResponseArchiveResponse(&archives[i])
+	}
+	return result, nil
+}
+
+// OpenArchive opens the file of a response archive
+func (s *archiveService) OpenArchive(ctx context.Context, archiveID uint) (storage.Object, *model.ResponseArchive, error) {
+	archive, err := s.archiveRepo.FindByID(ctx, archiveID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil, errors.ErrNotFound
+		}
+		return nil, nil, errors.WrapError(err, "failed to find response archive")
+	}
+
+	file, err := s.store.Open(ctx, archive.StorageKey)
+	if err != nil {
+		if err == storage.ErrNotFound {
+			return nil, nil, errors.ErrNotFound
+		}
+		return nil, nil, errors.WrapError(err, "failed to load response archive")
+	}
+	return file, archive, nil
+}
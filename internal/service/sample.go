@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"sort"
+
+	"survey-system/internal/dto/response"
+	"survey-system/internal/model"
+	"survey-system/internal/repository"
+	"survey-system/pkg/errors"
+
+	"gorm.io/gorm"
+)
+
+// Sample size limits
+const (
+	defaultSampleSize = 50
+	maxSampleSize     = 500
+)
+
+// SampleResponses draws a random sample of a survey's responses for qualitative review
+// When stratifyBy names a choice question, the sample is split across its options in
+// proportion to how many responses chose each one; responses that skipped the
+// question are then not sampled
+func (s *ResponseService) SampleResponses(ctx context.Context, userID, surveyID uint, size int, stratifyBy uint) ([]response.ResponseListItem, *response.SampleResponseMeta, error) {
+	// Verify survey ownership
+	survey, err := s.surveyRepo.FindByID(ctx, surveyID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil, errors.ErrNotFound
+		}
+		return nil, nil, errors.WrapError(err, "failed to find survey")
+	}
+
+	if survey.UserID != userID {
+		return nil, nil, errors.ErrForbidden
+	}
+
+	if size < 1 {
+		size = defaultSampleSize
+	}
+	if size > maxSampleSize {
+		size = maxSampleSize
+	}
+
+	meta := &response.SampleResponseMeta{StratifyBy: stratifyBy}
+	var ids []uint
+	if stratifyBy == 0 {
+		meta.Population, err = s.responseRepo.CountBySurveyID(ctx, surveyID)
+		if err != nil {
+			return nil, nil, errors.WrapError(err, "failed to count responses")
+		}
+		ids, err = s.responseRepo.SampleIDs(ctx, surveyID, repository.ResponseFilter{}, nil, size)
+		if err != nil {
+			return nil, nil, errors.WrapError(err, "failed to sample responses")
+		}
+	} else {
+		questions, err := s.questionRepo.FindBySurveyID(ctx, surveyID)
+		if err != nil {
+			return nil, nil, errors.WrapError(err, "failed to find questions")
+		}
+		question, err := choiceQuestion(questions, stratifyBy)
+		if err != nil {
+			return nil, nil, err
+		}
+		ids, err = s.sampleStrata(ctx, surveyID, question, size, meta)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	responses, err := s.responseRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, nil, errors.WrapError(err, "failed to find responses")
+	}
+
+	// Keep the order in which the responses were drawn
+	position := make(map[uint]int, len(ids))
+	for i, id := range ids {
+		position[id] = i
+	}
+	sort.Slice(responses, func(i, j int) bool {
+		return position[responses[i].ID] < position[responses[j].ID]
+	})
+
+	items, err := s.toResponseListItems(ctx, responses)
+	if err != nil {
+		return nil, nil, err
+	}
+	meta.Size = len(items)
+	return items, meta, nil
+}
+
+// sampleStrata draws the sample of each option of the stratification question
+// A multiple choice response can belong to several strata but is sampled only once
+func (s *ResponseService) sampleStrata(ctx context.Context, surveyID uint, question *model.Question, size int, meta *response.SampleResponseMeta) ([]uint, error) {
+	meta.Strata = make([]response.SampleStratum, len(question.Config.Options))
+	populations := make([]int64, len(question.Config.Options))
+	for i, option := range question.Config.Options {
+		filter := repository.ResponseFilter{QuestionID: question.ID, OptionID: option.ID}
+		count, err := s.responseRepo.CountByFilter(ctx, surveyID, filter)
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to count responses")
+		}
+		populations[i] = count
+		meta.Strata[i] = response.SampleStratum{OptionID: option.ID, Label: option.Label, Population: count}
+	}
+
+	var err error
+	meta.Population, err = s.responseRepo.CountByFilter(ctx, surveyID, repository.ResponseFilter{QuestionID: question.ID})
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to count responses")
+	}
+
+	var ids []uint
+	for i, quota := range allocateSample(populations, size) {
+		if quota == 0 {
+			continue
+		}
+		filter := repository.ResponseFilter{QuestionID: question.ID, OptionID: question.Config.Options[i].ID}
+		sampled, err := s.responseRepo.SampleIDs(ctx, surveyID, filter, ids, quota)
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to sample responses")
+		}
+		meta.Strata[i].Sampled = len(sampled)
+		ids = append(ids, sampled...)
+	}
+	return ids, nil
+}
+
+// allocateSample splits size across strata in proportion to their populations
+// using the largest remainder method, never exceeding a stratum's population
+func allocateSample(populations []int64, size int) []int {
+	quotas := make([]int, len(populations))
+	total := sumInt64(populations)
+	if total == 0 {
+		return quotas
+	}
+	if int64(size) >= total {
+		for i, population := range populations {
+			quotas[i] = int(population)
+		}
+		return quotas
+	}
+
+	remainders := make([]int64, len(populations))
+	allocated := 0
+	for i, population := range populations {
+		share := population * int64(size)
+		quotas[i] = int(share / total)
+		remainders[i] = share % total
+		allocated += quotas[i]
+	}
+
+	order := make([]int, len(populations))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return remainders[order[a]] > remainders[order[b]]
+	})
+	for _, i := range order {
+		if allocated >= size {
+			break
+		}
+		if int64(quotas[i]) < populations[i] {
+			quotas[i]++
+			allocated++
+		}
+	}
+	return quotas
+}
+
+// sumInt64 adds up a slice of counts
+func sumInt64(values []int64) int64 {
+	var sum int64
+	for _, v := range values {
+		sum += v
+	}
+	return sum
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	stderrors "errors"
+	"log"
+	"time"
+
+	"survey-system/internal/dto/response"
+	"survey-system/internal/model"
+	"survey-system/pkg/errors"
+
+	"gorm.io/gorm"
+)
+
+// sessionTouchInterval limits how often a request updates the last use time of its
+// session, so authenticated requests do not all write to the database
+const sessionTouchInterval = 5 * time.Minute
+
+// recordSession records the login session of a token about to be issued
+// The user's expired sessions are pruned on the way
+func (s *authService) recordSession(ctx context.Context, session *model.UserSession) error {
+	if err := s.sessionRepo.DeleteExpired(ctx, session.UserID); err != nil {
+		return errors.WrapError(err, "failed to prune sessions")
+	}
+	session.UserAgent = truncateRunes(session.UserAgent, 500)
+	if err := s.sessionRepo.Create(ctx, session); err != nil {
+		return errors.WrapError(err, "failed to record session")
+	}
+	return nil
+}
+
+// CheckSession verifies that the login session of a token has not been revoked
+// Tokens without a token ID, i.e. impersonation tokens, are not tied to a session
+func (s *authService) CheckSession(ctx context.Context, userID uint, tokenID string) error {
+	if tokenID == "" {
+		return nil
+	}
+
+	session, err := s.sessionRepo.FindByTokenID(ctx, tokenID)
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.ErrInvalidAuthToken
+		}
+		return errors.WrapError(err, "failed to find session")
+	}
+	if session.UserID != userID {
+		return errors.ErrInvalidAuthToken
+	}
+	if session.RevokedAt != nil {
+		return errors.ErrSessionRevoked
+	}
+
+	now := time.Now()
+	if session.LastUsedAt == nil || now.Sub(*session.LastUsedAt) >= sessionTouchInterval {
+		if err := s.sessionRepo.Touch(ctx, session.ID, now); err != nil {
+			log.Printf("Failed to update last use of session %d: %v", session.ID, err)
+		}
+	}
+	return nil
+}
+
+// ListSessions returns the active login sessions of a user, newest first, marking the
+// one of the token the request was made with
+func (s *authService) ListSessions(ctx context.Context, userID uint, currentTokenID string) ([]response.SessionResponse, error) {
+	sessions, err := s.sessionRepo.FindActiveByUserID(ctx, userID)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to find sessions")
+	}
+
+	result := make([]response.SessionResponse, len(sessions))
+	for i, session := range sessions {
+		result[i] = response.SessionResponse{
+			ID:         session.ID,
+			UserAgent:  session.UserAgent,
+			IPAddress:  session.IPAddress,
+			CreatedAt:  session.CreatedAt,
+			LastUsedAt: session.LastUsedAt,
+			ExpiresAt:  session.ExpiresAt,
+			Current:    currentTokenID != "" && session.TokenID == currentTokenID,
+		}
+	}
+	return result, nil
+}
+
+// RevokeSession revokes a login session of a user; its token is rejected from the next
+// request on. Revoking the session of the current token logs the user out
+func (s *authService) RevokeSession(ctx context.Context, userID, sessionID uint) error {
+	session, err := s.sessionRepo.FindByID(ctx, sessionID)
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.ErrNotFound
+		}
+		return errors.WrapError(err, "failed to find session")
+	}
+	// Sessions of other users are reported as missing
+	if session.UserID != userID || !session.IsActive() {
+		return errors.ErrNotFound
+	}
+
+	if err := s.sessionRepo.Revoke(ctx, session.ID); err != nil {
+		return errors.WrapError(err, "failed to revoke session")
+	}
+	return nil
+}
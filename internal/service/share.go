@@ -2,7 +2,10 @@ package service
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"net/url"
 	"time"
 
 	"survey-system/internal/dto/request"
@@ -10,6 +13,8 @@ import (
 	"survey-system/internal/model"
 	"survey-system/internal/repository"
 	"survey-system/pkg/errors"
+	"survey-system/pkg/markdown"
+	"survey-system/pkg/utils"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -18,19 +23,65 @@ import (
 // ShareService defines the interface for share link business logic
 type ShareService interface {
 	GenerateShareLink(ctx context.Context, userID, surveyID uint, req *request.GenerateShareLinkRequest) (*response.ShareLinkResponse, error)
-	ValidateAndGetSurvey(ctx context.Context, token string) (*response.SurveyWithPrefillResponse, error)
+	GenerateDelegatedShareLink(ctx context.Context, delegationToken string, surveyID uint, req *request.GenerateShareLinkRequest) (*response.ShareLinkResponse, error)
+	GeneratePreviewLink(ctx context.Context, userID, surveyID uint, req *request.GeneratePreviewLinkRequest) (*response.PreviewLinkResponse, error)
+	ValidateAndGetSurvey(ctx context.Context, token, clientIP string) (*response.SurveyWithPrefillResponse, error)
+	CheckLinkStatus(ctx context.Context, token string) (*response.LinkStatusResponse, error)
+	GetSurveyMeta(ctx context.Context, surveyID uint) (*response.SurveyMetaResponse, error)
+	GetEmbedInfo(ctx context.Context, surveyID uint, token string) (*response.EmbedResponse, error)
+	RevokeLinks(ctx context.Context, userID, surveyID uint, req *request.RevokeLinksRequest) (*response.RevokeLinksResponse, error)
+	GenerateLinkPool(ctx context.Context, userID, surveyID uint, req *request.GenerateLinkPoolRequest) (*response.LinkPoolResponse, error)
+	ClaimPoolLink(ctx context.Context, userID, surveyID uint, req *request.ClaimPoolLinkRequest) (*response.ClaimedLinkResponse, error)
+
+	ListLinkTemplates(ctx context.Context, userID, surveyID uint) ([]response.LinkTemplateResponse, error)
+	CreateLinkTemplate(ctx context.Context, userID, surveyID uint, req *request.LinkTemplateRequest) (*response.LinkTemplateResponse, error)
+	UpdateLinkTemplate(ctx context.Context, userID, surveyID, templateID uint, req *request.LinkTemplateRequest) (*response.LinkTemplateResponse, error)
+	DeleteLinkTemplate(ctx context.Context, userID, surveyID, templateID uint) error
+
+	ListDelegations(ctx context.Context, userID, surveyID uint) ([]response.DelegationResponse, error)
+	CreateDelegation(ctx context.Context, userID, surveyID uint, req *request.CreateDelegationRequest) (*response.CreateDelegationResponse, error)
+	RevokeDelegation(ctx context.Context, userID, surveyID, delegationID uint) error
 }
 
 // shareService implements ShareService interface
 type shareService struct {
-	surveyRepo    repository.SurveyRepository
-	questionRepo  repository.QuestionRepository
-	oneLinkRepo   repository.OneLinkRepository
-	encryptionSvc EncryptionService
-	cache         Cache
-	baseURL       string
-	defaultExpiry time.Duration
-	maxExpiry     time.Duration
+	surveyRepo       repository.SurveyRepository
+	questionRepo     repository.QuestionRepository
+	oneLinkRepo      repository.OneLinkRepository
+	eventRepo        repository.EventRepository
+	linkTemplateRepo repository.LinkTemplateRepository
+	delegationRepo   repository.DelegationRepository
+	encryptionSvc    EncryptionService
+	usage            UsageService
+	cache            Cache
+	baseURL          string
+	expiry           func() LinkExpiry
+	redirectDomains  []string
+	authz            *utils.AuthorizationUtil
+}
+
+// LinkExpiry bounds the lifetime of generated share links
+type LinkExpiry struct {
+	Default time.Duration // used when the request sets no expiration
+	Max     time.Duration // latest allowed expiration, relative to now
+	Preview time.Duration // lifetime of preview links
+}
+
+// forSurvey applies a survey's own link lifetime settings. A survey maximum can
+// only tighten the global maximum, and the default never exceeds the maximum
+func (e LinkExpiry) forSurvey(survey *model.Survey) LinkExpiry {
+	if survey.LinkMaxExpiryHours > 0 {
+		if limit := time.Duration(survey.LinkMaxExpiryHours) * time.Hour; limit < e.Max {
+			e.Max = limit
+		}
+	}
+	if survey.LinkDefaultExpiryHours > 0 {
+		e.Default = time.Duration(survey.LinkDefaultExpiryHours) * time.Hour
+	}
+	if e.Default > e.Max {
+		e.Default = e.Max
+	}
+	return e
 }
 
 // NewShareService creates a new share service instance
@@ -38,80 +89,88 @@ func NewShareService(
 	surveyRepo repository.SurveyRepository,
 	questionRepo repository.QuestionRepository,
 	oneLinkRepo repository.OneLinkRepository,
+	eventRepo repository.EventRepository,
+	linkTemplateRepo repository.LinkTemplateRepository,
+	delegationRepo repository.DelegationRepository,
 	encryptionSvc EncryptionService,
+	usage UsageService,
 	cache Cache,
 	baseURL string,
-	defaultExpiry time.Duration,
-	maxExpiry time.Duration,
+	expiry func() LinkExpiry,
+	redirectDomains []string,
+	authz *utils.AuthorizationUtil,
 ) ShareService {
 	return &shareService{
-		surveyRepo:    surveyRepo,
-		questionRepo:  questionRepo,
-		oneLinkRepo:   oneLinkRepo,
-		encryptionSvc: encryptionSvc,
-		cache:         cache,
-		baseURL:       baseURL,
-		defaultExpiry: defaultExpiry,
-		maxExpiry:     maxExpiry,
+		surveyRepo:       surveyRepo,
+		questionRepo:     questionRepo,
+		oneLinkRepo:      oneLinkRepo,
+		eventRepo:        eventRepo,
+		linkTemplateRepo: linkTemplateRepo,
+		delegationRepo:   delegationRepo,
+		encryptionSvc:    encryptionSvc,
+		usage:            usage,
+		cache:            cache,
+		baseURL:          baseURL,
+		expiry:           expiry,
+		redirectDomains:  redirectDomains,
+		authz:            authz,
 	}
 }
 
 // GenerateShareLink generates an encrypted share link with prefill data
 func (s *shareService) GenerateShareLink(ctx context.Context, userID, surveyID uint, req *request.GenerateShareLinkRequest) (*response.ShareLinkResponse, error) {
-	// Find the survey and verify ownership
-	survey, err := s.surveyRepo.FindByID(surveyID)
+	// Find the survey and verify the user owns it or shares it through its team
+	survey, err := findTeamSurvey(ctx, s.authz, userID, surveyID)
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return nil, errors.ErrNotFound
-		}
-		return nil, errors.WrapError(err, "failed to find survey")
+		return nil, err
 	}
 
-	// Verify ownership
-	if survey.UserID != userID {
-		return nil, errors.ErrForbidden
+	return s.generateShareLink(ctx, userID, survey, req)
+}
+
+// generateShareLink validates a share link request for a survey and creates the link
+// userID is recorded as the user who generated it
+func (s *shareService) generateShareLink(ctx context.Context, userID uint, survey *model.Survey, req *request.GenerateShareLinkRequest) (*response.ShareLinkResponse, error) {
+	surveyID := survey.ID
+
+	// A link bound to a known respondent would identify the answers of an anonymous survey
+	if survey.Anonymous && req.RespondentID != "" {
+		return nil, errors.NewValidationError("respondent_id", "anonymous surveys cannot bind links to a respondent")
+	}
+
+	// Fill in the saved settings of the referenced link template
+	if req.TemplateID != 0 {
+		if err := s.applyLinkTemplate(ctx, surveyID, req); err != nil {
+			return nil, err
+		}
 	}
 
 	// Get all questions for the survey to validate prefill keys
-	questions, err := s.questionRepo.FindBySurveyID(surveyID)
+	questions, err := s.questionRepo.FindBySurveyID(ctx, surveyID)
 	if err != nil {
 		return nil, errors.WrapError(err, "failed to find questions")
 	}
 
-	// Validate prefill data - ensure all prefill keys match question prefill_key fields
-	if req.PrefillData != nil && len(req.PrefillData) > 0 {
-		validPrefillKeys := make(map[string]bool)
-		for _, q := range questions {
-			if q.PrefillKey != "" {
-				validPrefillKeys[q.PrefillKey] = true
-			}
-		}
+	// Validate prefill data - ensure all prefill keys match question prefill_key fields and their types
+	if err := validatePrefillData(questions, req.PrefillData); err != nil {
+		return nil, err
+	}
 
-		for key := range req.PrefillData {
-			if !validPrefillKeys[key] {
-				return nil, errors.NewValidationError("prefill_data", fmt.Sprintf("invalid prefill key '%s' - no matching question found", key))
-			}
+	// Only allowlisted thank-you pages may be used, so links cannot become open redirects
+	if req.RedirectURL != "" {
+		if err := s.validateRedirectURL(req.RedirectURL); err != nil {
+			return nil, err
 		}
 	}
 
-	// Determine expiration time
-	var expiresAt time.Time
-	if req.ExpiresAt != nil {
-		expiresAt = *req.ExpiresAt
-
-		// Validate expiration is in the future
-		if expiresAt.Before(time.Now()) {
-			return nil, errors.NewValidationError("expires_at", "expiration time must be in the future")
-		}
+	expiresAt, err := s.linkExpiresAt(survey, req.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
 
-		// Validate expiration doesn't exceed max expiry
-		maxExpiresAt := time.Now().Add(s.maxExpiry)
-		if expiresAt.After(maxExpiresAt) {
-			return nil, errors.NewValidationError("expires_at", fmt.Sprintf("expiration time exceeds maximum allowed duration of %v", s.maxExpiry))
-		}
-	} else {
-		// Use default expiration
-		expiresAt = time.Now().Add(s.defaultExpiry)
+	variant, err := linkVariant(survey, req.Variant)
+	if err != nil {
+		return nil, err
 	}
 
 	// Generate unique ID for this link
@@ -133,32 +192,206 @@ func (s *shareService) GenerateShareLink(ctx context.Context, userID, surveyID u
 
 	// Create OneLink record in database
 	oneLink := &model.OneLink{
-		SurveyID:    surveyID,
-		Token:       encryptedToken,
-		PrefillData: model.PrefillDataType(req.PrefillData),
-		ExpiresAt:   expiresAt,
-		Used:        false,
+		SurveyID:     surveyID,
+		Token:        encryptedToken,
+		PrefillData:  model.PrefillDataType(req.PrefillData),
+		ExpiresAt:    expiresAt,
+		Used:         false,
+		RedirectURL:  req.RedirectURL,
+		Campaign:     req.Campaign,
+		RespondentID: req.RespondentID,
+		IsTest:       req.Test,
+		Variant:      variant,
 	}
 
-	if err := s.oneLinkRepo.Create(oneLink); err != nil {
+	if err := s.oneLinkRepo.Create(ctx, oneLink); err != nil {
 		return nil, errors.WrapError(err, "failed to create one-time link")
 	}
 
+	recordEvent(ctx, s.eventRepo, &model.SurveyEvent{
+		SurveyID: surveyID,
+		UserID:   userID,
+		Type:     model.EventLinkGenerated,
+		Campaign: req.Campaign,
+	})
+
 	// Build the complete share URL
 	shareURL := fmt.Sprintf("%s/survey/%d?token=%s", s.baseURL, surveyID, encryptedToken)
 
 	return &response.ShareLinkResponse{
-		Token:     encryptedToken,
-		URL:       shareURL,
+		Token:        encryptedToken,
+		URL:          shareURL,
+		ExpiresAt:    expiresAt,
+		RedirectURL:  req.RedirectURL,
+		Campaign:     req.Campaign,
+		RespondentID: req.RespondentID,
+		Test:         req.Test,
+		TemplateID:   req.TemplateID,
+		Variant:      variant,
+	}, nil
+}
+
+// linkExpiresAt validates the requested expiration of a link against the survey settings,
+// falling back to the global ones, and returns the default expiration when none is requested
+func (s *shareService) linkExpiresAt(survey *model.Survey, requested *time.Time) (time.Time, error) {
+	expiry := s.expiry().forSurvey(survey)
+	if requested == nil {
+		return time.Now().Add(expiry.Default), nil
+	}
+
+	// Validate expiration is in the future
+	if requested.Before(time.Now()) {
+		return time.Time{}, errors.NewValidationError("expires_at", "expiration time must be in the future")
+	}
+
+	// Validate expiration doesn't exceed max expiry
+	maxExpiresAt := time.Now().Add(expiry.Max)
+	if requested.After(maxExpiresAt) {
+		return time.Time{}, errors.NewValidationError("expires_at", fmt.Sprintf("expiration time exceeds maximum allowed duration of %v", expiry.Max))
+	}
+	return *requested, nil
+}
+
+// GeneratePreviewLink generates a preview link for the survey owner
+// Unlike share links it works for surveys in any status, is not stored as a
+// one-time link and can be opened and submitted any number of times; preview
+// submissions are validated but never saved
+func (s *shareService) GeneratePreviewLink(ctx context.Context, userID, surveyID uint, req *request.GeneratePreviewLinkRequest) (*response.PreviewLinkResponse, error) {
+	survey, err := s.surveyRepo.FindByID(ctx, surveyID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.WrapError(err, "failed to find survey")
+	}
+
+	if survey.UserID != userID {
+		return nil, errors.ErrForbidden
+	}
+
+	questions, err := s.questionRepo.FindBySurveyID(ctx, surveyID)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to find questions")
+	}
+	if err := validatePrefillData(questions, req.PrefillData); err != nil {
+		return nil, err
+	}
+	variant, err := linkVariant(survey, req.Variant)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(s.expiry().Preview)
+	token, err := s.encryptionSvc.EncryptPreviewToken(&PreviewTokenData{
+		SurveyID:    surveyID,
+		UserID:      userID,
+		PrefillData: req.PrefillData,
+		ExpiresAt:   expiresAt.Unix(),
+		Variant:     variant,
+	})
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to encrypt token")
+	}
+
+	return &response.PreviewLinkResponse{
+		Token:     token,
+		URL:       fmt.Sprintf("%s/survey/%d?token=%s", s.baseURL, surveyID, token),
 		ExpiresAt: expiresAt,
+		Variant:   variant,
 	}, nil
 }
 
+// validatePrefillData ensures every prefill key matches the prefill_key of a question
+// and that its value has the prefill type declared by the questions using the key
+func validatePrefillData(questions []model.Question, prefillData map[string]interface{}) error {
+	if len(prefillData) == 0 {
+		return nil
+	}
+
+	prefillQuestions := make(map[string][]*model.Question)
+	for i := range questions {
+		if key := questions[i].PrefillKey; key != "" {
+			prefillQuestions[key] = append(prefillQuestions[key], &questions[i])
+		}
+	}
+
+	for key, value := range prefillData {
+		keyQuestions, ok := prefillQuestions[key]
+		if !ok {
+			return errors.NewValidationError("prefill_data", fmt.Sprintf("invalid prefill key '%s' - no matching question found", key))
+		}
+		for _, q := range keyQuestions {
+			if err := checkPrefillValue(q, value); err != nil {
+				return errors.NewValidationError("prefill_data", fmt.Sprintf("value of '%s' %s", key, err.Error()))
+			}
+		}
+	}
+	return nil
+}
+
+// checkPrefillValue checks a prefill value against the prefill type of a question
+func checkPrefillValue(q *model.Question, value interface{}) error {
+	switch q.PrefillType {
+	case model.PrefillTypeString:
+		if _, ok := value.(string); !ok {
+			return fmt.Errorf("must be a string")
+		}
+
+	case model.PrefillTypeNumber:
+		if _, ok := value.(float64); !ok {
+			return fmt.Errorf("must be a number")
+		}
+
+	case model.PrefillTypeOption:
+		var ids []interface{}
+		if list, ok := value.([]interface{}); ok && q.Type == model.QuestionTypeMultiple {
+			ids = list
+		} else {
+			ids = []interface{}{value}
+		}
+		for _, raw := range ids {
+			id, ok := raw.(string)
+			if !ok {
+				if q.Type == model.QuestionTypeMultiple {
+					return fmt.Errorf("must be an option ID or a list of option IDs")
+				}
+				return fmt.Errorf("must be an option ID")
+			}
+			if _, found := q.Config.FindOption(id); !found {
+				return fmt.Errorf("'%s' is not an option of question '%s'", id, q.Title)
+			}
+		}
+	}
+	return nil
+}
+
+// validateRedirectURL checks a thank-you redirect against the configured allowlist
+func (s *shareService) validateRedirectURL(redirectURL string) error {
+	u, err := url.Parse(redirectURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.NewValidationError("redirect_url", "redirect URL must be an absolute http or https URL")
+	}
+	if len(s.redirectDomains) == 0 {
+		return errors.NewValidationError("redirect_url", "redirect URLs are not enabled on this server")
+	}
+	for _, domain := range s.redirectDomains {
+		if matchEmbedDomain(redirectURL, domain) {
+			return nil
+		}
+	}
+	return errors.NewValidationError("redirect_url", "redirect URL host is not in the allowed list")
+}
+
 // ValidateAndGetSurvey validates a token and returns the survey with prefilled values
-func (s *shareService) ValidateAndGetSurvey(ctx context.Context, token string) (*response.SurveyWithPrefillResponse, error) {
+// clientIP is checked against the survey's network allowlist
+func (s *shareService) ValidateAndGetSurvey(ctx context.Context, token, clientIP string) (*response.SurveyWithPrefillResponse, error) {
 	// Step 1: Decrypt the token to get TokenData
 	tokenData, err := s.encryptionSvc.DecryptToken(token)
 	if err != nil {
+		// Preview tokens are accepted without a one-time link
+		if previewData, previewErr := s.encryptionSvc.DecryptPreviewToken(token); previewErr == nil {
+			return s.previewSurvey(ctx, token, previewData)
+		}
 		return nil, errors.ErrInvalidToken
 	}
 
@@ -178,12 +411,9 @@ func (s *shareService) ValidateAndGetSurvey(ctx context.Context, token string) (
 	}
 
 	// Step 4: Find the OneLink record in database
-	oneLink, err := s.oneLinkRepo.FindByToken(token)
+	oneLink, err := findOneLinkByToken(ctx, s.oneLinkRepo, s.cache, token)
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return nil, errors.ErrInvalidToken
-		}
-		return nil, errors.WrapError(err, "failed to find one-time link")
+		return nil, err
 	}
 
 	// Step 5: Check if link has been used
@@ -199,7 +429,10 @@ func (s *shareService) ValidateAndGetSurvey(ctx context.Context, token string) (
 		return nil, errors.ErrLinkUsed
 	}
 
-	// Step 6: Check if link has expired (double check with database record)
+	// Step 6: Check if link was revoked or has expired (double check with database record)
+	if oneLink.IsRevoked() {
+		return nil, errors.ErrLinkRevoked
+	}
 	if oneLink.IsExpired() {
 		return nil, errors.ErrTokenExpired
 	}
@@ -213,16 +446,49 @@ func (s *shareService) ValidateAndGetSurvey(ctx context.Context, token string) (
 		}
 	}
 
-	// Step 8: Mark link as accessed (first time viewing)
+	// Step 8: Get the survey with questions
+	survey, err := s.findSurveyWithQuestions(ctx, tokenData.SurveyID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 9: Enforce the network allowlist before the link counts as accessed
+	if !IPAllowed(clientIP, survey.AllowedIPs) {
+		return nil, errors.ErrIPNotAllowed
+	}
+
+	// Step 10: Mark link as accessed (first time viewing)
 	if oneLink.AccessedAt == nil {
-		if err := s.oneLinkRepo.MarkAsAccessed(oneLink.ID); err != nil {
+		if err := s.oneLinkRepo.MarkAsAccessed(ctx, oneLink.ID); err != nil {
 			// Log error but don't fail the request
 			fmt.Printf("failed to mark link as accessed: %v\n", err)
 		}
 	}
 
-	// Step 9: Get the survey with questions
-	survey, err := s.surveyRepo.FindByIDWithQuestions(tokenData.SurveyID)
+	// Step 11: Build response with the questions of the link's variant, prefilled values
+	// and the timed session, if any. The cached survey is shared, so a copy is narrowed
+	view := *survey
+	view.Questions = variantQuestions(survey, survey.Questions, oneLink.Variant)
+	result := surveyWithPrefill(token, &view, linkPrefillData(tokenData, oneLink))
+	if err := startSession(ctx, s.oneLinkRepo, &view, oneLink, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// findSurveyWithQuestions loads a survey with its questions, using cache when available
+// The returned survey may be shared with other requests and must not be modified
+func (s *shareService) findSurveyWithQuestions(ctx context.Context, surveyID uint) (*model.Survey, error) {
+	cachedSurvey, err := s.cache.GetSurvey(ctx, surveyID)
+	if err != nil {
+		// Log error but continue to database
+		fmt.Printf("failed to get survey from cache: %v\n", err)
+	}
+	if cachedSurvey != nil {
+		return cachedSurvey, nil
+	}
+
+	survey, err := s.surveyRepo.FindByIDWithQuestions(ctx, surveyID)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, errors.ErrNotFound
@@ -230,9 +496,156 @@ func (s *shareService) ValidateAndGetSurvey(ctx context.Context, token string) (
 		return nil, errors.WrapError(err, "failed to find survey")
 	}
 
-	// Step 10: Build response with prefilled values
-	questionsWithPrefill := make([]response.QuestionWithPrefill, len(survey.Questions))
-	for i, q := range survey.Questions {
+	// Cache the survey for 1 hour, like the survey details
+	if err := s.cache.SetSurvey(ctx, survey, time.Hour); err != nil {
+		fmt.Printf("failed to cache survey: %v\n", err)
+	}
+	return survey, nil
+}
+
+// CheckLinkStatus reports whether a link can still be opened without loading the
+// survey or marking the link as accessed
+func (s *shareService) CheckLinkStatus(ctx context.Context, token string) (*response.LinkStatusResponse, error) {
+	tokenData, err := s.encryptionSvc.DecryptToken(token)
+	if err != nil {
+		// Preview tokens have no one-time link and can be used until they expire
+		if previewData, previewErr := s.encryptionSvc.DecryptPreviewToken(token); previewErr == nil {
+			result := &response.LinkStatusResponse{
+				Status:    response.LinkStatusValid,
+				SurveyID:  previewData.SurveyID,
+				ExpiresAt: time.Unix(previewData.ExpiresAt, 0),
+				Preview:   true,
+			}
+			if time.Now().Unix() > previewData.ExpiresAt {
+				result.Status = response.LinkStatusExpired
+			}
+			return result, nil
+		}
+		return nil, errors.ErrInvalidToken
+	}
+
+	result := &response.LinkStatusResponse{
+		Status:    response.LinkStatusValid,
+		SurveyID:  tokenData.SurveyID,
+		ExpiresAt: time.Unix(tokenData.ExpiresAt, 0),
+	}
+	if time.Now().Unix() > tokenData.ExpiresAt {
+		result.Status = response.LinkStatusExpired
+		return result, nil
+	}
+
+	// Links known to be used are answered from the cache
+	cachedUsed, err := s.cache.GetOneLinkStatus(ctx, token)
+	if err != nil {
+		fmt.Printf("failed to get onelink status from cache: %v\n", err)
+	} else if cachedUsed {
+		result.Status = response.LinkStatusUsed
+		return result, nil
+	}
+
+	oneLink, err := findOneLinkByToken(ctx, s.oneLinkRepo, s.cache, token)
+	if err != nil {
+		return nil, err
+	}
+
+	result.ExpiresAt = oneLink.ExpiresAt
+	switch {
+	case oneLink.Used:
+		result.Status = response.LinkStatusUsed
+	case oneLink.IsRevoked():
+		result.Status = response.LinkStatusRevoked
+	case oneLink.IsExpired():
+		result.Status = response.LinkStatusExpired
+	}
+	return result, nil
+}
+
+// GetSurveyMeta returns whether a survey accepts responses without requiring a token
+// Drafts are reported as not found so their titles are not exposed
+func (s *shareService) GetSurveyMeta(ctx context.Context, surveyID uint) (*response.SurveyMetaResponse, error) {
+	survey, err := s.surveyRepo.FindByID(ctx, surveyID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.WrapError(err, "failed to find survey")
+	}
+
+	if survey.Status == model.SurveyStatusDraft {
+		return nil, errors.ErrNotFound
+	}
+
+	return &response.SurveyMetaResponse{
+		ID:          survey.ID,
+		Title:       survey.Title,
+		Status:      survey.Status,
+		Open:        survey.Status == model.SurveyStatusPublished,
+		PublishedAt: survey.PublishedAt,
+	}, nil
+}
+
+// previewSurvey returns the survey of a preview token as a respondent would see it
+// The status and network allowlist checks are skipped because only the owner can
+// generate preview tokens; tokens stop working when the survey changes owner
+func (s *shareService) previewSurvey(ctx context.Context, token string, previewData *PreviewTokenData) (*response.SurveyWithPrefillResponse, error) {
+	if time.Now().Unix() > previewData.ExpiresAt {
+		return nil, errors.ErrTokenExpired
+	}
+
+	survey, err := s.surveyRepo.FindByIDWithQuestions(ctx, previewData.SurveyID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.WrapError(err, "failed to find survey")
+	}
+	if survey.UserID != previewData.UserID {
+		return nil, errors.ErrInvalidToken
+	}
+
+	survey.Questions = variantQuestions(survey, survey.Questions, previewData.Variant)
+	result := surveyWithPrefill(token, survey, previewData.PrefillData)
+	result.Preview = true
+	return result, nil
+}
+
+// surveyWithPrefill builds the respondent view of a survey with prefilled values
+func surveyWithPrefill(token string, survey *model.Survey, prefillData map[string]interface{}) *response.SurveyWithPrefillResponse {
+	// Hidden questions and the prefill values only they use are not shown to respondents
+	hiddenKeys := make(map[string]bool)
+	for _, q := range survey.Questions {
+		if q.Hidden {
+			hiddenKeys[q.PrefillKey] = true
+		}
+	}
+	for _, q := range survey.Questions {
+		if !q.Hidden {
+			delete(hiddenKeys, q.PrefillKey)
+		}
+	}
+	if len(hiddenKeys) > 0 && prefillData != nil {
+		visible := make(map[string]interface{}, len(prefillData))
+		for key, value := range prefillData {
+			if !hiddenKeys[key] {
+				visible[key] = value
+			}
+		}
+		prefillData = visible
+	}
+
+	// Each respondent receives only the questions drawn from the survey's question pools
+	withheld := withheldQuestions(survey.QuestionPools, survey.Questions, token)
+
+	questionsWithPrefill := make([]response.QuestionWithPrefill, 0, len(survey.Questions))
+	for _, q := range survey.Questions {
+		if q.Hidden || withheld[q.ID] {
+			continue
+		}
+
+		// The correct answers of an assessment never reach respondents
+		config := q.Config
+		config.Correct = nil
+
 		questionResp := response.QuestionWithPrefill{
 			QuestionResponse: response.QuestionResponse{
 				ID:          q.ID,
@@ -242,26 +655,129 @@ func (s *shareService) ValidateAndGetSurvey(ctx context.Context, token string) (
 				Description: q.Description,
 				Required:    q.Required,
 				Order:       q.Order,
-				Config:      q.Config,
+				Config:      config,
 				PrefillKey:  q.PrefillKey,
+				LockPrefill: q.LockPrefill,
 			},
+			DescriptionHTML: markdown.ToSafeHTML(q.Description),
 		}
 
 		// Add prefill value if available
-		if q.PrefillKey != "" && tokenData.PrefillData != nil {
-			if prefillValue, exists := tokenData.PrefillData[q.PrefillKey]; exists {
+		if q.PrefillKey != "" && prefillData != nil {
+			if prefillValue, exists := prefillData[q.PrefillKey]; exists {
 				questionResp.PrefillValue = prefillValue
 			}
 		}
 
-		questionsWithPrefill[i] = questionResp
+		questionsWithPrefill = append(questionsWithPrefill, questionResp)
 	}
 
 	return &response.SurveyWithPrefillResponse{
-		ID:          survey.ID,
-		Title:       survey.Title,
-		Description: survey.Description,
-		Questions:   questionsWithPrefill,
-		PrefillData: tokenData.PrefillData,
+		ID:              survey.ID,
+		Title:           survey.Title,
+		Description:     survey.Description,
+		DescriptionHTML: markdown.ToSafeHTML(survey.Description),
+		Questions:       questionsWithPrefill,
+		PrefillData:     prefillData,
+		Anonymous:       survey.Anonymous,
+		Honeypot:        honeypotField(token, survey),
+		ETag:            surveyETag(token, survey),
+		LastModified:    surveyLastModified(survey),
+	}
+}
+
+// surveyETag derives a weak ETag from the token and the update times of the survey and
+// its questions, so editing, adding or removing a question changes it
+func surveyETag(token string, survey *model.Survey) string {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s|%d", token, survey.UpdatedAt.UnixNano())
+	for _, q := range survey.Questions {
+		fmt.Fprintf(hash, "|%d:%d", q.ID, q.UpdatedAt.UnixNano())
+	}
+	return `W/"` + hex.EncodeToString(hash.Sum(nil)[:16]) + `"`
+}
+
+// surveyLastModified returns the latest update time of the survey and its questions
+func surveyLastModified(survey *model.Survey) time.Time {
+	lastModified := survey.UpdatedAt
+	for _, q := range survey.Questions {
+		if q.UpdatedAt.After(lastModified) {
+			lastModified = q.UpdatedAt
+		}
+	}
+	return lastModified
+}
+
+// GetEmbedInfo returns the widget payload for embedding a published survey in partner sites
+// The token is optional and is forwarded to the survey URL when provided
+func (s *shareService) GetEmbedInfo(ctx context.Context, surveyID uint, token string) (*response.EmbedResponse, error) {
+	survey, err := s.surveyRepo.FindByID(ctx, surveyID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.WrapError(err, "failed to find survey")
+	}
+
+	if survey.Status != model.SurveyStatusPublished {
+		return nil, errors.ErrSurveyNotPublished
+	}
+
+	if !survey.EmbedEnabled {
+		return nil, errors.ErrEmbedDisabled
+	}
+
+	// Make sure the token belongs to this survey before exposing it in the widget URL
+	if token != "" {
+		tokenData, err := s.encryptionSvc.DecryptToken(token)
+		if err != nil || tokenData.SurveyID != surveyID {
+			return nil, errors.ErrInvalidToken
+		}
+	}
+
+	surveyURL := fmt.Sprintf("%s/survey/%d", s.baseURL, surveyID)
+	if token != "" {
+		surveyURL = fmt.Sprintf("%s?token=%s", surveyURL, url.QueryEscape(token))
+	}
+
+	domains := []string(survey.EmbedDomains)
+	if domains == nil {
+		domains = []string{}
+	}
+
+	return &response.EmbedResponse{
+		SurveyID:        survey.ID,
+		Title:           survey.Title,
+		Description:     survey.Description,
+		DescriptionHTML: markdown.ToSafeHTML(survey.Description),
+		URL:             surveyURL,
+		AllowedDomains:  domains,
 	}, nil
 }
+
+// invalidTokenTTL is how long a token without a link is remembered, sparing the
+// database repeated lookups of the same bad token
+const invalidTokenTTL = 10 * time.Minute
+
+// findOneLinkByToken finds the link of a token that has already been decrypted
+// Tokens without a link are cached as invalid; cache failures fall back to the database
+func findOneLinkByToken(ctx context.Context, oneLinkRepo repository.OneLinkRepository, linkCache Cache, token string) (*model.OneLink, error) {
+	invalid, err := linkCache.IsTokenInvalid(ctx, token)
+	if err != nil {
+		fmt.Printf("failed to check invalid token cache: %v\n", err)
+	} else if invalid {
+		return nil, errors.ErrInvalidToken
+	}
+
+	oneLink, err := oneLinkRepo.FindByToken(ctx, token)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			if err := linkCache.MarkTokenInvalid(ctx, token, invalidTokenTTL); err != nil {
+				fmt.Printf("failed to cache invalid token: %v\n", err)
+			}
+			return nil, errors.ErrInvalidToken
+		}
+		return nil, errors.WrapError(err, "failed to find one-time link")
+	}
+	return oneLink, nil
+}
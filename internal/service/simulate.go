@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"reflect"
+
+	"survey-system/internal/dto/request"
+	"survey-system/internal/dto/response"
+	"survey-system/internal/model"
+	"survey-system/pkg/errors"
+
+	"gorm.io/gorm"
+)
+
+// Sources of a simulated answer
+const (
+	simulationSourceAnswer  = "answer"
+	simulationSourcePrefill = "prefill"
+)
+
+// SimulateResponse runs hypothetical answers through the rules applied on submission
+// without storing anything, so survey designers can check visibility and validation
+// before publishing. Unlike a submission it reports every problem found, localized
+// into lang, and works on draft surveys as well
+func (s *ResponseService) SimulateResponse(ctx context.Context, userID, surveyID uint, req *request.SimulateResponseRequest, lang string) (*response.SimulationResponse, error) {
+	// Verify survey ownership
+	survey, err := s.surveyRepo.FindByID(ctx, surveyID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.WrapError(err, "failed to find survey")
+	}
+
+	if survey.UserID != userID {
+		return nil, errors.ErrForbidden
+	}
+
+	questions, err := s.questionRepo.FindBySurveyID(ctx, surveyID)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to find questions")
+	}
+
+	result := &response.SimulationResponse{
+		Questions: make([]response.SimulatedQuestion, len(questions)),
+		Errors:    []response.SimulationError{},
+	}
+	fail := func(questionID uint, err *errors.AppError) string {
+		message := err.Localize(lang)
+		result.Errors = append(result.Errors, response.SimulationError{QuestionID: questionID, Message: message})
+		return message
+	}
+
+	known := make(map[uint]bool, len(questions))
+	for _, question := range questions {
+		known[question.ID] = true
+	}
+
+	// Keep the first answer of each question; repeated and unknown ones are reported
+	submitted := make(map[uint]interface{}, len(req.Answers))
+	for _, answer := range req.Answers {
+		if !known[answer.QuestionID] {
+			fail(0, errors.NewLocalizedError("VALIDATION_FAILED", 400, "validation.question_not_found", answer.QuestionID))
+			continue
+		}
+		if _, ok := submitted[answer.QuestionID]; ok {
+			fail(answer.QuestionID, errors.NewLocalizedError("VALIDATION_FAILED", 400, "validation.duplicate_answer", answer.QuestionID))
+			continue
+		}
+		submitted[answer.QuestionID] = answer.Value
+	}
+
+	for i := range questions {
+		question := &questions[i]
+		simulated := response.SimulatedQuestion{
+			QuestionID: question.ID,
+			Title:      question.Title,
+			Type:       question.Type,
+			Visible:    !question.Hidden,
+		}
+
+		value, answered := submitted[question.ID]
+		if answered {
+			simulated.Source = simulationSourceAnswer
+		}
+
+		// Hidden questions are answered from the prefill data only
+		if question.Hidden {
+			if answered {
+				simulated.Error = fail(question.ID, errors.NewLocalizedError("VALIDATION_FAILED", 400, "validation.hidden_question_answered", question.ID))
+				answered = false
+			}
+			if prefilled, ok := req.PrefillData[question.PrefillKey]; ok {
+				value, answered = prefilled, true
+				simulated.Source = simulationSourcePrefill
+			}
+		}
+
+		// Empty table cells are filled with column defaults and the totals row is checked like on submission
+		if answered && question.Type == model.QuestionTypeTable {
+			if filled := applyTableDefaults(question, value); filled != nil {
+				value = filled
+			}
+			if hasTotals(question) {
+				rows, err := checkTableTotals(question, value)
+				if err != nil {
+					appErr, ok := err.(*errors.AppError)
+					if !ok {
+						appErr = errors.ErrValidationFailed
+					}
+					simulated.Error = fail(question.ID, appErr)
+				} else {
+					value = rows
+				}
+			}
+		}
+
+		simulated.Answered = answered
+		if answered {
+			simulated.Value = value
+		}
+
+		if simulated.Error == "" {
+			simulated.Error = s.simulateQuestion(question, value, answered, req.PrefillData, fail)
+		}
+		result.Questions[i] = simulated
+	}
+
+	result.Valid = len(result.Errors) == 0
+	return result, nil
+}
+
+// simulateQuestion checks one answer like a submission would and returns the
+// localized message of the first problem, reporting it through fail
+func (s *ResponseService) simulateQuestion(question *model.Question, value interface{}, answered bool, prefillData map[string]interface{}, fail func(uint, *errors.AppError) string) string {
+	if answered {
+		if err := s.validateAnswer(question, value); err != nil {
+			appErr, ok := err.(*errors.AppError)
+			if !ok {
+				appErr = errors.ErrValidationFailed
+			}
+			return fail(question.ID, appErr)
+		}
+	} else if question.Required {
+		return fail(question.ID, errors.NewLocalizedError("VALIDATION_FAILED", 400, "validation.required_unanswered", question.Title))
+	}
+
+	// Locked prefilled answers must come back unchanged
+	if question.LockPrefill && question.PrefillKey != "" {
+		if prefilled, ok := prefillData[question.PrefillKey]; ok && (!answered || !reflect.DeepEqual(value, prefilled)) {
+			return fail(question.ID, errors.NewLocalizedError("VALIDATION_FAILED", 400, "validation.prefill_locked", question.Title))
+		}
+	}
+	return ""
+}
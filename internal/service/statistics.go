@@ -0,0 +1,482 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"survey-system/internal/cache"
+	"survey-system/internal/dto/response"
+	"survey-system/internal/model"
+	"survey-system/internal/repository"
+	"survey-system/pkg/errors"
+)
+
+// StatisticsOptions controls when statistics are served from the Redis counters
+type StatisticsOptions struct {
+	CacheThreshold    int64         // minimum responses before counters are used; 0 disables them
+	ReconcileInterval time.Duration // lifetime of the counters before they are rebuilt from the database
+}
+
+// Statistics counter fields shared by all questions of a survey
+const (
+	statsTotalField        = "total"
+	statsReconciledAtField = "reconciled_at"
+	statsVersionField      = "version"
+)
+
+// statsVersion identifies the layout of the counters. Counters cached in another layout
+// are rebuilt instead of read; version 2 added the per-variant counters
+const statsVersion = 2
+
+// statsBatchSize is the number of responses loaded at a time when aggregating statistics
+const statsBatchSize = 1000
+
+// statsField names a per-question counter, e.g. "q:12:answered"
+func statsField(questionID uint, name string) string {
+	return fmt.Sprintf("q:%d:%s", questionID, name)
+}
+
+// variantStatsPrefix prefixes the counters of the responses of an A/B variant,
+// e.g. "v:B:q:12:answered"
+func variantStatsPrefix(variant string) string {
+	return "v:" + variant + ":"
+}
+
+// splitExtremumPrefix splits the min or max prefix off a counter field
+func splitExtremumPrefix(field string) (string, string) {
+	if strings.HasPrefix(field, cache.StatsMinPrefix) || strings.HasPrefix(field, cache.StatsMaxPrefix) {
+		return field[:len(cache.StatsMinPrefix)], field[len(cache.StatsMinPrefix):]
+	}
+	return "", field
+}
+
+// responseStatsDelta returns the counter increments contributed by a response, also
+// counted under its variant's prefix when it was submitted through a variant link
+func responseStatsDelta(questions []model.Question, resp *model.Response) map[string]float64 {
+	delta := statsDelta(questions, resp.Data)
+	if resp.Variant == "" {
+		return delta
+	}
+
+	prefix := variantStatsPrefix(resp.Variant)
+	variantDelta := make(map[string]float64, len(delta))
+	for field, value := range delta {
+		extremum, name := splitExtremumPrefix(field)
+		variantDelta[extremum+prefix+name] = value
+	}
+	for field, value := range variantDelta {
+		delta[field] = value
+	}
+	return delta
+}
+
+// variantCounters extracts the counters of an A/B variant, without its prefix
+func variantCounters(counters map[string]float64, variant string) map[string]float64 {
+	prefix := variantStatsPrefix(variant)
+	result := map[string]float64{statsReconciledAtField: counters[statsReconciledAtField]}
+	for field, value := range counters {
+		extremum, name := splitExtremumPrefix(field)
+		if name, ok := strings.CutPrefix(name, prefix); ok {
+			result[extremum+name] = value
+		}
+	}
+	return result
+}
+
+// statsDelta returns the counter increments contributed by a single response
+func statsDelta(questions []model.Question, data model.ResponseData) map[string]float64 {
+	questionMap := make(map[uint]*model.Question, len(questions))
+	for i := range questions {
+		questionMap[questions[i].ID] = &questions[i]
+	}
+
+	delta := map[string]float64{statsTotalField: 1}
+	for _, id := range data.Withheld {
+		delta[statsField(id, "withheld")]++
+	}
+	for _, answer := range data.Answers {
+		question, ok := questionMap[answer.QuestionID]
+		if !ok {
+			continue
+		}
+		delta[statsField(question.ID, "answered")]++
+		if answer.DurationMs > 0 {
+			delta[statsField(question.ID, "duration_sum")] += float64(answer.DurationMs)
+			delta[statsField(question.ID, "timed")]++
+		}
+		if size, ok := answerSize(question.Type, answer.Value); ok {
+			delta[statsField(question.ID, "size_sum")] += size
+			delta[statsField(question.ID, "sized")]++
+		}
+
+		switch question.Type {
+		case model.QuestionTypeSingle, model.QuestionTypeMultiple:
+			var ids []string
+			switch v := answer.Value.(type) {
+			case string:
+				ids = []string{v}
+			case []interface{}:
+				for _, item := range v {
+					if str, ok := item.(string); ok {
+						ids = append(ids, str)
+					}
+				}
+			}
+
+			sum, scored := 0.0, false
+			for _, id := range ids {
+				delta[statsField(question.ID, "opt:"+id)]++
+				if option, ok := question.Config.FindOption(id); ok && option.Score != nil {
+					sum += *option.Score
+					scored = true
+				}
+			}
+			if scored {
+				delta[statsField(question.ID, "score_sum")] += sum
+				delta[statsField(question.ID, "scored")]++
+			}
+		case model.QuestionTypeNPS:
+			if score, ok := model.NPSScore(answer.Value); ok {
+				delta[statsField(question.ID, "nps:"+model.NPSCategory(score))]++
+			}
+		case model.QuestionTypeSlider:
+			if number, ok := answer.Value.(float64); ok {
+				addNumericDelta(delta, question.ID, "", number)
+			}
+		case model.QuestionTypeTable:
+			if totals, ok := columnTotals(question.Config.Columns, answer.Value); ok {
+				for colIdx, column := range question.Config.Columns {
+					if column.Total {
+						addNumericDelta(delta, question.ID, totalStatsPrefix(column), totals[colIdx])
+					}
+				}
+			}
+		}
+	}
+	return delta
+}
+
+// addNumericDelta adds a number to the running sums kept under the given field prefix
+func addNumericDelta(delta map[string]float64, questionID uint, prefix string, number float64) {
+	delta[statsField(questionID, prefix+"count")]++
+	delta[statsField(questionID, prefix+"sum")] += number
+	delta[statsField(questionID, prefix+"sumsq")] += number * number
+	delta[cache.StatsMinPrefix+statsField(questionID, prefix+"value")] = number
+	delta[cache.StatsMaxPrefix+statsField(questionID, prefix+"value")] = number
+}
+
+// totalStatsPrefix is the field prefix of the running sums of a table column's totals
+func totalStatsPrefix(column model.TableColumn) string {
+	return "total:" + column.ID + ":"
+}
+
+// mergeStatsDelta applies delta to counters the same way the cache does
+func mergeStatsDelta(counters, delta map[string]float64) {
+	for field, value := range delta {
+		current, exists := counters[field]
+		switch {
+		case strings.HasPrefix(field, cache.StatsMinPrefix):
+			if !exists || value < current {
+				counters[field] = value
+			}
+		case strings.HasPrefix(field, cache.StatsMaxPrefix):
+			if !exists || value > current {
+				counters[field] = value
+			}
+		default:
+			counters[field] = current + value
+		}
+	}
+}
+
+// eachResponse calls fn with every response of a survey matching filter, loading them
+// in batches so that large surveys are never held in memory at once
+func (s *ResponseService) eachResponse(ctx context.Context, surveyID uint, filter repository.ResponseFilter, fn func(resp *model.Response)) error {
+	var cursor *repository.ResponseCursor
+	for {
+		responses, err := s.responseRepo.FindBySurveyIDAfter(ctx, surveyID, filter, cursor, statsBatchSize)
+		if err != nil {
+			return errors.WrapError(err, "failed to load statistics")
+		}
+
+		for i := range responses {
+			fn(&responses[i])
+		}
+
+		if len(responses) < statsBatchSize {
+			return nil
+		}
+		last := responses[len(responses)-1]
+		cursor = &repository.ResponseCursor{SubmittedAt: last.SubmittedAt, ID: last.ID}
+	}
+}
+
+// aggregateStatsCounters builds the counters of the responses of a survey matching
+// filter. Of the responses only the numbers the medians are computed from are kept.
+// The slider and table total medians cannot be maintained incrementally, so they are
+// stored as a snapshot that is refreshed on every rebuild
+func (s *ResponseService) aggregateStatsCounters(ctx context.Context, surveyID uint, questions []model.Question, filter repository.ResponseFilter) (map[string]float64, error) {
+	counters := map[string]float64{
+		statsTotalField:        0,
+		statsReconciledAtField: float64(time.Now().Unix()),
+		statsVersionField:      statsVersion,
+	}
+	questionMap := make(map[uint]*model.Question, len(questions))
+	for i := range questions {
+		questionMap[questions[i].ID] = &questions[i]
+	}
+
+	medianValues := make(map[string][]float64)
+	err := s.eachResponse(ctx, surveyID, filter, func(resp *model.Response) {
+		mergeStatsDelta(counters, responseStatsDelta(questions, resp))
+		collectMedianValues(medianValues, questionMap, resp)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for field, values := range medianValues {
+		if numeric := calculateNumericStatistics(values, ""); numeric != nil {
+			counters[field] = numeric.Median
+		}
+	}
+	return counters, nil
+}
+
+// collectMedianValues adds the slider values and table column totals of a response to
+// the numbers each median is computed from, keyed by the median's counter field
+func collectMedianValues(values map[string][]float64, questionMap map[uint]*model.Question, resp *model.Response) {
+	add := func(field string, number float64) {
+		values[field] = append(values[field], number)
+		if resp.Variant != "" {
+			variantField := variantStatsPrefix(resp.Variant) + field
+			values[variantField] = append(values[variantField], number)
+		}
+	}
+
+	for _, answer := range resp.Data.Answers {
+		question, ok := questionMap[answer.QuestionID]
+		if !ok {
+			continue
+		}
+		switch question.Type {
+		case model.QuestionTypeSlider:
+			if number, ok := answer.Value.(float64); ok {
+				add(statsField(question.ID, "median"), number)
+			}
+		case model.QuestionTypeTable:
+			if totals, ok := columnTotals(question.Config.Columns, answer.Value); ok {
+				for colIdx, column := range question.Config.Columns {
+					if column.Total {
+						add(statsField(question.ID, totalStatsPrefix(column)+"median"), totals[colIdx])
+					}
+				}
+			}
+		}
+	}
+}
+
+// statisticsFromCounters builds the statistics response from cached counters
+func statisticsFromCounters(surveyID uint, questions []model.Question, counters map[string]float64) *response.StatisticsResponse {
+	count := int64(counters[statsTotalField])
+	completionRate := 100.0
+	if count == 0 {
+		completionRate = 0.0
+	}
+
+	stats := make([]response.QuestionStatistics, len(questions))
+	for i, question := range questions {
+		stats[i] = response.QuestionStatistics{
+			QuestionID: question.ID,
+			Title:      question.Title,
+			Type:       question.Type,
+			Answered:   int(counters[statsField(question.ID, "answered")]),
+		}
+		setAnswerRate(&stats[i], question, count, int64(counters[statsField(question.ID, "withheld")]))
+		// Counters cached before sizes were tracked lack the fields until the next rebuild
+		if sum, ok := counters[statsField(question.ID, "size_sum")]; ok {
+			setAverageSize(&stats[i], question, sum, int(counters[statsField(question.ID, "sized")]))
+		}
+		setAverageDuration(&stats[i], counters[statsField(question.ID, "duration_sum")], int(counters[statsField(question.ID, "timed")]))
+
+		switch question.Type {
+		case model.QuestionTypeNPS:
+			stats[i].NPS = calculateNPS(map[string]int{
+				model.NPSPromoter:  int(counters[statsField(question.ID, "nps:"+model.NPSPromoter)]),
+				model.NPSPassive:   int(counters[statsField(question.ID, "nps:"+model.NPSPassive)]),
+				model.NPSDetractor: int(counters[statsField(question.ID, "nps:"+model.NPSDetractor)]),
+			})
+		case model.QuestionTypeSlider:
+			stats[i].Numeric = numericStatisticsFromCounters(counters, question.ID, "", question.Config.Unit)
+		case model.QuestionTypeTable:
+			for _, column := range question.Config.Columns {
+				if !column.Total {
+					continue
+				}
+				prefix := totalStatsPrefix(column)
+				stats[i].Totals = append(stats[i].Totals, response.ColumnTotalStatistics{
+					ColumnID:    column.ID,
+					Label:       column.Label,
+					Sum:         math.Round(counters[statsField(question.ID, prefix+"sum")]*100) / 100,
+					PerResponse: numericStatisticsFromCounters(counters, question.ID, prefix, ""),
+				})
+			}
+		case model.QuestionTypeSingle, model.QuestionTypeMultiple:
+			stats[i].Options = make([]response.OptionStatistics, len(question.Config.Options))
+			for j, option := range question.Config.Options {
+				stats[i].Options[j] = response.OptionStatistics{
+					ID:    option.ID,
+					Label: option.Label,
+					Score: option.Score,
+					Count: int(counters[statsField(question.ID, "opt:"+option.ID)]),
+				}
+			}
+
+			if scored := counters[statsField(question.ID, "scored")]; scored > 0 {
+				avg := math.Round(counters[statsField(question.ID, "score_sum")]/scored*100) / 100
+				stats[i].AverageScore = &avg
+			}
+		}
+	}
+
+	cachedAt := time.Unix(int64(counters[statsReconciledAtField]), 0)
+	return &response.StatisticsResponse{
+		SurveyID:       surveyID,
+		TotalResponses: count,
+		CompletionRate: completionRate,
+		Questions:      stats,
+		CachedAt:       &cachedAt,
+	}
+}
+
+// answerSize returns the measure averaged for a question type: the character
+// count of a text answer or the row count of a table answer
+func answerSize(questionType string, value interface{}) (float64, bool) {
+	switch questionType {
+	case model.QuestionTypeText:
+		if text, ok := value.(string); ok {
+			return float64(utf8.RuneCountInString(text)), true
+		}
+	case model.QuestionTypeTable:
+		if rows, ok := value.([]interface{}); ok {
+			return float64(len(rows)), true
+		}
+	}
+	return 0, false
+}
+
+// setAverageDuration fills the average reported time spent on a question
+func setAverageDuration(stats *response.QuestionStatistics, sum float64, n int) {
+	if n == 0 {
+		return
+	}
+	avg := math.Round(sum / float64(n))
+	stats.AverageDurationMs = &avg
+}
+
+// setAnswerRate fills the answer rate of a question, and for optional
+// questions how many responses skipped it. Both count only the responses the
+// question was shown in, leaving out those of other variants and those its pool
+// withheld it from
+func setAnswerRate(stats *response.QuestionStatistics, question model.Question, total, withheld int64) {
+	exposed := total - withheld
+	if question.Pool != "" || question.Variant != "" || withheld > 0 {
+		n := int(exposed)
+		stats.Exposed = &n
+	}
+	if exposed > 0 {
+		stats.AnswerRate = math.Round(float64(stats.Answered)/float64(exposed)*10000) / 100
+	}
+	if !question.Required {
+		skipped := max(int(exposed)-stats.Answered, 0)
+		stats.Skipped = &skipped
+	}
+}
+
+// setAverageSize fills the average text length or table row count of a question
+func setAverageSize(stats *response.QuestionStatistics, question model.Question, sum float64, n int) {
+	if n == 0 {
+		return
+	}
+	avg := math.Round(sum/float64(n)*100) / 100
+	switch question.Type {
+	case model.QuestionTypeText:
+		stats.AverageLength = &avg
+	case model.QuestionTypeTable:
+		stats.AverageRows = &avg
+	}
+}
+
+// numericStatisticsFromCounters derives slider or column total statistics from the
+// running sums kept under the given field prefix
+func numericStatisticsFromCounters(counters map[string]float64, questionID uint, prefix, unit string) *response.NumericStatistics {
+	n := counters[statsField(questionID, prefix+"count")]
+	if n == 0 {
+		return nil
+	}
+
+	mean := counters[statsField(questionID, prefix+"sum")] / n
+	variance := math.Max(counters[statsField(questionID, prefix+"sumsq")]/n-mean*mean, 0)
+
+	round := func(v float64) float64 {
+		return math.Round(v*100) / 100
+	}
+	return &response.NumericStatistics{
+		Count:  int(n),
+		Min:    counters[cache.StatsMinPrefix+statsField(questionID, prefix+"value")],
+		Max:    counters[cache.StatsMaxPrefix+statsField(questionID, prefix+"value")],
+		Mean:   round(mean),
+		Median: round(counters[statsField(questionID, prefix+"median")]),
+		StdDev: round(math.Sqrt(variance)),
+		Unit:   unit,
+	}
+}
+
+// totalValues returns the per-response totals of each total column of a table question,
+// keyed by column ID
+func totalValues(question model.Question, responses []model.Response) map[string][]float64 {
+	values := make(map[string][]float64)
+	for _, resp := range responses {
+		for _, answer := range resp.Data.Answers {
+			if answer.QuestionID != question.ID {
+				continue
+			}
+			if totals, ok := columnTotals(question.Config.Columns, answer.Value); ok {
+				for colIdx, column := range question.Config.Columns {
+					if column.Total {
+						values[column.ID] = append(values[column.ID], totals[colIdx])
+					}
+				}
+			}
+			break
+		}
+	}
+	return values
+}
+
+// columnTotalStatistics summarizes the per-response totals of each total column of a table question
+func columnTotalStatistics(question model.Question, responses []model.Response) []response.ColumnTotalStatistics {
+	values := totalValues(question, responses)
+
+	var result []response.ColumnTotalStatistics
+	for _, column := range question.Config.Columns {
+		if !column.Total {
+			continue
+		}
+		sum := 0.0
+		for _, value := range values[column.ID] {
+			sum += value
+		}
+		result = append(result, response.ColumnTotalStatistics{
+			ColumnID:    column.ID,
+			Label:       column.Label,
+			Sum:         math.Round(sum*100) / 100,
+			PerResponse: calculateNumericStatistics(values[column.ID], ""),
+		})
+	}
+	return result
+}
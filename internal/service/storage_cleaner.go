@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"survey-system/internal/repository"
+	"survey-system/pkg/storage"
+)
+
+// storageCleanerBatchSize caps how many expired export jobs are loaded at once
+const storageCleanerBatchSize = 200
+
+// StorageCleaner periodically deletes expired export jobs with their files and
+// removes stored files older than the retention period
+type StorageCleaner struct {
+	jobRepo   repository.ExportJobRepository
+	store     storage.Storage
+	cache     Cache
+	interval  time.Duration
+	retention time.Duration
+}
+
+// NewStorageCleaner creates a new StorageCleaner
+func NewStorageCleaner(
+	jobRepo repository.ExportJobRepository,
+	store storage.Storage,
+	cache Cache,
+	interval time.Duration,
+	retention time.Duration,
+) *StorageCleaner {
+	return &StorageCleaner{
+		jobRepo:   jobRepo,
+		store:     store,
+		cache:     cache,
+		interval:  interval,
+		retention: retention,
+	}
+}
+
+// Run cleans up storage every interval until ctx is cancelled
+// A non-positive interval disables the cleaner
+func (c *StorageCleaner) Run(ctx context.Context) {
+	if c.interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.Clean(ctx); err != nil {
+			log.Printf("storage cleaner: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Clean deletes expired export jobs and their files, then sweeps export files older
+// than the retention period that no job refers to anymore. Response archives are kept.
+func (c *StorageCleaner) Clean(ctx context.Context) error {
+	// Only one instance should clean at a time
+	lockKey := "storage:cleaner"
+	acquired, err := c.cache.AcquireLock(ctx, lockKey, c.interval)
+	if err != nil {
+		return fmt.Errorf("failed to acquire lock: %w", err)
+	}
+	if !acquired {
+		return nil
+	}
+	defer c.cache.ReleaseLock(ctx, lockKey)
+
+	now := time.Now()
+	for {
+		jobs, err := c.jobRepo.FindExpired(ctx, now, storageCleanerBatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to find expired export jobs: %w", err)
+		}
+		if len(jobs) == 0 {
+			break
+		}
+
+		ids := make([]uint, 0, len(jobs))
+		for _, job := range jobs {
+			if job.StorageKey != "" {
+				if err := c.store.Delete(ctx, job.StorageKey); err != nil {
+					// Keep the job so the file is retried on the next run
+					log.Printf("storage cleaner: failed to delete file of export job %d: %v", job.ID, err)
+					continue
+				}
+			}
+			ids = append(ids, job.ID)
+		}
+		if len(ids) == 0 {
+			break
+		}
+		if err := c.jobRepo.DeleteByIDs(ctx, ids); err != nil {
+			return fmt.Errorf("failed to delete expired export jobs: %w", err)
+		}
+		if len(jobs) < storageCleanerBatchSize {
+			break
+		}
+	}
+
+	if c.retention > 0 {
+		deleted, err := c.store.DeleteOlderThan(ctx, exportStoragePrefix, now.Add(-c.retention))
+		if err != nil {
+			return fmt.Errorf("failed to delete old files: %w", err)
+		}
+		if deleted > 0 {
+			log.Printf("storage cleaner: deleted %d files older than %s", deleted, c.retention)
+		}
+	}
+
+	return nil
+}
@@ -3,6 +3,9 @@ package service
 import (
 	"context"
 	"fmt"
+	"net/netip"
+	"net/url"
+	"strings"
 	"time"
 
 	"survey-system/internal/cache"
@@ -11,6 +14,8 @@ import (
 	"survey-system/internal/model"
 	"survey-system/internal/repository"
 	"survey-system/pkg/errors"
+	"survey-system/pkg/netguard"
+	"survey-system/pkg/utils"
 
 	"gorm.io/gorm"
 )
@@ -20,35 +25,91 @@ type SurveyService interface {
 	CreateSurvey(ctx context.Context, userID uint, req *request.CreateSurveyRequest) (*response.SurveyResponse, error)
 	UpdateSurvey(ctx context.Context, userID, surveyID uint, req *request.UpdateSurveyRequest) (*response.SurveyResponse, error)
 	DeleteSurvey(ctx context.Context, userID, surveyID uint) error
-	GetSurvey(ctx context.Context, surveyID uint) (*response.SurveyDetailResponse, error)
-	ListSurveys(ctx context.Context, userID uint, page, pageSize int) (*response.PaginatedSurveyResponse, error)
+	GetSurvey(ctx context.Context, userID, surveyID uint) (*response.SurveyDetailResponse, error)
+	ListSurveys(ctx context.Context, userID uint, status string, page, pageSize int) (*response.PaginatedSurveyResponse, error)
 	PublishSurvey(ctx context.Context, userID, surveyID uint) error
+	ArchiveSurvey(ctx context.Context, userID, surveyID uint) error
+	UnarchiveSurvey(ctx context.Context, userID, surveyID uint) error
 }
 
+// SurveyStatusAll lists surveys of every status, including archived ones
+const SurveyStatusAll = "all"
+
 // surveyService implements SurveyService interface
 type surveyService struct {
 	surveyRepo repository.SurveyRepository
+	eventRepo  repository.EventRepository
 	cache      cache.Cache
+	authz      *utils.AuthorizationUtil
 }
 
 // NewSurveyService creates a new survey service instance
-func NewSurveyService(surveyRepo repository.SurveyRepository, cache cache.Cache) SurveyService {
+func NewSurveyService(surveyRepo repository.SurveyRepository, eventRepo repository.EventRepository, cache cache.Cache, authz *utils.AuthorizationUtil) SurveyService {
 	return &surveyService{
 		surveyRepo: surveyRepo,
+		eventRepo:  eventRepo,
 		cache:      cache,
+		authz:      authz,
 	}
 }
 
 // CreateSurvey creates a new survey with draft status
 func (s *surveyService) CreateSurvey(ctx context.Context, userID uint, req *request.CreateSurveyRequest) (*response.SurveyResponse, error) {
+	if err := validateEmbedDomains(req.EmbedDomains); err != nil {
+		return nil, err
+	}
+	if err := validateAllowedIPs(req.AllowedIPs); err != nil {
+		return nil, err
+	}
+	if err := validateExpiryNotification(ctx, req.ExpiryNotifyHours, req.ExpiryWebhookURL, req.ExpiryNotifyEmail); err != nil {
+		return nil, err
+	}
+	if err := validateLinkExpiry(req.LinkDefaultExpiryHours, req.LinkMaxExpiryHours); err != nil {
+		return nil, err
+	}
+	if err := validateWebhookURL(ctx, "reminder_webhook_url", req.ReminderWebhookURL); err != nil {
+		return nil, err
+	}
+	quotas, err := toQuotaRules(req.Quotas)
+	if err != nil {
+		return nil, err
+	}
+	pools, err := toQuestionPools(req.QuestionPools)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateVariants(req.Variants); err != nil {
+		return nil, err
+	}
+
 	survey := &model.Survey{
-		UserID:      userID,
-		Title:       req.Title,
-		Description: req.Description,
-		Status:      model.SurveyStatusDraft,
+		UserID:                 userID,
+		Title:                  req.Title,
+		Description:            req.Description,
+		Status:                 model.SurveyStatusDraft,
+		EmbedEnabled:           req.EmbedEnabled,
+		EmbedDomains:           model.StringList(req.EmbedDomains),
+		AllowedIPs:             model.StringList(req.AllowedIPs),
+		Anonymous:              req.Anonymous,
+		ExpiryNotifyHours:      req.ExpiryNotifyHours,
+		ExpiryWebhookURL:       req.ExpiryWebhookURL,
+		ExpiryNotifyEmail:      req.ExpiryNotifyEmail,
+		LinkDefaultExpiryHours: req.LinkDefaultExpiryHours,
+		LinkMaxExpiryHours:     req.LinkMaxExpiryHours,
+		EditWindowMinutes:      req.EditWindowMinutes,
+		TimeLimitMinutes:       req.TimeLimitMinutes,
+		Assessment:             req.Assessment,
+		ShowScore:              req.ShowScore,
+		PassPercent:            req.PassPercent,
+		NoResponseReminderDays: req.NoResponseReminderDays,
+		ReminderWebhookURL:     req.ReminderWebhookURL,
+		HoneypotAction:         req.HoneypotAction,
+		Quotas:                 quotas,
+		QuestionPools:          pools,
+		Variants:               model.StringList(req.Variants),
 	}
 
-	if err := s.surveyRepo.Create(survey); err != nil {
+	if err := s.surveyRepo.Create(ctx, survey); err != nil {
 		return nil, errors.WrapError(err, "failed to create survey")
 	}
 
@@ -58,7 +119,7 @@ func (s *surveyService) CreateSurvey(ctx context.Context, userID uint, req *requ
 // UpdateSurvey updates an existing survey after verifying ownership
 func (s *surveyService) UpdateSurvey(ctx context.Context, userID, surveyID uint, req *request.UpdateSurveyRequest) (*response.SurveyResponse, error) {
 	// Find the survey
-	survey, err := s.surveyRepo.FindByID(surveyID)
+	survey, err := s.surveyRepo.FindByID(ctx, surveyID)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, errors.ErrNotFound
@@ -71,11 +132,58 @@ func (s *surveyService) UpdateSurvey(ctx context.Context, userID, surveyID uint,
 		return nil, errors.ErrForbidden
 	}
 
+	if err := validateEmbedDomains(req.EmbedDomains); err != nil {
+		return nil, err
+	}
+	if err := validateAllowedIPs(req.AllowedIPs); err != nil {
+		return nil, err
+	}
+	if err := validateExpiryNotification(ctx, req.ExpiryNotifyHours, req.ExpiryWebhookURL, req.ExpiryNotifyEmail); err != nil {
+		return nil, err
+	}
+	if err := validateLinkExpiry(req.LinkDefaultExpiryHours, req.LinkMaxExpiryHours); err != nil {
+		return nil, err
+	}
+	if err := validateWebhookURL(ctx, "reminder_webhook_url", req.ReminderWebhookURL); err != nil {
+		return nil, err
+	}
+	quotas, err := toQuotaRules(req.Quotas)
+	if err != nil {
+		return nil, err
+	}
+	pools, err := toQuestionPools(req.QuestionPools)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateVariants(req.Variants); err != nil {
+		return nil, err
+	}
+
 	// Update fields
 	survey.Title = req.Title
 	survey.Description = req.Description
-
-	if err := s.surveyRepo.Update(survey); err != nil {
+	survey.EmbedEnabled = req.EmbedEnabled
+	survey.EmbedDomains = model.StringList(req.EmbedDomains)
+	survey.AllowedIPs = model.StringList(req.AllowedIPs)
+	survey.Anonymous = req.Anonymous
+	survey.ExpiryNotifyHours = req.ExpiryNotifyHours
+	survey.ExpiryWebhookURL = req.ExpiryWebhookURL
+	survey.ExpiryNotifyEmail = req.ExpiryNotifyEmail
+	survey.LinkDefaultExpiryHours = req.LinkDefaultExpiryHours
+	survey.LinkMaxExpiryHours = req.LinkMaxExpiryHours
+	survey.EditWindowMinutes = req.EditWindowMinutes
+	survey.TimeLimitMinutes = req.TimeLimitMinutes
+	survey.Assessment = req.Assessment
+	survey.ShowScore = req.ShowScore
+	survey.PassPercent = req.PassPercent
+	survey.NoResponseReminderDays = req.NoResponseReminderDays
+	survey.ReminderWebhookURL = req.ReminderWebhookURL
+	survey.HoneypotAction = req.HoneypotAction
+	survey.Quotas = quotas
+	survey.QuestionPools = pools
+	survey.Variants = model.StringList(req.Variants)
+
+	if err := s.surveyRepo.Update(ctx, survey); err != nil {
 		return nil, errors.WrapError(err, "failed to update survey")
 	}
 
@@ -92,7 +200,7 @@ func (s *surveyService) UpdateSurvey(ctx context.Context, userID, surveyID uint,
 // If cascade delete fails due to foreign key constraints, manually deletes associated data
 func (s *surveyService) DeleteSurvey(ctx context.Context, userID, surveyID uint) error {
 	// Find the survey
-	survey, err := s.surveyRepo.FindByID(surveyID)
+	survey, err := s.surveyRepo.FindByID(ctx, surveyID)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return errors.ErrNotFound
@@ -106,12 +214,12 @@ func (s *surveyService) DeleteSurvey(ctx context.Context, userID, surveyID uint)
 	}
 
 	// Delete the survey (cascade delete handled by database)
-	if err := s.surveyRepo.Delete(surveyID); err != nil {
+	if err := s.surveyRepo.Delete(ctx, surveyID); err != nil {
 		return errors.WrapError(err, "failed to delete survey")
 	}
 
-	// Invalidate cache
-	if err := s.cache.DeleteSurvey(ctx, surveyID); err != nil {
+	// Invalidate the survey and everything cached for it
+	if err := s.cache.InvalidateSurvey(ctx, surveyID); err != nil {
 		// Log error but don't fail the request
 		fmt.Printf("failed to invalidate survey cache: %v\n", err)
 	}
@@ -120,7 +228,12 @@ func (s *surveyService) DeleteSurvey(ctx context.Context, userID, surveyID uint)
 }
 
 // GetSurvey retrieves survey details with questions, using cache when available
-func (s *surveyService) GetSurvey(ctx context.Context, surveyID uint) (*response.SurveyDetailResponse, error) {
+func (s *surveyService) GetSurvey(ctx context.Context, userID, surveyID uint) (*response.SurveyDetailResponse, error) {
+	// Verify the user owns the survey or shares it through its team
+	if _, err := findTeamSurvey(ctx, s.authz, userID, surveyID); err != nil {
+		return nil, err
+	}
+
 	// Try to get from cache first
 	cachedSurvey, err := s.cache.GetSurvey(ctx, surveyID)
 	if err != nil {
@@ -133,7 +246,7 @@ func (s *surveyService) GetSurvey(ctx context.Context, surveyID uint) (*response
 	}
 
 	// Cache miss, get from database
-	survey, err := s.surveyRepo.FindByIDWithQuestions(surveyID)
+	survey, err := s.surveyRepo.FindByIDWithQuestions(ctx, surveyID)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, errors.ErrNotFound
@@ -151,7 +264,8 @@ func (s *surveyService) GetSurvey(ctx context.Context, surveyID uint) (*response
 }
 
 // ListSurveys retrieves a paginated list of surveys for a user
-func (s *surveyService) ListSurveys(ctx context.Context, userID uint, page, pageSize int) (*response.PaginatedSurveyResponse, error) {
+// An empty status lists every survey except archived ones
+func (s *surveyService) ListSurveys(ctx context.Context, userID uint, status string, page, pageSize int) (*response.PaginatedSurveyResponse, error) {
 	// Validate pagination parameters
 	if page < 1 {
 		page = 1
@@ -163,7 +277,18 @@ func (s *surveyService) ListSurveys(ctx context.Context, userID uint, page, page
 		pageSize = 100
 	}
 
-	surveys, total, err := s.surveyRepo.FindByUserID(userID, page, pageSize)
+	var filter repository.SurveyFilter
+	switch status {
+	case "":
+		filter.ExcludeStatus = model.SurveyStatusArchived
+	case SurveyStatusAll:
+	case model.SurveyStatusDraft, model.SurveyStatusPublished, model.SurveyStatusArchived:
+		filter.Status = status
+	default:
+		return nil, errors.NewValidationError("status", "must be one of draft, published, archived, all")
+	}
+
+	surveys, total, err := s.surveyRepo.FindByUserID(ctx, userID, filter, page, pageSize)
 	if err != nil {
 		return nil, errors.WrapError(err, "failed to list surveys")
 	}
@@ -194,7 +319,7 @@ func (s *surveyService) ListSurveys(ctx context.Context, userID uint, page, page
 // PublishSurvey publishes a survey after verifying ownership
 func (s *surveyService) PublishSurvey(ctx context.Context, userID, surveyID uint) error {
 	// Find the survey
-	survey, err := s.surveyRepo.FindByID(surveyID)
+	survey, err := s.surveyRepo.FindByID(ctx, surveyID)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return errors.ErrNotFound
@@ -207,11 +332,21 @@ func (s *surveyService) PublishSurvey(ctx context.Context, userID, surveyID uint
 		return errors.ErrForbidden
 	}
 
-	// Update status to published
-	if err := s.surveyRepo.UpdateStatus(surveyID, model.SurveyStatusPublished); err != nil {
-		return errors.WrapError(err, "failed to publish survey")
+	// Archived surveys must be restored before they can collect responses again
+	if survey.Status == model.SurveyStatusArchived {
+		return errors.ErrSurveyArchived
 	}
 
+	// Publishing starts a new publication for the no-response reminder; republishing a
+	// published survey keeps the current one
+	if survey.Status != model.SurveyStatusPublished {
+		if err := s.surveyRepo.MarkPublished(ctx, surveyID, time.Now()); err != nil {
+			return errors.WrapError(err, "failed to publish survey")
+		}
+	}
+
+	recordEvent(ctx, s.eventRepo, &model.SurveyEvent{SurveyID: surveyID, UserID: userID, Type: model.EventSurveyPublished})
+
 	// Invalidate cache
 	if err := s.cache.DeleteSurvey(ctx, surveyID); err != nil {
 		// Log error but don't fail the request
@@ -220,3 +355,266 @@ func (s *surveyService) PublishSurvey(ctx context.Context, userID, surveyID uint
 
 	return nil
 }
+
+// ArchiveSurvey hides a survey from the default list and stops it from collecting responses
+// Responses, questions and links are kept. Archiving an archived survey is a no-op
+func (s *surveyService) ArchiveSurvey(ctx context.Context, userID, surveyID uint) error {
+	survey, err := s.surveyRepo.FindByID(ctx, surveyID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return errors.ErrNotFound
+		}
+		return errors.WrapError(err, "failed to find survey")
+	}
+
+	if survey.UserID != userID {
+		return errors.ErrForbidden
+	}
+
+	if survey.Status == model.SurveyStatusArchived {
+		return nil
+	}
+
+	if err := s.setStatus(ctx, surveyID, model.SurveyStatusArchived); err != nil {
+		return err
+	}
+
+	recordEvent(ctx, s.eventRepo, &model.SurveyEvent{SurveyID: surveyID, UserID: userID, Type: model.EventSurveyArchived})
+	return nil
+}
+
+// UnarchiveSurvey restores an archived survey as a draft
+// The survey has to be published again so links do not reopen unnoticed
+func (s *surveyService) UnarchiveSurvey(ctx context.Context, userID, surveyID uint) error {
+	survey, err := s.surveyRepo.FindByID(ctx, surveyID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return errors.ErrNotFound
+		}
+		return errors.WrapError(err, "failed to find survey")
+	}
+
+	if survey.UserID != userID {
+		return errors.ErrForbidden
+	}
+
+	if survey.Status != model.SurveyStatusArchived {
+		return errors.ErrSurveyNotArchived
+	}
+
+	if err := s.setStatus(ctx, surveyID, model.SurveyStatusDraft); err != nil {
+		return err
+	}
+
+	recordEvent(ctx, s.eventRepo, &model.SurveyEvent{SurveyID: surveyID, UserID: userID, Type: model.EventSurveyUnarchived})
+	return nil
+}
+
+// setStatus updates a survey's status and invalidates its cache entry
+func (s *surveyService) setStatus(ctx context.Context, surveyID uint, status string) error {
+	if err := s.surveyRepo.UpdateStatus(ctx, surveyID, status); err != nil {
+		return errors.WrapError(err, "failed to update survey status")
+	}
+
+	if err := s.cache.DeleteSurvey(ctx, surveyID); err != nil {
+		// Log error but don't fail the request
+		fmt.Printf("failed to invalidate survey cache: %v\n", err)
+	}
+
+	return nil
+}
+
+// validateEmbedDomains validates the embedding domain allowlist of a survey
+// Entries may be a host (partner.com), a wildcard host (*.partner.com) or an origin (https://partner.com)
+func validateEmbedDomains(domains []string) error {
+	for i, domain := range domains {
+		field := fmt.Sprintf("embed_domains[%d]", i)
+		if strings.ContainsAny(domain, " \t;,'\"") {
+			return errors.NewValidationError(field, "domain must not contain whitespace, quotes, commas or semicolons")
+		}
+
+		host := domain
+		if scheme, rest, found := strings.Cut(domain, "://"); found {
+			if scheme != "http" && scheme != "https" {
+				return errors.NewValidationError(field, "scheme must be http or https")
+			}
+			host = rest
+		}
+
+		if host == "" || strings.Contains(host, "/") {
+			return errors.NewValidationError(field, "domain must be a host without path")
+		}
+		if strings.Contains(strings.TrimPrefix(host, "*."), "*") {
+			return errors.NewValidationError(field, "wildcard is only allowed as the leftmost label, e.g. *.example.com")
+		}
+	}
+	return nil
+}
+
+// validateAllowedIPs validates the respondent network allowlist of a survey
+// Entries may be a CIDR (10.0.0.0/8, 2001:db8::/32) or a single IP address
+func validateAllowedIPs(entries []string) error {
+	for i, entry := range entries {
+		if _, err := parseIPPrefix(entry); err != nil {
+			return errors.NewValidationError(fmt.Sprintf("allowed_ips[%d]", i), "must be an IP address or CIDR, e.g. 10.0.0.0/8")
+		}
+	}
+	return nil
+}
+
+// IPAllowed reports whether a client IP may access a survey with the given allowlist
+// An empty allowlist allows any IP
+func IPAllowed(clientIP string, entries []string) bool {
+	if len(entries) == 0 {
+		return true
+	}
+	addr, err := netip.ParseAddr(clientIP)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, entry := range entries {
+		prefix, err := parseIPPrefix(entry)
+		if err == nil && prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseIPPrefix parses a CIDR or a single IP address into a prefix
+func parseIPPrefix(entry string) (netip.Prefix, error) {
+	if strings.Contains(entry, "/") {
+		prefix, err := netip.ParsePrefix(entry)
+		if err != nil {
+			return netip.Prefix{}, err
+		}
+		return prefix.Masked(), nil
+	}
+	addr, err := netip.ParseAddr(entry)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	addr = addr.Unmap()
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+// validateExpiryNotification validates the link expiration notification settings of a survey
+func validateExpiryNotification(ctx context.Context, hours int, webhookURL, email string) error {
+	if err := validateWebhookURL(ctx, "expiry_webhook_url", webhookURL); err != nil {
+		return err
+	}
+	if hours > 0 && webhookURL == "" && email == "" {
+		return errors.NewValidationError("expiry_notify_hours", "a webhook URL or notification email is required when notifications are enabled")
+	}
+	return nil
+}
+
+// validateWebhookURL checks that a webhook URL of a survey setting is empty or an absolute http(s)
+// URL that does not point at an internal address
+func validateWebhookURL(ctx context.Context, field, webhookURL string) error {
+	if webhookURL == "" {
+		return nil
+	}
+	if err := netguard.ValidateURL(ctx, webhookURL); err != nil {
+		return errors.NewValidationError(field, "webhook URL must be an absolute http or https URL to a public address")
+	}
+	return nil
+}
+
+// validateLinkExpiry validates the share link lifetime settings of a survey
+func validateLinkExpiry(defaultHours, maxHours int) error {
+	if defaultHours > 0 && maxHours > 0 && defaultHours > maxHours {
+		return errors.NewValidationError("link_default_expiry_hours", "default link expiration must not exceed the maximum")
+	}
+	return nil
+}
+
+// toQuotaRules converts quota requests into model rules, rejecting duplicate segments
+func toQuotaRules(reqs []request.QuotaRuleRequest) (model.QuotaRules, error) {
+	rules := make(model.QuotaRules, len(reqs))
+	seen := make(map[string]bool)
+	for i, req := range reqs {
+		segment := req.Field + "=" + req.Value
+		if seen[segment] {
+			return nil, errors.NewValidationError(fmt.Sprintf("quotas[%d]", i), fmt.Sprintf("duplicate quota for segment %s", segment))
+		}
+		seen[segment] = true
+
+		rules[i] = model.QuotaRule{
+			Field:      req.Field,
+			Value:      req.Value,
+			Limit:      req.Limit,
+			CloseLinks: req.CloseLinks,
+		}
+	}
+	return rules, nil
+}
+
+// toQuestionPools converts question pool requests into model pools, rejecting duplicate keys
+func toQuestionPools(reqs []request.QuestionPoolRequest) (model.QuestionPools, error) {
+	pools := make(model.QuestionPools, len(reqs))
+	for i, req := range reqs {
+		if _, exists := pools[:i].Find(req.Key); exists {
+			return nil, errors.NewValidationError(fmt.Sprintf("question_pools[%d]", i), fmt.Sprintf("duplicate question pool %s", req.Key))
+		}
+		pools[i] = model.QuestionPool{Key: req.Key, Draw: req.Draw}
+	}
+	return pools, nil
+}
+
+// EmbedOriginAllowed reports whether an origin may embed a survey with the given allowlist
+// An empty allowlist allows no origin, like the frame-ancestors policy of the embed
+func EmbedOriginAllowed(origin string, domains []string) bool {
+	for _, domain := range domains {
+		if matchEmbedDomain(origin, domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchEmbedDomain reports whether the request origin matches an allowlist entry
+func matchEmbedDomain(origin, domain string) bool {
+	originURL, err := url.Parse(origin)
+	if err != nil || originURL.Host == "" {
+		return false
+	}
+
+	host := domain
+	if scheme, rest, found := strings.Cut(domain, "://"); found {
+		if !strings.EqualFold(scheme, originURL.Scheme) {
+			return false
+		}
+		host = rest
+	}
+
+	// Entries without a port match the origin host on any port
+	entry, err := url.Parse("//" + strings.ToLower(host))
+	if err != nil || entry.Hostname() == "" {
+		return false
+	}
+	if port := entry.Port(); port != "" && port != originPort(originURL) {
+		return false
+	}
+
+	originHost := strings.ToLower(originURL.Hostname())
+	if suffix, found := strings.CutPrefix(entry.Hostname(), "*"); found {
+		return strings.HasSuffix(originHost, suffix)
+	}
+	return originHost == entry.Hostname()
+}
+
+// originPort returns the port of an origin, the scheme's default port if it has none
+func originPort(originURL *url.URL) string {
+	if port := originURL.Port(); port != "" {
+		return port
+	}
+	switch strings.ToLower(originURL.Scheme) {
+	case "http":
+		return "80"
+	case "https":
+		return "443"
+	}
+	return ""
+}
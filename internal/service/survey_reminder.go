@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"survey-system/internal/model"
+	"survey-system/internal/repository"
+	"survey-system/pkg/email"
+	"survey-system/pkg/webhook"
+)
+
+// EventSurveyNoResponses is the webhook event emitted for published surveys without responses
+const EventSurveyNoResponses = "survey.no_responses"
+
+// surveyReminderBatchSize caps how many surveys are processed per scan
+const surveyReminderBatchSize = 100
+
+// NoResponsesEvent is the payload sent to a survey's reminder webhook
+type NoResponsesEvent struct {
+	Event       string    `json:"event"`
+	SurveyID    uint      `json:"survey_id"`
+	SurveyTitle string    `json:"survey_title"`
+	PublishedAt time.Time `json:"published_at"`
+	Days        int       `json:"days"` // The survey's no_response_reminder_days
+	SentAt      time.Time `json:"sent_at"`
+}
+
+// SurveyReminder periodically reminds survey owners of published surveys that have not
+// received any response some days after publishing
+type SurveyReminder struct {
+	surveyRepo repository.SurveyRepository
+	cache      Cache
+	mailer     email.Sender
+	webhooks   webhook.Sender
+	interval   time.Duration
+}
+
+// NewSurveyReminder creates a new SurveyReminder
+func NewSurveyReminder(
+	surveyRepo repository.SurveyRepository,
+	cache Cache,
+	mailer email.Sender,
+	webhooks webhook.Sender,
+	interval time.Duration,
+) *SurveyReminder {
+	return &SurveyReminder{
+		surveyRepo: surveyRepo,
+		cache:      cache,
+		mailer:     mailer,
+		webhooks:   webhooks,
+		interval:   interval,
+	}
+}
+
+// Run scans for surveys due for a reminder every interval until ctx is cancelled
+// A non-positive interval disables the reminder
+func (r *SurveyReminder) Run(ctx context.Context) {
+	if r.interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.RemindUnanswered(ctx); err != nil {
+			log.Printf("survey reminder: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RemindUnanswered performs a single scan and reminds the owner of each due survey once
+// per publication. A survey is only marked as reminded once every channel succeeded,
+// so failed deliveries are retried on the next scan
+func (r *SurveyReminder) RemindUnanswered(ctx context.Context) error {
+	// Only one instance should scan at a time
+	lockKey := "notifier:no_responses"
+	acquired, err := r.cache.AcquireLock(ctx, lockKey, r.interval)
+	if err != nil {
+		return fmt.Errorf("failed to acquire lock: %w", err)
+	}
+	if !acquired {
+		return nil
+	}
+	defer r.cache.ReleaseLock(ctx, lockKey)
+
+	surveys, err := r.surveyRepo.FindUnanswered(ctx, time.Now(), surveyReminderBatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to find surveys without responses: %w", err)
+	}
+
+	for i := range surveys {
+		r.remindOwner(ctx, &surveys[i])
+	}
+	return nil
+}
+
+// remindOwner delivers the reminder of a single survey to its webhook and its owner's email
+func (r *SurveyReminder) remindOwner(ctx context.Context, survey *model.Survey) {
+	event := &NoResponsesEvent{
+		Event:       EventSurveyNoResponses,
+		SurveyID:    survey.ID,
+		SurveyTitle: survey.Title,
+		PublishedAt: *survey.PublishedAt,
+		Days:        survey.NoResponseReminderDays,
+		SentAt:      time.Now(),
+	}
+
+	delivered := true
+	if survey.ReminderWebhookURL != "" {
+		if err := r.webhooks.Send(ctx, survey.ReminderWebhookURL, EventSurveyNoResponses, event); err != nil {
+			log.Printf("survey reminder: webhook for survey %d failed: %v", survey.ID, err)
+			delivered = false
+		}
+	}
+	// Deactivated owners are not emailed; surveys of owners without an email address
+	// and without a webhook are marked as reminded all the same
+	if survey.User.Email != "" && survey.User.IsActive() {
+		if err := r.mailer.Send(ctx, buildNoResponsesEmail(survey, event)); err != nil {
+			log.Printf("survey reminder: email for survey %d failed: %v", survey.ID, err)
+			delivered = false
+		}
+	}
+
+	if !delivered {
+		return
+	}
+	if err := r.surveyRepo.MarkReminded(ctx, []uint{survey.ID}, event.SentAt); err != nil {
+		log.Printf("survey reminder: failed to mark survey %d as reminded: %v", survey.ID, err)
+	}
+}
+
+// buildNoResponsesEmail builds the reminder email sent to the survey's owner
+func buildNoResponsesEmail(survey *model.Survey, event *NoResponsesEvent) *email.Message {
+	body := fmt.Sprintf("您好，\n\n问卷「%s」于 %s 发布，至今已超过 %d 天，尚未收到任何填答。\n\n"+
+		"请确认分享链接是否已发送给填答者，或检查链接是否已过期、问卷的 IP 限制和名额设置是否过严。\n",
+		survey.Title, event.PublishedAt.Format("2006-01-02 15:04"), event.Days)
+
+	return &email.Message{
+		To:      []string{survey.User.Email},
+		Subject: fmt.Sprintf("问卷尚未收到填答：%s", survey.Title),
+		Body:    body,
+	}
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+
+	"survey-system/internal/dto/response"
+	"survey-system/internal/model"
+	"survey-system/pkg/errors"
+
+	"gorm.io/gorm"
+)
+
+// Recommended limits of the size report. Surveys beyond them still work but load slowly
+// on mobile connections; lower configured question limits take their place
+const (
+	recommendedPayloadBytes = 256 << 10
+	recommendedQuestions    = 50
+	recommendedOptions      = 100 // per choice question, select column or cascade parent value
+	recommendedColumns      = 15
+)
+
+// Size warning codes
+const (
+	SizeWarningPayloadTooLarge  = "payload_too_large"
+	SizeWarningTooManyQuestions = "too_many_questions"
+	SizeWarningTooManyOptions   = "too_many_options"
+	SizeWarningTooManyColumns   = "too_many_columns"
+)
+
+// SizeReport estimates the public payload of a survey as respondents receive it and
+// warns about the survey and questions exceeding the recommended limits
+func (s *questionService) SizeReport(ctx context.Context, userID, surveyID uint) (*response.SurveySizeReport, error) {
+	survey, err := s.surveyRepo.FindByIDWithQuestions(ctx, surveyID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.WrapError(err, "failed to find survey")
+	}
+
+	if survey.UserID != userID {
+		return nil, errors.ErrForbidden
+	}
+
+	// Without a token no pool questions are withheld, so the estimate covers them all
+	payload := surveyWithPrefill("", survey, nil)
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to encode survey payload")
+	}
+	compressed, err := gzipSize(data)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to compress survey payload")
+	}
+
+	report := &response.SurveySizeReport{
+		SurveyID:        survey.ID,
+		PayloadBytes:    len(data),
+		CompressedBytes: compressed,
+		Questions:       len(payload.Questions),
+		HiddenQuestions: len(survey.Questions) - len(payload.Questions),
+		QuestionSizes:   make([]response.QuestionSize, len(payload.Questions)),
+		Warnings:        []response.SizeWarning{},
+	}
+	for i := range survey.Questions {
+		options, _ := questionOptionCounts(&survey.Questions[i].Config)
+		report.Options += options
+	}
+
+	if report.PayloadBytes > recommendedPayloadBytes {
+		report.Warnings = append(report.Warnings, response.SizeWarning{Code: SizeWarningPayloadTooLarge, Value: report.PayloadBytes, Limit: recommendedPayloadBytes})
+	}
+	if limit := min(recommendedQuestions, s.limits.MaxQuestions); len(survey.Questions) > limit {
+		report.Warnings = append(report.Warnings, response.SizeWarning{Code: SizeWarningTooManyQuestions, Value: len(survey.Questions), Limit: limit})
+	}
+
+	optionLimit := min(recommendedOptions, s.limits.MaxOptions)
+	columnLimit := min(recommendedColumns, s.limits.MaxColumns)
+	for i, q := range payload.Questions {
+		questionData, err := json.Marshal(q)
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to encode question payload")
+		}
+		options, largest := questionOptionCounts(&q.Config)
+		report.QuestionSizes[i] = response.QuestionSize{
+			QuestionID:   q.ID,
+			Title:        q.Title,
+			Type:         q.Type,
+			Options:      options,
+			Columns:      len(q.Config.Columns),
+			PayloadBytes: len(questionData),
+		}
+
+		if largest > optionLimit {
+			report.Warnings = append(report.Warnings, response.SizeWarning{Code: SizeWarningTooManyOptions, QuestionID: q.ID, Value: largest, Limit: optionLimit})
+		}
+		if len(q.Config.Columns) > columnLimit {
+			report.Warnings = append(report.Warnings, response.SizeWarning{Code: SizeWarningTooManyColumns, QuestionID: q.ID, Value: len(q.Config.Columns), Limit: columnLimit})
+		}
+	}
+	return report, nil
+}
+
+// questionOptionCounts returns the total number of options of a question's choice
+// options, select columns and cascade columns, and the length of its longest list
+func questionOptionCounts(config *model.QuestionConfig) (total, largest int) {
+	count := func(n int) {
+		total += n
+		largest = max(largest, n)
+	}
+
+	count(len(config.Options))
+	for _, column := range config.Columns {
+		count(len(column.Options))
+		for _, options := range column.Cascade {
+			count(len(options))
+		}
+	}
+	return total, largest
+}
+
+// gzipSize returns the size of data after gzip compression at the default level
+func gzipSize(data []byte) (int, error) {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(data); err != nil {
+		return 0, err
+	}
+	if err := w.Close(); err != nil {
+		return 0, err
+	}
+	return buf.Len(), nil
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"math"
+	"strconv"
+
+	"survey-system/internal/dto/request"
+	"survey-system/internal/model"
+	"survey-system/pkg/errors"
+)
+
+// totalsEpsilon absorbs floating point rounding when comparing totals without a tolerance
+const totalsEpsilon = 1e-9
+
+// hasTotals reports whether a table question has columns with a totals row
+func hasTotals(question *model.Question) bool {
+	for _, column := range question.Config.Columns {
+		if column.Total {
+			return true
+		}
+	}
+	return false
+}
+
+// columnTotals sums the numeric cells of each total column of a table answer, indexed
+// like the columns; cells that are empty or not numbers count as 0.
+// Returns false when the answer is not a table.
+func columnTotals(columns []model.TableColumn, value interface{}) ([]float64, bool) {
+	rows, ok := value.([]interface{})
+	if !ok {
+		return nil, false
+	}
+
+	totals := make([]float64, len(columns))
+	for _, rowValue := range rows {
+		row, ok := rowValue.([]interface{})
+		if !ok {
+			continue
+		}
+		for colIdx, column := range columns {
+			if !column.Total || colIdx >= len(row) {
+				continue
+			}
+			if cell, ok := row[colIdx].(string); ok && cell != "" {
+				if number, err := strconv.ParseFloat(cell, 64); err == nil && !math.IsNaN(number) && !math.IsInf(number, 0) {
+					totals[colIdx] += number
+				}
+			}
+		}
+	}
+	return totals, true
+}
+
+// withTableTotals checks the totals row that ends the answers of table questions with
+// total columns and returns the answers without it, so only the data rows are stored
+func withTableTotals(questions []model.Question, answers []request.AnswerRequest) ([]request.AnswerRequest, error) {
+	byID := make(map[uint]*model.Question, len(questions))
+	for i := range questions {
+		byID[questions[i].ID] = &questions[i]
+	}
+
+	var result []request.AnswerRequest
+	for i, answer := range answers {
+		question, ok := byID[answer.QuestionID]
+		if !ok || question.Type != model.QuestionTypeTable || !hasTotals(question) {
+			continue
+		}
+		rows, err := checkTableTotals(question, answer.Value)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = append([]request.AnswerRequest(nil), answers...)
+		}
+		result[i].Value = rows
+	}
+	if result == nil {
+		return answers, nil
+	}
+	return result, nil
+}
+
+// checkTableTotals verifies that each total column's cell in the last row of a table answer
+// matches the sum of the column within the question's tolerance, and returns the rows
+// before it. Cells of other columns in the totals row are ignored. Answers that are not
+// arrays are returned unchanged for the answer validation to reject
+func checkTableTotals(question *model.Question, value interface{}) (interface{}, error) {
+	rows, ok := value.([]interface{})
+	if !ok {
+		return value, nil
+	}
+	if len(rows) == 0 {
+		return nil, errors.NewLocalizedError("VALIDATION_FAILED", 400, "validation.table_totals_missing", question.Title)
+	}
+
+	columns := question.Config.Columns
+	totalsRow, ok := rows[len(rows)-1].([]interface{})
+	if !ok || len(totalsRow) != len(columns) {
+		return nil, errors.NewLocalizedError("VALIDATION_FAILED", 400, "validation.table_totals_missing", question.Title)
+	}
+	dataRows := rows[:len(rows)-1]
+
+	sums, _ := columnTotals(columns, dataRows)
+	for colIdx, column := range columns {
+		if !column.Total {
+			continue
+		}
+		cell, _ := totalsRow[colIdx].(string)
+		submitted, err := strconv.ParseFloat(cell, 64)
+		tolerance := question.Config.TotalTolerance + totalsEpsilon*math.Max(1, math.Abs(sums[colIdx]))
+		if err != nil || math.IsNaN(submitted) || math.Abs(submitted-sums[colIdx]) > tolerance {
+			return nil, errors.NewLocalizedError("VALIDATION_FAILED", 400, "validation.table_total_mismatch",
+				question.Title, column.Label, cell, strconv.FormatFloat(sums[colIdx], 'f', -1, 64))
+		}
+	}
+	return dataRows, nil
+}
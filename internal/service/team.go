@@ -0,0 +1,295 @@
+package service
+
+import (
+	"context"
+
+	"survey-system/internal/dto/request"
+	"survey-system/internal/dto/response"
+	"survey-system/internal/model"
+	"survey-system/internal/repository"
+	"survey-system/pkg/errors"
+	"survey-system/pkg/utils"
+
+	"gorm.io/gorm"
+)
+
+// TeamService defines the interface for team business logic
+type TeamService interface {
+	ListTeams(ctx context.Context, userID uint) ([]response.TeamResponse, error)
+	CreateTeam(ctx context.Context, userID uint, req *request.TeamRequest) (*response.TeamResponse, error)
+	GetTeam(ctx context.Context, userID, teamID uint) (*response.TeamResponse, error)
+	UpdateTeam(ctx context.Context, userID, teamID uint, req *request.TeamRequest) (*response.TeamResponse, error)
+	DeleteTeam(ctx context.Context, userID, teamID uint) error
+
+	AddMember(ctx context.Context, userID, teamID uint, req *request.AddTeamMemberRequest) (*response.TeamMemberResponse, error)
+	RemoveMember(ctx context.Context, userID, teamID, memberID uint) error
+
+	ListTeamSurveys(ctx context.Context, userID, teamID uint) ([]response.TeamSurveyResponse, error)
+	SetSurveyTeam(ctx context.Context, userID, surveyID uint, req *request.SetSurveyTeamRequest) error
+}
+
+// teamService implements TeamService interface
+type teamService struct {
+	teamRepo   repository.TeamRepository
+	surveyRepo repository.SurveyRepository
+	userRepo   repository.UserRepository
+	authz      *utils.AuthorizationUtil
+}
+
+// NewTeamService creates a new team service instance
+func NewTeamService(
+	teamRepo repository.TeamRepository,
+	surveyRepo repository.SurveyRepository,
+	userRepo repository.UserRepository,
+	authz *utils.AuthorizationUtil,
+) TeamService {
+	return &teamService{
+		teamRepo:   teamRepo,
+		surveyRepo: surveyRepo,
+		userRepo:   userRepo,
+		authz:      authz,
+	}
+}
+
+// ListTeams returns the teams the user is a member of
+func (s *teamService) ListTeams(ctx context.Context, userID uint) ([]response.TeamResponse, error) {
+	teams, err := s.teamRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to find teams")
+	}
+
+	result := make([]response.TeamResponse, len(teams))
+	for i := range teams {
+		result[i] = response.ToTeamResponse(&teams[i], teamRole(&teams[i], userID))
+	}
+	return result, nil
+}
+
+// CreateTeam creates a team owned by the user, who becomes its first member
+func (s *teamService) CreateTeam(ctx context.Context, userID uint, req *request.TeamRequest) (*response.TeamResponse, error) {
+	team := &model.Team{Name: req.Name, OwnerID: userID}
+	if err := s.teamRepo.Create(ctx, team); err != nil {
+		return nil, errors.WrapError(err, "failed to create team")
+	}
+
+	result := response.ToTeamResponse(team, model.TeamRoleOwner)
+	result.Members = nil
+	return &result, nil
+}
+
+// GetTeam returns a team with its members; only members can see it
+func (s *teamService) GetTeam(ctx context.Context, userID, teamID uint) (*response.TeamResponse, error) {
+	team, err := s.teamRepo.FindByIDWithMembers(ctx, teamID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.WrapError(err, "failed to find team")
+	}
+
+	for _, member := range team.Members {
+		if member.UserID == userID {
+			result := response.ToTeamResponse(team, member.Role)
+			return &result, nil
+		}
+	}
+	return nil, errors.ErrForbidden
+}
+
+// UpdateTeam renames a team
+func (s *teamService) UpdateTeam(ctx context.Context, userID, teamID uint, req *request.TeamRequest) (*response.TeamResponse, error) {
+	team, err := s.findOwnTeam(ctx, userID, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.teamRepo.Rename(ctx, teamID, req.Name); err != nil {
+		return nil, errors.WrapError(err, "failed to update team")
+	}
+	team.Name = req.Name
+
+	result := response.ToTeamResponse(team, model.TeamRoleOwner)
+	return &result, nil
+}
+
+// DeleteTeam deletes a team; its surveys stay with their owners and are no longer shared
+func (s *teamService) DeleteTeam(ctx context.Context, userID, teamID uint) error {
+	if _, err := s.findOwnTeam(ctx, userID, teamID); err != nil {
+		return err
+	}
+
+	if err := s.teamRepo.Delete(ctx, teamID); err != nil {
+		return errors.WrapError(err, "failed to delete team")
+	}
+	return nil
+}
+
+// AddMember adds an active user to a team by username
+func (s *teamService) AddMember(ctx context.Context, userID, teamID uint, req *request.AddTeamMemberRequest) (*response.TeamMemberResponse, error) {
+	if _, err := s.findOwnTeam(ctx, userID, teamID); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.FindByUsername(ctx, req.Username)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrUserNotFound
+		}
+		return nil, errors.WrapError(err, "failed to find user")
+	}
+	if !user.IsActive() {
+		return nil, errors.ErrAccountDeactivated
+	}
+
+	member := &model.TeamMember{TeamID: teamID, UserID: user.ID, Role: model.TeamRoleMember}
+	if err := s.teamRepo.AddMember(ctx, member); err != nil {
+		if err == gorm.ErrDuplicatedKey {
+			return nil, errors.ErrAlreadyTeamMember
+		}
+		return nil, errors.WrapError(err, "failed to add team member")
+	}
+	member.User = *user
+
+	result := response.ToTeamMemberResponse(member)
+	return &result, nil
+}
+
+// RemoveMember removes a user from a team. The owner can remove any other member and
+// members can remove themselves; the owner cannot leave the team
+func (s *teamService) RemoveMember(ctx context.Context, userID, teamID, memberID uint) error {
+	team, err := s.findTeam(ctx, teamID)
+	if err != nil {
+		return err
+	}
+
+	if memberID == team.OwnerID {
+		return errors.NewLocalizedValidationError("user_id", "team.owner_not_removable")
+	}
+	if userID != team.OwnerID && userID != memberID {
+		return errors.ErrForbidden
+	}
+
+	removed, err := s.teamRepo.RemoveMember(ctx, teamID, memberID)
+	if err != nil {
+		return errors.WrapError(err, "failed to remove team member")
+	}
+	if !removed {
+		return errors.ErrNotFound
+	}
+	return nil
+}
+
+// ListTeamSurveys returns the surveys shared with a team; only members can see them
+func (s *teamService) ListTeamSurveys(ctx context.Context, userID, teamID uint) ([]response.TeamSurveyResponse, error) {
+	if _, err := s.findTeam(ctx, teamID); err != nil {
+		return nil, err
+	}
+	if err := s.checkMember(ctx, userID, teamID); err != nil {
+		return nil, err
+	}
+
+	surveys, err := s.surveyRepo.FindByTeamID(ctx, teamID)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to find surveys")
+	}
+
+	result := make([]response.TeamSurveyResponse, len(surveys))
+	for i, survey := range surveys {
+		result[i] = response.TeamSurveyResponse{
+			ID:        survey.ID,
+			UserID:    survey.UserID,
+			Title:     survey.Title,
+			Status:    survey.Status,
+			CreatedAt: survey.CreatedAt,
+		}
+	}
+	return result, nil
+}
+
+// SetSurveyTeam shares a survey with a team the owner is a member of, or stops sharing it
+func (s *teamService) SetSurveyTeam(ctx context.Context, userID, surveyID uint, req *request.SetSurveyTeamRequest) error {
+	survey, err := s.surveyRepo.FindByID(ctx, surveyID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return errors.ErrNotFound
+		}
+		return errors.WrapError(err, "failed to find survey")
+	}
+
+	if survey.UserID != userID {
+		return errors.ErrForbidden
+	}
+
+	if req.TeamID != nil {
+		if _, err := s.findTeam(ctx, *req.TeamID); err != nil {
+			return err
+		}
+		if err := s.checkMember(ctx, userID, *req.TeamID); err != nil {
+			return err
+		}
+	}
+
+	if err := s.surveyRepo.SetTeam(ctx, surveyID, req.TeamID); err != nil {
+		return errors.WrapError(err, "failed to update survey team")
+	}
+	return nil
+}
+
+// findTeam loads a team by ID
+func (s *teamService) findTeam(ctx context.Context, teamID uint) (*model.Team, error) {
+	team, err := s.teamRepo.FindByID(ctx, teamID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.WrapError(err, "failed to find team")
+	}
+	return team, nil
+}
+
+// findOwnTeam loads a team and verifies the user owns it
+func (s *teamService) findOwnTeam(ctx context.Context, userID, teamID uint) (*model.Team, error) {
+	team, err := s.findTeam(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	if team.OwnerID != userID {
+		return nil, errors.ErrForbidden
+	}
+	return team, nil
+}
+
+// checkMember verifies the user is a member of a team
+func (s *teamService) checkMember(ctx context.Context, userID, teamID uint) error {
+	member, err := s.authz.IsTeamMember(ctx, userID, teamID)
+	if err != nil {
+		return errors.WrapError(err, "failed to check team membership")
+	}
+	if !member {
+		return errors.ErrForbidden
+	}
+	return nil
+}
+
+// teamRole returns the role of a member in a team
+func teamRole(team *model.Team, userID uint) string {
+	if team.OwnerID == userID {
+		return model.TeamRoleOwner
+	}
+	return model.TeamRoleMember
+}
+
+// findTeamSurvey loads a survey the user owns or shares through the survey's team
+func findTeamSurvey(ctx context.Context, authz *utils.AuthorizationUtil, userID, surveyID uint) (*model.Survey, error) {
+	survey, err := authz.GetSurveyIfAccessible(ctx, userID, surveyID)
+	switch err {
+	case nil:
+		return survey, nil
+	case utils.ErrSurveyNotFound:
+		return nil, errors.ErrNotFound
+	case utils.ErrForbidden:
+		return nil, errors.ErrForbidden
+	}
+	return nil, errors.WrapError(err, "failed to find survey")
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"survey-system/internal/dto/response"
+	"survey-system/internal/model"
+	"survey-system/internal/repository"
+	"survey-system/pkg/errors"
+)
+
+// timeLimitGrace tolerates the delay between a respondent submitting just in time and
+// the submission reaching the server
+const timeLimitGrace = 30 * time.Second
+
+// sessionDeadline returns when the timed session of a link ends, or nil when the survey
+// has no time limit or the session has not started. Links submitted without being opened
+// through the survey endpoint have no session
+func sessionDeadline(survey *model.Survey, oneLink *model.OneLink) *time.Time {
+	if survey.TimeLimitMinutes <= 0 || oneLink.StartedAt == nil {
+		return nil
+	}
+	deadline := oneLink.StartedAt.Add(time.Duration(survey.TimeLimitMinutes) * time.Minute)
+	return &deadline
+}
+
+// checkTimeLimit rejects submissions arriving after the deadline of the link's session
+func checkTimeLimit(survey *model.Survey, oneLink *model.OneLink) error {
+	if deadline := sessionDeadline(survey, oneLink); deadline != nil && time.Now().After(deadline.Add(timeLimitGrace)) {
+		return errors.ErrTimeLimitExceeded
+	}
+	return nil
+}
+
+// startSession starts the timed session of a link on its first opening and adds the
+// session to the survey payload. Reopening the link continues the session; once it is
+// over the survey can no longer be opened
+func startSession(ctx context.Context, oneLinkRepo repository.OneLinkRepository, survey *model.Survey, oneLink *model.OneLink, payload *response.SurveyWithPrefillResponse) error {
+	if survey.TimeLimitMinutes <= 0 {
+		return nil
+	}
+
+	if oneLink.StartedAt == nil {
+		now := time.Now()
+		started, err := oneLinkRepo.MarkAsStarted(ctx, oneLink.ID, now)
+		if err != nil {
+			return errors.WrapError(err, "failed to start response session")
+		}
+		if started {
+			oneLink.StartedAt = &now
+		} else {
+			// Opened concurrently; the other request started the session
+			current, err := oneLinkRepo.FindByID(ctx, oneLink.ID)
+			if err != nil {
+				return errors.WrapError(err, "failed to find one-time link")
+			}
+			oneLink.StartedAt = current.StartedAt
+		}
+	}
+
+	deadline := sessionDeadline(survey, oneLink)
+	remaining := int64(time.Until(*deadline) / time.Second)
+	if remaining <= 0 {
+		return errors.ErrTimeLimitExceeded
+	}
+
+	payload.TimeLimitMinutes = survey.TimeLimitMinutes
+	payload.StartedAt = oneLink.StartedAt
+	payload.Deadline = deadline
+	payload.RemainingSeconds = &remaining
+	return nil
+}
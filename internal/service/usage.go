@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"time"
+
+	"survey-system/internal/cache"
+	"survey-system/internal/dto/response"
+	"survey-system/pkg/errors"
+)
+
+// Kinds of actions counted against per-user daily quotas
+const (
+	UsageShareLinks = "share_links"
+	UsageExports    = "exports"
+)
+
+// usageKinds lists the counted actions in the order they are reported
+var usageKinds = []string{UsageShareLinks, UsageExports}
+
+// usageDayLayout formats the day usage is counted for
+const usageDayLayout = "2006-01-02"
+
+// UsageLimits are the daily per-user limits of each counted action.
+// Zero values leave the action unlimited.
+type UsageLimits struct {
+	ShareLinks int
+	Exports    int
+}
+
+// limit returns the daily limit of a kind of action
+func (l UsageLimits) limit(kind string) int {
+	switch kind {
+	case UsageShareLinks:
+		return l.ShareLinks
+	case UsageExports:
+		return l.Exports
+	}
+	return 0
+}
+
+// UsageService defines the interface for per-user daily quotas
+type UsageService interface {
+	ReserveUsage(ctx context.Context, userID uint, kind string) (func(), error)
+	ReserveUsageCount(ctx context.Context, userID uint, kind string, count int) (func(), error)
+	GetUsage(ctx context.Context, userID uint) (*response.UsageResponse, error)
+}
+
+// usageService implements UsageService interface
+type usageService struct {
+	cache  cache.Cache
+	limits UsageLimits
+}
+
+// NewUsageService creates a new usage service instance
+func NewUsageService(cache cache.Cache, limits UsageLimits) UsageService {
+	return &usageService{
+		cache:  cache,
+		limits: limits,
+	}
+}
+
+// ReserveUsage counts one action of a user against today's quota and returns a function
+// giving it back, for actions that end up failing. Returns ErrUsageQuotaExceeded once
+// the quota is used up. When Redis cannot be reached the action is let through uncounted.
+func (s *usageService) ReserveUsage(ctx context.Context, userID uint, kind string) (func(), error) {
+	return s.ReserveUsageCount(ctx, userID, kind, 1)
+}
+
+// ReserveUsageCount counts count actions at once, e.g. links generated in bulk, like
+// ReserveUsage. Either all of them fit in the remaining quota or none are counted
+func (s *usageService) ReserveUsageCount(ctx context.Context, userID uint, kind string, count int) (func(), error) {
+	limit := s.limits.limit(kind)
+	if limit <= 0 {
+		return func() {}, nil
+	}
+
+	day := time.Now().Format(usageDayLayout)
+	if _, err := s.cache.ReserveUsage(ctx, userID, day, kind, count, limit); err != nil {
+		if stderrors.Is(err, cache.ErrQuotaExceeded) {
+			return nil, errors.ErrUsageQuotaExceeded
+		}
+		fmt.Printf("failed to count %s usage of user %d: %v\n", kind, userID, err)
+		return func() {}, nil
+	}
+
+	return func() {
+		if err := s.cache.ReleaseUsage(context.WithoutCancel(ctx), userID, day, kind, count); err != nil {
+			fmt.Printf("failed to release %s usage of user %d: %v\n", kind, userID, err)
+		}
+	}, nil
+}
+
+// GetUsage returns the user's consumption of each daily quota
+func (s *usageService) GetUsage(ctx context.Context, userID uint) (*response.UsageResponse, error) {
+	now := time.Now()
+	day := now.Format(usageDayLayout)
+
+	used, err := s.cache.GetUsage(ctx, userID, day)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to get usage")
+	}
+
+	year, month, date := now.Date()
+	resp := &response.UsageResponse{
+		Date:     day,
+		ResetsAt: time.Date(year, month, date+1, 0, 0, 0, 0, now.Location()),
+		Quotas:   make([]response.UsageQuota, 0, len(usageKinds)),
+	}
+	for _, kind := range usageKinds {
+		quota := response.UsageQuota{
+			Kind:  kind,
+			Used:  used[kind],
+			Limit: s.limits.limit(kind),
+		}
+		if quota.Limit > 0 {
+			remaining := max(int64(quota.Limit)-quota.Used, 0)
+			quota.Remaining = &remaining
+		}
+		resp.Quotas = append(resp.Quotas, quota)
+	}
+	return resp, nil
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+
+	"survey-system/internal/dto/response"
+	"survey-system/internal/model"
+	"survey-system/pkg/errors"
+)
+
+// validateVariants checks the A/B variant keys of a survey: none, or at least two distinct ones
+func validateVariants(variants []string) error {
+	if len(variants) == 1 {
+		return errors.NewValidationError("variants", "a survey needs at least two variants")
+	}
+	for i, variant := range variants {
+		if slices.Contains(variants[:i], variant) {
+			return errors.NewValidationError(fmt.Sprintf("variants[%d]", i), fmt.Sprintf("duplicate variant %s", variant))
+		}
+	}
+	return nil
+}
+
+// linkVariant returns the variant of a new link of a survey: the requested one, or a
+// random one when the survey has variants and none is requested
+func linkVariant(survey *model.Survey, requested string) (string, error) {
+	if requested != "" {
+		if !slices.Contains(survey.Variants, requested) {
+			return "", errors.NewValidationError("variant", fmt.Sprintf("survey has no variant %s", requested))
+		}
+		return requested, nil
+	}
+	if len(survey.Variants) == 0 {
+		return "", nil
+	}
+	return survey.Variants[rand.IntN(len(survey.Variants))], nil
+}
+
+// variantQuestions returns the questions links of a variant receive: the variant's own and
+// those common to all variants. Questions of a variant the survey no longer defines are
+// common ones, and surveys without variants show every question
+func variantQuestions(survey *model.Survey, questions []model.Question, variant string) []model.Question {
+	if len(survey.Variants) == 0 {
+		return questions
+	}
+	shown := make([]model.Question, 0, len(questions))
+	for _, question := range questions {
+		if question.Variant == "" || question.Variant == variant || !slices.Contains(survey.Variants, question.Variant) {
+			shown = append(shown, question)
+		}
+	}
+	return shown
+}
+
+// unseenQuestions lists the questions of a survey a respondent never saw, in ascending
+// order: those outside the link's variant and the withheld pool questions
+func unseenQuestions(questions, shown []model.Question, withheld map[uint]bool) []uint {
+	var ids []uint
+	for _, question := range questions {
+		if withheld[question.ID] || !slices.ContainsFunc(shown, func(q model.Question) bool { return q.ID == question.ID }) {
+			ids = append(ids, question.ID)
+		}
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// variantStatistics breaks the statistics of a survey down by A/B variant, from the
+// per-variant counters of the survey's statistics counters
+func (s *ResponseService) variantStatistics(ctx context.Context, survey *model.Survey, questions []model.Question, counters map[string]float64, includeTest bool) ([]response.VariantStatistics, error) {
+	if len(survey.Variants) == 0 {
+		return nil, nil
+	}
+
+	links, err := s.oneLinkRepo.CountByVariant(ctx, survey.ID, includeTest)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to count links by variant")
+	}
+
+	stats := make([]response.VariantStatistics, len(survey.Variants))
+	for i, variant := range survey.Variants {
+		variantStats := statisticsFromCounters(survey.ID, variantQuestions(survey, questions, variant), variantCounters(counters, variant))
+		count := variantStats.TotalResponses
+
+		stats[i] = response.VariantStatistics{
+			Variant:   variant,
+			Responses: count,
+			Questions: variantStats.Questions,
+		}
+		for _, linkCount := range links {
+			if linkCount.Variant == variant {
+				stats[i].Links = linkCount.Count
+			}
+		}
+		if stats[i].Links > 0 {
+			stats[i].ResponseRate = math.Round(float64(count)/float64(stats[i].Links)*10000) / 100
+		}
+	}
+	return stats, nil
+}
@@ -0,0 +1,11 @@
+-- Look up one-time links by the SHA-256 hash of their token instead of the token itself.
+-- The server applies this automatically on startup (pkg/database/migrate.go);
+-- run it by hand only when auto-migration is disabled.
+
+ALTER TABLE one_links ADD COLUMN token_hash VARCHAR(64) NOT NULL DEFAULT '' AFTER survey_id;
+
+UPDATE one_links SET token_hash = SHA2(token, 256);
+
+ALTER TABLE one_links
+  DROP INDEX idx_one_links_token,
+  ADD UNIQUE INDEX idx_one_links_token_hash (token_hash);
@@ -0,0 +1,142 @@
+package chat
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"survey-system/pkg/netguard"
+)
+
+// Supported chat platforms
+const (
+	Slack    = "slack"
+	DingTalk = "dingtalk"
+	WeCom    = "wecom"
+)
+
+// webhookHosts lists the host incoming webhooks of each platform are served from
+// Restricting deliveries to these hosts keeps channels from being used to reach internal services
+var webhookHosts = map[string]string{
+	Slack:    "hooks.slack.com",
+	DingTalk: "oapi.dingtalk.com",
+	WeCom:    "qyapi.weixin.qq.com",
+}
+
+// Sender defines the interface for posting text messages to chat webhooks
+type Sender interface {
+	// Send posts text to the platform's incoming webhook
+	// secret is only used by DingTalk robots with signing enabled
+	Send(ctx context.Context, platform, webhookURL, secret, text string) error
+}
+
+// NewSender creates a chat sender with the given delivery timeout
+func NewSender(timeout time.Duration) Sender {
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	return &httpSender{client: netguard.NewClient(timeout)}
+}
+
+// ValidateWebhookURL checks that a webhook URL is an HTTPS URL on the platform's webhook host
+func ValidateWebhookURL(platform, webhookURL string) error {
+	host, ok := webhookHosts[platform]
+	if !ok {
+		return fmt.Errorf("unsupported platform %q", platform)
+	}
+
+	u, err := url.Parse(webhookURL)
+	if err != nil || u.Scheme != "https" || u.Hostname() != host {
+		return fmt.Errorf("%s webhooks must be https://%s URLs", platform, host)
+	}
+	return nil
+}
+
+// httpSender posts messages using each platform's text message format
+type httpSender struct {
+	client *http.Client
+}
+
+// Send posts text to the platform's incoming webhook
+func (s *httpSender) Send(ctx context.Context, platform, webhookURL, secret, text string) error {
+	if err := ValidateWebhookURL(platform, webhookURL); err != nil {
+		return err
+	}
+
+	var payload interface{}
+	switch platform {
+	case Slack:
+		payload = map[string]string{"text": text}
+	case DingTalk:
+		payload = map[string]interface{}{"msgtype": "text", "text": map[string]string{"content": text}}
+		if secret != "" {
+			webhookURL = signDingTalkURL(webhookURL, secret, time.Now())
+		}
+	case WeCom:
+		payload = map[string]interface{}{"msgtype": "text", "text": map[string]string{"content": text}}
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal chat message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create chat request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to deliver chat message: %w", err)
+	}
+	defer resp.Body.Close()
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s webhook returned status %d", platform, resp.StatusCode)
+	}
+
+	// DingTalk and WeCom report failures such as invalid keys in the body of a 200 response
+	if platform == DingTalk || platform == WeCom {
+		var result struct {
+			ErrCode int    `json:"errcode"`
+			ErrMsg  string `json:"errmsg"`
+		}
+		if err := json.Unmarshal(respBody, &result); err != nil {
+			return fmt.Errorf("unexpected %s webhook response: %w", platform, err)
+		}
+		if result.ErrCode != 0 {
+			return fmt.Errorf("%s webhook error %d: %s", platform, result.ErrCode, result.ErrMsg)
+		}
+	}
+
+	return nil
+}
+
+// signDingTalkURL appends the timestamp and signature required by DingTalk robots with signing enabled
+// The signature is the base64 HMAC-SHA256 of "<timestamp>\n<secret>" keyed by the secret
+func signDingTalkURL(webhookURL, secret string, now time.Time) string {
+	timestamp := strconv.FormatInt(now.UnixMilli(), 10)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "\n" + secret))
+	sign := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	separator := "?"
+	if strings.Contains(webhookURL, "?") {
+		separator = "&"
+	}
+	return webhookURL + separator + "timestamp=" + timestamp + "&sign=" + url.QueryEscape(sign)
+}
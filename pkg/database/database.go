@@ -10,7 +10,6 @@ import (
 
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
 
 	"survey-system/internal/config"
 )
@@ -58,12 +57,20 @@ func InitDB(cfg *config.DatabaseConfig) (*gorm.DB, error) {
 		)
 	}
 
-	// Configure GORM logger
-	gormLogger := logger.Default.LogMode(logger.Info)
+	// Configure GORM logger; its settings can be changed later through SetLogSettings
+	if err := SetLogSettings(cfg); err != nil {
+		return nil, err
+	}
+	gormLogger := &queryLogger{
+		settings: settings,
+		counters: counters,
+	}
 
 	// Open database connection
 	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{
 		Logger: gormLogger,
+		// Report constraint violations as gorm.ErrDuplicatedKey and friends
+		TranslateError: true,
 		NowFunc: func() time.Time {
 			return time.Now().Local()
 		},
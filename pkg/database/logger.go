@@ -0,0 +1,183 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/utils"
+
+	"survey-system/internal/config"
+)
+
+// QueryStats are the statement counters of the database connection since startup
+// and the current state of its connection pool
+type QueryStats struct {
+	Queries     int64      `json:"queries"`
+	SlowQueries int64      `json:"slow_queries"`
+	Errors      int64      `json:"errors"`
+	SlowestMs   float64    `json:"slowest_ms"`
+	Pool        *PoolStats `json:"pool,omitempty"`
+}
+
+// queryLogger writes GORM's log through slog and counts statements, slow statements and
+// failed statements. Statements are logged without their bound values, so answers and
+// credentials never reach the logs
+type queryLogger struct {
+	settings *atomic.Pointer[logSettings] // swapped when the configuration is reloaded
+	counters *queryCounters
+}
+
+// logSettings are the reloadable settings of a queryLogger
+type logSettings struct {
+	level         logger.LogLevel
+	slowThreshold time.Duration // 0 disables slow query detection
+}
+
+type queryCounters struct {
+	queries     atomic.Int64
+	slowQueries atomic.Int64
+	errors      atomic.Int64
+	slowest     atomic.Int64 // nanoseconds
+}
+
+// counters of the connection opened by InitDB
+var counters = &queryCounters{}
+
+// settings of the logger of the connection opened by InitDB
+var settings = &atomic.Pointer[logSettings]{}
+
+// SetLogSettings applies the log level and slow query threshold of cfg to the
+// connection opened by InitDB, e.g. after the configuration was reloaded
+func SetLogSettings(cfg *config.DatabaseConfig) error {
+	level, err := parseLogLevel(cfg.LogLevel)
+	if err != nil {
+		return err
+	}
+	settings.Store(&logSettings{level: level, slowThreshold: cfg.SlowQueryThreshold})
+	return nil
+}
+
+// Stats returns the statement counters of the connection opened by InitDB
+func Stats() QueryStats {
+	stats := QueryStats{
+		Queries:     counters.queries.Load(),
+		SlowQueries: counters.slowQueries.Load(),
+		Errors:      counters.errors.Load(),
+		SlowestMs:   float64(counters.slowest.Load()) / float64(time.Millisecond),
+	}
+	if DB != nil {
+		if sqlDB, err := DB.DB(); err == nil {
+			pool := toPoolStats(sqlDB.Stats())
+			stats.Pool = &pool
+		}
+	}
+	return stats
+}
+
+// parseLogLevel maps a configured log level to GORM's levels; empty means warn
+func parseLogLevel(level string) (logger.LogLevel, error) {
+	switch level {
+	case "silent":
+		return logger.Silent, nil
+	case "error":
+		return logger.Error, nil
+	case "", "warn":
+		return logger.Warn, nil
+	case "info":
+		return logger.Info, nil
+	}
+	return 0, fmt.Errorf("database log level must be silent, error, warn or info, got %q", level)
+}
+
+// LogMode returns a copy of the logger at another level, sharing its counters
+// The copy keeps that level when the configuration is reloaded
+func (l *queryLogger) LogMode(level logger.LogLevel) logger.Interface {
+	fixed := *l.settings.Load()
+	fixed.level = level
+	copied := &queryLogger{settings: &atomic.Pointer[logSettings]{}, counters: l.counters}
+	copied.settings.Store(&fixed)
+	return copied
+}
+
+// level returns the current log level
+func (l *queryLogger) level() logger.LogLevel {
+	return l.settings.Load().level
+}
+
+func (l *queryLogger) Info(ctx context.Context, msg string, data ...interface{}) {
+	if l.level() >= logger.Info {
+		slog.InfoContext(ctx, fmt.Sprintf(msg, data...), "component", "gorm", "source", utils.FileWithLineNum())
+	}
+}
+
+func (l *queryLogger) Warn(ctx context.Context, msg string, data ...interface{}) {
+	if l.level() >= logger.Warn {
+		slog.WarnContext(ctx, fmt.Sprintf(msg, data...), "component", "gorm", "source", utils.FileWithLineNum())
+	}
+}
+
+func (l *queryLogger) Error(ctx context.Context, msg string, data ...interface{}) {
+	if l.level() >= logger.Error {
+		slog.ErrorContext(ctx, fmt.Sprintf(msg, data...), "component", "gorm", "source", utils.FileWithLineNum())
+	}
+}
+
+// Trace counts a finished statement and logs it when it failed (error), was slow (warn)
+// or at the info level. Missing records are expected and not counted as failures
+func (l *queryLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	elapsed := time.Since(begin)
+	current := l.settings.Load()
+	l.counters.queries.Add(1)
+	for {
+		slowest := l.counters.slowest.Load()
+		if int64(elapsed) <= slowest || l.counters.slowest.CompareAndSwap(slowest, int64(elapsed)) {
+			break
+		}
+	}
+
+	failed := err != nil && !errors.Is(err, gorm.ErrRecordNotFound)
+	slow := current.slowThreshold > 0 && elapsed > current.slowThreshold
+	if failed {
+		l.counters.errors.Add(1)
+	}
+	if slow {
+		l.counters.slowQueries.Add(1)
+	}
+
+	var level slog.Level
+	var msg string
+	switch {
+	case failed && current.level >= logger.Error:
+		level, msg = slog.LevelError, "database query failed"
+	case slow && current.level >= logger.Warn:
+		level, msg = slog.LevelWarn, "slow database query"
+	case current.level >= logger.Info:
+		level, msg = slog.LevelInfo, "database query"
+	default:
+		return
+	}
+
+	sql, rows := fc()
+	attrs := []any{
+		"component", "gorm",
+		"duration_ms", float64(elapsed) / float64(time.Millisecond),
+		"rows", rows,
+		"sql", sql,
+		"source", utils.FileWithLineNum(),
+	}
+	if failed {
+		attrs = append(attrs, "error", err)
+	}
+	slog.Log(ctx, level, msg, attrs...)
+}
+
+// ParamsFilter drops the bound values from logged statements
+func (l *queryLogger) ParamsFilter(ctx context.Context, sql string, params ...interface{}) (string, []interface{}) {
+	return sql, nil
+}
@@ -1,12 +1,16 @@
 package database
 
 import (
+	"crypto/rand"
+	"encoding/base64"
 	"fmt"
 	"log"
+	"time"
 
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 
+	"survey-system/internal/config"
 	"survey-system/internal/model"
 )
 
@@ -14,13 +18,40 @@ import (
 func AutoMigrate(db *gorm.DB) error {
 	log.Println("Starting database auto-migration...")
 
+	if err := migrateOneLinkTokenHash(db); err != nil {
+		return err
+	}
+	if err := migrateUserEmailVerification(db); err != nil {
+		return err
+	}
+
 	// List of all models to migrate
 	models := []interface{}{
 		&model.User{},
+		&model.UserSession{},
+		&model.UserToken{},
+		&model.LoginRecord{},
+		&model.Team{},
+		&model.TeamMember{},
 		&model.Survey{},
 		&model.Question{},
 		&model.Response{},
 		&model.OneLink{},
+		&model.ResponseDraft{},
+		&model.ResponseComment{},
+		&model.SurveyEvent{},
+		&model.ReportSubscription{},
+		&model.NotificationChannel{},
+		&model.ExportJob{},
+		&model.SurveySequence{},
+		&model.LinkTemplate{},
+		&model.Delegation{},
+		&model.Hook{},
+		&model.HookDelivery{},
+		&model.QuestionChange{},
+		&model.ResponseArchive{},
+		&model.ResponseVersion{},
+		&model.AuditLog{},
 	}
 
 	// Run auto-migration for each model
@@ -35,17 +66,86 @@ func AutoMigrate(db *gorm.DB) error {
 	return nil
 }
 
+// migrateOneLinkTokenHash moves one_links lookups from the token itself to its SHA-256 hash
+// Existing links get the hash backfilled before AutoMigrate adds its unique index,
+// and the unique index on the long token column is dropped
+func migrateOneLinkTokenHash(db *gorm.DB) error {
+	migrator := db.Migrator()
+	if !migrator.HasTable(&model.OneLink{}) || migrator.HasColumn(&model.OneLink{}, "TokenHash") {
+		return nil
+	}
+
+	log.Println("Migrating one_links to token hashes...")
+
+	if err := migrator.AddColumn(&model.OneLink{}, "TokenHash"); err != nil {
+		return fmt.Errorf("failed to add one_links.token_hash: %w", err)
+	}
+	// SHA2 returns lowercase hex, matching model.HashLinkToken
+	if err := db.Exec("UPDATE one_links SET token_hash = SHA2(token, 256)").Error; err != nil {
+		return fmt.Errorf("failed to backfill one_links.token_hash: %w", err)
+	}
+	if migrator.HasIndex(&model.OneLink{}, "idx_one_links_token") {
+		if err := migrator.DropIndex(&model.OneLink{}, "idx_one_links_token"); err != nil {
+			return fmt.Errorf("failed to drop index on one_links.token: %w", err)
+		}
+	}
+
+	log.Println("Successfully migrated one_links to token hashes")
+	return nil
+}
+
+// migrateUserEmailVerification adds users.email_verified_at, treating accounts that
+// existed before email verification as verified so they are not locked out
+func migrateUserEmailVerification(db *gorm.DB) error {
+	migrator := db.Migrator()
+	if !migrator.HasTable(&model.User{}) || migrator.HasColumn(&model.User{}, "EmailVerifiedAt") {
+		return nil
+	}
+
+	log.Println("Migrating users to email verification...")
+
+	if err := migrator.AddColumn(&model.User{}, "EmailVerifiedAt"); err != nil {
+		return fmt.Errorf("failed to add users.email_verified_at: %w", err)
+	}
+	if err := db.Exec("UPDATE users SET email_verified_at = created_at").Error; err != nil {
+		return fmt.Errorf("failed to backfill users.email_verified_at: %w", err)
+	}
+
+	log.Println("Successfully migrated users to email verification")
+	return nil
+}
+
 // DropAllTables drops all tables (use with caution, mainly for testing)
 func DropAllTables(db *gorm.DB) error {
 	log.Println("Dropping all tables...")
 
 	// Drop tables in reverse order to respect foreign key constraints
 	models := []interface{}{
+		&model.LoginRecord{},
+		&model.UserToken{},
+		&model.UserSession{},
+		&model.AuditLog{},
+		&model.ResponseVersion{},
+		&model.ResponseArchive{},
+		&model.QuestionChange{},
+		&model.HookDelivery{},
+		&model.Hook{},
+		&model.Delegation{},
+		&model.LinkTemplate{},
+		&model.SurveySequence{},
+		&model.ExportJob{},
+		&model.NotificationChannel{},
+		&model.ReportSubscription{},
+		&model.SurveyEvent{},
+		&model.ResponseComment{},
+		&model.ResponseDraft{},
 		&model.OneLink{},
 		&model.Response{},
 		&model.Question{},
 		&model.Survey{},
 		&model.User{},
+		&model.Team{},
+		&model.TeamMember{},
 	}
 
 	for _, m := range models {
@@ -73,8 +173,18 @@ func CreateIndexes(db *gorm.DB) error {
 	return nil
 }
 
-// InitializeDefaultAdmin creates a default admin account if no users exist
-func InitializeDefaultAdmin(db *gorm.DB) error {
+// minSeedPasswordLength is the shortest configured admin password accepted for seeding
+const minSeedPasswordLength = 8
+
+// InitializeDefaultAdmin creates the initial admin account if no users exist
+// Seeding can be disabled, and is refused in release mode unless explicitly allowed.
+// When no password is configured a random one is generated and logged once
+func InitializeDefaultAdmin(db *gorm.DB, cfg *config.SeedConfig, mode string) error {
+	if !cfg.Enabled {
+		log.Println("Default admin seeding is disabled")
+		return nil
+	}
+
 	log.Println("Checking for existing users...")
 
 	// Check if any users exist
@@ -89,20 +199,40 @@ func InitializeDefaultAdmin(db *gorm.DB) error {
 		return nil
 	}
 
+	if mode == "release" && !cfg.AllowInRelease {
+		log.Println("⚠️  No users found, but default admin seeding is refused in release mode")
+		log.Println("  Set seed.allow_in_release (SEED_ALLOW_IN_RELEASE=true) to create the initial admin account")
+		return nil
+	}
+
+	password := cfg.AdminPassword
+	generated := password == ""
+	if generated {
+		var err error
+		if password, err = randomPassword(); err != nil {
+			return fmt.Errorf("failed to generate admin password: %w", err)
+		}
+	} else if len(password) < minSeedPasswordLength {
+		return fmt.Errorf("seed admin password must be at least %d characters", minSeedPasswordLength)
+	}
+
 	log.Println("No users found, creating default admin account...")
 
-	// Hash the default password
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte("admin123"), bcrypt.DefaultCost)
+	// Hash the password
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	if err != nil {
 		return fmt.Errorf("failed to hash password: %w", err)
 	}
 
 	// Create default admin user
+	// The seeded admin has no verification email to follow
+	verifiedAt := time.Now()
 	defaultAdmin := &model.User{
-		Username: "admin",
-		Password: string(hashedPassword),
-		Email:    "admin@example.com",
-		Role:     "admin",
+		Username:        cfg.AdminUsername,
+		Password:        string(hashedPassword),
+		Email:           cfg.AdminEmail,
+		Role:            "admin",
+		EmailVerifiedAt: &verifiedAt,
 	}
 
 	if err := db.Create(defaultAdmin).Error; err != nil {
@@ -110,10 +240,24 @@ func InitializeDefaultAdmin(db *gorm.DB) error {
 	}
 
 	log.Println("✓ Default admin account created successfully")
-	log.Println("  Username: admin")
-	log.Println("  Password: admin123")
-	log.Println("  Email: admin@example.com")
-	log.Println("  ⚠️  Please change the default password after first login!")
+	log.Printf("  Username: %s", defaultAdmin.Username)
+	if generated {
+		// Printed only this once, it is not stored anywhere in plain text
+		log.Printf("  Password: %s", password)
+		log.Println("  ⚠️  This generated password will not be shown again, please change it after first login!")
+	} else {
+		log.Println("  Password: (from configuration)")
+	}
+	log.Printf("  Email: %s", defaultAdmin.Email)
 
 	return nil
 }
+
+// randomPassword returns a random URL-safe password with 128 bits of entropy
+func randomPassword() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
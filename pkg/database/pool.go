@@ -0,0 +1,112 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PoolStats are the connection pool statistics of the connection opened by InitDB
+type PoolStats struct {
+	MaxOpen           int     `json:"max_open"`
+	Open              int     `json:"open"`
+	InUse             int     `json:"in_use"`
+	Idle              int     `json:"idle"`
+	WaitCount         int64   `json:"wait_count"`       // Connections that had to be waited for
+	WaitDurationMs    float64 `json:"wait_duration_ms"` // Total time spent waiting for connections
+	MaxIdleClosed     int64   `json:"max_idle_closed"`
+	MaxLifetimeClosed int64   `json:"max_lifetime_closed"`
+}
+
+func toPoolStats(stats sql.DBStats) PoolStats {
+	return PoolStats{
+		MaxOpen:           stats.MaxOpenConnections,
+		Open:              stats.OpenConnections,
+		InUse:             stats.InUse,
+		Idle:              stats.Idle,
+		WaitCount:         stats.WaitCount,
+		WaitDurationMs:    float64(stats.WaitDuration) / float64(time.Millisecond),
+		MaxIdleClosed:     stats.MaxIdleClosed,
+		MaxLifetimeClosed: stats.MaxLifetimeClosed,
+	}
+}
+
+// PoolMonitor periodically logs the usage of the connection pool since the previous report
+// and warns, with a hint for tuning the pool, when requests waited too long for connections
+type PoolMonitor struct {
+	db            *gorm.DB
+	interval      time.Duration
+	waitThreshold time.Duration
+}
+
+// NewPoolMonitor creates a new PoolMonitor
+func NewPoolMonitor(db *gorm.DB, interval, waitThreshold time.Duration) *PoolMonitor {
+	return &PoolMonitor{
+		db:            db,
+		interval:      interval,
+		waitThreshold: waitThreshold,
+	}
+}
+
+// Run reports the pool usage every interval until ctx is cancelled
+// A non-positive interval disables the reports
+func (m *PoolMonitor) Run(ctx context.Context) {
+	if m.interval <= 0 {
+		return
+	}
+	sqlDB, err := m.db.DB()
+	if err != nil {
+		slog.Error("database pool monitor disabled", "error", err)
+		return
+	}
+
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	previous := sqlDB.Stats()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		current := sqlDB.Stats()
+		m.report(previous, current)
+		previous = current
+	}
+}
+
+// report logs the pool usage between two snapshots of its statistics
+func (m *PoolMonitor) report(previous, current sql.DBStats) {
+	waits := current.WaitCount - previous.WaitCount
+	waited := current.WaitDuration - previous.WaitDuration
+	idleClosed := current.MaxIdleClosed - previous.MaxIdleClosed
+
+	attrs := []any{
+		"component", "database",
+		"max_open", current.MaxOpenConnections,
+		"open", current.OpenConnections,
+		"in_use", current.InUse,
+		"idle", current.Idle,
+		"waits", waits,
+		"wait_ms", float64(waited) / float64(time.Millisecond),
+		"max_idle_closed", idleClosed,
+	}
+
+	switch {
+	case m.waitThreshold > 0 && waited > m.waitThreshold:
+		// Requests only wait once all max_open_conns connections are in use
+		slog.Warn(fmt.Sprintf("requests waited %v for database connections in the last %v", waited.Round(time.Millisecond), m.interval),
+			append(attrs, "hint", "consider raising database.max_open_conns, within the MySQL max_connections")...)
+	case idleClosed > 0:
+		// Connections were closed after use because the idle pool was full and are reopened later
+		slog.Info("database pool report", append(attrs, "hint", "consider raising database.max_idle_conns")...)
+	default:
+		slog.Info("database pool report", attrs...)
+	}
+}
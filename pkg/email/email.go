@@ -0,0 +1,106 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"mime"
+	"net/mail"
+	"net/smtp"
+	"strings"
+
+	"survey-system/internal/config"
+)
+
+// Message represents an outgoing email
+type Message struct {
+	To      []string
+	Subject string
+	Body    string // Plain text body
+}
+
+// Sender defines the interface for sending emails
+type Sender interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+// NewSender creates an email sender from configuration
+// Falls back to a log sender when no SMTP host is configured
+func NewSender(cfg *config.EmailConfig) Sender {
+	if cfg.Host == "" {
+		return &logSender{}
+	}
+
+	port := cfg.Port
+	if port == 0 {
+		port = 587
+	}
+
+	return &smtpSender{
+		addr:     fmt.Sprintf("%s:%d", cfg.Host, port),
+		host:     cfg.Host,
+		username: cfg.Username,
+		password: cfg.Password,
+		from:     cfg.From,
+	}
+}
+
+// smtpSender sends emails through an SMTP server
+type smtpSender struct {
+	addr     string
+	host     string
+	username string
+	password string
+	from     string
+}
+
+// Send sends an email via SMTP
+func (s *smtpSender) Send(ctx context.Context, msg *Message) error {
+	if len(msg.To) == 0 {
+		return fmt.Errorf("email has no recipients")
+	}
+
+	fromAddr, err := mail.ParseAddress(s.from)
+	if err != nil {
+		return fmt.Errorf("invalid sender address: %w", err)
+	}
+
+	var auth smtp.Auth
+	if s.username != "" {
+		auth = smtp.PlainAuth("", s.username, s.password, s.host)
+	}
+
+	if err := smtp.SendMail(s.addr, auth, fromAddr.Address, msg.To, buildMessage(s.from, msg)); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return nil
+}
+
+// logSender writes emails to the log, used when SMTP is not configured
+type logSender struct{}
+
+// Send logs the email instead of sending it
+func (s *logSender) Send(ctx context.Context, msg *Message) error {
+	log.Printf("[email] to=%s subject=%q\n%s", strings.Join(msg.To, ","), msg.Subject, msg.Body)
+	return nil
+}
+
+// buildMessage builds an RFC 5322 message with UTF-8 headers and body
+func buildMessage(from string, msg *Message) []byte {
+	var b strings.Builder
+	b.WriteString("From: " + from + "\r\n")
+	b.WriteString("To: " + strings.Join(msg.To, ", ") + "\r\n")
+	b.WriteString("Subject: " + mimeEncode(msg.Subject) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	return []byte(b.String())
+}
+
+// mimeEncode encodes a header value so non-ASCII text (e.g. Chinese) is transmitted correctly
+func mimeEncode(value string) string {
+	return mime.QEncoding.Encode("utf-8", value)
+}
@@ -0,0 +1,37 @@
+package email
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// Template renders the subject and plain text body of an email from data
+type Template struct {
+	subject *template.Template
+	body    *template.Template
+}
+
+// MustTemplate parses an email template defined in code and panics on syntax errors
+func MustTemplate(name, subject, body string) *Template {
+	return &Template{
+		subject: template.Must(template.New(name + ".subject").Parse(subject)),
+		body:    template.Must(template.New(name + ".body").Parse(body)),
+	}
+}
+
+// Render builds a message to the recipients from the template and data
+func (t *Template) Render(to []string, data interface{}) (*Message, error) {
+	var subject, body strings.Builder
+	if err := t.subject.Execute(&subject, data); err != nil {
+		return nil, fmt.Errorf("failed to render email subject: %w", err)
+	}
+	if err := t.body.Execute(&body, data); err != nil {
+		return nil, fmt.Errorf("failed to render email body: %w", err)
+	}
+	return &Message{
+		To:      to,
+		Subject: subject.String(),
+		Body:    body.String(),
+	}, nil
+}
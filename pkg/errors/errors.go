@@ -1,18 +1,34 @@
 package errors
 
-import "fmt"
+import (
+	"fmt"
+
+	"survey-system/pkg/i18n"
+)
 
 // AppError represents an application error with code, message and HTTP status
+// Key and Args optionally reference an i18n message used to localize Message
 type AppError struct {
-	Code    string `json:"code"`
-	Message string `json:"message"`
-	Status  int    `json:"-"`
+	Code    string        `json:"code"`
+	Message string        `json:"message"`
+	Status  int           `json:"-"`
+	Key     string        `json:"-"`
+	Args    []interface{} `json:"-"`
 }
 
 func (e *AppError) Error() string {
 	return e.Message
 }
 
+// Localize returns the error message translated into the given language
+// Errors without a message key keep their original message
+func (e *AppError) Localize(lang string) string {
+	if e.Key == "" {
+		return e.Message
+	}
+	return i18n.T(lang, e.Key, e.Args...)
+}
+
 // NewAppError creates a new AppError
 func NewAppError(code, message string, status int) *AppError {
 	return &AppError{
@@ -24,18 +40,69 @@ func NewAppError(code, message string, status int) *AppError {
 
 // Predefined errors
 var (
-	ErrUnauthorized       = &AppError{"UNAUTHORIZED", "未授权访问", 401}
-	ErrForbidden          = &AppError{"FORBIDDEN", "禁止访问", 403}
-	ErrNotFound           = &AppError{"NOT_FOUND", "资源不存在", 404}
-	ErrInvalidToken       = &AppError{"INVALID_TOKEN", "无效的令牌", 400}
-	ErrTokenExpired       = &AppError{"TOKEN_EXPIRED", "令牌已过期", 403}
-	ErrLinkUsed           = &AppError{"LINK_USED", "链接已被使用", 403}
-	ErrValidationFailed   = &AppError{"VALIDATION_FAILED", "数据验证失败", 400}
-	ErrSurveyNotPublished = &AppError{"SURVEY_NOT_PUBLISHED", "问卷未发布", 400}
-	ErrInternalServer     = &AppError{"INTERNAL_ERROR", "服务器内部错误", 500}
-	ErrBadRequest         = &AppError{"BAD_REQUEST", "请求参数错误", 400}
+	ErrUnauthorized         = NewLocalizedError("UNAUTHORIZED", 401, "error.UNAUTHORIZED")
+	ErrForbidden            = NewLocalizedError("FORBIDDEN", 403, "error.FORBIDDEN")
+	ErrNotFound             = NewLocalizedError("NOT_FOUND", 404, "error.NOT_FOUND")
+	ErrInvalidToken         = NewLocalizedError("INVALID_TOKEN", 400, "error.INVALID_TOKEN")
+	ErrTokenExpired         = NewLocalizedError("TOKEN_EXPIRED", 403, "error.TOKEN_EXPIRED")
+	ErrLinkUsed             = NewLocalizedError("LINK_USED", 403, "error.LINK_USED")
+	ErrLinkRevoked          = NewLocalizedError("LINK_REVOKED", 403, "error.LINK_REVOKED")
+	ErrValidationFailed     = NewLocalizedError("VALIDATION_FAILED", 400, "error.VALIDATION_FAILED")
+	ErrSurveyNotPublished   = NewLocalizedError("SURVEY_NOT_PUBLISHED", 400, "error.SURVEY_NOT_PUBLISHED")
+	ErrInternalServer       = NewLocalizedError("INTERNAL_ERROR", 500, "error.INTERNAL_ERROR")
+	ErrBadRequest           = NewLocalizedError("BAD_REQUEST", 400, "error.BAD_REQUEST")
+	ErrEmbedDisabled        = NewLocalizedError("EMBED_DISABLED", 403, "error.EMBED_DISABLED")
+	ErrInvalidID            = NewLocalizedError("INVALID_ID", 400, "error.INVALID_ID")
+	ErrMissingToken         = NewLocalizedError("MISSING_TOKEN", 400, "error.MISSING_TOKEN")
+	ErrMissingAuthToken     = NewLocalizedError("UNAUTHORIZED", 401, "error.MISSING_AUTH_TOKEN")
+	ErrMalformedAuthToken   = NewLocalizedError("UNAUTHORIZED", 401, "error.MALFORMED_AUTH_TOKEN")
+	ErrInvalidAuthToken     = NewLocalizedError("UNAUTHORIZED", 401, "error.INVALID_AUTH_TOKEN")
+	ErrSessionRevoked       = NewLocalizedError("UNAUTHORIZED", 401, "error.SESSION_REVOKED")
+	ErrInvalidCredentials   = NewLocalizedError("INVALID_CREDENTIALS", 401, "error.INVALID_CREDENTIALS")
+	ErrUserNotFound         = NewLocalizedError("USER_NOT_FOUND", 404, "error.USER_NOT_FOUND")
+	ErrAccountDeactivated   = NewLocalizedError("ACCOUNT_DEACTIVATED", 403, "error.ACCOUNT_DEACTIVATED")
+	ErrUsernameExists       = NewLocalizedError("USERNAME_EXISTS", 409, "error.USERNAME_EXISTS")
+	ErrInvalidPassword      = NewLocalizedError("INVALID_PASSWORD", 400, "error.INVALID_PASSWORD")
+	ErrPayloadTooLarge      = NewLocalizedError("PAYLOAD_TOO_LARGE", 413, "error.PAYLOAD_TOO_LARGE")
+	ErrIPNotAllowed         = NewLocalizedError("IP_NOT_ALLOWED", 403, "error.IP_NOT_ALLOWED")
+	ErrQuotaFull            = NewLocalizedError("QUOTA_FULL", 403, "error.QUOTA_FULL")
+	ErrInvalidCursor        = NewLocalizedError("INVALID_CURSOR", 400, "error.INVALID_CURSOR")
+	ErrConcurrentSubmission = NewLocalizedError("CONCURRENT_SUBMISSION", 409, "error.CONCURRENT_SUBMISSION")
+	ErrInvalidExportFormat  = NewLocalizedError("INVALID_FORMAT", 400, "error.INVALID_FORMAT")
+	ErrRequestTimeout       = NewLocalizedError("REQUEST_TIMEOUT", 504, "error.REQUEST_TIMEOUT")
+	ErrSurveyArchived       = NewLocalizedError("SURVEY_ARCHIVED", 400, "error.SURVEY_ARCHIVED")
+	ErrSurveyNotArchived    = NewLocalizedError("SURVEY_NOT_ARCHIVED", 400, "error.SURVEY_NOT_ARCHIVED")
+	ErrAlreadyResponded     = NewLocalizedError("ALREADY_RESPONDED", 409, "error.ALREADY_RESPONDED")
+	ErrAlreadySubscribed    = NewLocalizedError("ALREADY_SUBSCRIBED", 409, "error.ALREADY_SUBSCRIBED")
+	ErrChannelUnreachable   = NewLocalizedError("CHANNEL_UNREACHABLE", 502, "error.CHANNEL_UNREACHABLE")
+	ErrExportNotReady       = NewLocalizedError("EXPORT_NOT_READY", 409, "error.EXPORT_NOT_READY")
+	ErrInvalidDateRange     = NewLocalizedError("INVALID_DATE_RANGE", 400, "error.INVALID_DATE_RANGE")
+	ErrTemplateNameExists   = NewLocalizedError("TEMPLATE_NAME_EXISTS", 409, "error.TEMPLATE_NAME_EXISTS")
+	ErrTooManyRequests      = NewLocalizedError("TOO_MANY_REQUESTS", 429, "error.TOO_MANY_REQUESTS")
+	ErrUsageQuotaExceeded   = NewLocalizedError("USAGE_QUOTA_EXCEEDED", 429, "error.USAGE_QUOTA_EXCEEDED")
+	ErrImpersonationDenied  = NewLocalizedError("IMPERSONATION_NOT_ALLOWED", 403, "error.IMPERSONATION_NOT_ALLOWED")
+	ErrAlreadyTeamMember    = NewLocalizedError("ALREADY_TEAM_MEMBER", 409, "error.ALREADY_TEAM_MEMBER")
+	ErrLinkPoolEmpty        = NewLocalizedError("LINK_POOL_EMPTY", 409, "error.LINK_POOL_EMPTY")
+	ErrLinkNotClaimable     = NewLocalizedError("LINK_NOT_CLAIMABLE", 409, "error.LINK_NOT_CLAIMABLE")
+	ErrTimeLimitExceeded    = NewLocalizedError("TIME_LIMIT_EXCEEDED", 403, "error.TIME_LIMIT_EXCEEDED")
+	ErrAlreadyDelivered     = NewLocalizedError("ALREADY_DELIVERED", 409, "error.ALREADY_DELIVERED")
+	ErrEmailExists          = NewLocalizedError("EMAIL_EXISTS", 409, "error.EMAIL_EXISTS")
+	ErrEmailNotVerified     = NewLocalizedError("EMAIL_NOT_VERIFIED", 403, "error.EMAIL_NOT_VERIFIED")
+	ErrRegistrationDisabled = NewLocalizedError("REGISTRATION_DISABLED", 403, "error.REGISTRATION_DISABLED")
+	ErrInvalidAccountToken  = NewLocalizedError("INVALID_TOKEN", 400, "error.INVALID_ACCOUNT_TOKEN")
 )
 
+// NewLocalizedError creates an AppError whose message is resolved from the i18n catalog
+func NewLocalizedError(code string, status int, key string, args ...interface{}) *AppError {
+	return &AppError{
+		Code:    code,
+		Message: i18n.T(i18n.DefaultLang, key, args...),
+		Status:  status,
+		Key:     key,
+		Args:    args,
+	}
+}
+
 // WrapError wraps an error with additional context
 func WrapError(err error, message string) error {
 	return fmt.Errorf("%s: %w", message, err)
@@ -49,3 +116,8 @@ func NewValidationError(field, reason string) *AppError {
 		Status:  400,
 	}
 }
+
+// NewLocalizedValidationError creates a validation error for a field with a translatable reason
+func NewLocalizedValidationError(field, reasonKey string, args ...interface{}) *AppError {
+	return NewLocalizedError("VALIDATION_FAILED", 400, "validation.field_failed", field, i18n.NewMessage(reasonKey, args...))
+}
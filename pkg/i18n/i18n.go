@@ -0,0 +1,109 @@
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Supported languages
+const (
+	LangZH = "zh"
+	LangEN = "en"
+)
+
+// DefaultLang is used when the client does not request a supported language
+const DefaultLang = LangZH
+
+// Message is a translatable message key with format arguments
+// Messages can be nested as arguments of other messages
+type Message struct {
+	Key  string
+	Args []interface{}
+}
+
+// NewMessage creates a new translatable message
+func NewMessage(key string, args ...interface{}) Message {
+	return Message{Key: key, Args: args}
+}
+
+// T translates a message key into the given language and formats it with args
+// Falls back to the default language, and finally to the key itself
+func T(lang, key string, args ...interface{}) string {
+	format, ok := lookup(lang, key)
+	if !ok {
+		return key
+	}
+
+	if len(args) == 0 {
+		return format
+	}
+
+	// Resolve nested messages in the target language
+	resolved := make([]interface{}, len(args))
+	for i, arg := range args {
+		if msg, ok := arg.(Message); ok {
+			resolved[i] = T(lang, msg.Key, msg.Args...)
+		} else {
+			resolved[i] = arg
+		}
+	}
+
+	return fmt.Sprintf(format, resolved...)
+}
+
+// lookup finds the message format for a key, falling back to the default language
+func lookup(lang, key string) (string, bool) {
+	if messages, ok := catalog[lang]; ok {
+		if format, ok := messages[key]; ok {
+			return format, true
+		}
+	}
+	format, ok := catalog[DefaultLang][key]
+	return format, ok
+}
+
+// FromAcceptLanguage picks the best supported language from an Accept-Language header
+// e.g. "en-US,en;q=0.9,zh-CN;q=0.8" returns "en"
+func FromAcceptLanguage(header string) string {
+	type candidate struct {
+		lang    string
+		quality float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(header, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		tag, params, _ := strings.Cut(part, ";")
+		quality := 1.0
+		if q, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			if v, err := strconv.ParseFloat(q, 64); err == nil {
+				quality = v
+			}
+		}
+
+		// Only the primary subtag matters for the catalog (zh-CN -> zh)
+		primary, _, _ := strings.Cut(strings.TrimSpace(tag), "-")
+		candidates = append(candidates, candidate{lang: strings.ToLower(primary), quality: quality})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].quality > candidates[j].quality
+	})
+
+	for _, c := range candidates {
+		if c.quality <= 0 {
+			continue
+		}
+		if _, ok := catalog[c.lang]; ok {
+			return c.lang
+		}
+	}
+
+	return DefaultLang
+}
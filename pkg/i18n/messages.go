@@ -0,0 +1,431 @@
+package i18n
+
+// catalog holds all translated messages by language and message key
+var catalog = map[string]map[string]string{
+	LangZH: {
+		// Predefined application errors (keyed by error code)
+		"error.UNAUTHORIZED":          "未授权访问",
+		"error.FORBIDDEN":             "禁止访问",
+		"error.NOT_FOUND":             "资源不存在",
+		"error.INVALID_TOKEN":         "无效的令牌",
+		"error.TOKEN_EXPIRED":         "令牌已过期",
+		"error.LINK_USED":             "链接已被使用",
+		"error.LINK_REVOKED":          "链接已被撤销",
+		"error.VALIDATION_FAILED":     "数据验证失败",
+		"error.SURVEY_NOT_PUBLISHED":  "问卷未发布",
+		"error.INTERNAL_ERROR":        "服务器内部错误",
+		"error.BAD_REQUEST":           "请求参数错误",
+		"error.EMBED_DISABLED":        "问卷未开启嵌入",
+		"error.INVALID_ID":            "无效的 ID",
+		"error.MISSING_TOKEN":         "缺少 token 参数",
+		"error.MISSING_AUTH_TOKEN":    "未授权访问：缺少认证令牌",
+		"error.MALFORMED_AUTH_TOKEN":  "未授权访问：令牌格式错误",
+		"error.INVALID_AUTH_TOKEN":    "未授权访问：令牌无效或已过期",
+		"error.SESSION_REVOKED":       "未授权访问：登录会话已被注销",
+		"error.INVALID_CREDENTIALS":   "用户名或密码错误",
+		"error.USER_NOT_FOUND":        "用户不存在",
+		"error.ACCOUNT_DEACTIVATED":   "账号已停用",
+		"error.USERNAME_EXISTS":       "用户名已存在",
+		"error.INVALID_PASSWORD":      "旧密码不正确",
+		"error.NO_PROFILE_FIELDS":     "至少需要提供一个要更新的字段",
+		"error.OLD_PASSWORD_REQUIRED": "修改密码需要提供旧密码",
+		"error.PAYLOAD_TOO_LARGE":     "请求体过大",
+		"error.IP_NOT_ALLOWED":        "当前网络不允许访问该问卷",
+		"error.QUOTA_FULL":            "该问卷在您所属分组的名额已满",
+		"error.INVALID_CURSOR":        "无效的分页游标",
+		"error.CONCURRENT_SUBMISSION": "请勿重复提交",
+		"error.INVALID_FORMAT":        "不支持的导出格式",
+		"error.REQUEST_TIMEOUT":       "请求处理超时，请稍后重试",
+		"error.SURVEY_ARCHIVED":       "问卷已归档，请先取消归档",
+		"error.SURVEY_NOT_ARCHIVED":   "问卷未归档",
+		"error.ALREADY_RESPONDED":     "您已填写过该问卷",
+		"error.ALREADY_SUBSCRIBED":    "该邮箱已订阅此频率的报告",
+		"error.CHANNEL_UNREACHABLE":   "通知渠道消息发送失败，请检查 Webhook 地址和密钥",
+		"error.EXPORT_NOT_READY":      "导出任务尚未完成",
+		"error.INVALID_DATE_RANGE":    "结束日期不能早于开始日期",
+		"error.TEMPLATE_NAME_EXISTS":  "该问卷已有同名的链接模板",
+		"error.TOO_MANY_REQUESTS":     "请求过于频繁，请稍后重试",
+		"error.USAGE_QUOTA_EXCEEDED":  "已达到今日使用额度，请明天再试",
+
+		// Impersonation
+		"error.IMPERSONATION_NOT_ALLOWED": "代登录期间不能执行该操作",
+
+		// Teams
+		"error.ALREADY_TEAM_MEMBER": "该用户已是团队成员",
+
+		// Link pools
+		"error.LINK_POOL_EMPTY":    "链接池中没有可领取的链接",
+		"error.LINK_NOT_CLAIMABLE": "该链接不属于链接池，或已被领取、使用、撤销或已过期",
+
+		// Timed responses
+		"error.TIME_LIMIT_EXCEEDED": "答题时间已用完",
+
+		// Hook deliveries
+		"error.ALREADY_DELIVERED": "该事件已成功投递，不能重试",
+
+		// Account verification and password reset
+		"error.EMAIL_EXISTS":          "该邮箱已被注册",
+		"error.EMAIL_NOT_VERIFIED":    "邮箱尚未验证，请点击验证邮件中的链接，或重新发送验证邮件",
+		"error.REGISTRATION_DISABLED": "未开放注册",
+		"error.INVALID_ACCOUNT_TOKEN": "链接无效、已使用或已过期",
+
+		// Generic field validation
+		"validation.field_failed": "字段 '%s' 验证失败: %s",
+
+		// Request binding rules (go-playground/validator tags)
+		"validation.rule.required": "为必填项",
+		"validation.rule.min":      "长度或数值不能小于 %s",
+		"validation.rule.max":      "长度或数值不能大于 %s",
+		"validation.rule.email":    "必须是有效的邮箱地址",
+		"validation.rule.oneof":    "必须是以下值之一: %s",
+		"validation.rule.url":      "必须是有效的 URL",
+		"validation.rule.datetime": "必须是 YYYY-MM-DD 格式的日期",
+		"validation.rule.default":  "未通过 %s 校验",
+
+		// Response answer validation
+		"validation.required_unanswered":         "必填题目 '%s' 未回答",
+		"validation.question_not_found":          "题目 ID %d 不存在",
+		"validation.duplicate_answer":            "题目 ID %d 的答案重复提交",
+		"validation.unsupported_question_type":   "不支持的题目类型: %s",
+		"validation.answer_must_be_string":       "题目 '%s' 的答案必须是字符串",
+		"validation.answer_must_be_string_array": "题目 '%s' 的答案必须是字符串数组",
+		"validation.answer_must_be_array":        "题目 '%s' 的答案必须是数组",
+		"validation.answer_not_in_options":       "题目 '%s' 的答案 '%s' 不在选项中",
+		"validation.table_min_rows":              "题目 '%s' 至少需要 %d 行，当前只有 %d 行",
+		"validation.table_max_rows":              "题目 '%s' 最多允许 %d 行，当前有 %d 行",
+		"validation.table_row_not_array":         "题目 '%s' 第 %d 行格式错误，应为数组",
+		"validation.table_column_count":          "题目 '%s' 第 %d 行列数错误，期望 %d 列，实际 %d 列",
+		"validation.cell_must_be_string":         "题目 '%s' 第 %d 行列 '%s' 必须是字符串",
+		"validation.cell_must_be_number":         "题目 '%s' 第 %d 行列 '%s' 必须是有效的数字",
+		"validation.cell_not_in_options":         "题目 '%s' 第 %d 行列 '%s' 的值 '%s' 不在选项中",
+		"validation.cell_not_in_cascade":         "题目 '%s' 第 %d 行列 '%s' 的值 '%s' 不是 '%s' 的下级选项",
+		"validation.table_totals_missing":        "题目 '%s' 的答案必须以合计行结尾",
+		"validation.table_total_mismatch":        "题目 '%s' 列 '%s' 的合计 '%s' 与各行之和 %s 不符",
+		"validation.text_too_long":               "题目 '%s' 的答案不能超过 %d 个字符",
+		"validation.nps_out_of_range":            "题目 '%s' 的答案必须是 0 到 10 之间的整数",
+		"validation.answer_must_be_number":       "题目 '%s' 的答案必须是数字",
+		"validation.slider_out_of_range":         "题目 '%s' 的答案必须在 %s 到 %s 之间",
+		"validation.slider_step_mismatch":        "题目 '%s' 的答案必须是步长 %s 的整数倍",
+		"validation.cell_too_long":               "题目 '%s' 第 %d 行列 '%s' 不能超过 %d 个字符",
+		"validation.prefill_locked":              "题目 '%s' 的预填答案不能修改",
+		"validation.hidden_question_answered":    "题目 ID %d 不能作答",
+
+		// Question configuration validation
+		"question.options_required":          "单选题和多选题至少需要一个选项",
+		"question.option_label_required":     "选项文本不能为空",
+		"question.option_id_duplicate":       "选项 ID '%s' 重复",
+		"question.option_image_invalid":      "选项图片必须是 http 或 https 地址",
+		"question.columns_required":          "表格题至少需要一列",
+		"question.column_id_required":        "列 ID 不能为空",
+		"question.column_type_required":      "列类型不能为空",
+		"question.column_type_invalid":       "列类型必须是 text、number 或 select",
+		"question.column_label_required":     "列名称不能为空",
+		"question.select_options_required":   "下拉列至少需要一个选项",
+		"question.min_rows_negative":         "min_rows 不能为负数",
+		"question.max_rows_negative":         "max_rows 不能为负数",
+		"question.min_rows_exceeds_max":      "min_rows 不能大于 max_rows",
+		"question.invalid_type":              "无效的题目类型: %s",
+		"question.max_length_negative":       "max_length 不能为负数",
+		"question.slider_min_required":       "滑块题必须设置最小值",
+		"question.slider_max_required":       "滑块题必须设置最大值",
+		"question.slider_min_exceeds_max":    "最小值必须小于最大值",
+		"question.slider_step_invalid":       "步长不能为负数且不能超过取值范围",
+		"question.prefill_type_requires_key": "设置预填类型时必须同时设置 prefill_key",
+		"question.prefill_option_not_choice": "只有单选题和多选题可以使用 option 预填类型",
+		"question.lock_prefill_requires_key": "锁定预填答案时必须设置 prefill_key",
+		"question.hidden_requires_key":       "隐藏题目必须设置 prefill_key",
+
+		// Cascade table columns
+		"question.cascade_depends_on_required": "级联列必须设置 depends_on",
+		"question.cascade_parent_invalid":      "depends_on '%s' 必须是该列之前的下拉列或级联列",
+		"question.cascade_options_required":    "级联列至少需要为一个上级选项设置下级选项",
+		"question.cascade_unknown_value":       "'%s' 不是列 '%s' 的选项",
+
+		// Table column display and defaults
+		"question.column_width_negative":    "列宽度不能为负数",
+		"question.column_default_required":  "设置 apply_default 时必须同时设置 default",
+		"question.column_default_invalid":   "默认值 '%s' 不符合列类型或不在选项中",
+		"question.column_total_not_number":  "只有 number 列可以设置 total",
+		"question.total_tolerance_negative": "total_tolerance 不能为负数",
+
+		// Option import
+		"question.option_import_not_choice":    "只有单选题和多选题可以导入选项",
+		"question.option_set_unknown":          "选项集 '%s' 不存在或未启用",
+		"question.options_too_many":            "每道题最多 %d 个选项",
+		"question.option_csv_unreadable":       "CSV 无法解析: %s",
+		"question.option_csv_too_many_columns": "第 %d 行最多包含 id、label、score 三列",
+		"question.option_csv_label_required":   "第 %d 行的选项文本不能为空",
+		"question.option_csv_score_invalid":    "第 %d 行的分值 '%s' 不是有效数字",
+		"question.option_csv_empty":            "CSV 中没有选项",
+
+		// Removed options
+		"question.options_in_use":           "选项 %s 已被 %d 份填答选择，请通过 option_remap 指定替代选项，或设置 force_option_removal 强制删除",
+		"question.remap_option_not_removed": "选项 '%s' 未被删除，不能重新映射",
+		"question.remap_option_unknown":     "'%s' 不是更新后题目的选项",
+
+		// Question pools
+		"question.pool_unknown": "问卷没有名为 '%s' 的题目池",
+		"question.pool_hidden":  "隐藏题目不能加入题目池",
+
+		// Survey variants
+		"question.variant_unknown": "问卷没有名为 '%s' 的变体",
+
+		// Assessments
+		"question.correct_required":       "设置分值的题目必须设置正确答案",
+		"question.points_required":        "设置正确答案的题目分值必须大于 0",
+		"question.correct_not_supported":  "'%s' 类型的题目不支持正确答案",
+		"question.correct_option_unknown": "正确答案引用了不存在的选项 '%s'",
+
+		// Statistics queries
+		"statistics.not_choice_question":   "题目 '%s' 不是单选题或多选题",
+		"statistics.compare_base_required": "需要提供对比问卷或对比日期范围",
+		"statistics.not_assessment":        "问卷未开启测评模式",
+
+		// Response import
+		"import.file_required":        "请通过 file 字段上传 CSV 或 XLSX 文件",
+		"import.unsupported_file":     "只支持 .csv 和 .xlsx 文件",
+		"import.unreadable_file":      "文件无法解析: %s",
+		"import.empty_file":           "文件中没有表头行",
+		"import.too_many_rows":        "文件最多包含 %d 行数据",
+		"import.no_question_columns":  "没有与题目标题匹配的列",
+		"import.duplicate_column":     "列 '%s' 与其他列对应同一题目或字段",
+		"import.ambiguous_column":     "有多道题目的标题为 '%s'，无法匹配列",
+		"import.no_answers":           "该行没有任何答案",
+		"import.invalid_submitted_at": "无法识别的提交时间 '%s'",
+		"import.invalid_ip":           "无效的 IP 地址 '%s'",
+		"import.value_too_long":       "列 '%s' 的值不能超过 %d 个字符",
+		"import.duplicate_respondent": "填答者 '%s' 在文件中出现多次",
+		"import.respondent_exists":    "填答者 '%s' 已填写过该问卷",
+
+		// Response versions
+		"response.version_range_invalid": "版本范围无效，需满足 1 ≤ from < to ≤ %d",
+
+		// Response review
+		"response.review_selection_required": "需提供 ids 或 filter 之一（不可同时提供）",
+
+		// Team membership
+		"team.owner_not_removable": "团队所有者不能被移出团队",
+
+		// Feature flags
+		"feature.disabled": "功能 %s 未开启",
+
+		// Question limits
+		"question.questions_too_many":      "每份问卷最多 %d 道题",
+		"question.columns_too_many":        "表格题最多 %d 列",
+		"question.column_options_too_many": "每列最多 %d 个选项",
+		"question.rows_too_many":           "表格题最多允许 %d 行",
+	},
+	LangEN: {
+		// Predefined application errors (keyed by error code)
+		"error.UNAUTHORIZED":          "Unauthorized",
+		"error.FORBIDDEN":             "Forbidden",
+		"error.NOT_FOUND":             "Resource not found",
+		"error.INVALID_TOKEN":         "Invalid token",
+		"error.TOKEN_EXPIRED":         "Token has expired",
+		"error.LINK_USED":             "Link has already been used",
+		"error.LINK_REVOKED":          "Link has been revoked",
+		"error.VALIDATION_FAILED":     "Validation failed",
+		"error.SURVEY_NOT_PUBLISHED":  "Survey is not published",
+		"error.INTERNAL_ERROR":        "Internal server error",
+		"error.BAD_REQUEST":           "Bad request",
+		"error.EMBED_DISABLED":        "Embedding is not enabled for this survey",
+		"error.INVALID_ID":            "Invalid ID",
+		"error.MISSING_TOKEN":         "Token parameter is required",
+		"error.MISSING_AUTH_TOKEN":    "Unauthorized: missing authentication token",
+		"error.MALFORMED_AUTH_TOKEN":  "Unauthorized: malformed authentication token",
+		"error.INVALID_AUTH_TOKEN":    "Unauthorized: token is invalid or expired",
+		"error.SESSION_REVOKED":       "Unauthorized: the session has been revoked",
+		"error.INVALID_CREDENTIALS":   "Invalid username or password",
+		"error.USER_NOT_FOUND":        "User not found",
+		"error.ACCOUNT_DEACTIVATED":   "Account has been deactivated",
+		"error.USERNAME_EXISTS":       "Username already exists",
+		"error.INVALID_PASSWORD":      "Old password is incorrect",
+		"error.NO_PROFILE_FIELDS":     "At least one field must be provided",
+		"error.OLD_PASSWORD_REQUIRED": "Old password is required to change the password",
+		"error.PAYLOAD_TOO_LARGE":     "Request body is too large",
+		"error.IP_NOT_ALLOWED":        "This survey is not available from your network",
+		"error.QUOTA_FULL":            "The response quota for your group has been reached",
+		"error.INVALID_CURSOR":        "Invalid pagination cursor",
+		"error.CONCURRENT_SUBMISSION": "Submission already in progress",
+		"error.INVALID_FORMAT":        "Unsupported export format",
+		"error.REQUEST_TIMEOUT":       "The request timed out, please try again later",
+		"error.SURVEY_ARCHIVED":       "Survey is archived, unarchive it first",
+		"error.SURVEY_NOT_ARCHIVED":   "Survey is not archived",
+		"error.ALREADY_RESPONDED":     "You have already responded to this survey",
+		"error.ALREADY_SUBSCRIBED":    "This email is already subscribed to reports at this frequency",
+		"error.CHANNEL_UNREACHABLE":   "Failed to deliver the message to the notification channel, check its webhook URL and secret",
+		"error.EXPORT_NOT_READY":      "The export job has not completed",
+		"error.INVALID_DATE_RANGE":    "The end date must not be before the start date",
+		"error.TEMPLATE_NAME_EXISTS":  "The survey already has a link template with this name",
+		"error.TOO_MANY_REQUESTS":     "Too many requests, please try again later",
+		"error.USAGE_QUOTA_EXCEEDED":  "Your daily usage quota has been reached, please try again tomorrow",
+
+		// Impersonation
+		"error.IMPERSONATION_NOT_ALLOWED": "This action is not allowed while impersonating a user",
+
+		// Teams
+		"error.ALREADY_TEAM_MEMBER": "The user is already a member of the team",
+
+		// Link pools
+		"error.LINK_POOL_EMPTY":    "The link pool has no links left to claim",
+		"error.LINK_NOT_CLAIMABLE": "The link is not a pool link, or was already claimed, used, revoked or has expired",
+
+		// Timed responses
+		"error.TIME_LIMIT_EXCEEDED": "The time limit for answering has been exceeded",
+
+		// Hook deliveries
+		"error.ALREADY_DELIVERED": "The event was already delivered and cannot be retried",
+
+		// Account verification and password reset
+		"error.EMAIL_EXISTS":          "Email is already registered",
+		"error.EMAIL_NOT_VERIFIED":    "Email address is not verified; follow the link in the verification email or request a new one",
+		"error.REGISTRATION_DISABLED": "Registration is not open",
+		"error.INVALID_ACCOUNT_TOKEN": "The link is invalid, used or expired",
+
+		// Generic field validation
+		"validation.field_failed": "validation failed for field '%s': %s",
+
+		// Request binding rules (go-playground/validator tags)
+		"validation.rule.required": "is required",
+		"validation.rule.min":      "must be at least %s",
+		"validation.rule.max":      "must be at most %s",
+		"validation.rule.email":    "must be a valid email address",
+		"validation.rule.oneof":    "must be one of: %s",
+		"validation.rule.url":      "must be a valid URL",
+		"validation.rule.datetime": "must be a date in YYYY-MM-DD format",
+		"validation.rule.default":  "failed the '%s' rule",
+
+		// Response answer validation
+		"validation.required_unanswered":         "Required question '%s' is not answered",
+		"validation.question_not_found":          "Question ID %d does not exist",
+		"validation.duplicate_answer":            "Question ID %d is answered more than once",
+		"validation.unsupported_question_type":   "Unsupported question type: %s",
+		"validation.answer_must_be_string":       "Answer to question '%s' must be a string",
+		"validation.answer_must_be_string_array": "Answer to question '%s' must be an array of strings",
+		"validation.answer_must_be_array":        "Answer to question '%s' must be an array",
+		"validation.answer_not_in_options":       "Answer '%[2]s' to question '%[1]s' is not one of the options",
+		"validation.table_min_rows":              "Question '%s' requires at least %d rows, got %d",
+		"validation.table_max_rows":              "Question '%s' allows at most %d rows, got %d",
+		"validation.table_row_not_array":         "Question '%s' row %d must be an array",
+		"validation.table_column_count":          "Question '%s' row %d has the wrong number of columns, expected %d, got %d",
+		"validation.cell_must_be_string":         "Question '%s' row %d column '%s' must be a string",
+		"validation.cell_must_be_number":         "Question '%s' row %d column '%s' must be a valid number",
+		"validation.cell_not_in_options":         "Question '%s' row %d column '%s' value '%s' is not one of the options",
+		"validation.cell_not_in_cascade":         "Question '%s' row %d column '%s' value '%s' is not an option under '%s'",
+		"validation.table_totals_missing":        "Question '%s' answer must end with a totals row",
+		"validation.table_total_mismatch":        "Question '%s' column '%s' total '%s' does not match the sum of its rows %s",
+		"validation.text_too_long":               "Answer to question '%s' must not exceed %d characters",
+		"validation.nps_out_of_range":            "Answer to question '%s' must be an integer from 0 to 10",
+		"validation.answer_must_be_number":       "Answer to question '%s' must be a number",
+		"validation.slider_out_of_range":         "Answer to question '%s' must be between %s and %s",
+		"validation.slider_step_mismatch":        "Answer to question '%s' must be a multiple of the step %s",
+		"validation.cell_too_long":               "Question '%s' row %d column '%s' must not exceed %d characters",
+		"validation.prefill_locked":              "The prefilled answer of question '%s' cannot be changed",
+		"validation.hidden_question_answered":    "Question ID %d cannot be answered",
+
+		// Question configuration validation
+		"question.options_required":          "single and multiple choice questions must have at least one option",
+		"question.option_label_required":     "option label is required",
+		"question.option_id_duplicate":       "option ID '%s' is duplicated",
+		"question.option_image_invalid":      "option image must be an http or https URL",
+		"question.columns_required":          "table questions must have at least one column",
+		"question.column_id_required":        "column ID is required",
+		"question.column_type_required":      "column type is required",
+		"question.column_type_invalid":       "column type must be text, number, or select",
+		"question.column_label_required":     "column label is required",
+		"question.select_options_required":   "select columns must have at least one option",
+		"question.min_rows_negative":         "min_rows cannot be negative",
+		"question.max_rows_negative":         "max_rows cannot be negative",
+		"question.min_rows_exceeds_max":      "min_rows cannot be greater than max_rows",
+		"question.invalid_type":              "invalid question type: %s",
+		"question.max_length_negative":       "max_length cannot be negative",
+		"question.slider_min_required":       "slider questions must have a min value",
+		"question.slider_max_required":       "slider questions must have a max value",
+		"question.slider_min_exceeds_max":    "min must be less than max",
+		"question.slider_step_invalid":       "step cannot be negative or exceed the value range",
+		"question.prefill_type_requires_key": "prefill_type requires a prefill_key",
+		"question.prefill_option_not_choice": "only single and multiple choice questions can use the option prefill type",
+		"question.lock_prefill_requires_key": "lock_prefill requires a prefill_key",
+		"question.hidden_requires_key":       "hidden questions require a prefill_key",
+
+		// Cascade table columns
+		"question.cascade_depends_on_required": "cascade columns require depends_on",
+		"question.cascade_parent_invalid":      "depends_on '%s' must be a select or cascade column before this column",
+		"question.cascade_options_required":    "cascade columns must map at least one parent option to options",
+		"question.cascade_unknown_value":       "'%s' is not an option of column '%s'",
+
+		// Table column display and defaults
+		"question.column_width_negative":    "column width cannot be negative",
+		"question.column_default_required":  "apply_default requires a default",
+		"question.column_default_invalid":   "default '%s' does not match the column type or options",
+		"question.column_total_not_number":  "only number columns can have a total",
+		"question.total_tolerance_negative": "total_tolerance cannot be negative",
+
+		// Option import
+		"question.option_import_not_choice":    "options can only be imported into single and multiple choice questions",
+		"question.option_set_unknown":          "option set '%s' does not exist or is not enabled",
+		"question.options_too_many":            "a question can have at most %d options",
+		"question.option_csv_unreadable":       "CSV cannot be parsed: %s",
+		"question.option_csv_too_many_columns": "line %d has more than the id, label and score columns",
+		"question.option_csv_label_required":   "line %d has an empty option label",
+		"question.option_csv_score_invalid":    "line %d has an invalid score '%s'",
+		"question.option_csv_empty":            "CSV contains no options",
+
+		// Removed options
+		"question.options_in_use":           "options %s were chosen in %d responses; map them to other options with option_remap or set force_option_removal",
+		"question.remap_option_not_removed": "option '%s' is not removed and cannot be remapped",
+		"question.remap_option_unknown":     "'%s' is not an option of the updated question",
+
+		// Question pools
+		"question.pool_unknown": "the survey has no question pool '%s'",
+		"question.pool_hidden":  "hidden questions cannot be part of a question pool",
+
+		// Survey variants
+		"question.variant_unknown": "the survey has no variant '%s'",
+
+		// Assessments
+		"question.correct_required":       "questions with points need a correct answer",
+		"question.points_required":        "questions with a correct answer need points greater than 0",
+		"question.correct_not_supported":  "questions of type '%s' cannot have a correct answer",
+		"question.correct_option_unknown": "the correct answer references the unknown option '%s'",
+
+		// Statistics queries
+		"statistics.not_choice_question":   "Question '%s' is not a single or multiple choice question",
+		"statistics.compare_base_required": "a base survey or base date range is required",
+		"statistics.not_assessment":        "the survey is not an assessment",
+
+		// Response import
+		"import.file_required":        "upload a CSV or XLSX file in the file field",
+		"import.unsupported_file":     "only .csv and .xlsx files are supported",
+		"import.unreadable_file":      "the file cannot be read: %s",
+		"import.empty_file":           "the file has no header row",
+		"import.too_many_rows":        "the file may contain at most %d data rows",
+		"import.no_question_columns":  "no column matches a question title",
+		"import.duplicate_column":     "column '%s' maps to the same question or field as another column",
+		"import.ambiguous_column":     "several questions are titled '%s', so the column cannot be matched",
+		"import.no_answers":           "the row has no answers",
+		"import.invalid_submitted_at": "unrecognized submission time '%s'",
+		"import.invalid_ip":           "invalid IP address '%s'",
+		"import.value_too_long":       "values of column '%s' cannot exceed %d characters",
+		"import.duplicate_respondent": "respondent '%s' appears more than once in the file",
+		"import.respondent_exists":    "respondent '%s' has already responded to this survey",
+
+		// Response versions
+		"response.version_range_invalid": "invalid version range, expected 1 <= from < to <= %d",
+
+		// Response review
+		"response.review_selection_required": "exactly one of ids or filter is required",
+
+		// Team membership
+		"team.owner_not_removable": "the team owner cannot be removed from the team",
+
+		// Feature flags
+		"feature.disabled": "The %s feature is not enabled",
+
+		// Question limits
+		"question.questions_too_many":      "a survey can have at most %d questions",
+		"question.columns_too_many":        "a table question can have at most %d columns",
+		"question.column_options_too_many": "a column can have at most %d options",
+		"question.rows_too_many":           "a table question can allow at most %d rows",
+	},
+}
@@ -0,0 +1,42 @@
+package markdown
+
+import (
+	"bytes"
+	"log"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+)
+
+var (
+	// renderer converts Markdown to HTML; raw HTML in the source is escaped, not passed through
+	renderer = goldmark.New(goldmark.WithExtensions(extension.GFM))
+
+	// policy removes anything that could execute script from the rendered HTML
+	policy = newPolicy()
+)
+
+// newPolicy allows common formatting and links, forcing links to open safely in a new tab
+func newPolicy() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+	p.RequireNoFollowOnLinks(true)
+	p.AddTargetBlankToFullyQualifiedLinks(true)
+	return p
+}
+
+// ToSafeHTML renders Markdown to sanitized HTML
+// Returns an empty string for empty input
+func ToSafeHTML(source string) string {
+	if source == "" {
+		return ""
+	}
+
+	var buf bytes.Buffer
+	if err := renderer.Convert([]byte(source), &buf); err != nil {
+		log.Printf("failed to render markdown: %v", err)
+		return policy.Sanitize(source)
+	}
+
+	return policy.Sanitize(buf.String())
+}
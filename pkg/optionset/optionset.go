@@ -0,0 +1,98 @@
+// Package optionset provides built-in lists of choice options, such as countries and
+// provinces, that can be imported into choice questions instead of typing them in.
+package optionset
+
+import (
+	"bytes"
+	"embed"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// sets holds the built-in option sets, one "id,label" CSV file per set
+//
+//go:embed sets/*.csv
+var sets embed.FS
+
+// Option is a choice option of a set
+type Option struct {
+	ID    string
+	Label string
+}
+
+// Set is a named list of options
+type Set struct {
+	Name    string
+	Options []Option
+}
+
+// Names returns the names of all built-in sets in alphabetical order
+func Names() []string {
+	entries, _ := sets.ReadDir("sets")
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), ".csv"))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Library is the collection of sets offered for import
+type Library struct {
+	sets map[string]*Set
+	// names keeps the configured order for listing
+	names []string
+}
+
+// NewLibrary loads the named built-in sets
+// Returns an error if a name is not a built-in set
+func NewLibrary(names []string) (*Library, error) {
+	lib := &Library{sets: make(map[string]*Set, len(names))}
+	for _, name := range names {
+		if _, ok := lib.sets[name]; ok {
+			continue
+		}
+		set, err := load(name)
+		if err != nil {
+			return nil, err
+		}
+		lib.sets[name] = set
+		lib.names = append(lib.names, name)
+	}
+	return lib, nil
+}
+
+// Get returns the set with the given name, or false if it is not offered
+func (l *Library) Get(name string) (*Set, bool) {
+	set, ok := l.sets[name]
+	return set, ok
+}
+
+// Sets returns the offered sets in configured order
+func (l *Library) Sets() []*Set {
+	result := make([]*Set, len(l.names))
+	for i, name := range l.names {
+		result[i] = l.sets[name]
+	}
+	return result
+}
+
+// load parses a built-in set, skipping its header row
+func load(name string) (*Set, error) {
+	data, err := sets.ReadFile("sets/" + name + ".csv")
+	if err != nil {
+		return nil, fmt.Errorf("unknown option set %q", name)
+	}
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse option set %q: %w", name, err)
+	}
+
+	set := &Set{Name: name, Options: make([]Option, 0, len(records))}
+	for _, record := range records[1:] {
+		set.Options = append(set.Options, Option{ID: record[0], Label: record[1]})
+	}
+	return set, nil
+}
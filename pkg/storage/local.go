@@ -0,0 +1,207 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Errors returned when checking a signed local download link
+var (
+	ErrInvalidSignature = errors.New("storage: invalid download signature")
+	ErrLinkExpired      = errors.New("storage: download link expired")
+)
+
+// Local stores objects as files below a directory and signs download links
+// that are served by this API under /api/v1/files
+type Local struct {
+	dir        string
+	publicURL  string
+	signingKey []byte
+}
+
+// NewLocal creates a local disk driver, creating dir if needed
+func NewLocal(dir, publicURL, signingKey string) (*Local, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create storage directory: %w", err)
+	}
+	return &Local{
+		dir:        dir,
+		publicURL:  strings.TrimRight(publicURL, "/"),
+		signingKey: []byte(signingKey),
+	}, nil
+}
+
+// path returns the file path of a key
+func (l *Local) path(key string) (string, error) {
+	if !validKey(key) {
+		return "", fmt.Errorf("invalid storage key %q", key)
+	}
+	return filepath.Join(l.dir, filepath.FromSlash(key)), nil
+}
+
+// Put writes an object; the content type is derived from the key when served
+func (l *Local) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	path, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create storage directory: %w", err)
+	}
+
+	// Write to a temporary file first so readers never see a partial object
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o640); err != nil {
+		return fmt.Errorf("failed to write object: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write object: %w", err)
+	}
+	return nil
+}
+
+// Get reads an object
+func (l *Local) Get(ctx context.Context, key string) ([]byte, error) {
+	path, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return data, err
+}
+
+// Open opens an object file for reading
+func (l *Local) Open(ctx context.Context, key string) (Object, error) {
+	path, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &localObject{File: file, info: info}, nil
+}
+
+// localObject is an object file opened by Local.Open
+type localObject struct {
+	*os.File
+	info fs.FileInfo
+}
+
+// Size returns the file size in bytes
+func (o *localObject) Size() int64 {
+	return o.info.Size()
+}
+
+// ModTime returns when the file was last written
+func (o *localObject) ModTime() time.Time {
+	return o.info.ModTime()
+}
+
+// Delete removes an object; removing a missing object is not an error
+func (l *Local) Delete(ctx context.Context, key string) error {
+	path, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete object: %w", err)
+	}
+	return nil
+}
+
+// PresignGet returns a signed link to the file download endpoint of this API
+func (l *Local) PresignGet(ctx context.Context, key, filename string, expires time.Duration) (string, error) {
+	if !validKey(key) {
+		return "", fmt.Errorf("invalid storage key %q", key)
+	}
+	expiresAt := time.Now().Add(expires).Unix()
+
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(expiresAt, 10))
+	query.Set("filename", filename)
+	query.Set("signature", l.sign(key, filename, expiresAt))
+	return fmt.Sprintf("%s/api/v1/files/%s?%s", l.publicURL, key, query.Encode()), nil
+}
+
+// Verify checks the signature and expiry of a download link created by PresignGet
+func (l *Local) Verify(key, filename string, expiresAt int64, signature string) error {
+	expected := l.sign(key, filename, expiresAt)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return ErrInvalidSignature
+	}
+	if time.Now().Unix() > expiresAt {
+		return ErrLinkExpired
+	}
+	return nil
+}
+
+// sign computes the HMAC-SHA256 signature of a download link
+func (l *Local) sign(key, filename string, expiresAt int64) string {
+	mac := hmac.New(sha256.New, l.signingKey)
+	fmt.Fprintf(mac, "%s\n%s\n%d", key, filename, expiresAt)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// DeleteOlderThan removes files under prefix modified before the cutoff
+func (l *Local) DeleteOlderThan(ctx context.Context, prefix string, before time.Time) (int, error) {
+	root := l.dir
+	if prefix != "" {
+		path, err := l.path(strings.TrimSuffix(prefix, "/"))
+		if err != nil {
+			return 0, err
+		}
+		root = path
+	}
+
+	deleted := 0
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		if info.ModTime().Before(before) {
+			if err := os.Remove(path); err == nil {
+				deleted++
+			}
+		}
+		return nil
+	})
+	return deleted, err
+}
@@ -0,0 +1,94 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"survey-system/internal/config"
+)
+
+// ErrNotFound is returned when an object does not exist
+var ErrNotFound = errors.New("storage: object not found")
+
+// Storage stores generated files such as exports under slash-separated keys
+type Storage interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	Get(ctx context.Context, key string) ([]byte, error)
+
+	// Open returns an object that reads from any offset, e.g. to serve HTTP Range requests
+	// without loading the whole file; the caller must close it
+	Open(ctx context.Context, key string) (Object, error)
+
+	Delete(ctx context.Context, key string) error
+
+	// PresignGet returns a URL that downloads the object as filename until it expires
+	PresignGet(ctx context.Context, key, filename string, expires time.Duration) (string, error)
+
+	// DeleteOlderThan removes the objects under prefix last modified before the cutoff
+	// and returns how many were removed
+	DeleteOlderThan(ctx context.Context, prefix string, before time.Time) (int, error)
+}
+
+// Object is an opened stored object
+type Object interface {
+	io.ReadSeekCloser
+	Size() int64
+	ModTime() time.Time
+}
+
+// New creates the storage driver selected by the configuration
+func New(cfg *config.StorageConfig) (Storage, error) {
+	switch cfg.Driver {
+	case "local":
+		return NewLocal(cfg.LocalDir, cfg.PublicURL, cfg.SigningKey)
+	case "s3":
+		return NewS3(&cfg.S3), nil
+	default:
+		return nil, fmt.Errorf("unsupported storage driver %q", cfg.Driver)
+	}
+}
+
+// validKey reports whether a key is a relative slash-separated path without
+// empty, "." or ".." segments, so it cannot escape the storage root
+func validKey(key string) bool {
+	if key == "" || strings.ContainsAny(key, "\\\x00") {
+		return false
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return false
+		}
+	}
+	return true
+}
+
+// contentDisposition returns an attachment header value for filename
+// Non-ASCII names are sent in the RFC 5987 filename* parameter
+func contentDisposition(filename string) string {
+	ascii := strings.Map(func(r rune) rune {
+		if r < 0x20 || r > 0x7e || r == '"' || r == '\\' {
+			return '_'
+		}
+		return r
+	}, filename)
+	return fmt.Sprintf(`attachment; filename="%s"; filename*=UTF-8''%s`, ascii, encodeURIComponent(filename))
+}
+
+// encodeURIComponent percent-encodes everything except RFC 3986 unreserved characters
+func encodeURIComponent(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if ('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z') || ('0' <= c && c <= '9') ||
+			c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
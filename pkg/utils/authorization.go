@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"context"
 	"errors"
 	"survey-system/internal/model"
 	"survey-system/internal/repository"
@@ -23,19 +24,21 @@ var (
 type AuthorizationUtil struct {
 	surveyRepo   repository.SurveyRepository
 	questionRepo repository.QuestionRepository
+	teamRepo     repository.TeamRepository
 }
 
 // NewAuthorizationUtil creates a new authorization utility instance
-func NewAuthorizationUtil(surveyRepo repository.SurveyRepository, questionRepo repository.QuestionRepository) *AuthorizationUtil {
+func NewAuthorizationUtil(surveyRepo repository.SurveyRepository, questionRepo repository.QuestionRepository, teamRepo repository.TeamRepository) *AuthorizationUtil {
 	return &AuthorizationUtil{
 		surveyRepo:   surveyRepo,
 		questionRepo: questionRepo,
+		teamRepo:     teamRepo,
 	}
 }
 
 // CheckSurveyOwnership verifies that the user owns the specified survey
-func (a *AuthorizationUtil) CheckSurveyOwnership(userID, surveyID uint) error {
-	survey, err := a.surveyRepo.FindByID(surveyID)
+func (a *AuthorizationUtil) CheckSurveyOwnership(ctx context.Context, userID, surveyID uint) error {
+	survey, err := a.surveyRepo.FindByID(ctx, surveyID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return ErrSurveyNotFound
@@ -51,8 +54,8 @@ func (a *AuthorizationUtil) CheckSurveyOwnership(userID, surveyID uint) error {
 }
 
 // CheckQuestionOwnership verifies that the user owns the survey containing the question
-func (a *AuthorizationUtil) CheckQuestionOwnership(userID, questionID uint) (*model.Question, error) {
-	question, err := a.questionRepo.FindByID(questionID)
+func (a *AuthorizationUtil) CheckQuestionOwnership(ctx context.Context, userID, questionID uint) (*model.Question, error) {
+	question, err := a.questionRepo.FindByID(ctx, questionID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.New("题目不存在")
@@ -61,7 +64,7 @@ func (a *AuthorizationUtil) CheckQuestionOwnership(userID, questionID uint) (*mo
 	}
 
 	// Check if user owns the survey
-	if err := a.CheckSurveyOwnership(userID, question.SurveyID); err != nil {
+	if err := a.CheckSurveyOwnership(ctx, userID, question.SurveyID); err != nil {
 		return nil, err
 	}
 
@@ -69,8 +72,8 @@ func (a *AuthorizationUtil) CheckQuestionOwnership(userID, questionID uint) (*mo
 }
 
 // GetSurveyIfOwned retrieves a survey only if the user owns it
-func (a *AuthorizationUtil) GetSurveyIfOwned(userID, surveyID uint) (*model.Survey, error) {
-	survey, err := a.surveyRepo.FindByID(surveyID)
+func (a *AuthorizationUtil) GetSurveyIfOwned(ctx context.Context, userID, surveyID uint) (*model.Survey, error) {
+	survey, err := a.surveyRepo.FindByID(ctx, surveyID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, ErrSurveyNotFound
@@ -86,8 +89,8 @@ func (a *AuthorizationUtil) GetSurveyIfOwned(userID, surveyID uint) (*model.Surv
 }
 
 // GetSurveyWithQuestionsIfOwned retrieves a survey with questions only if the user owns it
-func (a *AuthorizationUtil) GetSurveyWithQuestionsIfOwned(userID, surveyID uint) (*model.Survey, error) {
-	survey, err := a.surveyRepo.FindByIDWithQuestions(surveyID)
+func (a *AuthorizationUtil) GetSurveyWithQuestionsIfOwned(ctx context.Context, userID, surveyID uint) (*model.Survey, error) {
+	survey, err := a.surveyRepo.FindByIDWithQuestions(ctx, surveyID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, ErrSurveyNotFound
@@ -101,3 +104,44 @@ func (a *AuthorizationUtil) GetSurveyWithQuestionsIfOwned(userID, surveyID uint)
 
 	return survey, nil
 }
+
+// CheckSurveyAccess verifies that the user owns the specified survey or is a member of
+// the team it is shared with
+func (a *AuthorizationUtil) CheckSurveyAccess(ctx context.Context, userID, surveyID uint) error {
+	_, err := a.GetSurveyIfAccessible(ctx, userID, surveyID)
+	return err
+}
+
+// GetSurveyIfAccessible retrieves a survey only if the user owns it or is a member of
+// the team it is shared with
+func (a *AuthorizationUtil) GetSurveyIfAccessible(ctx context.Context, userID, surveyID uint) (*model.Survey, error) {
+	survey, err := a.surveyRepo.FindByID(ctx, surveyID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSurveyNotFound
+		}
+		return nil, err
+	}
+
+	if survey.UserID == userID {
+		return survey, nil
+	}
+	if survey.TeamID == nil {
+		return nil, ErrForbidden
+	}
+
+	member, err := a.IsTeamMember(ctx, userID, *survey.TeamID)
+	if err != nil {
+		return nil, err
+	}
+	if !member {
+		return nil, ErrForbidden
+	}
+
+	return survey, nil
+}
+
+// IsTeamMember checks whether the user is a member of the specified team
+func (a *AuthorizationUtil) IsTeamMember(ctx context.Context, userID, teamID uint) (bool, error) {
+	return a.teamRepo.IsMember(ctx, teamID, userID)
+}
@@ -1,58 +1,216 @@
 package utils
 
 import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"math/big"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// Supported JWT signing algorithms
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+	AlgorithmEdDSA = "EdDSA"
+)
+
+// minRSAKeyBits is the minimum RSA key size accepted for RS256
+const minRSAKeyBits = 2048
+
 // JWTClaims represents the claims stored in JWT token
 type JWTClaims struct {
 	UserID uint   `json:"user_id"`
 	Role   string `json:"role"`
+
+	// Set on impersonation tokens: the admin acting as the user and the
+	// audit log entry of the session
+	ImpersonatorID  uint `json:"impersonator_id,omitempty"`
+	ImpersonationID uint `json:"impersonation_id,omitempty"`
+
 	jwt.RegisteredClaims
 }
 
+// JWK is a public key in JSON Web Key format (RFC 7517)
+type JWK struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	N   string `json:"n,omitempty"`   // RSA modulus
+	E   string `json:"e,omitempty"`   // RSA exponent
+	Crv string `json:"crv,omitempty"` // Ed25519 curve
+	X   string `json:"x,omitempty"`   // Ed25519 public key
+}
+
+// JWKSet is the document served at /.well-known/jwks.json
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
 // JWTUtil provides JWT token generation and validation
+// Tokens are signed with the HMAC secret unless a key pair is configured with UseKeyPair
 type JWTUtil struct {
-	secret     []byte
 	expiration time.Duration
+	method     jwt.SigningMethod
+	signKey    interface{}
+	verifyKey  interface{}
+	keyID      string
+	jwks       JWKSet
 }
 
 // NewJWTUtil creates a new JWT utility instance
 func NewJWTUtil(secret string, expiration time.Duration) *JWTUtil {
 	return &JWTUtil{
-		secret:     []byte(secret),
 		expiration: expiration,
+		method:     jwt.SigningMethodHS256,
+		signKey:    []byte(secret),
+		verifyKey:  []byte(secret),
+		jwks:       JWKSet{Keys: []JWK{}},
+	}
+}
+
+// UseKeyPair switches token signing to RS256 or EdDSA with a PEM encoded private key,
+// so other services can verify tokens with the public key published in JWKS. Tokens
+// signed with the HMAC secret are no longer accepted. An empty keyID defaults to the
+// key's JWK thumbprint (RFC 7638)
+func (j *JWTUtil) UseKeyPair(algorithm string, privateKeyPEM []byte, keyID string) error {
+	var jwk JWK
+	switch algorithm {
+	case AlgorithmRS256:
+		key, err := jwt.ParseRSAPrivateKeyFromPEM(privateKeyPEM)
+		if err != nil {
+			return fmt.Errorf("invalid RS256 private key: %w", err)
+		}
+		if key.N.BitLen() < minRSAKeyBits {
+			return fmt.Errorf("RS256 keys must have at least %d bits, got %d", minRSAKeyBits, key.N.BitLen())
+		}
+		j.method, j.signKey, j.verifyKey = jwt.SigningMethodRS256, key, &key.PublicKey
+		jwk = rsaJWK(&key.PublicKey)
+	case AlgorithmEdDSA:
+		key, err := jwt.ParseEdPrivateKeyFromPEM(privateKeyPEM)
+		if err != nil {
+			return fmt.Errorf("invalid EdDSA private key: %w", err)
+		}
+		edKey, ok := key.(ed25519.PrivateKey)
+		if !ok {
+			return errors.New("EdDSA keys must be Ed25519 keys")
+		}
+		publicKey := edKey.Public().(ed25519.PublicKey)
+		j.method, j.signKey, j.verifyKey = jwt.SigningMethodEdDSA, edKey, publicKey
+		jwk = JWK{Kty: "OKP", Crv: "Ed25519", X: base64.RawURLEncoding.EncodeToString(publicKey)}
+	default:
+		return fmt.Errorf("unsupported JWT signing algorithm %q", algorithm)
+	}
+
+	if keyID == "" {
+		keyID = thumbprint(jwk)
 	}
+	jwk.Use, jwk.Alg, jwk.Kid = "sig", algorithm, keyID
+	j.keyID = keyID
+	j.jwks = JWKSet{Keys: []JWK{jwk}}
+	return nil
+}
+
+// JWKS returns the public keys tokens are verified with, none when they are signed with
+// the HMAC secret
+func (j *JWTUtil) JWKS() JWKSet {
+	return j.jwks
 }
 
-// GenerateToken generates a new JWT token for the given user
-func (j *JWTUtil) GenerateToken(userID uint, role string) (string, error) {
+// rsaJWK returns the JWK members of an RSA public key
+func rsaJWK(key *rsa.PublicKey) JWK {
+	return JWK{
+		Kty: "RSA",
+		N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
+
+// thumbprint computes the RFC 7638 thumbprint of a public key from its required members
+// in lexicographic order
+func thumbprint(jwk JWK) string {
+	var members interface{}
+	if jwk.Kty == "RSA" {
+		members = struct {
+			E   string `json:"e"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+		}{jwk.E, jwk.Kty, jwk.N}
+	} else {
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+		}{jwk.Crv, jwk.Kty, jwk.X}
+	}
+	data, _ := json.Marshal(members)
+	sum := sha256.Sum256(data)
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// sign signs claims with the configured method, naming the key pair in the kid header
+func (j *JWTUtil) sign(claims JWTClaims) (string, error) {
+	token := jwt.NewWithClaims(j.method, claims)
+	if j.keyID != "" {
+		token.Header["kid"] = j.keyID
+	}
+	return token.SignedString(j.signKey)
+}
+
+// GenerateToken generates a new JWT token for the given user and returns it with its
+// expiration time. tokenID becomes the jti claim that identifies the login session
+func (j *JWTUtil) GenerateToken(userID uint, role, tokenID string) (string, time.Time, error) {
 	now := time.Now()
+	expiresAt := now.Add(j.expiration)
 	claims := JWTClaims{
 		UserID: userID,
 		Role:   role,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(now.Add(j.expiration)),
+			ID:        tokenID,
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+		},
+	}
+
+	token, err := j.sign(claims)
+	return token, expiresAt, err
+}
+
+// GenerateImpersonationToken generates a token that lets an admin act as the given user
+// until expiresAt, carrying the admin and the audit log entry of the session
+func (j *JWTUtil) GenerateImpersonationToken(userID uint, role string, impersonatorID, impersonationID uint, expiresAt time.Time) (string, error) {
+	now := time.Now()
+	claims := JWTClaims{
+		UserID:          userID,
+		Role:            role,
+		ImpersonatorID:  impersonatorID,
+		ImpersonationID: impersonationID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
 			IssuedAt:  jwt.NewNumericDate(now),
 			NotBefore: jwt.NewNumericDate(now),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(j.secret)
+	return j.sign(claims)
 }
 
 // ValidateToken validates a JWT token and returns the claims
 func (j *JWTUtil) ValidateToken(tokenString string) (*JWTClaims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
-		// Verify signing method
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		// Verify signing method; only the configured algorithm is accepted
+		if token.Method.Alg() != j.method.Alg() {
 			return nil, errors.New("unexpected signing method")
 		}
-		return j.secret, nil
+		return j.verifyKey, nil
 	})
 
 	if err != nil {
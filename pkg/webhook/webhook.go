@@ -0,0 +1,107 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"survey-system/internal/config"
+	"survey-system/pkg/netguard"
+)
+
+// Header names set on every delivery
+const (
+	HeaderEvent     = "X-Survey-Event"
+	HeaderTimestamp = "X-Survey-Timestamp"
+	HeaderSignature = "X-Survey-Signature"
+)
+
+// ErrGone is returned when the endpoint answers 410 Gone, asking not to be sent events again
+var ErrGone = errors.New("webhook endpoint is gone")
+
+// Sender defines the interface for delivering webhook events
+type Sender interface {
+	Send(ctx context.Context, url, event string, payload interface{}) error
+	// Deliver sends like Send and also returns the status code the endpoint answered with,
+	// 0 when no answer was received
+	Deliver(ctx context.Context, url, event string, payload interface{}) (int, error)
+}
+
+// NewSender creates a webhook sender from configuration
+func NewSender(cfg *config.NotifierConfig) Sender {
+	timeout := cfg.WebhookTimeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	return &httpSender{
+		client: netguard.NewClient(timeout),
+		secret: []byte(cfg.WebhookSecret),
+	}
+}
+
+// httpSender posts JSON payloads signed with HMAC-SHA256
+type httpSender struct {
+	client *http.Client
+	secret []byte
+}
+
+// Send posts the payload as JSON to url
+func (s *httpSender) Send(ctx context.Context, url, event string, payload interface{}) error {
+	_, err := s.Deliver(ctx, url, event, payload)
+	return err
+}
+
+// Deliver posts the payload as JSON to url
+// The signature covers "<timestamp>.<body>" so receivers can reject replayed deliveries
+func (s *httpSender) Deliver(ctx context.Context, url, event string, payload interface{}) (int, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create webhook request: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, event)
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, "sha256="+Sign(s.secret, timestamp, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to deliver webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode == http.StatusGone {
+		return resp.StatusCode, ErrGone
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("webhook endpoint returned status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// Sign returns the hex-encoded HMAC-SHA256 of "<timestamp>.<body>"
+func Sign(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
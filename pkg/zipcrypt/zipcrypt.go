@@ -0,0 +1,141 @@
+// Package zipcrypt writes password-protected ZIP archives using WinZip AES-256
+// encryption (AE-2), which 7-Zip, WinZip, macOS Archive Utility and libarchive can open.
+// The legacy ZipCrypto scheme is not supported because it is trivially broken.
+package zipcrypt
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/flate"
+	"crypto/aes"
+	"crypto/hmac"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+const (
+	methodAES     = 99     // compression method marking WinZip AES encrypted entries
+	aesExtraID    = 0x9901 // extra field holding the AES parameters
+	aesVersion    = 2      // AE-2: the CRC is omitted and only the authentication code protects the data
+	aesStrength   = 3      // AES-256
+	keyLength     = 32
+	saltLength    = 16
+	macLength     = 10
+	kdfRounds     = 1000
+	flagEncrypted = 0x1
+	readerVersion = 51 // version 5.1 introduced AES encryption
+)
+
+// Encrypt returns a ZIP archive holding a single deflated file named name,
+// encrypted with the given password
+func Encrypt(name string, data []byte, password string, modified time.Time) ([]byte, error) {
+	if password == "" {
+		return nil, fmt.Errorf("password must not be empty")
+	}
+
+	var compressed bytes.Buffer
+	fw, err := flate.NewWriter(&compressed, flate.DefaultCompression)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create compressor: %w", err)
+	}
+	if _, err := fw.Write(data); err != nil {
+		return nil, fmt.Errorf("failed to compress %s: %w", name, err)
+	}
+	if err := fw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress %s: %w", name, err)
+	}
+
+	payload, err := encryptPayload(compressed.Bytes(), password)
+	if err != nil {
+		return nil, err
+	}
+
+	// Vendor version, vendor ID "AE", strength and the actual compression method
+	extra := make([]byte, 11)
+	binary.LittleEndian.PutUint16(extra[0:], aesExtraID)
+	binary.LittleEndian.PutUint16(extra[2:], 7)
+	binary.LittleEndian.PutUint16(extra[4:], aesVersion)
+	copy(extra[6:], "AE")
+	extra[8] = aesStrength
+	binary.LittleEndian.PutUint16(extra[9:], zip.Deflate)
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.CreateRaw(&zip.FileHeader{
+		Name:               name,
+		Method:             methodAES,
+		Flags:              flagEncrypted,
+		ReaderVersion:      readerVersion,
+		Modified:           modified,
+		Extra:              extra,
+		CompressedSize64:   uint64(len(payload)),
+		UncompressedSize64: uint64(len(data)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to add %s to archive: %w", name, err)
+	}
+	if _, err := w.Write(payload); err != nil {
+		return nil, fmt.Errorf("failed to add %s to archive: %w", name, err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finish archive: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// encryptPayload returns salt, password verifier, ciphertext and authentication code
+func encryptPayload(plaintext []byte, password string) ([]byte, error) {
+	salt := make([]byte, saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	keys, err := pbkdf2.Key(sha1.New, password, salt, kdfRounds, 2*keyLength+2)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive keys: %w", err)
+	}
+	encKey, authKey, verifier := keys[:keyLength], keys[keyLength:2*keyLength], keys[2*keyLength:]
+
+	block, err := aes.NewCipher(encKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	payload := make([]byte, 0, saltLength+2+len(plaintext)+macLength)
+	payload = append(payload, salt...)
+	payload = append(payload, verifier...)
+	ciphertext := payload[len(payload) : len(payload)+len(plaintext)]
+	xorKeyStream(block, ciphertext, plaintext)
+	payload = payload[:len(payload)+len(plaintext)]
+
+	mac := hmac.New(sha1.New, authKey)
+	mac.Write(ciphertext)
+	payload = append(payload, mac.Sum(nil)[:macLength]...)
+
+	return payload, nil
+}
+
+// xorKeyStream applies AES-CTR as specified by WinZip: the counter block is a
+// little-endian integer starting at 1, unlike the big-endian cipher.NewCTR
+func xorKeyStream(block interface{ Encrypt(dst, src []byte) }, dst, src []byte) {
+	var counter, stream [aes.BlockSize]byte
+	for offset := 0; offset < len(src); offset += aes.BlockSize {
+		for i := range counter {
+			counter[i]++
+			if counter[i] != 0 {
+				break
+			}
+		}
+		block.Encrypt(stream[:], counter[:])
+
+		end := min(offset+aes.BlockSize, len(src))
+		for i := offset; i < end; i++ {
+			dst[i] = src[i] ^ stream[i-offset]
+		}
+	}
+}